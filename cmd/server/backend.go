@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	fbdb "github.com/theHinneh/budgeting/internal/infrastructure/db/firebase"
+	memdb "github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/migrate"
+	pgdb "github.com/theHinneh/budgeting/internal/infrastructure/db/postgres"
+	sqlitedb "github.com/theHinneh/budgeting/internal/infrastructure/db/sqlite"
+	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
+)
+
+// backend is an opened storage driver together with its repositories.
+// migrator is nil for drivers that have no persistent schema.
+type backend struct {
+	database ports.Database
+	fbAuth   *fbdb.Auth
+	migrator *migrate.Migrator
+
+	userRepo          ports.UserRepository
+	categoryRepo      ports.CategoryRepoPort
+	accountRepo       ports.AccountRepoPort
+	transferRepo      ports.TransferRepoPort
+	budgetRepo        ports.BudgetRepoPort
+	envelopeRepo      ports.EnvelopeRepoPort
+	savingsGoalRepo   ports.SavingsGoalRepoPort
+	debtRepo          ports.DebtRepoPort
+	holdingRepo       ports.HoldingRepoPort
+	netWorthRepo      ports.NetWorthSnapshotRepoPort
+	incomeRepo        ports.IncomeRepoPort
+	expenseRepo       ports.ExpenseRepoPort
+	refreshTokenRepo  ports.RefreshTokenRepository
+	securityEventRepo ports.SecurityEventRepoPort
+	twoFactorRepo     ports.TwoFactorRepoPort
+	passkeyRepo       ports.PasskeyRepoPort
+	loginAttemptRepo  ports.LoginAttemptRepoPort
+	credentialRepo    ports.CredentialRepository
+	rateRepo          ports.ExchangeRateRepository
+}
+
+func openBackend(ctx context.Context, cfg *config.Configuration, dbConfig config.DatabaseConfig) (*backend, error) {
+	switch dbConfig.Driver {
+	case "firebase":
+		logger.Info("Initializing Firebase database adapter")
+		fbInstance, err := fbdb.NewDatabase(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize firebase: %w", err)
+		}
+		return &backend{
+			database:          fbInstance,
+			fbAuth:            fbInstance.Auth,
+			migrator:          fbInstance.Migrator(),
+			userRepo:          fbInstance.UserRepository,
+			categoryRepo:      fbInstance.CategoryRepository,
+			accountRepo:       fbInstance.AccountRepository,
+			transferRepo:      fbInstance.TransferRepository,
+			budgetRepo:        fbInstance.BudgetRepository,
+			envelopeRepo:      fbInstance.EnvelopeRepository,
+			savingsGoalRepo:   fbInstance.SavingsGoalRepository,
+			debtRepo:          fbInstance.DebtRepository,
+			holdingRepo:       fbInstance.HoldingRepository,
+			netWorthRepo:      fbInstance.NetWorthSnapshotRepository,
+			incomeRepo:        fbInstance.IncomeRepository,
+			expenseRepo:       fbInstance.ExpenseRepository,
+			refreshTokenRepo:  fbInstance.RefreshTokenRepository,
+			securityEventRepo: fbInstance.SecurityEventRepository,
+			twoFactorRepo:     fbInstance.TwoFactorRepository,
+			passkeyRepo:       fbInstance.PasskeyRepository,
+			loginAttemptRepo:  fbInstance.LoginAttemptRepository,
+			rateRepo:          fbInstance.ExchangeRateRepository,
+		}, nil
+	case "postgres":
+		logger.Info("Initializing PostgreSQL database adapter")
+		pgInstance, err := pgdb.NewDatabase(ctx, dbConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize postgres: %w", err)
+		}
+		migrator, err := pgInstance.Migrator()
+		if err != nil {
+			_ = pgInstance.Close()
+			return nil, err
+		}
+		return &backend{
+			database:          pgInstance,
+			migrator:          migrator,
+			userRepo:          pgInstance.UserRepository,
+			categoryRepo:      pgInstance.CategoryRepository,
+			accountRepo:       pgInstance.AccountRepository,
+			transferRepo:      pgInstance.TransferRepository,
+			budgetRepo:        pgInstance.BudgetRepository,
+			envelopeRepo:      pgInstance.EnvelopeRepository,
+			savingsGoalRepo:   pgInstance.SavingsGoalRepository,
+			debtRepo:          pgInstance.DebtRepository,
+			holdingRepo:       pgInstance.HoldingRepository,
+			netWorthRepo:      pgInstance.NetWorthSnapshotRepository,
+			incomeRepo:        pgInstance.IncomeRepository,
+			expenseRepo:       pgInstance.ExpenseRepository,
+			refreshTokenRepo:  pgInstance.RefreshTokenRepository,
+			securityEventRepo: pgInstance.SecurityEventRepository,
+			twoFactorRepo:     pgInstance.TwoFactorRepository,
+			passkeyRepo:       pgInstance.PasskeyRepository,
+			loginAttemptRepo:  pgInstance.LoginAttemptRepository,
+			credentialRepo:    pgInstance.CredentialRepository,
+			rateRepo:          pgInstance.ExchangeRateRepository,
+		}, nil
+	case "sqlite":
+		logger.Info("Initializing SQLite database adapter")
+		sqliteInstance, err := sqlitedb.NewDatabase(ctx, dbConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize sqlite: %w", err)
+		}
+		migrator, err := sqliteInstance.Migrator()
+		if err != nil {
+			_ = sqliteInstance.Close()
+			return nil, err
+		}
+		return &backend{
+			database:          sqliteInstance,
+			migrator:          migrator,
+			userRepo:          sqliteInstance.UserRepository,
+			categoryRepo:      sqliteInstance.CategoryRepository,
+			accountRepo:       sqliteInstance.AccountRepository,
+			transferRepo:      sqliteInstance.TransferRepository,
+			budgetRepo:        sqliteInstance.BudgetRepository,
+			envelopeRepo:      sqliteInstance.EnvelopeRepository,
+			savingsGoalRepo:   sqliteInstance.SavingsGoalRepository,
+			debtRepo:          sqliteInstance.DebtRepository,
+			holdingRepo:       sqliteInstance.HoldingRepository,
+			netWorthRepo:      sqliteInstance.NetWorthSnapshotRepository,
+			incomeRepo:        sqliteInstance.IncomeRepository,
+			expenseRepo:       sqliteInstance.ExpenseRepository,
+			refreshTokenRepo:  sqliteInstance.RefreshTokenRepository,
+			securityEventRepo: sqliteInstance.SecurityEventRepository,
+			twoFactorRepo:     sqliteInstance.TwoFactorRepository,
+			passkeyRepo:       sqliteInstance.PasskeyRepository,
+			loginAttemptRepo:  sqliteInstance.LoginAttemptRepository,
+			credentialRepo:    sqliteInstance.CredentialRepository,
+			rateRepo:          sqliteInstance.ExchangeRateRepository,
+		}, nil
+	case "memory":
+		logger.Info("Initializing in-memory database adapter; data will not survive a restart")
+		memInstance := memdb.NewDatabase()
+		return &backend{
+			database:          memInstance,
+			userRepo:          memInstance.UserRepository,
+			categoryRepo:      memInstance.CategoryRepository,
+			accountRepo:       memInstance.AccountRepository,
+			transferRepo:      memInstance.TransferRepository,
+			budgetRepo:        memInstance.BudgetRepository,
+			envelopeRepo:      memInstance.EnvelopeRepository,
+			savingsGoalRepo:   memInstance.SavingsGoalRepository,
+			debtRepo:          memInstance.DebtRepository,
+			holdingRepo:       memInstance.HoldingRepository,
+			netWorthRepo:      memInstance.NetWorthSnapshotRepository,
+			incomeRepo:        memInstance.IncomeRepository,
+			expenseRepo:       memInstance.ExpenseRepository,
+			refreshTokenRepo:  memInstance.RefreshTokenRepository,
+			securityEventRepo: memInstance.SecurityEventRepository,
+			twoFactorRepo:     memInstance.TwoFactorRepository,
+			passkeyRepo:       memInstance.PasskeyRepository,
+			loginAttemptRepo:  memInstance.LoginAttemptRepository,
+			credentialRepo:    memInstance.CredentialRepository,
+			rateRepo:          memInstance.ExchangeRateRepository,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q. Supported drivers are 'firebase', 'postgres', 'sqlite' and 'memory'", dbConfig.Driver)
+	}
+}
+
+func (b *backend) dataStore() application.DataStore {
+	return application.DataStore{
+		Users:          b.userRepo,
+		Categories:     b.categoryRepo,
+		Accounts:       b.accountRepo,
+		Transfers:      b.transferRepo,
+		Budgets:        b.budgetRepo,
+		Envelopes:      b.envelopeRepo,
+		SavingsGoals:   b.savingsGoalRepo,
+		Debts:          b.debtRepo,
+		Holdings:       b.holdingRepo,
+		NetWorth:       b.netWorthRepo,
+		Incomes:        b.incomeRepo,
+		Expenses:       b.expenseRepo,
+		RefreshTokens:  b.refreshTokenRepo,
+		SecurityEvents: b.securityEventRepo,
+		TwoFactor:      b.twoFactorRepo,
+		Passkeys:       b.passkeyRepo,
+		Credentials:    b.credentialRepo,
+		ExchangeRates:  b.rateRepo,
+	}
+}
+
+// ensureSchema refuses to continue while migrations are pending unless
+// autoMigrate is set, in which case it applies them.
+func (b *backend) ensureSchema(ctx context.Context, autoMigrate bool) error {
+	if b.migrator == nil {
+		return nil
+	}
+	if autoMigrate {
+		applied, err := b.migrator.Up(ctx)
+		for _, m := range applied {
+			logger.Info(fmt.Sprintf("Applied migration %04d_%s", m.Version, m.Name))
+		}
+		return err
+	}
+	pending, err := b.migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is out of date (%d pending migrations, next %04d_%s); run `server migrate up` or set DB_AUTO_MIGRATE=true",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/exchangerate"
+	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
+)
+
+func newExchangeRateProvider(cfg config.ExchangeRateConfig, store *backend) (ports.ExchangeRateProvider, error) {
+	switch cfg.Provider {
+	case "database":
+		return application.NewExchangeRateService(store.rateRepo, cfg.PivotCurrency), nil
+	case "static":
+		rates, err := exchangerate.ParseStaticRates(cfg.StaticRates)
+		if err != nil {
+			return nil, fmt.Errorf("FX_STATIC_RATES: %w", err)
+		}
+		logger.Info(fmt.Sprintf("Using %d static exchange rates", len(rates)))
+		provider, err := exchangerate.NewStaticProvider(rates)
+		if err != nil {
+			return nil, fmt.Errorf("FX_STATIC_RATES: %w", err)
+		}
+		return provider, nil
+	case "file":
+		if cfg.RatesFile == "" {
+			return nil, fmt.Errorf("FX_PROVIDER=file requires FX_RATES_FILE")
+		}
+		logger.Info("Loading exchange rates from " + cfg.RatesFile)
+		provider, err := exchangerate.NewFileProvider(cfg.RatesFile)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("unsupported FX_PROVIDER %q. Supported providers are 'database', 'static' and 'file'", cfg.Provider)
+	}
+}
+
+// runImportRates implements `server import-rates [-format ecb|csv] FILE...`,
+// which loads historical rates into the configured database. The format
+// defaults to ecb for .xml files and csv otherwise.
+func runImportRates(cfg *config.Configuration, args []string) error {
+	fs := flag.NewFlagSet("import-rates", flag.ContinueOnError)
+	format := fs.String("format", "", "input format: ecb (eurofxref XML) or csv (date,base,quote,rate)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("usage: server import-rates [-format ecb|csv] FILE...")
+	}
+
+	ctx := context.Background()
+	dbConfig := cfg.GetDatabaseConfig()
+	store, err := openBackend(ctx, cfg, dbConfig)
+	if err != nil {
+		return err
+	}
+	defer store.database.Close()
+	if err := store.ensureSchema(ctx, dbConfig.AutoMigrate); err != nil {
+		return err
+	}
+
+	service := application.NewExchangeRateService(store.rateRepo, cfg.GetExchangeRateConfig().PivotCurrency)
+	for _, path := range fs.Args() {
+		rates, err := readRatesFile(path, *format)
+		if err != nil {
+			return err
+		}
+		n, err := service.ImportRates(ctx, rates)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Printf("imported %d rates from %s\n", n, path)
+	}
+	return nil
+}
+
+func readRatesFile(path, format string) ([]domain.ExchangeRate, error) {
+	if format == "" {
+		format = "csv"
+		if strings.EqualFold(filepath.Ext(path), ".xml") {
+			format = "ecb"
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rates []domain.ExchangeRate
+	switch format {
+	case "ecb":
+		rates, err = exchangerate.ParseECB(f)
+	case "csv":
+		rates, err = exchangerate.ParseCSV(f)
+	default:
+		return nil, fmt.Errorf("unsupported format %q. Supported formats are 'ecb' and 'csv'", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rates, nil
+}
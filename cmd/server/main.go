@@ -10,10 +10,14 @@ import (
 	"time"
 
 	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/ports"
 	api_http "github.com/theHinneh/budgeting/internal/infrastructure/api/http"
+	localauth "github.com/theHinneh/budgeting/internal/infrastructure/auth/local"
+	"github.com/theHinneh/budgeting/internal/infrastructure/auth/passkey"
 	"github.com/theHinneh/budgeting/internal/infrastructure/config"
 	fbdb "github.com/theHinneh/budgeting/internal/infrastructure/db/firebase"
 	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
+	"github.com/theHinneh/budgeting/internal/infrastructure/mail"
 	"github.com/theHinneh/budgeting/internal/worker"
 	"go.uber.org/zap"
 )
@@ -26,55 +30,233 @@ func main() {
 
 	logger.InitZaplogger(cfg)
 
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			if err := runMigrate(cfg, os.Args[2:]); err != nil {
+				logger.Fatal("Migration failed", zap.Error(err))
+			}
+			return
+		case "migrate-data":
+			if err := runMigrateData(cfg, os.Args[2:]); err != nil {
+				logger.Fatal("Data migration failed", zap.Error(err))
+			}
+			return
+		case "import-rates":
+			if err := runImportRates(cfg, os.Args[2:]); err != nil {
+				logger.Fatal("Rate import failed", zap.Error(err))
+			}
+			return
+		}
+	}
+
 	dbConfig := cfg.GetDatabaseConfig()
 
-	var fbInstance *fbdb.Database
+	store, err := openBackend(context.Background(), cfg, dbConfig)
+	if err != nil {
+		logger.Fatal("Failed to initialize database", zap.Error(err))
+	}
+	if err := store.ensureSchema(context.Background(), dbConfig.AutoMigrate); err != nil {
+		logger.Fatal("Refusing to start", zap.Error(err))
+	}
+
+	var (
+		database          = store.database
+		fbAuth            = store.fbAuth
+		userRepo          = store.userRepo
+		categoryRepo      = store.categoryRepo
+		accountRepo       = store.accountRepo
+		transferRepo      = store.transferRepo
+		budgetRepo        = store.budgetRepo
+		envelopeRepo      = store.envelopeRepo
+		savingsGoalRepo   = store.savingsGoalRepo
+		debtRepo          = store.debtRepo
+		holdingRepo       = store.holdingRepo
+		netWorthRepo      = store.netWorthRepo
+		incomeRepo        = store.incomeRepo
+		expenseRepo       = store.expenseRepo
+		refreshTokenRepo  = store.refreshTokenRepo
+		securityEventRepo = store.securityEventRepo
+		twoFactorRepo     = store.twoFactorRepo
+		passkeyRepo       = store.passkeyRepo
+		loginAttemptRepo  = store.loginAttemptRepo
+		credentialRepo    = store.credentialRepo
+	)
+
+	defer func() {
+		if err := database.Close(); err != nil {
+			logger.Error("Failed to close database", zap.Error(err))
+		}
+	}()
+
+	var (
+		userAuthenticator ports.UserAuthenticator
+		tokenAuth         ports.TokenAuthenticator
+		accessTokenTTL    time.Duration
+		tokenGenerator    ports.TokenGenerator
+		passwordVerifier  ports.PasswordVerifier
+	)
 
-	switch dbConfig.Driver {
+	authConfig := cfg.GetAuthConfig()
+	switch authConfig.Provider {
 	case "firebase":
-		logger.Info("Initializing Firebase database adapter")
-		fbInstance, err = fbdb.NewDatabase(context.Background(), cfg)
+		if fbAuth == nil {
+			logger.Info("Initializing Firebase authentication")
+			fbAuth, err = fbdb.NewAuth(context.Background(), cfg)
+			if err != nil {
+				logger.Fatal("Failed to initialize firebase auth", zap.Error(err))
+			}
+		}
+		userAuthenticator = fbAuth.UserAuthenticator
+		tokenAuth = fbAuth.TokenAuthenticator
+		// Firebase ID tokens always last an hour.
+		accessTokenTTL = time.Hour
+		tokenGenerator = fbAuth.TokenGenerator
+	case "local":
+		if credentialRepo == nil {
+			logger.Fatal("AUTH_PROVIDER=local is not supported by the configured DB_DRIVER")
+		}
+		logger.Info("Initializing local authentication")
+		if authConfig.SigningKeyFile == "" {
+			logger.Warn("AUTH_JWT_SIGNING_KEY_FILE is not set; using an ephemeral signing key, tokens will not survive a restart")
+		}
+		localAuth, err := localauth.NewAuthenticator(credentialRepo, authConfig)
 		if err != nil {
-			logger.Fatal("Failed to initialize firebase", zap.Error(err))
+			logger.Fatal("Failed to initialize local auth", zap.Error(err))
 		}
+		userAuthenticator = localAuth
+		tokenAuth = localAuth
+		accessTokenTTL = authConfig.AccessTokenTTL
+		passwordVerifier = localAuth
+		tokenGenerator = localauth.NewTokenGenerator()
 	default:
-		logger.Fatal("Unsupported DB_DRIVER. Only 'firebase' is supported.")
+		logger.Fatal("Unsupported AUTH_PROVIDER. Supported providers are 'firebase' and 'local'.")
 	}
 
-	defer func() {
-		if fbInstance != nil {
-			if err := fbInstance.Close(); err != nil {
-				logger.Error("Failed to close database", zap.Error(err))
-			}
+	var passkeyVerifier ports.PasskeyVerifier
+	if authConfig.PasskeyRPID != "" {
+		verifier, err := passkey.NewVerifier(authConfig)
+		if err != nil {
+			logger.Fatal("Failed to initialize passkeys", zap.Error(err))
 		}
-	}()
+		passkeyVerifier = verifier
+	} else {
+		logger.Info("AUTH_PASSKEY_RP_ID is not set; passkey login is disabled")
+	}
 
-	healthHandler := api_http.NewHealthHandler(cfg, fbInstance.FirestoreClient)
+	var authMailer ports.AuthMailer
+	if mailConfig := cfg.GetMailConfig(); mailConfig.Host != "" {
+		mailer, err := mail.NewSMTPMailer(mailConfig, authConfig.AccountUnlockURL)
+		if err != nil {
+			logger.Fatal("Failed to initialize mail", zap.Error(err))
+		}
+		authMailer = mailer
+	} else {
+		logger.Info("SMTP_HOST is not set; locked accounts are not sent unlock emails")
+	}
+
+	healthHandler := api_http.NewHealthHandler(cfg, database)
 
+	categoryService := application.NewCategoryService(
+		categoryRepo,
+		expenseRepo,
+		incomeRepo,
+		budgetRepo,
+		envelopeRepo,
+	)
 	userService := application.NewUserService(
-		fbInstance.UserRepository,
-		fbInstance.UserAuthenticator,
+		userRepo,
+		userAuthenticator,
+		categoryService,
+	)
+	tagService := application.NewTagService(
+		expenseRepo,
+		incomeRepo,
 	)
 	incomeService := application.NewIncomeService(
-		fbInstance.IncomeRepository,
+		incomeRepo,
+		categoryRepo,
+		accountRepo,
 	)
 	expenseService := application.NewExpenseService(
-		fbInstance.ExpenseRepository,
+		expenseRepo,
+		categoryRepo,
+		accountRepo,
+		debtRepo,
+	)
+	accountService := application.NewAccountService(
+		accountRepo,
+		expenseRepo,
+		incomeRepo,
+		transferRepo,
+		savingsGoalRepo,
+	)
+	rateProvider, err := newExchangeRateProvider(cfg.GetExchangeRateConfig(), store)
+	if err != nil {
+		logger.Fatal("Failed to initialize exchange rates", zap.Error(err))
+	}
+	transferService := application.NewTransferService(
+		transferRepo,
+		accountRepo,
+		rateProvider,
+	)
+	budgetService := application.NewBudgetService(
+		budgetRepo,
+		categoryRepo,
+		expenseRepo,
+		incomeRepo,
+		userRepo,
+		rateProvider,
+	)
+	envelopeService := application.NewEnvelopeService(
+		envelopeRepo,
+		categoryRepo,
+		expenseRepo,
+		rateProvider,
+	)
+	savingsGoalService := application.NewSavingsGoalService(
+		savingsGoalRepo,
+		accountService,
+	)
+	debtService := application.NewDebtService(
+		debtRepo,
+		expenseRepo,
+	)
+	holdingService := application.NewHoldingService(
+		holdingRepo,
 	)
 	netWorthService := application.NewNetWorthService(
-		fbInstance.IncomeRepository,
-		fbInstance.ExpenseRepository,
+		incomeRepo,
+		expenseRepo,
+		userRepo,
+		accountService,
+		debtService,
+		holdingService,
+		netWorthRepo,
+		rateProvider,
 	)
 
 	authService := application.NewAuthService(
-		fbInstance.RefreshTokenRepository,
-		fbInstance.TokenAuthenticator,
-		fbInstance.TokenGenerator,
+		refreshTokenRepo,
+		securityEventRepo,
+		twoFactorRepo,
+		passkeyRepo,
+		loginAttemptRepo,
+		userRepo,
+		tokenAuth,
+		accessTokenTTL,
+		tokenGenerator,
+		passwordVerifier,
+		passkeyVerifier,
+		authMailer,
 	)
 
-	router := api_http.NewRouter(
-		healthHandler, userService, incomeService, expenseService, netWorthService, fbInstance.App, authService, cfg,
+	router, err := api_http.NewRouter(
+		healthHandler, userService, incomeService, expenseService, categoryService, tagService, accountService, transferService, budgetService, envelopeService, savingsGoalService, debtService, holdingService, netWorthService, tokenAuth, userAuthenticator, authService, cfg,
 	)
+	if err != nil {
+		logger.Fatal("Failed to create router", zap.Error(err))
+	}
 
 	serverConfig := cfg.GetServerConfig()
 	port := serverConfig.Port
@@ -95,9 +277,10 @@ func main() {
 	}()
 
 	// Start background workers
-	worker.StartRecurringExpenseProcessor(expenseService, fbInstance.UserRepository)
-	worker.StartRecurringIncomeProcessor(incomeService, fbInstance.UserRepository)
+	worker.StartRecurringExpenseProcessor(expenseService, userRepo)
+	worker.StartRecurringIncomeProcessor(incomeService, userRepo)
 	worker.StartTokenCleanupWorker(authService)
+	worker.StartNetWorthSnapshotWorker(netWorthService, userRepo)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
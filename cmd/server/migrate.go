@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+)
+
+const migrateUsage = "usage: server migrate up|down|status"
+
+// runMigrate implements `server migrate up|down|status` against the
+// configured DB_DRIVER.
+func runMigrate(cfg *config.Configuration, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	ctx := context.Background()
+	b, err := openBackend(ctx, cfg, cfg.GetDatabaseConfig())
+	if err != nil {
+		return err
+	}
+	defer b.database.Close()
+
+	if b.migrator == nil {
+		fmt.Println("The configured DB_DRIVER has no migrations.")
+		return nil
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := b.migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	case "down":
+		reverted, err := b.migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if reverted == nil {
+			fmt.Println("no migrations to revert")
+			return nil
+		}
+		fmt.Printf("reverted %04d_%s\n", reverted.Version, reverted.Name)
+	case "status":
+		statuses, err := b.migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.Applied {
+				appliedAt = s.AppliedAt.UTC().Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+	return nil
+}
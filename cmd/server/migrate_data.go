@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/migrate"
+)
+
+// runMigrateData implements `server migrate-data`, which copies every user's
+// data from the DB_* backend into the TARGET_DB_* backend and then verifies
+// the copy.
+func runMigrateData(cfg *config.Configuration, args []string) error {
+	fs := flag.NewFlagSet("migrate-data", flag.ContinueOnError)
+	checkpointPath := fs.String("checkpoint", "migrate-data.checkpoint.json", "file recording which users have been copied")
+	verifyOnly := fs.Bool("verify-only", false, "skip copying and only compare source and target")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	sourceConfig := cfg.GetDatabaseConfig()
+	targetConfig := cfg.GetTargetDatabaseConfig()
+	if targetConfig.Driver == "" {
+		return errors.New("TARGET_DB_DRIVER must be set")
+	}
+	if targetConfig == sourceConfig {
+		return errors.New("source and target databases are the same")
+	}
+
+	ctx := context.Background()
+	source, err := openBackend(ctx, cfg, sourceConfig)
+	if err != nil {
+		return err
+	}
+	defer source.database.Close()
+	if err := source.ensureSchema(ctx, false); err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+
+	target, err := openBackend(ctx, cfg, targetConfig)
+	if err != nil {
+		return err
+	}
+	defer target.database.Close()
+	if err := target.ensureSchema(ctx, targetConfig.AutoMigrate); err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+
+	checkpoint, err := migrate.NewFileCheckpoint(*checkpointPath)
+	if err != nil {
+		return err
+	}
+
+	service := application.NewDataMigrationService(source.dataStore(), target.dataStore(), checkpoint)
+
+	if !*verifyOnly {
+		copied, err := service.Copy(ctx)
+		if copied != nil {
+			fmt.Printf("copied %d users (%d already done): %d categories, %d accounts, %d incomes, %d income sources, %d expenses, %d transfers, %d budgets, %d envelopes, %d envelope moves, %d savings goals, %d debts, %d holdings, %d valuations, %d net worth snapshots, %d refresh tokens, %d security events, %d two-factor enrollments, %d passkeys, %d credentials, %d exchange rates\n",
+				copied.Users, copied.SkippedUsers, copied.Categories, copied.Accounts, copied.Incomes, copied.IncomeSources, copied.Expenses, copied.Transfers, copied.Budgets, copied.Envelopes, copied.EnvelopeMoves, copied.SavingsGoals, copied.Debts, copied.Holdings, copied.Valuations, copied.NetWorthSnapshots, copied.RefreshTokens, copied.SecurityEvents, copied.TwoFactor, copied.Passkeys, copied.Credentials, copied.ExchangeRates)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	report, err := service.Verify(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range report.Mismatches {
+		fmt.Printf("MISMATCH user=%s %s: %s\n", m.UserID, m.Entity, m.Detail)
+	}
+	if !report.OK() {
+		return fmt.Errorf("verification found %d mismatches across %d users", len(report.Mismatches), report.Users)
+	}
+	fmt.Printf("verified %d users: counts and totals match\n", report.Users)
+	return nil
+}
@@ -0,0 +1,377 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type AccountService struct {
+	repo         ports.AccountRepoPort
+	expenseRepo  ports.ExpenseRepoPort
+	incomeRepo   ports.IncomeRepoPort
+	transferRepo ports.TransferRepoPort
+	goalRepo     ports.SavingsGoalRepoPort
+}
+
+func NewAccountService(repo ports.AccountRepoPort, expenseRepo ports.ExpenseRepoPort, incomeRepo ports.IncomeRepoPort, transferRepo ports.TransferRepoPort, goalRepo ports.SavingsGoalRepoPort) *AccountService {
+	return &AccountService{repo: repo, expenseRepo: expenseRepo, incomeRepo: incomeRepo, transferRepo: transferRepo, goalRepo: goalRepo}
+}
+
+var _ ports.AccountServicePort = (*AccountService)(nil)
+
+func (s *AccountService) CreateAccount(ctx context.Context, in dto.AccountInput) (*domain.Account, error) {
+	userID := strings.TrimSpace(in.UserID)
+	name := strings.TrimSpace(in.Name)
+	accountType := domain.AccountType(strings.ToLower(strings.TrimSpace(in.Type)))
+	opening, ok := normalizeBalance(in.OpeningBalance)
+	if userID == "" || name == "" || !accountType.Valid() || !ok {
+		return nil, ErrValidation
+	}
+
+	accounts, err := s.repo.ListAccountsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if accountNameTaken(accounts, name, "") {
+		return nil, ErrValidation
+	}
+
+	account, err := s.repo.CreateAccount(ctx, &domain.Account{
+		UID:            uuid.NewString(),
+		UserID:         userID,
+		Name:           name,
+		Type:           accountType,
+		Currency:       opening.Currency,
+		OpeningBalance: opening,
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	account.Balance = account.OpeningBalance
+	return account, nil
+}
+
+func (s *AccountService) ListAccounts(ctx context.Context, userID string) ([]*domain.Account, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	accounts, err := s.repo.ListAccountsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.computeBalances(ctx, userID, accounts); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (s *AccountService) GetAccount(ctx context.Context, userID string, accountID string) (*domain.Account, error) {
+	userID = strings.TrimSpace(userID)
+	accountID = strings.TrimSpace(accountID)
+	if userID == "" || accountID == "" {
+		return nil, ErrValidation
+	}
+	account, err := s.repo.GetAccount(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.computeBalances(ctx, userID, []*domain.Account{account}); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// UpdateAccount renames an account, changes its type or corrects its
+// opening balance. The currency is fixed once the account exists.
+func (s *AccountService) UpdateAccount(ctx context.Context, userID string, accountID string, in dto.AccountInput) (*domain.Account, error) {
+	userID = strings.TrimSpace(userID)
+	accountID = strings.TrimSpace(accountID)
+	name := strings.TrimSpace(in.Name)
+	accountType := domain.AccountType(strings.ToLower(strings.TrimSpace(in.Type)))
+	opening, ok := normalizeBalance(in.OpeningBalance)
+	if userID == "" || accountID == "" || name == "" || !ok {
+		return nil, ErrValidation
+	}
+	if accountType != "" && !accountType.Valid() {
+		return nil, ErrValidation
+	}
+
+	account, err := s.repo.GetAccount(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if opening.Currency != account.Currency {
+		return nil, ErrValidation
+	}
+	accounts, err := s.repo.ListAccountsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if accountNameTaken(accounts, name, accountID) {
+		return nil, ErrValidation
+	}
+
+	account.Name = name
+	if accountType != "" {
+		account.Type = accountType
+	}
+	account.OpeningBalance = opening
+	account.UpdatedAt = time.Now().UTC()
+	account, err = s.repo.UpdateAccount(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.computeBalances(ctx, userID, []*domain.Account{account}); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// DeleteAccount refuses to delete an account that still has transactions or
+// transfers.
+func (s *AccountService) DeleteAccount(ctx context.Context, userID string, accountID string) error {
+	userID = strings.TrimSpace(userID)
+	accountID = strings.TrimSpace(accountID)
+	if userID == "" || accountID == "" {
+		return ErrValidation
+	}
+	if _, err := s.repo.GetAccount(ctx, userID, accountID); err != nil {
+		return err
+	}
+
+	entries, err := s.ledger(ctx, userID, accountID)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return ErrAccountInUse
+	}
+	goals, err := s.goalRepo.ListSavingsGoalsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, g := range goals {
+		if g.AccountID == accountID {
+			return ErrAccountInUse
+		}
+	}
+	return s.repo.DeleteAccount(ctx, userID, accountID)
+}
+
+// ListAccountLedger returns the account's transactions oldest first with the
+// running balance after each one.
+func (s *AccountService) ListAccountLedger(ctx context.Context, userID string, accountID string) ([]dto.AccountEntry, error) {
+	userID = strings.TrimSpace(userID)
+	accountID = strings.TrimSpace(accountID)
+	if userID == "" || accountID == "" {
+		return nil, ErrValidation
+	}
+	account, err := s.repo.GetAccount(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.ledger(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	balance := account.OpeningBalance
+	for i := range entries {
+		if balance, err = balance.Add(entries[i].Amount); err != nil {
+			return nil, fmt.Errorf("account %s: %w", accountID, err)
+		}
+		entries[i].Balance = balance
+	}
+	return entries, nil
+}
+
+// ledger collects the account's transactions and transfers as signed
+// entries, oldest first, without running balances.
+func (s *AccountService) ledger(ctx context.Context, userID, accountID string) ([]dto.AccountEntry, error) {
+	var entries []dto.AccountEntry
+
+	incomes, err := s.incomeRepo.ListIncomesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range incomes {
+		if i.AccountID == accountID {
+			entries = append(entries, dto.AccountEntry{Kind: "income", UID: i.UID, Source: i.Source, Date: i.CreatedAt, Amount: i.Amount})
+		}
+	}
+
+	expenses, err := s.expenseRepo.ListExpensesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range expenses {
+		if e.AccountID == accountID {
+			entries = append(entries, dto.AccountEntry{Kind: "expense", UID: e.UID, Source: e.Source, Date: e.CreatedAt, Amount: e.Amount.Neg()})
+		}
+	}
+
+	transfers, err := s.transferRepo.ListTransfersByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(transfers) > 0 {
+		accounts, err := s.repo.ListAccountsByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[string]string, len(accounts))
+		for _, a := range accounts {
+			names[a.UID] = a.Name
+		}
+		for _, t := range transfers {
+			switch accountID {
+			case t.FromAccountID:
+				entries = append(entries, dto.AccountEntry{Kind: "transfer_out", UID: t.UID, Source: "Transfer to " + names[t.ToAccountID], Date: t.CreatedAt, Amount: t.Amount.Neg()})
+			case t.ToAccountID:
+				entries = append(entries, dto.AccountEntry{Kind: "transfer_in", UID: t.UID, Source: "Transfer from " + names[t.FromAccountID], Date: t.CreatedAt, Amount: t.ToAmount})
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Date.Equal(entries[j].Date) {
+			return entries[i].Date.Before(entries[j].Date)
+		}
+		return entries[i].UID < entries[j].UID
+	})
+	return entries, nil
+}
+
+// computeBalances sets Balance on every account from its opening balance,
+// the user's transactions and transfers.
+func (s *AccountService) computeBalances(ctx context.Context, userID string, accounts []*domain.Account) error {
+	byID := make(map[string]*domain.Account, len(accounts))
+	for _, a := range accounts {
+		a.Balance = a.OpeningBalance
+		byID[a.UID] = a
+	}
+	apply := func(accountID string, amount domain.Money) error {
+		a, ok := byID[accountID]
+		if !ok {
+			return nil
+		}
+		balance, err := a.Balance.Add(amount)
+		if err != nil {
+			return fmt.Errorf("account %s: %w", accountID, err)
+		}
+		a.Balance = balance
+		return nil
+	}
+
+	incomes, err := s.incomeRepo.ListIncomesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, i := range incomes {
+		if err := apply(i.AccountID, i.Amount); err != nil {
+			return err
+		}
+	}
+
+	expenses, err := s.expenseRepo.ListExpensesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, e := range expenses {
+		if err := apply(e.AccountID, e.Amount.Neg()); err != nil {
+			return err
+		}
+	}
+
+	transfers, err := s.transferRepo.ListTransfersByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, t := range transfers {
+		if err := apply(t.FromAccountID, t.Amount.Neg()); err != nil {
+			return err
+		}
+		if err := apply(t.ToAccountID, t.ToAmount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeBalance is normalizeAmount for balances, which may be zero or
+// negative.
+func normalizeBalance(m domain.Money) (domain.Money, bool) {
+	if m.Currency == "" {
+		m.Currency = domain.DefaultCurrency
+	}
+	code, ok := domain.NormalizeCurrency(m.Currency)
+	m.Currency = code
+	return m, ok
+}
+
+func accountNameTaken(accounts []*domain.Account, name, exceptID string) bool {
+	for _, a := range accounts {
+		if a.UID != exceptID && strings.EqualFold(a.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAccount returns the account a transaction of amount is recorded
+// on. The account must be in the amount's currency. An empty accountID
+// picks the user's oldest account in that currency, opening a cash account
+// when there is none, so that every transaction belongs to an account.
+func resolveAccount(ctx context.Context, repo ports.AccountRepoPort, userID, accountID string, amount domain.Money) (string, error) {
+	accountID = strings.TrimSpace(accountID)
+	if accountID != "" {
+		account, err := repo.GetAccount(ctx, userID, accountID)
+		if err != nil {
+			return "", err
+		}
+		if account.Currency != amount.Currency {
+			return "", ErrValidation
+		}
+		return accountID, nil
+	}
+
+	accounts, err := repo.ListAccountsByUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	for _, a := range accounts {
+		if a.Currency == amount.Currency {
+			return a.UID, nil
+		}
+	}
+
+	name := "Cash"
+	if accountNameTaken(accounts, name, "") {
+		name = "Cash " + amount.Currency
+	}
+	account, err := repo.CreateAccount(ctx, &domain.Account{
+		UID:            uuid.NewString(),
+		UserID:         userID,
+		Name:           name,
+		Type:           domain.AccountTypeCash,
+		Currency:       amount.Currency,
+		OpeningBalance: domain.NewMoney(0, amount.Currency),
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return account.UID, nil
+}
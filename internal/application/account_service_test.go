@@ -0,0 +1,78 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestAccountBalancesAndLedger(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	accounts := application.NewAccountService(db.AccountRepository, db.ExpenseRepository, db.IncomeRepository, db.TransferRepository, db.SavingsGoalRepository)
+	expenses := application.NewExpenseService(db.ExpenseRepository, db.CategoryRepository, db.AccountRepository, db.DebtRepository)
+	incomes := application.NewIncomeService(db.IncomeRepository, db.CategoryRepository, db.AccountRepository)
+	const userID = "u1"
+
+	checking, err := accounts.CreateAccount(ctx, dto.AccountInput{UserID: userID, Name: "Checking", Type: "checking", OpeningBalance: domain.NewMoney(10000, "USD")})
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	card, err := accounts.CreateAccount(ctx, dto.AccountInput{UserID: userID, Name: "Visa", Type: "credit_card", OpeningBalance: domain.NewMoney(0, "USD")})
+	if err != nil {
+		t.Fatalf("CreateAccount(card): %v", err)
+	}
+	if _, err := accounts.CreateAccount(ctx, dto.AccountInput{UserID: userID, Name: "visa", Type: "cash", OpeningBalance: domain.NewMoney(0, "USD")}); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("duplicate name: err = %v, want ErrValidation", err)
+	}
+
+	if _, err := incomes.AddIncome(ctx, dto.AddIncomeInput{UserID: userID, Source: "salary", Amount: domain.NewMoney(250000, "USD")}); err != nil {
+		t.Fatalf("AddIncome: %v", err)
+	}
+	if _, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: userID, Source: "dinner", AccountID: card.UID, Amount: domain.NewMoney(4550, "USD")}); err != nil {
+		t.Fatalf("AddExpense(card): %v", err)
+	}
+	if _, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: userID, Source: "rent", AccountID: checking.UID, Amount: domain.NewMoney(120000, "USD")}); err != nil {
+		t.Fatalf("AddExpense(checking): %v", err)
+	}
+	if _, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: userID, Source: "bad", AccountID: checking.UID, Amount: domain.NewMoney(100, "EUR")}); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("currency mismatch: err = %v, want ErrValidation", err)
+	}
+	// Without an account, a EUR expense opens a EUR cash account.
+	euro, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: userID, Source: "museum", Amount: domain.NewMoney(1200, "EUR")})
+	if err != nil {
+		t.Fatalf("AddExpense(EUR): %v", err)
+	}
+
+	list, err := accounts.ListAccounts(ctx, userID)
+	if err != nil || len(list) != 3 {
+		t.Fatalf("ListAccounts = %d accounts, %v; want 3", len(list), err)
+	}
+	want := map[string]domain.Money{
+		checking.UID:   domain.NewMoney(140000, "USD"),
+		card.UID:       domain.NewMoney(-4550, "USD"),
+		euro.AccountID: domain.NewMoney(-1200, "EUR"),
+	}
+	for _, a := range list {
+		if a.Balance != want[a.UID] {
+			t.Errorf("%s balance = %s, want %s", a.Name, a.Balance, want[a.UID])
+		}
+	}
+
+	ledger, err := accounts.ListAccountLedger(ctx, userID, checking.UID)
+	if err != nil || len(ledger) != 2 {
+		t.Fatalf("ListAccountLedger = %d entries, %v; want 2", len(ledger), err)
+	}
+	if ledger[0].Kind != "income" || ledger[0].Balance != domain.NewMoney(260000, "USD") || ledger[1].Balance != domain.NewMoney(140000, "USD") {
+		t.Fatalf("ListAccountLedger = %+v", ledger)
+	}
+
+	if err := accounts.DeleteAccount(ctx, userID, card.UID); !errors.Is(err, application.ErrAccountInUse) {
+		t.Fatalf("DeleteAccount(in use) = %v, want ErrAccountInUse", err)
+	}
+}
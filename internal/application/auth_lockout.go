@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// loginFailureWindow is how long failures keep counting; a key whose last
+	// failure is older starts over.
+	loginFailureWindow = 24 * time.Hour
+	loginBackoffBase   = time.Second
+	maxLoginBackoff    = 15 * time.Minute
+	accountUnlockTTL   = 24 * time.Hour
+)
+
+// loginThrottle describes how failed logins hold back one kind of key. From
+// backoffAfter failures on, each failure doubles the wait before the next
+// attempt is allowed, and lockoutAfter failures lock the key for lockout.
+type loginThrottle struct {
+	prefix       string
+	backoffAfter int
+	lockoutAfter int
+	lockout      time.Duration
+}
+
+var (
+	accountThrottle = loginThrottle{prefix: "account:", backoffAfter: 3, lockoutAfter: 10, lockout: time.Hour}
+	// Many users can share an address behind a NAT, so it gets more room
+	// before it is held back.
+	ipThrottle = loginThrottle{prefix: "ip:", backoffAfter: 10, lockoutAfter: 50, lockout: time.Hour}
+)
+
+func (t loginThrottle) delay(failures int) time.Duration {
+	if failures >= t.lockoutAfter {
+		return t.lockout
+	}
+	if failures < t.backoffAfter {
+		return 0
+	}
+	d := loginBackoffBase
+	for i := t.backoffAfter; i < failures && d < maxLoginBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxLoginBackoff)
+}
+
+// LoginThrottledError is returned by Login while failed attempts hold back
+// the account or the client address. Its gRPC code is ResourceExhausted.
+type LoginThrottledError struct {
+	Until time.Time
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, try again after %s", e.Until.UTC().Format(time.RFC3339))
+}
+
+// RetryAfter is how long the caller has to wait, rounded up to a second.
+func (e *LoginThrottledError) RetryAfter() time.Duration {
+	return max(time.Until(e.Until).Truncate(time.Second)+time.Second, time.Second)
+}
+
+func (e *LoginThrottledError) GRPCStatus() *status.Status {
+	return status.New(codes.ResourceExhausted, e.Error())
+}
+
+func accountKey(email string) string {
+	return accountThrottle.prefix + strings.ToLower(strings.TrimSpace(email))
+}
+
+// checkLoginThrottle refuses the login while the account or the address is
+// locked.
+func (s *AuthService) checkLoginThrottle(ctx context.Context, email, ipAddress string) error {
+	keys := []string{accountKey(email)}
+	if ipAddress != "" {
+		keys = append(keys, ipThrottle.prefix+ipAddress)
+	}
+	now := time.Now()
+	var until time.Time
+	for _, key := range keys {
+		attempts, err := s.loginAttempts.GetLoginAttempts(ctx, key)
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check login attempts: %w", err)
+		}
+		if attempts.LockedUntil != nil && attempts.LockedUntil.After(now) && attempts.LockedUntil.After(until) {
+			until = *attempts.LockedUntil
+		}
+	}
+	if !until.IsZero() {
+		return &LoginThrottledError{Until: until}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login against the account and the
+// address and locks whichever has failed too often. Locking the account
+// mails its owner a way to unlock it.
+func (s *AuthService) recordLoginFailure(ctx context.Context, email, ipAddress, userAgent string) {
+	now := time.Now().UTC()
+	count := func(t loginThrottle, key string) {
+		attempts, err := s.loginAttempts.RecordLoginFailure(ctx, key, now, now.Add(-loginFailureWindow))
+		if err != nil {
+			logger.Error("failed to record failed login", zap.String("key", key), zap.Error(err))
+			return
+		}
+		delay := t.delay(attempts.Failures)
+		if delay == 0 {
+			return
+		}
+		until := now.Add(delay)
+		if err := s.loginAttempts.LockLogin(ctx, key, until); err != nil {
+			logger.Error("failed to hold back login", zap.String("key", key), zap.Error(err))
+			return
+		}
+		if t == accountThrottle && attempts.Failures >= t.lockoutAfter {
+			s.sendAccountUnlock(ctx, email, until, ipAddress, userAgent)
+		}
+	}
+	count(accountThrottle, accountKey(email))
+	if ipAddress != "" {
+		count(ipThrottle, ipThrottle.prefix+ipAddress)
+	}
+}
+
+// resetLoginFailures starts the account's count over once a session has been
+// issued. Only the account starts over; one good login must not clear the
+// failures of everything else tried from the same address.
+func (s *AuthService) resetLoginFailures(ctx context.Context, email, userID string) {
+	if err := s.loginAttempts.ResetLoginAttempts(ctx, accountKey(email)); err != nil {
+		logger.Error("failed to reset failed logins", zap.String("user_id", userID), zap.Error(err))
+	}
+}
+
+// sendAccountUnlock records the lockout on the account, if there is one for
+// email, and mails its owner an unlock token.
+func (s *AuthService) sendAccountUnlock(ctx context.Context, email string, lockedUntil time.Time, ipAddress, userAgent string) {
+	user, err := s.tokenAuth.GetUserByEmail(ctx, email)
+	if err != nil {
+		// Nobody to notify; the email does not belong to an account.
+		return
+	}
+	s.recordSecurityEvent(ctx, user.UID, domain.SecurityEventAccountLocked,
+		fmt.Sprintf("too many failed logins, locked until %s", lockedUntil.Format(time.RFC3339)),
+		ipAddress, userAgent)
+	if s.mailer == nil {
+		return
+	}
+
+	token, err := s.tokenGenerator.GenerateSecureToken()
+	if err != nil {
+		logger.Error("failed to generate account unlock token", zap.Error(err))
+		return
+	}
+	now := time.Now().UTC()
+	unlock := &domain.AccountUnlock{
+		TokenHash: s.tokenGenerator.HashToken(token),
+		Email:     strings.ToLower(strings.TrimSpace(email)),
+		UserID:    user.UID,
+		ExpiresAt: now.Add(accountUnlockTTL),
+		CreatedAt: now,
+	}
+	if err := s.loginAttempts.CreateAccountUnlock(ctx, unlock); err != nil {
+		logger.Error("failed to store account unlock token", zap.Error(err))
+		return
+	}
+	if err := s.mailer.SendAccountUnlock(ctx, user.Email, token, lockedUntil); err != nil {
+		logger.Error("failed to send account unlock email", zap.String("user_id", user.UID), zap.Error(err))
+	}
+}
+
+// UnlockAccount lifts the lock on the account that the emailed token was
+// issued for. The address that failed is still held back.
+func (s *AuthService) UnlockAccount(ctx context.Context, token, ipAddress, userAgent string) error {
+	if token == "" {
+		return ErrInvalidAccountUnlock
+	}
+	unlock, err := s.loginAttempts.TakeAccountUnlock(ctx, s.tokenGenerator.HashToken(token))
+	if status.Code(err) == codes.NotFound {
+		return ErrInvalidAccountUnlock
+	}
+	if err != nil {
+		return err
+	}
+	if !time.Now().Before(unlock.ExpiresAt) {
+		return ErrInvalidAccountUnlock
+	}
+	if err := s.loginAttempts.ResetLoginAttempts(ctx, accountKey(unlock.Email)); err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+	s.recordSecurityEvent(ctx, unlock.UserID, domain.SecurityEventAccountUnlocked, "unlocked from the emailed link", ipAddress, userAgent)
+	return nil
+}
@@ -0,0 +1,196 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	passkeyCeremonyTTL   = 5 * time.Minute
+	defaultPasskeyName   = "Passkey"
+	maxPasskeyNameLength = 64
+)
+
+// BeginPasskeyRegistration starts adding a passkey to the user's account. The
+// passkeys the user already has are excluded so that an authenticator is not
+// registered twice.
+func (s *AuthService) BeginPasskeyRegistration(ctx context.Context, userID string) (*dto.PasskeyCeremony, error) {
+	if s.passkeyVerifier == nil {
+		return nil, ErrPasskeysDisabled
+	}
+	user, existing, err := s.passkeyOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	options, session, err := s.passkeyVerifier.BeginRegistration(user, existing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start passkey registration: %w", err)
+	}
+	return s.createPasskeyCeremony(ctx, userID, options, session)
+}
+
+// FinishPasskeyRegistration verifies the authenticator's response to a
+// registration started by the same user and stores the new passkey.
+func (s *AuthService) FinishPasskeyRegistration(ctx context.Context, userID, sessionToken, name string, response []byte) (*domain.Passkey, error) {
+	if s.passkeyVerifier == nil {
+		return nil, ErrPasskeysDisabled
+	}
+	ceremony, err := s.takePasskeyCeremony(ctx, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony.UserID == "" || ceremony.UserID != userID {
+		return nil, ErrInvalidPasskeyCeremony
+	}
+	user, existing, err := s.passkeyOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	passkey, err := s.passkeyVerifier.FinishRegistration(user, existing, ceremony.Session, response)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPasskeyVerification, err)
+	}
+	passkey.UserID = userID
+	passkey.Name = strings.TrimSpace(name)
+	if passkey.Name == "" {
+		passkey.Name = defaultPasskeyName
+	}
+	if len(passkey.Name) > maxPasskeyNameLength {
+		return nil, ErrValidation
+	}
+	passkey.CreatedAt = time.Now().UTC()
+
+	if err := s.passkeys.CreatePasskey(ctx, passkey); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return nil, ErrPasskeyAlreadyRegistered
+		}
+		return nil, err
+	}
+	return passkey, nil
+}
+
+func (s *AuthService) ListPasskeys(ctx context.Context, userID string) ([]*domain.Passkey, error) {
+	return s.passkeys.ListPasskeys(ctx, userID)
+}
+
+func (s *AuthService) DeletePasskey(ctx context.Context, userID, passkeyID string) error {
+	return s.passkeys.DeletePasskey(ctx, userID, passkeyID)
+}
+
+// BeginPasskeyLogin starts a login with a discoverable credential: the
+// authenticator lets the user pick one of their passkeys for this site, so no
+// email is needed.
+func (s *AuthService) BeginPasskeyLogin(ctx context.Context) (*dto.PasskeyCeremony, error) {
+	if s.passkeyVerifier == nil {
+		return nil, ErrPasskeysDisabled
+	}
+	options, session, err := s.passkeyVerifier.BeginLogin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start passkey login: %w", err)
+	}
+	return s.createPasskeyCeremony(ctx, "", options, session)
+}
+
+// FinishPasskeyLogin verifies the assertion and starts a session. Passkey
+// logins require user verification on the authenticator, so they stand in
+// for both the password and the second factor.
+func (s *AuthService) FinishPasskeyLogin(ctx context.Context, sessionToken string, response []byte, deviceInfo, ipAddress, userAgent string) (*dto.LoginResponse, error) {
+	if s.passkeyVerifier == nil {
+		return nil, ErrPasskeysDisabled
+	}
+	ceremony, err := s.takePasskeyCeremony(ctx, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony.UserID != "" {
+		return nil, ErrInvalidPasskeyCeremony
+	}
+
+	passkey, cloned, err := s.passkeyVerifier.FinishLogin(ceremony.Session, response, func(userID string) ([]*domain.Passkey, error) {
+		return s.passkeys.ListPasskeys(ctx, userID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPasskeyVerification, err)
+	}
+	if cloned {
+		s.recordSecurityEvent(ctx, passkey.UserID, domain.SecurityEventPasskeyCloned,
+			fmt.Sprintf("passkey %s presented signature counter %d, which did not advance", passkey.ID, passkey.SignCount),
+			ipAddress, userAgent)
+		return nil, ErrPasskeyVerification
+	}
+
+	if err := s.passkeys.UpdatePasskeyUsage(ctx, passkey.ID, passkey.SignCount, passkey.BackupState, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	user, err := s.users.GetUser(ctx, passkey.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.startSession(ctx, user, deviceInfo, ipAddress, userAgent)
+}
+
+func (s *AuthService) passkeyOwner(ctx context.Context, userID string) (*domain.User, []*domain.Passkey, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, nil, ErrValidation
+	}
+	user, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	existing, err := s.passkeys.ListPasskeys(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, existing, nil
+}
+
+func (s *AuthService) createPasskeyCeremony(ctx context.Context, userID string, options json.RawMessage, session []byte) (*dto.PasskeyCeremony, error) {
+	token, err := s.tokenGenerator.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	ceremony := &domain.PasskeyCeremony{
+		TokenHash: s.tokenGenerator.HashToken(token),
+		UserID:    userID,
+		Session:   session,
+		ExpiresAt: now.Add(passkeyCeremonyTTL),
+		CreatedAt: now,
+	}
+	if err := s.passkeys.CreatePasskeyCeremony(ctx, ceremony); err != nil {
+		return nil, fmt.Errorf("failed to store passkey session: %w", err)
+	}
+	return &dto.PasskeyCeremony{
+		SessionToken: token,
+		Options:      options,
+		ExpiresIn:    int64(passkeyCeremonyTTL.Seconds()),
+	}, nil
+}
+
+// takePasskeyCeremony consumes the ceremony behind sessionToken, so a
+// response can only be verified once whatever its outcome.
+func (s *AuthService) takePasskeyCeremony(ctx context.Context, sessionToken string) (*domain.PasskeyCeremony, error) {
+	if sessionToken == "" {
+		return nil, ErrInvalidPasskeyCeremony
+	}
+	ceremony, err := s.passkeys.TakePasskeyCeremony(ctx, s.tokenGenerator.HashToken(sessionToken))
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrInvalidPasskeyCeremony
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(ceremony.ExpiresAt) {
+		return nil, ErrInvalidPasskeyCeremony
+	}
+	return ceremony, nil
+}
@@ -2,54 +2,129 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/theHinneh/budgeting/internal/application/dto"
 	"github.com/theHinneh/budgeting/internal/application/ports"
 	"github.com/theHinneh/budgeting/internal/domain"
 	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const refreshTokenTTL = 30 * 24 * time.Hour
+
+var ErrRefreshTokenReused = errors.New("refresh token was already used; the sessions of that login have been revoked")
+
 type AuthService struct {
 	refreshTokenRepo ports.RefreshTokenRepository
+	securityEvents   ports.SecurityEventRepoPort
+	twoFactor        ports.TwoFactorRepoPort
+	passkeys         ports.PasskeyRepoPort
+	loginAttempts    ports.LoginAttemptRepoPort
+	users            ports.UserRepository
 	tokenAuth        ports.TokenAuthenticator
+	accessTokenTTL   time.Duration
 	tokenGenerator   ports.TokenGenerator
+	passwords        ports.PasswordVerifier
+	passkeyVerifier  ports.PasskeyVerifier
+	mailer           ports.AuthMailer
 }
 
+// NewAuthService builds the auth service. accessTokenTTL is how long the
+// tokens tokenAuth issues stay valid, reported to clients as expires_in.
+// passwords is nil for providers that cannot check a password on the server,
+// which turns password login off.
+// passkeyVerifier is nil when no WebAuthn relying party is
+// configured, which turns passkey registration and login off. Without a mailer
+// a locked account only unlocks when its lock runs out.
 func NewAuthService(
 	refreshTokenRepo ports.RefreshTokenRepository,
+	securityEvents ports.SecurityEventRepoPort,
+	twoFactor ports.TwoFactorRepoPort,
+	passkeys ports.PasskeyRepoPort,
+	loginAttempts ports.LoginAttemptRepoPort,
+	users ports.UserRepository,
 	tokenAuth ports.TokenAuthenticator,
+	accessTokenTTL time.Duration,
 	tokenGenerator ports.TokenGenerator,
+	passwords ports.PasswordVerifier,
+	passkeyVerifier ports.PasskeyVerifier,
+	mailer ports.AuthMailer,
 ) ports.AuthServicePort {
 	return &AuthService{
 		refreshTokenRepo: refreshTokenRepo,
+		securityEvents:   securityEvents,
+		twoFactor:        twoFactor,
+		passkeys:         passkeys,
+		loginAttempts:    loginAttempts,
+		users:            users,
 		tokenAuth:        tokenAuth,
+		accessTokenTTL:   accessTokenTTL,
 		tokenGenerator:   tokenGenerator,
+		passwords:        passwords,
+		passkeyVerifier:  passkeyVerifier,
+		mailer:           mailer,
 	}
 }
 
+// Login checks the credentials and starts a session. Users with two-factor
+// authentication enabled get a challenge token instead, to be completed with
+// CompleteTwoFactorLogin.
+//
+// Failed attempts are counted per account and per client address, and past a
+// few of them Login returns a LoginThrottledError until a growing delay, or a
+// lockout, has passed.
 func (s *AuthService) Login(ctx context.Context, email, password string, deviceInfo, ipAddress, userAgent string) (*dto.LoginResponse, error) {
-	user, err := s.tokenAuth.GetUserByEmail(ctx, email)
+	if s.passwords == nil {
+		return nil, ErrPasswordLoginDisabled
+	}
+	if err := s.checkLoginThrottle(ctx, email, ipAddress); err != nil {
+		return nil, err
+	}
+	user, err := s.authenticate(ctx, email, password)
 	if err != nil {
+		if !errors.Is(err, ErrValidation) {
+			s.recordLoginFailure(ctx, email, ipAddress, userAgent)
+		}
 		return nil, fmt.Errorf("invalid email or password: %w", err)
 	}
 
+	tf, err := s.twoFactor.GetTwoFactor(ctx, user.UID)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+	if tf != nil && tf.Enabled {
+		// The failures are kept until the second factor has been passed too.
+		return s.createTwoFactorChallenge(ctx, user)
+	}
+	resp, err := s.startSession(ctx, user, deviceInfo, ipAddress, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	s.resetLoginFailures(ctx, email, user.UID)
+	return resp, nil
+}
+
+func (s *AuthService) startSession(ctx context.Context, user *domain.User, deviceInfo, ipAddress, userAgent string) (*dto.LoginResponse, error) {
 	accessToken, err := s.tokenAuth.CreateCustomToken(ctx, user.UID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, err := s.CreateRefreshToken(ctx, user.UID, deviceInfo, ipAddress, userAgent)
+	_, refreshToken, err := s.CreateRefreshToken(ctx, user.UID, deviceInfo, ipAddress, userAgent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create refresh token: %w", err)
 	}
 
 	return &dto.LoginResponse{
 		AccessToken:  accessToken,
-		RefreshToken: refreshToken.TokenHash,
-		ExpiresIn:    3600, // 1 hour
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.accessTokenTTL.Seconds()),
 		TokenType:    "Bearer",
 		User: &dto.UserInfo{
 			UID:         user.UID,
@@ -60,51 +135,88 @@ func (s *AuthService) Login(ctx context.Context, email, password string, deviceI
 	}, nil
 }
 
+func (s *AuthService) authenticate(ctx context.Context, email, password string) (*domain.User, error) {
+	if password == "" {
+		return nil, ErrValidation
+	}
+	return s.passwords.VerifyPassword(ctx, email, password)
+}
+
+// RefreshToken rotates the presented refresh token. Presenting a token that
+// was already rotated means two parties hold it, so the whole family is
+// revoked and the reuse is recorded.
 func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string, deviceInfo, ipAddress, userAgent string) (*dto.RefreshTokenResponse, error) {
-	userID, err := s.tokenAuth.VerifyIDToken(ctx, refreshToken)
+	oldToken, err := s.refreshTokenRepo.GetByTokenHash(ctx, s.tokenGenerator.HashToken(refreshToken))
 	if err != nil {
 		return nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
-
-	oldToken, err := s.ValidateRefreshToken(ctx, userID, refreshToken)
-	if err != nil {
-		return nil, fmt.Errorf("invalid or expired refresh token: %w", err)
+	if oldToken.ReplacedBy != "" {
+		s.revokeReusedFamily(ctx, oldToken, ipAddress, userAgent)
+		return nil, ErrRefreshTokenReused
 	}
-
-	newAccessToken, err := s.tokenAuth.CreateCustomToken(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate new access token: %w", err)
+	if oldToken.IsRevoked || !time.Now().Before(oldToken.ExpiresAt) {
+		return nil, fmt.Errorf("invalid or expired refresh token")
 	}
 
-	newRefreshToken, err := s.CreateRefreshToken(ctx, userID, deviceInfo, ipAddress, userAgent)
+	newRefreshToken, secret, err := s.issueRefreshToken(ctx, oldToken.UserID, oldToken.FamilyID, deviceInfo, ipAddress, userAgent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate new refresh token: %w", err)
 	}
 
-	if err := s.RevokeSession(ctx, oldToken.ID); err != nil {
-		logger.Error("failed to revoke old refresh token", zap.Error(err))
+	if err := s.refreshTokenRepo.RotateToken(ctx, oldToken.ID, newRefreshToken.ID); err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			// A concurrent request rotated the same token first.
+			s.revokeReusedFamily(ctx, oldToken, ipAddress, userAgent)
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	newAccessToken, err := s.tokenAuth.CreateCustomToken(ctx, oldToken.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate new access token: %w", err)
 	}
 
 	return &dto.RefreshTokenResponse{
 		AccessToken:  newAccessToken,
-		RefreshToken: newRefreshToken.TokenHash,
-		ExpiresIn:    3600, // 1 hour
+		RefreshToken: secret,
+		ExpiresIn:    int64(s.accessTokenTTL.Seconds()),
 		TokenType:    "Bearer",
 	}, nil
 }
 
-func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+func (s *AuthService) revokeReusedFamily(ctx context.Context, token *domain.RefreshToken, ipAddress, userAgent string) {
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+		logger.Error("failed to revoke refresh token family", zap.String("family_id", token.FamilyID), zap.Error(err))
+	}
+	s.recordSecurityEvent(ctx, token.UserID, domain.SecurityEventRefreshTokenReuse,
+		fmt.Sprintf("refresh token %s of family %s was presented after rotation", token.ID, token.FamilyID),
+		ipAddress, userAgent)
+}
 
-	userID, err := s.tokenAuth.VerifyIDToken(ctx, refreshToken)
-	if err != nil {
-		return fmt.Errorf("invalid refresh token: %w", err)
+func (s *AuthService) recordSecurityEvent(ctx context.Context, userID string, eventType domain.SecurityEventType, details, ipAddress, userAgent string) {
+	event := &domain.SecurityEvent{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Type:      eventType,
+		Details:   details,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.securityEvents.CreateSecurityEvent(ctx, event); err != nil {
+		logger.Error("failed to record security event", zap.String("type", string(event.Type)), zap.Error(err))
 	}
+}
 
-	token, err := s.ValidateRefreshToken(ctx, userID, refreshToken)
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	token, err := s.refreshTokenRepo.GetByTokenHash(ctx, s.tokenGenerator.HashToken(refreshToken))
 	if err != nil {
-		return fmt.Errorf("invalid or expired refresh token: %w", err)
+		return fmt.Errorf("invalid refresh token: %w", err)
+	}
+	if token.IsRevoked {
+		return fmt.Errorf("refresh token revoked")
 	}
-
 	return s.RevokeSession(ctx, token.ID)
 }
 
@@ -120,35 +232,48 @@ func (s *AuthService) RevokeAllUserSessions(ctx context.Context, userID string)
 	return s.refreshTokenRepo.RevokeAllUserTokens(ctx, userID)
 }
 
-func (s *AuthService) CreateRefreshToken(ctx context.Context, userID string, deviceInfo, ipAddress, userAgent string) (*domain.RefreshToken, error) {
+func (s *AuthService) GetSecurityEvents(ctx context.Context, userID string) ([]*domain.SecurityEvent, error) {
+	return s.securityEvents.ListSecurityEvents(ctx, userID)
+}
 
-	tokenString, err := s.tokenGenerator.GenerateSecureToken()
+func (s *AuthService) CreateRefreshToken(ctx context.Context, userID string, deviceInfo, ipAddress, userAgent string) (*domain.RefreshToken, string, error) {
+	return s.issueRefreshToken(ctx, userID, "", deviceInfo, ipAddress, userAgent)
+}
+
+// issueRefreshToken stores the hash of a new secret in familyID, starting a
+// new family when familyID is empty, and returns the secret.
+func (s *AuthService) issueRefreshToken(ctx context.Context, userID, familyID, deviceInfo, ipAddress, userAgent string) (*domain.RefreshToken, string, error) {
+	secret, err := s.tokenGenerator.GenerateSecureToken()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
-	expiresAt := time.Now().AddDate(0, 0, 30)
-
+	now := time.Now()
 	refreshToken := &domain.RefreshToken{
+		ID:         uuid.NewString(),
 		UserID:     userID,
-		TokenHash:  tokenString,
+		TokenHash:  s.tokenGenerator.HashToken(secret),
+		FamilyID:   familyID,
 		IsRevoked:  false,
-		ExpiresAt:  expiresAt,
-		CreatedAt:  time.Now(),
+		ExpiresAt:  now.Add(refreshTokenTTL),
+		CreatedAt:  now,
 		DeviceInfo: deviceInfo,
 		IPAddress:  ipAddress,
 		UserAgent:  userAgent,
 	}
+	if refreshToken.FamilyID == "" {
+		refreshToken.FamilyID = refreshToken.ID
+	}
 
 	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
-		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+		return nil, "", fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
-	return refreshToken, nil
+	return refreshToken, secret, nil
 }
 
 func (s *AuthService) ValidateRefreshToken(ctx context.Context, userID, tokenString string) (*domain.RefreshToken, error) {
-	token, err := s.refreshTokenRepo.GetValidToken(ctx, userID, tokenString)
+	token, err := s.refreshTokenRepo.GetValidToken(ctx, userID, s.tokenGenerator.HashToken(tokenString))
 	if err != nil {
 		return nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
@@ -165,5 +290,17 @@ func (s *AuthService) ValidateRefreshToken(ctx context.Context, userID, tokenStr
 }
 
 func (s *AuthService) CleanupExpiredTokens(ctx context.Context) error {
+	if err := s.twoFactor.DeleteExpiredTwoFactorChallenges(ctx); err != nil {
+		return err
+	}
+	if err := s.passkeys.DeleteExpiredPasskeyCeremonies(ctx); err != nil {
+		return err
+	}
+	if err := s.loginAttempts.DeleteExpiredAccountUnlocks(ctx); err != nil {
+		return err
+	}
+	if err := s.loginAttempts.DeleteStaleLoginAttempts(ctx, time.Now().Add(-loginFailureWindow)); err != nil {
+		return err
+	}
 	return s.refreshTokenRepo.DeleteExpiredTokens(ctx)
 }
@@ -0,0 +1,476 @@
+package application_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+	localauth "github.com/theHinneh/budgeting/internal/infrastructure/auth/local"
+	"github.com/theHinneh/budgeting/internal/infrastructure/auth/passkey"
+	"github.com/theHinneh/budgeting/internal/infrastructure/auth/passkey/passkeytest"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testAccessTokenTTL = 15 * time.Minute
+
+func newLocalAuthService(t *testing.T) (*localauth.Authenticator, *memory.Database, ports.AuthServicePort) {
+	t.Helper()
+	return newLocalAuthServiceWithMailer(t, nil)
+}
+
+func newLocalAuthServiceWithMailer(t *testing.T, mailer ports.AuthMailer) (*localauth.Authenticator, *memory.Database, ports.AuthServicePort) {
+	t.Helper()
+	db := memory.NewDatabase()
+	auth, err := localauth.NewAuthenticator(db.CredentialRepository, config.AuthConfig{JWTIssuer: "budgeting", AccessTokenTTL: testAccessTokenTTL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := passkey.NewVerifier(config.AuthConfig{PasskeyRPID: "localhost", PasskeyRPName: "Budgeting", PasskeyOrigins: []string{testOrigin}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := localauth.NewTokenGenerator()
+	return auth, db, application.NewAuthService(db.RefreshTokenRepository, db.SecurityEventRepository, db.TwoFactorRepository, db.PasskeyRepository,
+		db.LoginAttemptRepository, db.UserRepository, auth, testAccessTokenTTL, tokens, auth, verifier, mailer)
+}
+
+type unlockMail struct {
+	email       string
+	token       string
+	lockedUntil time.Time
+}
+
+type recordingMailer struct {
+	sent []unlockMail
+}
+
+func (m *recordingMailer) SendAccountUnlock(ctx context.Context, email, token string, lockedUntil time.Time) error {
+	m.sent = append(m.sent, unlockMail{email: email, token: token, lockedUntil: lockedUntil})
+	return nil
+}
+
+const testOrigin = "https://localhost:3000"
+
+func TestLoginWithoutPasswordVerifierIsRefused(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	auth, err := localauth.NewAuthenticator(db.CredentialRepository, config.AuthConfig{JWTIssuer: "budgeting", AccessTokenTTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := auth.CreateAuthUser(ctx, "ama@example.com", "secret123", "Ama", nil); err != nil {
+		t.Fatal(err)
+	}
+	// As with Firebase auth: the provider cannot check passwords on the
+	// server, so knowing an email must not be enough to log in.
+	svc := application.NewAuthService(db.RefreshTokenRepository, db.SecurityEventRepository, db.TwoFactorRepository, db.PasskeyRepository,
+		db.LoginAttemptRepository, db.UserRepository, auth, time.Hour, localauth.NewTokenGenerator(), nil, nil, nil)
+	for _, password := range []string{"", "wrong", "secret123"} {
+		if login, err := svc.Login(ctx, "ama@example.com", password, "", "", ""); !errors.Is(err, application.ErrPasswordLoginDisabled) {
+			t.Fatalf("Login(%q) = %+v, %v, want ErrPasswordLoginDisabled", password, login, err)
+		}
+	}
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	auth, _, svc := newLocalAuthService(t)
+
+	uid, err := auth.CreateAuthUser(ctx, "ama@example.com", "secret123", "Ama", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Login(ctx, "ama@example.com", "wrong", "", "", ""); err == nil {
+		t.Fatal("Login accepted a wrong password")
+	}
+	login, err := svc.Login(ctx, "ama@example.com", "secret123", "laptop", "10.0.0.1", "curl")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if login.ExpiresIn != 900 {
+		t.Fatalf("Login ExpiresIn = %d, want the configured 900 seconds", login.ExpiresIn)
+	}
+	other, err := svc.Login(ctx, "ama@example.com", "secret123", "phone", "10.0.0.2", "app")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	// Only the hash of the secret is stored.
+	sessions, _ := svc.GetUserSessions(ctx, uid)
+	for _, s := range sessions {
+		if s.TokenHash == login.RefreshToken || s.TokenHash == other.RefreshToken {
+			t.Fatal("refresh token stored in plain text")
+		}
+	}
+
+	rotated, err := svc.RefreshToken(ctx, login.RefreshToken, "laptop", "10.0.0.1", "curl")
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+	if rotated.ExpiresIn != 900 {
+		t.Fatalf("RefreshToken ExpiresIn = %d, want the configured 900 seconds", rotated.ExpiresIn)
+	}
+	if _, err := svc.ValidateRefreshToken(ctx, uid, login.RefreshToken); err == nil {
+		t.Fatal("rotated refresh token is still valid")
+	}
+
+	// Replaying the rotated token revokes its successor as well.
+	if _, err := svc.RefreshToken(ctx, login.RefreshToken, "laptop", "203.0.113.9", "evil"); !errors.Is(err, application.ErrRefreshTokenReused) {
+		t.Fatalf("RefreshToken(reused) = %v, want ErrRefreshTokenReused", err)
+	}
+	if _, err := svc.RefreshToken(ctx, rotated.RefreshToken, "laptop", "10.0.0.1", "curl"); err == nil {
+		t.Fatal("successor of a reused token still refreshes")
+	}
+	if _, err := svc.RefreshToken(ctx, other.RefreshToken, "phone", "10.0.0.2", "app"); err != nil {
+		t.Fatalf("reuse revoked an unrelated login: %v", err)
+	}
+
+	events, err := svc.GetSecurityEvents(ctx, uid)
+	if err != nil {
+		t.Fatalf("GetSecurityEvents: %v", err)
+	}
+	if len(events) != 1 || events[0].IPAddress != "203.0.113.9" {
+		t.Fatalf("GetSecurityEvents returned %d events, want the one reuse", len(events))
+	}
+}
+
+// totp computes the RFC 6238 code independently of the service.
+func totp(t *testing.T, secret string, now time.Time) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("decode secret: %v", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(now.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[19] & 0x0f
+	return fmt.Sprintf("%06d", (binary.BigEndian.Uint32(sum[offset:])&0x7fffffff)%1000000)
+}
+
+func TestTwoFactorLoginWithTOTPAndRecoveryCodes(t *testing.T) {
+	ctx := context.Background()
+	auth, db, svc := newLocalAuthService(t)
+	uid, err := auth.CreateAuthUser(ctx, "ama@example.com", "secret123", "Ama", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enrollment, err := svc.EnrollTwoFactor(ctx, uid, "ama@example.com")
+	if err != nil {
+		t.Fatalf("EnrollTwoFactor: %v", err)
+	}
+	if !strings.HasPrefix(enrollment.URI, "otpauth://totp/Budgeting:ama@example.com?") || !strings.Contains(enrollment.URI, "secret="+enrollment.Secret) {
+		t.Fatalf("otpauth URI = %q", enrollment.URI)
+	}
+	// Enrollment alone does not turn 2FA on.
+	if login, err := svc.Login(ctx, "ama@example.com", "secret123", "", "", ""); err != nil || login.TwoFactorRequired {
+		t.Fatalf("Login before confirming = %+v, %v", login, err)
+	}
+
+	if _, err := svc.ConfirmTwoFactor(ctx, uid, "000000"); !errors.Is(err, application.ErrInvalidTwoFactorCode) && totp(t, enrollment.Secret, time.Now()) != "000000" {
+		t.Fatalf("ConfirmTwoFactor(wrong) = %v, want ErrInvalidTwoFactorCode", err)
+	}
+	code := totp(t, enrollment.Secret, time.Now())
+	recovery, err := svc.ConfirmTwoFactor(ctx, uid, code)
+	if err != nil {
+		t.Fatalf("ConfirmTwoFactor: %v", err)
+	}
+	if len(recovery.Codes) != 10 {
+		t.Fatalf("got %d recovery codes, want 10", len(recovery.Codes))
+	}
+
+	login, err := svc.Login(ctx, "ama@example.com", "secret123", "", "", "")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if !login.TwoFactorRequired || login.ChallengeToken == "" || login.AccessToken != "" || login.RefreshToken != "" {
+		t.Fatalf("Login with 2FA = %+v, want only a challenge", login)
+	}
+
+	// The code used to confirm enrollment cannot be replayed.
+	if _, err := svc.CompleteTwoFactorLogin(ctx, login.ChallengeToken, code, "", "", ""); !errors.Is(err, application.ErrInvalidTwoFactorCode) {
+		t.Fatalf("CompleteTwoFactorLogin(replayed code) = %v, want ErrInvalidTwoFactorCode", err)
+	}
+	session, err := svc.CompleteTwoFactorLogin(ctx, login.ChallengeToken, strings.ToUpper(recovery.Codes[0]), "", "", "")
+	if err != nil {
+		t.Fatalf("CompleteTwoFactorLogin(recovery code): %v", err)
+	}
+	if session.AccessToken == "" || session.RefreshToken == "" || session.User.UID != uid {
+		t.Fatalf("CompleteTwoFactorLogin = %+v, want a session", session)
+	}
+	if _, err := svc.CompleteTwoFactorLogin(ctx, login.ChallengeToken, recovery.Codes[1], "", "", ""); !errors.Is(err, application.ErrInvalidTwoFactorChallenge) {
+		t.Fatalf("CompleteTwoFactorLogin(used challenge) = %v, want ErrInvalidTwoFactorChallenge", err)
+	}
+
+	// Recovery codes are single use, and a challenge dies after five wrong codes.
+	// The account's failures are cleared in between so that the backoff
+	// does not kick in first.
+	login, _ = svc.Login(ctx, "ama@example.com", "secret123", "", "", "")
+	for i := 0; i < 5; i++ {
+		if err := db.LoginAttemptRepository.ResetLoginAttempts(ctx, "account:ama@example.com"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.CompleteTwoFactorLogin(ctx, login.ChallengeToken, recovery.Codes[0], "", "", ""); !errors.Is(err, application.ErrInvalidTwoFactorCode) {
+			t.Fatalf("CompleteTwoFactorLogin(used recovery code) = %v, want ErrInvalidTwoFactorCode", err)
+		}
+	}
+	if _, err := svc.CompleteTwoFactorLogin(ctx, login.ChallengeToken, recovery.Codes[1], "", "", ""); !errors.Is(err, application.ErrInvalidTwoFactorChallenge) {
+		t.Fatalf("CompleteTwoFactorLogin(exhausted challenge) = %v, want ErrInvalidTwoFactorChallenge", err)
+	}
+
+	if err := svc.DisableTwoFactor(ctx, uid, recovery.Codes[1]); err != nil {
+		t.Fatalf("DisableTwoFactor: %v", err)
+	}
+	if login, err := svc.Login(ctx, "ama@example.com", "secret123", "", "", ""); err != nil || login.TwoFactorRequired || login.AccessToken == "" {
+		t.Fatalf("Login after disabling = %+v, %v", login, err)
+	}
+}
+
+func TestWrongTwoFactorCodesCountAgainstTheAccount(t *testing.T) {
+	ctx := context.Background()
+	auth, _, svc := newLocalAuthService(t)
+	uid, err := auth.CreateAuthUser(ctx, "ama@example.com", "secret123", "Ama", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enrollment, err := svc.EnrollTwoFactor(ctx, uid, "ama@example.com")
+	if err != nil {
+		t.Fatalf("EnrollTwoFactor: %v", err)
+	}
+	if _, err := svc.ConfirmTwoFactor(ctx, uid, totp(t, enrollment.Secret, time.Now())); err != nil {
+		t.Fatalf("ConfirmTwoFactor: %v", err)
+	}
+
+	// Each round opens a fresh challenge with the right password, which
+	// must neither reset the count nor let the guessing go on unchecked.
+	for i := 0; i < 3; i++ {
+		login, err := svc.Login(ctx, "ama@example.com", "secret123", "", "", "")
+		if err != nil || !login.TwoFactorRequired {
+			t.Fatalf("Login %d = %+v, %v, want a challenge", i+1, login, err)
+		}
+		if _, err := svc.CompleteTwoFactorLogin(ctx, login.ChallengeToken, "aaaa-bbbb-cccc-dddd", "", "", ""); !errors.Is(err, application.ErrInvalidTwoFactorCode) {
+			t.Fatalf("CompleteTwoFactorLogin(wrong code) = %v, want ErrInvalidTwoFactorCode", err)
+		}
+	}
+
+	var throttled *application.LoginThrottledError
+	if _, err := svc.Login(ctx, "ama@example.com", "secret123", "", "", ""); !errors.As(err, &throttled) {
+		t.Fatalf("Login after three wrong codes = %v, want LoginThrottledError", err)
+	}
+}
+
+func TestPasskeyRegistrationAndLogin(t *testing.T) {
+	ctx := context.Background()
+	auth, db, svc := newLocalAuthService(t)
+	uid, err := auth.CreateAuthUser(ctx, "ama@example.com", "secret123", "Ama", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.UserRepository.CreateUser(ctx, &domain.User{UID: uid, Email: "ama@example.com", FirstName: "Ama", LastName: "Mensah"}); err != nil {
+		t.Fatal(err)
+	}
+
+	phone := passkeytest.New(testOrigin)
+	ceremony, err := svc.BeginPasskeyRegistration(ctx, uid)
+	if err != nil {
+		t.Fatalf("BeginPasskeyRegistration: %v", err)
+	}
+	credential, err := phone.Register(ceremony.Options)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	registered, err := svc.FinishPasskeyRegistration(ctx, uid, ceremony.SessionToken, "Phone", credential)
+	if err != nil {
+		t.Fatalf("FinishPasskeyRegistration: %v", err)
+	}
+	if registered.UserID != uid || registered.Name != "Phone" || !registered.BackupEligible {
+		t.Fatalf("FinishPasskeyRegistration = %+v", registered)
+	}
+	if _, err := svc.FinishPasskeyRegistration(ctx, uid, ceremony.SessionToken, "Phone", credential); !errors.Is(err, application.ErrInvalidPasskeyCeremony) {
+		t.Fatalf("FinishPasskeyRegistration(used session) = %v, want ErrInvalidPasskeyCeremony", err)
+	}
+
+	// The registered passkey is excluded from further registrations.
+	ceremony, _ = svc.BeginPasskeyRegistration(ctx, uid)
+	if _, err := phone.Register(ceremony.Options); err == nil {
+		t.Fatal("registration options do not exclude the existing passkey")
+	}
+
+	login := func(a *passkeytest.Authenticator) (*dto.LoginResponse, []byte, error) {
+		t.Helper()
+		ceremony, err := svc.BeginPasskeyLogin(ctx)
+		if err != nil {
+			t.Fatalf("BeginPasskeyLogin: %v", err)
+		}
+		assertion, err := a.Login(ceremony.Options)
+		if err != nil {
+			t.Fatalf("Login: %v", err)
+		}
+		session, err := svc.FinishPasskeyLogin(ctx, ceremony.SessionToken, assertion, "phone", "10.0.0.1", "app")
+		return session, assertion, err
+	}
+
+	session, assertion, err := login(phone)
+	if err != nil {
+		t.Fatalf("FinishPasskeyLogin: %v", err)
+	}
+	if session.AccessToken == "" || session.RefreshToken == "" || session.User.UID != uid || session.User.Email != "ama@example.com" {
+		t.Fatalf("FinishPasskeyLogin = %+v, want a session for %s", session, uid)
+	}
+	passkeys, _ := svc.ListPasskeys(ctx, uid)
+	if len(passkeys) != 1 || passkeys[0].LastUsedAt == nil {
+		t.Fatalf("ListPasskeys = %+v, want the passkey marked used", passkeys)
+	}
+
+	// An assertion is bound to the challenge of its own ceremony.
+	replay, err := svc.BeginPasskeyLogin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.FinishPasskeyLogin(ctx, replay.SessionToken, assertion, "", "", ""); !errors.Is(err, application.ErrPasskeyVerification) {
+		t.Fatalf("FinishPasskeyLogin(replayed assertion) = %v, want ErrPasskeyVerification", err)
+	}
+
+	// A security key whose counter falls behind has been cloned.
+	key := passkeytest.New(testOrigin)
+	key.Counting = true
+	ceremony, _ = svc.BeginPasskeyRegistration(ctx, uid)
+	credential, err = key.Register(ceremony.Options)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if _, err := svc.FinishPasskeyRegistration(ctx, uid, ceremony.SessionToken, "", credential); err != nil {
+		t.Fatalf("FinishPasskeyRegistration(security key): %v", err)
+	}
+	clone := key.Clone()
+	if _, _, err := login(key); err != nil {
+		t.Fatalf("FinishPasskeyLogin(security key): %v", err)
+	}
+	if _, _, err := login(clone); !errors.Is(err, application.ErrPasskeyVerification) {
+		t.Fatalf("FinishPasskeyLogin(cloned key) = %v, want ErrPasskeyVerification", err)
+	}
+	events, _ := svc.GetSecurityEvents(ctx, uid)
+	if len(events) != 1 || events[0].Type != domain.SecurityEventPasskeyCloned {
+		t.Fatalf("GetSecurityEvents = %+v, want one cloned passkey event", events)
+	}
+
+	if err := svc.DeletePasskey(ctx, uid, registered.ID); err != nil {
+		t.Fatalf("DeletePasskey: %v", err)
+	}
+	if _, _, err := login(phone); !errors.Is(err, application.ErrPasskeyVerification) {
+		t.Fatalf("FinishPasskeyLogin(deleted passkey) = %v, want ErrPasskeyVerification", err)
+	}
+}
+
+func TestLoginBackoffLockoutAndUnlock(t *testing.T) {
+	ctx := context.Background()
+	mailer := &recordingMailer{}
+	auth, db, svc := newLocalAuthServiceWithMailer(t, mailer)
+	uid, err := auth.CreateAuthUser(ctx, "ama@example.com", "secret123", "Ama", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const account = "account:ama@example.com"
+	throttled := func(_ *dto.LoginResponse, err error) *application.LoginThrottledError {
+		t.Helper()
+		var locked *application.LoginThrottledError
+		if !errors.As(err, &locked) || status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("Login = %v, want a LoginThrottledError", err)
+		}
+		return locked
+	}
+
+	// Each failure comes from another address so only the account counts.
+	for i := 1; i <= 10; i++ {
+		_, err := svc.Login(ctx, "Ama@Example.com", "wrong", "", fmt.Sprintf("10.0.0.%d", i), "")
+		if err == nil || errors.As(err, new(*application.LoginThrottledError)) {
+			t.Fatalf("Login #%d with a wrong password = %v, want invalid credentials", i, err)
+		}
+		attempts, err := db.LoginAttemptRepository.GetLoginAttempts(ctx, account)
+		if err != nil || attempts.Failures != i {
+			t.Fatalf("GetLoginAttempts after %d failures = %+v, %v", i, attempts, err)
+		}
+		if i < 3 {
+			if attempts.LockedUntil != nil {
+				t.Fatalf("account held back after %d failures", i)
+			}
+			continue
+		}
+		if i == 10 {
+			break
+		}
+		// The delay doubles with every failure and holds back even the
+		// right password.
+		wait := time.Second << (i - 3)
+		if got := throttled(svc.Login(ctx, "ama@example.com", "secret123", "", "10.0.1.1", "")).RetryAfter(); got < wait || got > wait+time.Second {
+			t.Fatalf("RetryAfter after %d failures = %v, want %v", i, got, wait)
+		}
+		// Wait the delay out.
+		if err := db.LoginAttemptRepository.LockLogin(ctx, account, time.Now().Add(-time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := throttled(svc.Login(ctx, "ama@example.com", "secret123", "", "10.0.1.1", "")).RetryAfter(); got < 59*time.Minute {
+		t.Fatalf("RetryAfter after lockout = %v, want an hour", got)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].email != "ama@example.com" || mailer.sent[0].token == "" {
+		t.Fatalf("sent %+v, want one unlock email to ama@example.com", mailer.sent)
+	}
+	events, _ := svc.GetSecurityEvents(ctx, uid)
+	if len(events) != 1 || events[0].Type != domain.SecurityEventAccountLocked || events[0].IPAddress != "10.0.0.10" {
+		t.Fatalf("GetSecurityEvents = %+v, want the lockout", events)
+	}
+
+	if err := svc.UnlockAccount(ctx, "not-the-token", "", ""); !errors.Is(err, application.ErrInvalidAccountUnlock) {
+		t.Fatalf("UnlockAccount(wrong token) = %v, want ErrInvalidAccountUnlock", err)
+	}
+	if err := svc.UnlockAccount(ctx, mailer.sent[0].token, "10.0.2.1", "mail"); err != nil {
+		t.Fatalf("UnlockAccount: %v", err)
+	}
+	if err := svc.UnlockAccount(ctx, mailer.sent[0].token, "", ""); !errors.Is(err, application.ErrInvalidAccountUnlock) {
+		t.Fatalf("UnlockAccount(used token) = %v, want ErrInvalidAccountUnlock", err)
+	}
+	if _, err := svc.Login(ctx, "ama@example.com", "secret123", "", "10.0.1.1", ""); err != nil {
+		t.Fatalf("Login after unlock: %v", err)
+	}
+
+	// A successful login starts the account's count over.
+	_, _ = svc.Login(ctx, "ama@example.com", "wrong", "", "10.0.1.1", "")
+	if _, err := svc.Login(ctx, "ama@example.com", "secret123", "", "10.0.1.1", ""); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if _, err := db.LoginAttemptRepository.GetLoginAttempts(ctx, account); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetLoginAttempts after a successful login = %v, want NotFound", err)
+	}
+
+	// One address guessing at many accounts is held back too, without
+	// mailing anyone.
+	for i := 1; i <= 10; i++ {
+		_, _ = svc.Login(ctx, fmt.Sprintf("user%d@example.com", i), "guess", "", "192.0.2.1", "")
+	}
+	throttled(svc.Login(ctx, "ama@example.com", "secret123", "", "192.0.2.1", ""))
+	if _, err := svc.Login(ctx, "ama@example.com", "secret123", "", "192.0.2.2", ""); err != nil {
+		t.Fatalf("Login from another address: %v", err)
+	}
+	if len(mailer.sent) != 1 {
+		t.Fatalf("sent %d unlock emails, want 1", len(mailer.sent))
+	}
+}
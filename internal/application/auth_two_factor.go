@@ -0,0 +1,271 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	totpIssuer            = "Budgeting"
+	recoveryCodeCount     = 10
+	twoFactorChallengeTTL = 5 * time.Minute
+	maxTwoFactorAttempts  = 5 // wrong codes before a challenge is dropped
+)
+
+// EnrollTwoFactor starts (or restarts) TOTP enrollment. Two-factor stays off
+// until ConfirmTwoFactor sees a code from the authenticator app.
+func (s *AuthService) EnrollTwoFactor(ctx context.Context, userID, accountName string) (*dto.TwoFactorEnrollment, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, ErrValidation
+	}
+	existing, err := s.twoFactor.GetTwoFactor(ctx, userID)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+	if existing != nil && existing.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	tf := &domain.TwoFactor{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.twoFactor.SaveTwoFactor(ctx, tf); err != nil {
+		return nil, err
+	}
+
+	account := strings.TrimSpace(accountName)
+	if account == "" {
+		account = userID
+	}
+	return &dto.TwoFactorEnrollment{Secret: secret, URI: totpURI(totpIssuer, account, secret)}, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication once code matches the
+// enrolled secret and returns the initial recovery codes.
+func (s *AuthService) ConfirmTwoFactor(ctx context.Context, userID, code string) (*dto.RecoveryCodes, error) {
+	tf, err := s.twoFactor.GetTwoFactor(ctx, userID)
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tf.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := matchTOTP(tf.Secret, normalizeCode(code), time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	plain, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	tf.Enabled = true
+	tf.EnabledAt = &now
+	tf.LastUsedStep = step
+	tf.RecoveryCodes = hashes
+	if err := s.twoFactor.SaveTwoFactor(ctx, tf); err != nil {
+		return nil, err
+	}
+	return &dto.RecoveryCodes{Codes: plain}, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off. It takes a current
+// TOTP code or a recovery code so a stolen session alone cannot do it.
+func (s *AuthService) DisableTwoFactor(ctx context.Context, userID, code string) error {
+	tf, err := s.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.verifyTwoFactorCode(ctx, tf, code); err != nil {
+		return err
+	}
+	return s.twoFactor.DeleteTwoFactor(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces every recovery code of the user.
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID, code string) (*dto.RecoveryCodes, error) {
+	tf, err := s.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifyTwoFactorCode(ctx, tf, code); err != nil {
+		return nil, err
+	}
+
+	// Reload so the step recorded while verifying is not overwritten.
+	tf, err = s.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	plain, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	tf.RecoveryCodes = hashes
+	if err := s.twoFactor.SaveTwoFactor(ctx, tf); err != nil {
+		return nil, err
+	}
+	return &dto.RecoveryCodes{Codes: plain}, nil
+}
+
+// CompleteTwoFactorLogin trades a login challenge and a TOTP or recovery code
+// for a session. A challenge is dropped after too many wrong codes.
+func (s *AuthService) CompleteTwoFactorLogin(ctx context.Context, challengeToken, code string, deviceInfo, ipAddress, userAgent string) (*dto.LoginResponse, error) {
+	hash := s.tokenGenerator.HashToken(challengeToken)
+	challenge, err := s.twoFactor.GetTwoFactorChallenge(ctx, hash)
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(challenge.ExpiresAt) || challenge.Attempts >= maxTwoFactorAttempts {
+		_ = s.twoFactor.DeleteTwoFactorChallenge(ctx, hash)
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	if err := s.checkLoginThrottle(ctx, challenge.Email, ipAddress); err != nil {
+		return nil, err
+	}
+
+	tf, err := s.enabledTwoFactor(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifyTwoFactorCode(ctx, tf, code); err != nil {
+		if err == ErrInvalidTwoFactorCode {
+			// Wrong codes count against the account like wrong passwords, so
+			// fresh challenges cannot be used to keep guessing.
+			s.recordLoginFailure(ctx, challenge.Email, ipAddress, userAgent)
+			if attempts, aerr := s.twoFactor.RecordChallengeAttempt(ctx, hash); aerr == nil && attempts >= maxTwoFactorAttempts {
+				_ = s.twoFactor.DeleteTwoFactorChallenge(ctx, hash)
+			}
+		}
+		return nil, err
+	}
+
+	// Deleting is what consumes the challenge; losing a race to another
+	// request with the same challenge means this one does not get a session.
+	if err := s.twoFactor.DeleteTwoFactorChallenge(ctx, hash); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+
+	user, err := s.tokenAuth.GetUserByEmail(ctx, challenge.Email)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.startSession(ctx, user, deviceInfo, ipAddress, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	s.resetLoginFailures(ctx, challenge.Email, user.UID)
+	return resp, nil
+}
+
+func (s *AuthService) createTwoFactorChallenge(ctx context.Context, user *domain.User) (*dto.LoginResponse, error) {
+	token, err := s.tokenGenerator.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	challenge := &domain.TwoFactorChallenge{
+		TokenHash: s.tokenGenerator.HashToken(token),
+		UserID:    user.UID,
+		Email:     user.Email,
+		ExpiresAt: now.Add(twoFactorChallengeTTL),
+		CreatedAt: now,
+	}
+	if err := s.twoFactor.CreateTwoFactorChallenge(ctx, challenge); err != nil {
+		return nil, fmt.Errorf("failed to create two-factor challenge: %w", err)
+	}
+	return &dto.LoginResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresIn:         int64(twoFactorChallengeTTL.Seconds()),
+	}, nil
+}
+
+func (s *AuthService) enabledTwoFactor(ctx context.Context, userID string) (*domain.TwoFactor, error) {
+	tf, err := s.twoFactor.GetTwoFactor(ctx, userID)
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !tf.Enabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	return tf, nil
+}
+
+// verifyTwoFactorCode accepts a TOTP code that was not used before or an
+// unused recovery code, and marks it used.
+func (s *AuthService) verifyTwoFactorCode(ctx context.Context, tf *domain.TwoFactor, code string) error {
+	code = normalizeCode(code)
+	if code == "" {
+		return ErrInvalidTwoFactorCode
+	}
+
+	var err error
+	if step, ok := matchTOTP(tf.Secret, code, time.Now()); ok {
+		err = s.twoFactor.UseTOTPStep(ctx, tf.UserID, step)
+	} else if len(code) == totpDigits {
+		return ErrInvalidTwoFactorCode
+	} else {
+		err = s.twoFactor.UseRecoveryCode(ctx, tf.UserID, s.tokenGenerator.HashToken(code))
+	}
+
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.NotFound, codes.FailedPrecondition:
+		return ErrInvalidTwoFactorCode
+	default:
+		return err
+	}
+}
+
+// newRecoveryCodes returns the codes to show the user, formatted as
+// xxxx-xxxx-xxxx-xxxx, and the hashes to store.
+func (s *AuthService) newRecoveryCodes() ([]string, []string, error) {
+	plain := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range plain {
+		raw := make([]byte, 10)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(raw))
+		plain[i] = code[0:4] + "-" + code[4:8] + "-" + code[8:12] + "-" + code[12:16]
+		hashes[i] = s.tokenGenerator.HashToken(code)
+	}
+	return plain, hashes, nil
+}
+
+// normalizeCode drops the separators users type or paste along with a code.
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+}
@@ -0,0 +1,525 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type BudgetService struct {
+	repo         ports.BudgetRepoPort
+	categoryRepo ports.CategoryRepoPort
+	expenseRepo  ports.ExpenseRepoPort
+	incomeRepo   ports.IncomeRepoPort
+	userRepo     ports.UserRepository
+	rates        ports.ExchangeRateProvider
+}
+
+func NewBudgetService(repo ports.BudgetRepoPort, categoryRepo ports.CategoryRepoPort, expenseRepo ports.ExpenseRepoPort, incomeRepo ports.IncomeRepoPort, userRepo ports.UserRepository, rates ports.ExchangeRateProvider) *BudgetService {
+	return &BudgetService{repo: repo, categoryRepo: categoryRepo, expenseRepo: expenseRepo, incomeRepo: incomeRepo, userRepo: userRepo, rates: rates}
+}
+
+var _ ports.BudgetServicePort = (*BudgetService)(nil)
+
+// CreateBudget plans an amount for an expense category in a period. A
+// category has at most one budget per period, and the amount comes out of
+// the to-be-assigned pool like an allocation does.
+func (s *BudgetService) CreateBudget(ctx context.Context, in dto.BudgetInput) (*domain.Budget, error) {
+	userID := strings.TrimSpace(in.UserID)
+	if userID == "" || strings.TrimSpace(in.CategoryID) == "" {
+		return nil, ErrValidation
+	}
+	planned, err := normalizeAmount(in.Planned)
+	if err != nil {
+		return nil, err
+	}
+	period, err := domain.ParsePeriod(strings.TrimSpace(in.Period))
+	if err != nil {
+		return nil, ErrValidation
+	}
+	categoryID, err := resolveCategory(ctx, s.categoryRepo, userID, in.CategoryID, domain.CategoryKindExpense)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureUnique(ctx, userID, categoryID, period.String(), ""); err != nil {
+		return nil, err
+	}
+	if err := s.ensureFunds(ctx, userID, period, planned, nil); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateBudget(ctx, &domain.Budget{
+		UID:        uuid.NewString(),
+		UserID:     userID,
+		CategoryID: categoryID,
+		Period:     period.String(),
+		Planned:    planned,
+		CreatedAt:  time.Now().UTC(),
+		UpdatedAt:  time.Now().UTC(),
+	})
+}
+
+func (s *BudgetService) ListBudgets(ctx context.Context, userID string, period string) ([]*domain.Budget, error) {
+	userID = strings.TrimSpace(userID)
+	period = strings.TrimSpace(period)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	if period != "" {
+		p, err := domain.ParsePeriod(period)
+		if err != nil {
+			return nil, ErrValidation
+		}
+		period = p.String()
+	}
+	budgets, err := s.repo.ListBudgetsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if period == "" {
+		return budgets, nil
+	}
+	var res []*domain.Budget
+	for _, b := range budgets {
+		if b.Period == period {
+			res = append(res, b)
+		}
+	}
+	return res, nil
+}
+
+func (s *BudgetService) GetBudget(ctx context.Context, userID string, budgetID string) (*domain.Budget, error) {
+	userID = strings.TrimSpace(userID)
+	budgetID = strings.TrimSpace(budgetID)
+	if userID == "" || budgetID == "" {
+		return nil, ErrValidation
+	}
+	return s.repo.GetBudget(ctx, userID, budgetID)
+}
+
+// UpdateBudget replaces the budget's category, period and planned amount.
+// Empty category or period keep the current ones. Any increase comes out of
+// the to-be-assigned pool.
+func (s *BudgetService) UpdateBudget(ctx context.Context, userID string, budgetID string, in dto.BudgetInput) (*domain.Budget, error) {
+	userID = strings.TrimSpace(userID)
+	budgetID = strings.TrimSpace(budgetID)
+	if userID == "" || budgetID == "" {
+		return nil, ErrValidation
+	}
+	planned, err := normalizeAmount(in.Planned)
+	if err != nil {
+		return nil, err
+	}
+	budget, err := s.repo.GetBudget(ctx, userID, budgetID)
+	if err != nil {
+		return nil, err
+	}
+	old := *budget
+
+	if strings.TrimSpace(in.CategoryID) != "" {
+		categoryID, err := resolveCategory(ctx, s.categoryRepo, userID, in.CategoryID, domain.CategoryKindExpense)
+		if err != nil {
+			return nil, err
+		}
+		budget.CategoryID = categoryID
+	}
+	if strings.TrimSpace(in.Period) != "" {
+		period, err := domain.ParsePeriod(strings.TrimSpace(in.Period))
+		if err != nil {
+			return nil, ErrValidation
+		}
+		budget.Period = period.String()
+	}
+	if err := s.ensureUnique(ctx, userID, budget.CategoryID, budget.Period, budgetID); err != nil {
+		return nil, err
+	}
+	period, err := domain.ParsePeriod(budget.Period)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureFunds(ctx, userID, period, planned, &old); err != nil {
+		return nil, err
+	}
+
+	budget.Planned = planned
+	budget.UpdatedAt = time.Now().UTC()
+	return s.repo.UpdateBudget(ctx, budget)
+}
+
+func (s *BudgetService) DeleteBudget(ctx context.Context, userID string, budgetID string) error {
+	userID = strings.TrimSpace(userID)
+	budgetID = strings.TrimSpace(budgetID)
+	if userID == "" || budgetID == "" {
+		return ErrValidation
+	}
+	return s.repo.DeleteBudget(ctx, userID, budgetID)
+}
+
+// GetBudgetPeriod compares every budget in the period with the expenses
+// recorded in it. A budget on a parent category counts the expenses of its
+// subcategories too. Expenses in another currency are converted at the rate
+// of the day they were recorded; a budget that cannot be converted for want
+// of a rate is marked Unconverted rather than failing the whole period. The
+// view also carries the period's to-be-assigned pool.
+func (s *BudgetService) GetBudgetPeriod(ctx context.Context, userID string, period string) (*dto.BudgetPeriodView, error) {
+	userID = strings.TrimSpace(userID)
+	p, err := domain.ParsePeriod(strings.TrimSpace(period))
+	if userID == "" || err != nil {
+		return nil, ErrValidation
+	}
+
+	budgets, err := s.ListBudgets(ctx, userID, p.String())
+	if err != nil {
+		return nil, err
+	}
+	view := &dto.BudgetPeriodView{Period: p.String(), Lines: []dto.BudgetLine{}}
+
+	pool, err := s.pool(ctx, userID, p)
+	if err != nil {
+		return nil, err
+	}
+	flow := pool.flow(p)
+	view.Carried = pool.carried(p)
+	view.Received, view.Expected, view.Assigned = flow.received, flow.expected, flow.assigned
+	view.ToBeAssigned = domain.NewMoney(view.Carried.MinorUnits+flow.net().MinorUnits, pool.currency)
+	for uid := range pool.unconvertedIncomes {
+		view.UnconvertedIncomes = append(view.UnconvertedIncomes, uid)
+	}
+	sort.Strings(view.UnconvertedIncomes)
+	if len(budgets) == 0 {
+		return view, nil
+	}
+
+	categories, err := s.categoryRepo.ListCategoriesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.expenseRepo.ListExpensesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range budgets {
+		line := dto.BudgetLine{Budget: b, Planned: b.Planned, Spent: domain.NewMoney(0, b.Planned.Currency), Unconverted: pool.unconverted[b.UID]}
+		if c := findCategory(categories, b.CategoryID); c != nil {
+			line.CategoryName = c.Name
+		}
+		spent, missing, err := spentByPeriod(ctx, s.rates, expenses, categorySubtree(categories, b.CategoryID), b.Planned.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if m, ok := spent[p]; ok {
+			line.Spent = m
+		}
+		line.Unconverted = line.Unconverted || missing[p]
+		if line.Remaining, err = line.Planned.Sub(line.Spent); err != nil {
+			return nil, err
+		}
+		line.Percentage = percentage(line.Spent, line.Planned)
+		view.Lines = append(view.Lines, line)
+	}
+	return view, nil
+}
+
+// AllocateBudget adds Amount to the category's budget for the period,
+// creating the budget if there is none. Positive amounts come out of the
+// to-be-assigned pool and may not exceed what is unassigned in that period
+// or any later one, so an allocation never spends money a later period has
+// already assigned. A budget brought down to zero is deleted.
+func (s *BudgetService) AllocateBudget(ctx context.Context, in dto.BudgetAllocationInput) (*domain.Budget, error) {
+	userID := strings.TrimSpace(in.UserID)
+	if userID == "" || strings.TrimSpace(in.CategoryID) == "" || in.Amount.IsZero() {
+		return nil, ErrValidation
+	}
+	p, err := domain.ParsePeriod(strings.TrimSpace(in.Period))
+	if err != nil {
+		return nil, ErrValidation
+	}
+	categoryID, err := resolveCategory(ctx, s.categoryRepo, userID, in.CategoryID, domain.CategoryKindExpense)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := s.pool(ctx, userID, p)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := normalizeBalance(in.Amount)
+	if !ok || amount.Currency != pool.currency {
+		return nil, ErrValidation
+	}
+	if amount.IsPositive() && amount.MinorUnits > pool.available(p).MinorUnits {
+		return nil, ErrInsufficientFunds
+	}
+
+	budgets, err := s.ListBudgets(ctx, userID, p.String())
+	if err != nil {
+		return nil, err
+	}
+	var budget *domain.Budget
+	for _, b := range budgets {
+		if b.CategoryID == categoryID {
+			budget = b
+		}
+	}
+	if budget == nil {
+		if amount.IsNegative() {
+			return nil, ErrValidation
+		}
+		return s.repo.CreateBudget(ctx, &domain.Budget{
+			UID:        uuid.NewString(),
+			UserID:     userID,
+			CategoryID: categoryID,
+			Period:     p.String(),
+			Planned:    amount,
+			CreatedAt:  time.Now().UTC(),
+			UpdatedAt:  time.Now().UTC(),
+		})
+	}
+
+	if budget.Planned.Currency != amount.Currency {
+		return nil, ErrValidation
+	}
+	planned, err := budget.Planned.Add(amount)
+	if err != nil {
+		return nil, err
+	}
+	if planned.IsNegative() {
+		return nil, ErrValidation
+	}
+	budget.Planned = planned
+	budget.UpdatedAt = time.Now().UTC()
+	if planned.IsZero() {
+		return budget, s.repo.DeleteBudget(ctx, userID, budget.UID)
+	}
+	return s.repo.UpdateBudget(ctx, budget)
+}
+
+// budgetPool is the money flowing through the to-be-assigned pool, by
+// period, in the user's reporting currency. Budgets, incomes and income
+// sources with no rate into that currency are left out and listed in
+// unconverted and unconvertedIncomes.
+type budgetPool struct {
+	currency           string
+	flows              map[domain.Period]*poolFlow
+	unconverted        map[string]bool
+	unconvertedIncomes map[string]bool
+}
+
+type poolFlow struct {
+	received domain.Money
+	expected domain.Money
+	assigned domain.Money
+}
+
+func (f *poolFlow) net() domain.Money {
+	return domain.NewMoney(f.received.MinorUnits+f.expected.MinorUnits-f.assigned.MinorUnits, f.received.Currency)
+}
+
+func (p *budgetPool) flow(period domain.Period) *poolFlow {
+	f, ok := p.flows[period]
+	if !ok {
+		zero := domain.NewMoney(0, p.currency)
+		f = &poolFlow{received: zero, expected: zero, assigned: zero}
+		p.flows[period] = f
+	}
+	return f
+}
+
+// carried is what the periods before period left unassigned.
+func (p *budgetPool) carried(period domain.Period) domain.Money {
+	var total int64
+	for q, f := range p.flows {
+		if q.Before(period) {
+			total += f.net().MinorUnits
+		}
+	}
+	return domain.NewMoney(total, p.currency)
+}
+
+// available is the least that is unassigned in period or any later period
+// with money flowing, which is how much can still be assigned in period.
+func (p *budgetPool) available(period domain.Period) domain.Money {
+	last := period
+	for q := range p.flows {
+		if last.Before(q) {
+			last = q
+		}
+	}
+	balance := p.carried(period).MinorUnits
+	least := int64(0)
+	for q := period; !last.Before(q); q = q.Next() {
+		balance += p.flow(q).net().MinorUnits
+		if q == period || balance < least {
+			least = balance
+		}
+	}
+	return domain.NewMoney(least, p.currency)
+}
+
+// pool gathers the user's recorded incomes, every budget, and the paydays
+// their active income sources have still to come up to the later of through
+// and the last budgeted period. Paydays before today are not expected any
+// more: they were either recorded as incomes or missed.
+func (s *BudgetService) pool(ctx context.Context, userID string, through domain.Period) (*budgetPool, error) {
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	pool := &budgetPool{currency: user.ReportingCurrency(), flows: map[domain.Period]*poolFlow{}, unconverted: map[string]bool{}, unconvertedIncomes: map[string]bool{}}
+	add := func(into *domain.Money, amount domain.Money, on time.Time) error {
+		converted, err := convertMoney(ctx, s.rates, amount, pool.currency, on)
+		if err != nil {
+			return err
+		}
+		into.MinorUnits += converted.MinorUnits
+		return nil
+	}
+
+	incomes, err := s.incomeRepo.ListIncomesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range incomes {
+		if err := add(&pool.flow(domain.PeriodOf(i.CreatedAt)).received, i.Amount, i.CreatedAt); errors.Is(err, domain.ErrExchangeRateNotFound) {
+			pool.unconvertedIncomes[i.UID] = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	budgets, err := s.repo.ListBudgetsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	horizon := through
+	for _, b := range budgets {
+		p, err := domain.ParsePeriod(b.Period)
+		if err != nil {
+			return nil, err
+		}
+		if horizon.Before(p) {
+			horizon = p
+		}
+		if err := add(&pool.flow(p).assigned, b.Planned, p.Start()); errors.Is(err, domain.ErrExchangeRateNotFound) {
+			pool.unconverted[b.UID] = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	sources, err := s.incomeRepo.ListIncomeSourcesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for _, src := range sources {
+		if !src.Active {
+			continue
+		}
+		for payday := src.NextPayAt.UTC(); payday.Before(horizon.End()); payday = advanceNext(payday, src.Frequency) {
+			if payday.Before(today) {
+				continue
+			}
+			if err := add(&pool.flow(domain.PeriodOf(payday)).expected, src.Amount, payday); errors.Is(err, domain.ErrExchangeRateNotFound) {
+				pool.unconvertedIncomes[src.UID] = true
+			} else if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return pool, nil
+}
+
+// ensureFunds refuses to plan more in period than the pool has unassigned
+// there and in later periods. old is the budget being changed, whose amount
+// goes back to the pool first; lowering a budget is always allowed.
+func (s *BudgetService) ensureFunds(ctx context.Context, userID string, period domain.Period, planned domain.Money, old *domain.Budget) error {
+	pool, err := s.pool(ctx, userID, period)
+	if err != nil {
+		return err
+	}
+	amount, err := convertMoney(ctx, s.rates, planned, pool.currency, period.Start())
+	if err != nil {
+		return err
+	}
+	if old != nil && !pool.unconverted[old.UID] {
+		oldPeriod, err := domain.ParsePeriod(old.Period)
+		if err != nil {
+			return err
+		}
+		oldAmount, err := convertMoney(ctx, s.rates, old.Planned, pool.currency, oldPeriod.Start())
+		if err != nil {
+			return err
+		}
+		if oldPeriod == period && amount.MinorUnits <= oldAmount.MinorUnits {
+			return nil
+		}
+		pool.flow(oldPeriod).assigned.MinorUnits -= oldAmount.MinorUnits
+	}
+	if amount.MinorUnits > pool.available(period).MinorUnits {
+		return ErrInsufficientFunds
+	}
+	return nil
+}
+
+func (s *BudgetService) ensureUnique(ctx context.Context, userID, categoryID, period, exceptID string) error {
+	budgets, err := s.repo.ListBudgetsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, b := range budgets {
+		if b.UID != exceptID && b.CategoryID == categoryID && b.Period == period {
+			return ErrValidation
+		}
+	}
+	return nil
+}
+
+// spentByPeriod sums, per period, the expenses recorded in any of
+// categories, converted to currency at the rate of the day they were
+// recorded. Only the splits of a split expense that fall in categories
+// count. Expenses with no rate into currency are left out and their periods
+// reported in missing.
+func spentByPeriod(ctx context.Context, rates ports.ExchangeRateProvider, expenses []*domain.Expense, categories map[string]bool, currency string) (map[domain.Period]domain.Money, map[domain.Period]bool, error) {
+	spent, missing := map[domain.Period]domain.Money{}, map[domain.Period]bool{}
+	for _, e := range expenses {
+		p := domain.PeriodOf(e.CreatedAt)
+		for _, line := range e.Lines() {
+			if !categories[line.CategoryID] {
+				continue
+			}
+			amount, err := convertMoney(ctx, rates, line.Amount, currency, e.CreatedAt)
+			if errors.Is(err, domain.ErrExchangeRateNotFound) {
+				missing[p] = true
+				continue
+			} else if err != nil {
+				return nil, nil, err
+			}
+			total, ok := spent[p]
+			if !ok {
+				total = domain.NewMoney(0, currency)
+			}
+			if spent[p], err = total.Add(amount); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return spent, missing, nil
+}
+
+// percentage returns part as a percentage of whole, rounded to one decimal.
+func percentage(part, whole domain.Money) float64 {
+	if whole.IsZero() {
+		return 0
+	}
+	return math.Round(float64(part.MinorUnits)/float64(whole.MinorUnits)*1000) / 10
+}
@@ -0,0 +1,305 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type CategoryService struct {
+	repo         ports.CategoryRepoPort
+	expenseRepo  ports.ExpenseRepoPort
+	incomeRepo   ports.IncomeRepoPort
+	budgetRepo   ports.BudgetRepoPort
+	envelopeRepo ports.EnvelopeRepoPort
+}
+
+func NewCategoryService(repo ports.CategoryRepoPort, expenseRepo ports.ExpenseRepoPort, incomeRepo ports.IncomeRepoPort, budgetRepo ports.BudgetRepoPort, envelopeRepo ports.EnvelopeRepoPort) *CategoryService {
+	return &CategoryService{repo: repo, expenseRepo: expenseRepo, incomeRepo: incomeRepo, budgetRepo: budgetRepo, envelopeRepo: envelopeRepo}
+}
+
+var _ ports.CategoryServicePort = (*CategoryService)(nil)
+
+func (s *CategoryService) CreateCategory(ctx context.Context, in dto.CategoryInput) (*domain.Category, error) {
+	userID := strings.TrimSpace(in.UserID)
+	name := strings.TrimSpace(in.Name)
+	parentID := strings.TrimSpace(in.ParentID)
+	kind := domain.CategoryKind(strings.ToLower(strings.TrimSpace(in.Kind)))
+	if userID == "" || name == "" {
+		return nil, ErrValidation
+	}
+
+	categories, err := s.repo.ListCategoriesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != "" {
+		parent := findCategory(categories, parentID)
+		if parent == nil {
+			return nil, ErrValidation
+		}
+		if kind == "" {
+			kind = parent.Kind
+		}
+		if parent.Kind != kind {
+			return nil, ErrValidation
+		}
+	}
+	if !kind.Valid() || siblingNameTaken(categories, parentID, kind, name, "") {
+		return nil, ErrValidation
+	}
+
+	return s.repo.CreateCategory(ctx, &domain.Category{
+		UID:       uuid.NewString(),
+		UserID:    userID,
+		ParentID:  parentID,
+		Name:      name,
+		Kind:      kind,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	})
+}
+
+func (s *CategoryService) ListCategories(ctx context.Context, userID string) ([]*domain.Category, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	return s.repo.ListCategoriesByUser(ctx, userID)
+}
+
+func (s *CategoryService) GetCategory(ctx context.Context, userID string, categoryID string) (*domain.Category, error) {
+	userID = strings.TrimSpace(userID)
+	categoryID = strings.TrimSpace(categoryID)
+	if userID == "" || categoryID == "" {
+		return nil, ErrValidation
+	}
+	return s.repo.GetCategory(ctx, userID, categoryID)
+}
+
+// UpdateCategory renames or moves a category. Its kind cannot change, and
+// it cannot be moved below itself or one of its own subcategories.
+func (s *CategoryService) UpdateCategory(ctx context.Context, userID string, categoryID string, in dto.CategoryInput) (*domain.Category, error) {
+	userID = strings.TrimSpace(userID)
+	categoryID = strings.TrimSpace(categoryID)
+	name := strings.TrimSpace(in.Name)
+	parentID := strings.TrimSpace(in.ParentID)
+	kind := domain.CategoryKind(strings.ToLower(strings.TrimSpace(in.Kind)))
+	if userID == "" || categoryID == "" || name == "" {
+		return nil, ErrValidation
+	}
+
+	category, err := s.repo.GetCategory(ctx, userID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if kind != "" && kind != category.Kind {
+		return nil, ErrValidation
+	}
+
+	categories, err := s.repo.ListCategoriesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != "" {
+		parent := findCategory(categories, parentID)
+		if parent == nil || parent.Kind != category.Kind || categorySubtree(categories, categoryID)[parentID] {
+			return nil, ErrValidation
+		}
+	}
+	if siblingNameTaken(categories, parentID, category.Kind, name, categoryID) {
+		return nil, ErrValidation
+	}
+
+	category.Name = name
+	category.ParentID = parentID
+	category.UpdatedAt = time.Now().UTC()
+	return s.repo.UpdateCategory(ctx, category)
+}
+
+// DeleteCategory refuses to delete a category that still has subcategories
+// or is assigned to an expense, income, budget or envelope.
+func (s *CategoryService) DeleteCategory(ctx context.Context, userID string, categoryID string) error {
+	userID = strings.TrimSpace(userID)
+	categoryID = strings.TrimSpace(categoryID)
+	if userID == "" || categoryID == "" {
+		return ErrValidation
+	}
+	if _, err := s.repo.GetCategory(ctx, userID, categoryID); err != nil {
+		return err
+	}
+
+	categories, err := s.repo.ListCategoriesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, c := range categories {
+		if c.ParentID == categoryID {
+			return ErrCategoryInUse
+		}
+	}
+
+	expenses, err := s.expenseRepo.ListExpensesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, e := range expenses {
+		for _, line := range e.Lines() {
+			if line.CategoryID == categoryID {
+				return ErrCategoryInUse
+			}
+		}
+	}
+	incomes, err := s.incomeRepo.ListIncomesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, i := range incomes {
+		if i.CategoryID == categoryID {
+			return ErrCategoryInUse
+		}
+	}
+	budgets, err := s.budgetRepo.ListBudgetsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, b := range budgets {
+		if b.CategoryID == categoryID {
+			return ErrCategoryInUse
+		}
+	}
+	envelopes, err := s.envelopeRepo.ListEnvelopesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, e := range envelopes {
+		if e.CategoryID == categoryID {
+			return ErrCategoryInUse
+		}
+	}
+
+	return s.repo.DeleteCategory(ctx, userID, categoryID)
+}
+
+// SeedDefaultCategories adds whatever part of domain.DefaultCategories the
+// user does not have yet, matching names case-insensitively, and returns the
+// categories it created. Running it twice creates nothing the second time.
+func (s *CategoryService) SeedDefaultCategories(ctx context.Context, userID string) ([]*domain.Category, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	categories, err := s.repo.ListCategoriesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []*domain.Category
+	ensure := func(parentID, name string, kind domain.CategoryKind) (*domain.Category, error) {
+		for _, c := range categories {
+			if c.ParentID == parentID && c.Kind == kind && strings.EqualFold(c.Name, name) {
+				return c, nil
+			}
+		}
+		c, err := s.repo.CreateCategory(ctx, &domain.Category{
+			UID:       uuid.NewString(),
+			UserID:    userID,
+			ParentID:  parentID,
+			Name:      name,
+			Kind:      kind,
+			CreatedAt: time.Now().UTC(),
+			UpdatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+		created = append(created, c)
+		return c, nil
+	}
+
+	for _, tmpl := range domain.DefaultCategories {
+		parent, err := ensure("", tmpl.Name, tmpl.Kind)
+		if err != nil {
+			return created, err
+		}
+		for _, child := range tmpl.Children {
+			if _, err := ensure(parent.UID, child, tmpl.Kind); err != nil {
+				return created, err
+			}
+		}
+	}
+	return created, nil
+}
+
+func findCategory(categories []*domain.Category, id string) *domain.Category {
+	for _, c := range categories {
+		if c.UID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func siblingNameTaken(categories []*domain.Category, parentID string, kind domain.CategoryKind, name, exceptID string) bool {
+	for _, c := range categories {
+		if c.UID != exceptID && c.ParentID == parentID && c.Kind == kind && strings.EqualFold(c.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// categorySubtree returns the IDs of rootID and all of its descendants.
+func categorySubtree(categories []*domain.Category, rootID string) map[string]bool {
+	subtree := map[string]bool{rootID: true}
+	for grew := true; grew; {
+		grew = false
+		for _, c := range categories {
+			if !subtree[c.UID] && subtree[c.ParentID] {
+				subtree[c.UID] = true
+				grew = true
+			}
+		}
+	}
+	return subtree
+}
+
+// resolveCategory checks that categoryID, if set, names one of the user's
+// categories of the given kind.
+func resolveCategory(ctx context.Context, repo ports.CategoryRepoPort, userID, categoryID string, kind domain.CategoryKind) (string, error) {
+	categoryID = strings.TrimSpace(categoryID)
+	if categoryID == "" {
+		return "", nil
+	}
+	category, err := repo.GetCategory(ctx, userID, categoryID)
+	if err != nil {
+		return "", err
+	}
+	if category.Kind != kind {
+		return "", ErrValidation
+	}
+	return categoryID, nil
+}
+
+// categoryFilter returns the set of category IDs a listing filtered on
+// categoryID should match, or nil when it should match everything.
+func categoryFilter(ctx context.Context, repo ports.CategoryRepoPort, userID, categoryID string) (map[string]bool, error) {
+	categoryID = strings.TrimSpace(categoryID)
+	if categoryID == "" {
+		return nil, nil
+	}
+	if _, err := repo.GetCategory(ctx, userID, categoryID); err != nil {
+		return nil, err
+	}
+	categories, err := repo.ListCategoriesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return categorySubtree(categories, categoryID), nil
+}
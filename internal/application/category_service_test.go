@@ -0,0 +1,196 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestSeedDefaultCategoriesFillsInTheTree(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	categories := application.NewCategoryService(db.CategoryRepository, db.ExpenseRepository, db.IncomeRepository, db.BudgetRepository, db.EnvelopeRepository)
+
+	const userID = "u1"
+	// A category the user already has is kept rather than duplicated.
+	food, err := categories.CreateCategory(ctx, dto.CategoryInput{UserID: userID, Name: "food", Kind: "expense"})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+
+	want := 0
+	for _, tmpl := range domain.DefaultCategories {
+		want += 1 + len(tmpl.Children)
+	}
+	created, err := categories.SeedDefaultCategories(ctx, userID)
+	if err != nil {
+		t.Fatalf("SeedDefaultCategories: %v", err)
+	}
+	if len(created) != want-1 {
+		t.Fatalf("SeedDefaultCategories created %d categories, want %d", len(created), want-1)
+	}
+
+	all, err := categories.ListCategories(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListCategories: %v", err)
+	}
+	byName := map[string]*domain.Category{}
+	for _, c := range all {
+		byName[c.Name] = c
+	}
+	if len(all) != want || byName["Food"] != nil {
+		t.Fatalf("ListCategories = %d categories with Food %v, want %d and the user's own food", len(all), byName["Food"], want)
+	}
+	if g := byName["Groceries"]; g == nil || g.ParentID != food.UID || g.Kind != domain.CategoryKindExpense {
+		t.Fatalf("Groceries = %+v, want an expense category under the user's food", g)
+	}
+	if d := byName["Dividends"]; d == nil || d.ParentID != byName["Investments"].UID || d.Kind != domain.CategoryKindIncome {
+		t.Fatalf("Dividends = %+v, want an income category under Investments", d)
+	}
+
+	if again, err := categories.SeedDefaultCategories(ctx, userID); err != nil || len(again) != 0 {
+		t.Fatalf("SeedDefaultCategories again = %d created, %v, want none", len(again), err)
+	}
+}
+
+func TestCategoryHierarchyRules(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	categories := application.NewCategoryService(db.CategoryRepository, db.ExpenseRepository, db.IncomeRepository, db.BudgetRepository, db.EnvelopeRepository)
+	expenses := application.NewExpenseService(db.ExpenseRepository, db.CategoryRepository, db.AccountRepository, db.DebtRepository)
+
+	const userID = "u1"
+	create := func(parentID, name, kind string) (*domain.Category, error) {
+		return categories.CreateCategory(ctx, dto.CategoryInput{UserID: userID, ParentID: parentID, Name: name, Kind: kind})
+	}
+	mustCreate := func(parentID, name, kind string) *domain.Category {
+		t.Helper()
+		c, err := create(parentID, name, kind)
+		if err != nil {
+			t.Fatalf("CreateCategory(%s): %v", name, err)
+		}
+		return c
+	}
+	food := mustCreate("", "Food", "expense")
+	groceries := mustCreate(food.UID, "Groceries", "")
+	produce := mustCreate(groceries.UID, "Produce", "")
+	salary := mustCreate("", "Salary", "income")
+	if groceries.Kind != domain.CategoryKindExpense || groceries.ParentID != food.UID {
+		t.Fatalf("Groceries = %+v, want an expense subcategory of Food", groceries)
+	}
+
+	for name, in := range map[string]dto.CategoryInput{
+		"income under an expense": {UserID: userID, ParentID: food.UID, Name: "Refunds", Kind: "income"},
+		"unknown parent":          {UserID: userID, ParentID: "missing", Name: "Snacks"},
+		"taken sibling name":      {UserID: userID, ParentID: food.UID, Name: "groceries"},
+		"unknown kind":            {UserID: userID, Name: "Misc", Kind: "transfer"},
+	} {
+		if _, err := categories.CreateCategory(ctx, in); !errors.Is(err, application.ErrValidation) {
+			t.Errorf("CreateCategory(%s) err = %v, want ErrValidation", name, err)
+		}
+	}
+	// The same name is fine under another parent or of another kind.
+	mustCreate(salary.UID, "Groceries", "")
+	mustCreate("", "Groceries", "expense")
+
+	update := func(c *domain.Category, parentID, kind string) error {
+		_, err := categories.UpdateCategory(ctx, userID, c.UID, dto.CategoryInput{Name: c.Name, ParentID: parentID, Kind: kind})
+		return err
+	}
+	if err := update(food, produce.UID, ""); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("moving Food below its own subcategory: err = %v, want ErrValidation", err)
+	}
+	if err := update(groceries, food.UID, "income"); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("changing the kind: err = %v, want ErrValidation", err)
+	}
+	if err := update(produce, food.UID, ""); err != nil {
+		t.Fatalf("moving Produce up to Food: %v", err)
+	}
+
+	if _, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: userID, Source: "Market", CategoryID: produce.UID, Amount: domain.NewMoney(1500, "USD")}); err != nil {
+		t.Fatalf("AddExpense: %v", err)
+	}
+	if err := categories.DeleteCategory(ctx, userID, food.UID); !errors.Is(err, application.ErrCategoryInUse) {
+		t.Fatalf("DeleteCategory(with subcategories) err = %v, want ErrCategoryInUse", err)
+	}
+	if err := categories.DeleteCategory(ctx, userID, produce.UID); !errors.Is(err, application.ErrCategoryInUse) {
+		t.Fatalf("DeleteCategory(with an expense) err = %v, want ErrCategoryInUse", err)
+	}
+	if err := categories.DeleteCategory(ctx, userID, groceries.UID); err != nil {
+		t.Fatalf("DeleteCategory(unused): %v", err)
+	}
+}
+
+func TestListExpensesFiltersByCategorySubtree(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	categories := application.NewCategoryService(db.CategoryRepository, db.ExpenseRepository, db.IncomeRepository, db.BudgetRepository, db.EnvelopeRepository)
+	expenses := application.NewExpenseService(db.ExpenseRepository, db.CategoryRepository, db.AccountRepository, db.DebtRepository)
+
+	const userID = "u1"
+	category := func(parentID, name string) string {
+		t.Helper()
+		c, err := categories.CreateCategory(ctx, dto.CategoryInput{UserID: userID, ParentID: parentID, Name: name, Kind: "expense"})
+		if err != nil {
+			t.Fatalf("CreateCategory(%s): %v", name, err)
+		}
+		return c.UID
+	}
+	food := category("", "Food")
+	groceries := category(food, "Groceries")
+	rent := category("", "Rent")
+
+	add := func(in dto.AddExpenseInput) {
+		t.Helper()
+		in.UserID = userID
+		if _, err := expenses.AddExpense(ctx, in); err != nil {
+			t.Fatalf("AddExpense(%s): %v", in.Source, err)
+		}
+	}
+	add(dto.AddExpenseInput{Source: "Market", CategoryID: groceries, Amount: domain.NewMoney(3000, "USD")})
+	add(dto.AddExpenseInput{Source: "Cafe", CategoryID: food, Amount: domain.NewMoney(800, "USD")})
+	add(dto.AddExpenseInput{Source: "Landlord", CategoryID: rent, Amount: domain.NewMoney(90000, "USD")})
+	add(dto.AddExpenseInput{Source: "Supermarket", Amount: domain.NewMoney(5000, "USD"), Splits: []domain.ExpenseSplit{
+		{CategoryID: groceries, Amount: domain.NewMoney(4000, "USD")},
+		{CategoryID: rent, Amount: domain.NewMoney(1000, "USD")},
+	}})
+	add(dto.AddExpenseInput{Source: "Uncategorized", Amount: domain.NewMoney(100, "USD")})
+
+	sources := func(categoryID string) []string {
+		t.Helper()
+		list, err := expenses.ListExpenses(ctx, userID, dto.TransactionFilter{CategoryID: categoryID})
+		if err != nil {
+			t.Fatalf("ListExpenses(%s): %v", categoryID, err)
+		}
+		var res []string
+		for _, e := range list {
+			res = append(res, e.Source)
+		}
+		sort.Strings(res)
+		return res
+	}
+	for _, c := range []struct {
+		name, categoryID string
+		want             []string
+	}{
+		{"all", "", []string{"Cafe", "Landlord", "Market", "Supermarket", "Uncategorized"}},
+		{"parent", food, []string{"Cafe", "Market", "Supermarket"}},
+		{"leaf", groceries, []string{"Market", "Supermarket"}},
+		{"split line", rent, []string{"Landlord", "Supermarket"}},
+	} {
+		if got := sources(c.categoryID); !slices.Equal(got, c.want) {
+			t.Errorf("ListExpenses(%s) = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	if _, err := expenses.ListExpenses(ctx, userID, dto.TransactionFilter{CategoryID: "missing"}); err == nil {
+		t.Fatal("ListExpenses accepted an unknown category")
+	}
+}
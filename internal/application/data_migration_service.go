@@ -0,0 +1,588 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DataStore is the set of repositories a data migration reads from or
+// writes to. Credentials is nil for backends that do not support local
+// auth.
+type DataStore struct {
+	Users          ports.UserRepository
+	Categories     ports.CategoryRepoPort
+	Accounts       ports.AccountRepoPort
+	Transfers      ports.TransferRepoPort
+	Budgets        ports.BudgetRepoPort
+	Envelopes      ports.EnvelopeRepoPort
+	SavingsGoals   ports.SavingsGoalRepoPort
+	Debts          ports.DebtRepoPort
+	Holdings       ports.HoldingRepoPort
+	NetWorth       ports.NetWorthSnapshotRepoPort
+	Incomes        ports.IncomeRepoPort
+	Expenses       ports.ExpenseRepoPort
+	RefreshTokens  ports.RefreshTokenRepository
+	SecurityEvents ports.SecurityEventRepoPort
+	TwoFactor      ports.TwoFactorRepoPort
+	Passkeys       ports.PasskeyRepoPort
+	Credentials    ports.CredentialRepository
+	ExchangeRates  ports.ExchangeRateRepository
+}
+
+// DataMigrationService copies every user's data from one storage backend to
+// another, one user at a time. Writes are idempotent, so a user that was
+// interrupted half-way is simply copied again on the next run.
+type DataMigrationService struct {
+	source     DataStore
+	target     DataStore
+	checkpoint ports.MigrationCheckpoint
+}
+
+func NewDataMigrationService(source, target DataStore, checkpoint ports.MigrationCheckpoint) *DataMigrationService {
+	return &DataMigrationService{source: source, target: target, checkpoint: checkpoint}
+}
+
+var _ ports.DataMigrationServicePort = (*DataMigrationService)(nil)
+
+func (s *DataMigrationService) Copy(ctx context.Context) (*dto.DataMigrationReport, error) {
+	userIDs, err := s.sourceUserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := s.checkpoint.CompletedUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.DataMigrationReport{}
+	// Rates are shared by all users and saving them is an upsert, so they
+	// are copied again on every run.
+	rates, err := s.source.ExchangeRates.ListRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.target.ExchangeRates.SaveRates(ctx, rates); err != nil {
+		return nil, err
+	}
+	report.ExchangeRates = len(rates)
+
+	for i, userID := range userIDs {
+		if completed[userID] {
+			report.SkippedUsers++
+			continue
+		}
+		if err := s.copyUser(ctx, userID, report); err != nil {
+			return report, fmt.Errorf("copy user %s: %w", userID, err)
+		}
+		if err := s.checkpoint.MarkUserCompleted(ctx, userID); err != nil {
+			return report, err
+		}
+		report.Users++
+		logger.Info("Copied user data", zap.String("user_id", userID), zap.Int("done", i+1), zap.Int("total", len(userIDs)))
+	}
+	return report, nil
+}
+
+func (s *DataMigrationService) copyUser(ctx context.Context, userID string, report *dto.DataMigrationReport) error {
+	user, err := s.source.Users.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.target.Users.CreateUser(ctx, user); err != nil {
+		return err
+	}
+
+	categories, err := s.source.Categories.ListCategoriesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, category := range categories {
+		if _, err := s.target.Categories.CreateCategory(ctx, category); err != nil {
+			return err
+		}
+		report.Categories++
+	}
+
+	accounts, err := s.source.Accounts.ListAccountsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		if _, err := s.target.Accounts.CreateAccount(ctx, account); err != nil {
+			return err
+		}
+		report.Accounts++
+	}
+
+	incomes, err := s.source.Incomes.ListIncomesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, income := range incomes {
+		if _, err := s.target.Incomes.CreateIncome(ctx, income); err != nil {
+			return err
+		}
+		report.Incomes++
+	}
+
+	sources, err := s.source.Incomes.ListIncomeSourcesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, src := range sources {
+		if _, err := s.target.Incomes.CreateIncomeSource(ctx, src); err != nil {
+			return err
+		}
+		report.IncomeSources++
+	}
+
+	debts, err := s.source.Debts.ListDebtsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, debt := range debts {
+		if _, err := s.target.Debts.CreateDebt(ctx, debt); err != nil {
+			return err
+		}
+		report.Debts++
+	}
+
+	expenses, err := s.source.Expenses.ListExpensesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, expense := range expenses {
+		if _, err := s.target.Expenses.CreateExpense(ctx, expense); err != nil {
+			return err
+		}
+		report.Expenses++
+	}
+
+	transfers, err := s.source.Transfers.ListTransfersByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, transfer := range transfers {
+		if _, err := s.target.Transfers.CreateTransfer(ctx, transfer); err != nil {
+			return err
+		}
+		report.Transfers++
+	}
+
+	budgets, err := s.source.Budgets.ListBudgetsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, budget := range budgets {
+		if _, err := s.target.Budgets.CreateBudget(ctx, budget); err != nil {
+			return err
+		}
+		report.Budgets++
+	}
+
+	envelopes, err := s.source.Envelopes.ListEnvelopesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, envelope := range envelopes {
+		if _, err := s.target.Envelopes.CreateEnvelope(ctx, envelope); err != nil {
+			return err
+		}
+		report.Envelopes++
+	}
+
+	moves, err := s.source.Envelopes.ListEnvelopeMovesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, move := range moves {
+		if _, err := s.target.Envelopes.CreateEnvelopeMove(ctx, move); err != nil {
+			return err
+		}
+		report.EnvelopeMoves++
+	}
+
+	goals, err := s.source.SavingsGoals.ListSavingsGoalsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, goal := range goals {
+		if _, err := s.target.SavingsGoals.CreateSavingsGoal(ctx, goal); err != nil {
+			return err
+		}
+		report.SavingsGoals++
+	}
+
+	holdings, err := s.source.Holdings.ListHoldingsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, holding := range holdings {
+		if _, err := s.target.Holdings.CreateHolding(ctx, holding); err != nil {
+			return err
+		}
+		report.Holdings++
+	}
+
+	valuations, err := s.source.Holdings.ListValuationsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, valuation := range valuations {
+		if _, err := s.target.Holdings.CreateValuation(ctx, valuation); err != nil {
+			return err
+		}
+		report.Valuations++
+	}
+
+	snapshots, err := s.source.NetWorth.ListNetWorthSnapshots(ctx, userID, time.Time{}, time.Time{})
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if err := s.target.NetWorth.SaveNetWorthSnapshot(ctx, snapshot); err != nil {
+			return err
+		}
+		report.NetWorthSnapshots++
+	}
+
+	tokens, err := s.source.RefreshTokens.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		// Refresh token creation is not an upsert, so skip tokens copied by an
+		// earlier, interrupted run.
+		if _, err := s.target.RefreshTokens.GetByID(ctx, token.ID); err == nil {
+			continue
+		}
+		if err := s.target.RefreshTokens.Create(ctx, token); err != nil {
+			return err
+		}
+		report.RefreshTokens++
+	}
+
+	events, err := s.source.SecurityEvents.ListSecurityEvents(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := s.target.SecurityEvents.CreateSecurityEvent(ctx, event); err != nil {
+			return err
+		}
+		report.SecurityEvents++
+	}
+
+	tf, err := s.source.TwoFactor.GetTwoFactor(ctx, userID)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+	if tf != nil {
+		if err := s.target.TwoFactor.SaveTwoFactor(ctx, tf); err != nil {
+			return err
+		}
+		report.TwoFactor++
+	}
+
+	passkeys, err := s.source.Passkeys.ListPasskeys(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, p := range passkeys {
+		// Passkeys are keyed by credential ID, so a rerun finds them present.
+		if err := s.target.Passkeys.CreatePasskey(ctx, p); err != nil && status.Code(err) != codes.AlreadyExists {
+			return err
+		}
+		report.Passkeys++
+	}
+
+	if s.source.Credentials == nil || s.target.Credentials == nil {
+		return nil
+	}
+	cred, err := s.source.Credentials.GetCredential(ctx, userID)
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := s.target.Credentials.GetCredential(ctx, userID); err == nil {
+		err = s.target.Credentials.UpdateCredential(ctx, cred)
+	} else if status.Code(err) == codes.NotFound {
+		err = s.target.Credentials.CreateCredential(ctx, cred)
+	}
+	if err != nil {
+		return err
+	}
+	report.Credentials++
+	return nil
+}
+
+// Verify compares record counts and per-currency totals for every source
+// user against the target.
+func (s *DataMigrationService) Verify(ctx context.Context) (*dto.DataVerificationReport, error) {
+	userIDs, err := s.sourceUserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.DataVerificationReport{Users: len(userIDs)}
+	mismatch := func(userID, entity, format string, args ...interface{}) {
+		report.Mismatches = append(report.Mismatches, dto.DataMismatch{UserID: userID, Entity: entity, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	targetIDs, err := s.target.Users.ListAllUserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(targetIDs) != len(userIDs) {
+		mismatch("", "users", "source has %d users, target has %d", len(userIDs), len(targetIDs))
+	}
+
+	srcRates, err := s.source.ExchangeRates.ListRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dstRates, err := s.target.ExchangeRates.ListRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(srcRates) != len(dstRates) {
+		mismatch("", "exchange_rates", "count %d in source, %d in target", len(srcRates), len(dstRates))
+	}
+
+	for _, userID := range userIDs {
+		if _, err := s.target.Users.GetUser(ctx, userID); err != nil {
+			mismatch(userID, "user", "not readable in target: %v", err)
+			continue
+		}
+
+		src, err := summarize(ctx, s.source, userID)
+		if err != nil {
+			return nil, err
+		}
+		dst, err := summarize(ctx, s.target, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range []struct {
+			entity   string
+			src, dst int
+		}{
+			{"categories", src.categories, dst.categories},
+			{"accounts", src.accounts, dst.accounts},
+			{"incomes", src.incomes, dst.incomes},
+			{"income_sources", src.incomeSources, dst.incomeSources},
+			{"expenses", src.expenses, dst.expenses},
+			{"transfers", src.transfers, dst.transfers},
+			{"budgets", src.budgets, dst.budgets},
+			{"envelopes", src.envelopes, dst.envelopes},
+			{"envelope_moves", src.envelopeMoves, dst.envelopeMoves},
+			{"savings_goals", src.savingsGoals, dst.savingsGoals},
+			{"debts", src.debts, dst.debts},
+			{"holdings", src.holdings, dst.holdings},
+			{"valuations", src.valuations, dst.valuations},
+			{"net worth snapshots", src.snapshots, dst.snapshots},
+			{"refresh_tokens", src.refreshTokens, dst.refreshTokens},
+			{"security_events", src.securityEvents, dst.securityEvents},
+			{"two_factor", src.twoFactor, dst.twoFactor},
+			{"passkeys", src.passkeys, dst.passkeys},
+			{"credentials", src.credentials, dst.credentials},
+		} {
+			if c.src != c.dst {
+				mismatch(userID, c.entity, "count %d in source, %d in target", c.src, c.dst)
+			}
+		}
+		compareTotals(userID, "incomes", src.incomeTotals, dst.incomeTotals, mismatch)
+		compareTotals(userID, "expenses", src.expenseTotals, dst.expenseTotals, mismatch)
+	}
+	return report, nil
+}
+
+func (s *DataMigrationService) sourceUserIDs(ctx context.Context) ([]string, error) {
+	userIDs, err := s.source.Users.ListAllUserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+type userSummary struct {
+	categories     int
+	accounts       int
+	incomes        int
+	incomeSources  int
+	expenses       int
+	transfers      int
+	budgets        int
+	envelopes      int
+	envelopeMoves  int
+	savingsGoals   int
+	debts          int
+	holdings       int
+	valuations     int
+	snapshots      int
+	refreshTokens  int
+	securityEvents int
+	twoFactor      int
+	passkeys       int
+	credentials    int
+	incomeTotals   map[string]int64
+	expenseTotals  map[string]int64
+}
+
+func summarize(ctx context.Context, store DataStore, userID string) (*userSummary, error) {
+	sum := &userSummary{incomeTotals: map[string]int64{}, expenseTotals: map[string]int64{}}
+
+	categories, err := store.Categories.ListCategoriesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.categories = len(categories)
+
+	accounts, err := store.Accounts.ListAccountsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.accounts = len(accounts)
+
+	incomes, err := store.Incomes.ListIncomesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.incomes = len(incomes)
+	for _, income := range incomes {
+		sum.incomeTotals[income.Amount.Currency] += income.Amount.MinorUnits
+	}
+
+	sources, err := store.Incomes.ListIncomeSourcesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.incomeSources = len(sources)
+
+	expenses, err := store.Expenses.ListExpensesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.expenses = len(expenses)
+	for _, expense := range expenses {
+		sum.expenseTotals[expense.Amount.Currency] += expense.Amount.MinorUnits
+	}
+
+	transfers, err := store.Transfers.ListTransfersByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.transfers = len(transfers)
+
+	budgets, err := store.Budgets.ListBudgetsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.budgets = len(budgets)
+
+	envelopes, err := store.Envelopes.ListEnvelopesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.envelopes = len(envelopes)
+
+	moves, err := store.Envelopes.ListEnvelopeMovesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.envelopeMoves = len(moves)
+
+	goals, err := store.SavingsGoals.ListSavingsGoalsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.savingsGoals = len(goals)
+
+	debts, err := store.Debts.ListDebtsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.debts = len(debts)
+
+	holdings, err := store.Holdings.ListHoldingsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.holdings = len(holdings)
+
+	valuations, err := store.Holdings.ListValuationsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.valuations = len(valuations)
+
+	snapshots, err := store.NetWorth.ListNetWorthSnapshots(ctx, userID, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	sum.snapshots = len(snapshots)
+
+	tokens, err := store.RefreshTokens.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.refreshTokens = len(tokens)
+
+	events, err := store.SecurityEvents.ListSecurityEvents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.securityEvents = len(events)
+
+	if _, err := store.TwoFactor.GetTwoFactor(ctx, userID); err == nil {
+		sum.twoFactor = 1
+	} else if status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	passkeys, err := store.Passkeys.ListPasskeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sum.passkeys = len(passkeys)
+
+	if store.Credentials != nil {
+		if _, err := store.Credentials.GetCredential(ctx, userID); err == nil {
+			sum.credentials = 1
+		} else if status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
+func compareTotals(userID, entity string, src, dst map[string]int64, mismatch func(userID, entity, format string, args ...interface{})) {
+	seen := map[string]bool{}
+	for currency := range src {
+		seen[currency] = true
+	}
+	for currency := range dst {
+		seen[currency] = true
+	}
+	for currency := range seen {
+		if src[currency] != dst[currency] {
+			mismatch(userID, entity, "total %s in source, %s in target",
+				domain.NewMoney(src[currency], currency), domain.NewMoney(dst[currency], currency))
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package application_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/domain"
+	localauth "github.com/theHinneh/budgeting/internal/infrastructure/auth/local"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/migrate"
+	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
+)
+
+func memoryDataStore(db *memory.Database) application.DataStore {
+	return application.DataStore{
+		Users:          db.UserRepository,
+		Categories:     db.CategoryRepository,
+		Accounts:       db.AccountRepository,
+		Transfers:      db.TransferRepository,
+		Budgets:        db.BudgetRepository,
+		Envelopes:      db.EnvelopeRepository,
+		SavingsGoals:   db.SavingsGoalRepository,
+		Debts:          db.DebtRepository,
+		Holdings:       db.HoldingRepository,
+		NetWorth:       db.NetWorthSnapshotRepository,
+		Incomes:        db.IncomeRepository,
+		Expenses:       db.ExpenseRepository,
+		RefreshTokens:  db.RefreshTokenRepository,
+		SecurityEvents: db.SecurityEventRepository,
+		TwoFactor:      db.TwoFactorRepository,
+		Passkeys:       db.PasskeyRepository,
+		Credentials:    db.CredentialRepository,
+		ExchangeRates:  db.ExchangeRateRepository,
+	}
+}
+
+func TestDataMigrationCopiesBetweenStores(t *testing.T) {
+	logger.InitZaplogger(nil)
+	ctx := context.Background()
+	auth, source, _ := newLocalAuthService(t)
+	target := memory.NewDatabase()
+	now := time.Now().UTC()
+
+	uid, err := auth.CreateAuthUser(ctx, "ama@example.com", "secret123", "Ama Mensah", nil)
+	if err != nil {
+		t.Fatalf("CreateAuthUser: %v", err)
+	}
+	if _, err := source.UserRepository.CreateUser(ctx, &domain.User{UID: uid, Username: "ama", Email: "ama@example.com", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if _, err := source.AccountRepository.CreateAccount(ctx, &domain.Account{UID: "acc-1", UserID: uid, Name: "Wallet", Type: domain.AccountTypeCash, Currency: "GHS", OpeningBalance: domain.NewMoney(10000, "GHS"), CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	for i, amount := range []int64{2500, 1250} {
+		if _, err := source.ExpenseRepository.CreateExpense(ctx, &domain.Expense{UID: []string{"exp-1", "exp-2"}[i], UserID: uid, Source: "Market", AccountID: "acc-1", Amount: domain.NewMoney(amount, "GHS"), CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatalf("CreateExpense: %v", err)
+		}
+	}
+	if err := source.ExchangeRateRepository.SaveRates(ctx, []domain.ExchangeRate{
+		{Base: "USD", Quote: "GHS", Date: now.Truncate(24 * time.Hour), Rate: 15.5},
+		{Base: "EUR", Quote: "GHS", Date: now.Truncate(24 * time.Hour), Rate: 16.8},
+	}); err != nil {
+		t.Fatalf("SaveRates: %v", err)
+	}
+
+	checkpoint, err := migrate.NewFileCheckpoint(filepath.Join(t.TempDir(), "checkpoint.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := application.NewDataMigrationService(memoryDataStore(source), memoryDataStore(target), checkpoint)
+
+	copied, err := service.Copy(ctx)
+	if err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+	if copied.Users != 1 || copied.Accounts != 1 || copied.Expenses != 2 || copied.Credentials != 1 || copied.ExchangeRates != 2 {
+		t.Fatalf("Copy report = %+v, want 1 user, 1 account, 2 expenses, 1 credential and 2 rates", copied)
+	}
+
+	report, err := service.Verify(ctx)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("Verify found mismatches: %+v", report.Mismatches)
+	}
+
+	again, err := service.Copy(ctx)
+	if err != nil {
+		t.Fatalf("Copy (rerun): %v", err)
+	}
+	if again.Users != 0 || again.SkippedUsers != 1 {
+		t.Fatalf("Copy (rerun) = %d copied, %d skipped, want the user skipped", again.Users, again.SkippedUsers)
+	}
+
+	targetAuth, err := localauth.NewAuthenticator(target.CredentialRepository, config.AuthConfig{JWTIssuer: "budgeting", AccessTokenTTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user, err := targetAuth.VerifyPassword(ctx, "ama@example.com", "secret123"); err != nil || user.UID != uid {
+		t.Fatalf("VerifyPassword against the target = %+v, %v, want user %s", user, err, uid)
+	}
+
+	if err := target.ExpenseRepository.DeleteExpense(ctx, uid, "exp-2"); err != nil {
+		t.Fatalf("DeleteExpense: %v", err)
+	}
+	report, err = service.Verify(ctx)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if report.OK() {
+		t.Fatal("Verify passed with an expense missing from the target")
+	}
+}
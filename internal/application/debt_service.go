@@ -0,0 +1,513 @@
+package application
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+const (
+	// maxInstallments bounds a schedule at fifty years of weekly payments.
+	maxInstallments = 52 * 50
+	maxPlanMonths   = 12 * 50
+)
+
+type DebtService struct {
+	repo        ports.DebtRepoPort
+	expenseRepo ports.ExpenseRepoPort
+}
+
+func NewDebtService(repo ports.DebtRepoPort, expenseRepo ports.ExpenseRepoPort) *DebtService {
+	return &DebtService{repo: repo, expenseRepo: expenseRepo}
+}
+
+var _ ports.DebtServicePort = (*DebtService)(nil)
+
+// CreateDebt records a loan or credit balance. Kind defaults to a loan and
+// Frequency to monthly. Either Term or Payment must be set, and the payment
+// must pay the debt off.
+func (s *DebtService) CreateDebt(ctx context.Context, in dto.DebtInput) (*domain.Debt, error) {
+	userID := strings.TrimSpace(in.UserID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	debt := &domain.Debt{UID: uuid.NewString(), UserID: userID, CreatedAt: time.Now().UTC()}
+	if err := applyDebtInput(debt, in); err != nil {
+		return nil, err
+	}
+	debt.UpdatedAt = debt.CreatedAt
+	return s.repo.CreateDebt(ctx, debt)
+}
+
+func (s *DebtService) ListDebts(ctx context.Context, userID string) ([]dto.DebtStatus, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	debts, err := s.repo.ListDebtsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]dto.DebtStatus, 0, len(debts))
+	if len(debts) == 0 {
+		return res, nil
+	}
+	payments, err := s.payments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for _, d := range debts {
+		res = append(res, debtStatus(d, payments[d.UID], now))
+	}
+	return res, nil
+}
+
+func (s *DebtService) GetDebt(ctx context.Context, userID string, debtID string) (*dto.DebtStatus, error) {
+	userID = strings.TrimSpace(userID)
+	debtID = strings.TrimSpace(debtID)
+	if userID == "" || debtID == "" {
+		return nil, ErrValidation
+	}
+	debt, err := s.repo.GetDebt(ctx, userID, debtID)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.payments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	status := debtStatus(debt, payments[debt.UID], time.Now().UTC())
+	return &status, nil
+}
+
+// UpdateDebt replaces the debt's terms. The currency is fixed once the debt
+// exists, since payments are recorded in it.
+func (s *DebtService) UpdateDebt(ctx context.Context, userID string, debtID string, in dto.DebtInput) (*domain.Debt, error) {
+	userID = strings.TrimSpace(userID)
+	debtID = strings.TrimSpace(debtID)
+	if userID == "" || debtID == "" {
+		return nil, ErrValidation
+	}
+	debt, err := s.repo.GetDebt(ctx, userID, debtID)
+	if err != nil {
+		return nil, err
+	}
+	currency := debt.Principal.Currency
+	if err := applyDebtInput(debt, in); err != nil {
+		return nil, err
+	}
+	if debt.Principal.Currency != currency {
+		return nil, ErrValidation
+	}
+	debt.UpdatedAt = time.Now().UTC()
+	return s.repo.UpdateDebt(ctx, debt)
+}
+
+// DeleteDebt removes a debt no payment has been recorded against.
+func (s *DebtService) DeleteDebt(ctx context.Context, userID string, debtID string) error {
+	userID = strings.TrimSpace(userID)
+	debtID = strings.TrimSpace(debtID)
+	if userID == "" || debtID == "" {
+		return ErrValidation
+	}
+	if _, err := s.repo.GetDebt(ctx, userID, debtID); err != nil {
+		return err
+	}
+	payments, err := s.payments(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(payments[debtID]) > 0 {
+		return ErrDebtInUse
+	}
+	return s.repo.DeleteDebt(ctx, userID, debtID)
+}
+
+// GetDebtSchedule lays out the debt's original amortization schedule and
+// how far the recorded payments cover it.
+func (s *DebtService) GetDebtSchedule(ctx context.Context, userID string, debtID string) (*dto.DebtSchedule, error) {
+	userID = strings.TrimSpace(userID)
+	debtID = strings.TrimSpace(debtID)
+	if userID == "" || debtID == "" {
+		return nil, ErrValidation
+	}
+	debt, err := s.repo.GetDebt(ctx, userID, debtID)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.payments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := debt.Principal.Currency
+	installments, _ := amortize(debt, debt.Principal.MinorUnits, firstDue(debt))
+	var pool int64
+	for _, p := range payments[debtID] {
+		pool += p.Amount.MinorUnits
+	}
+	now := time.Now().UTC()
+	var interest int64
+	for i := range installments {
+		inst := &installments[i]
+		interest += inst.Interest.MinorUnits
+		paid := min(pool, inst.Payment.MinorUnits)
+		pool -= paid
+		inst.Paid = domain.NewMoney(paid, currency)
+		switch {
+		case paid == inst.Payment.MinorUnits:
+			inst.Status = dto.InstallmentPaid
+		case paid > 0:
+			inst.Status = dto.InstallmentPartial
+		case inst.DueDate.Before(now):
+			inst.Status = dto.InstallmentMissed
+		default:
+			inst.Status = dto.InstallmentUpcoming
+		}
+	}
+	return &dto.DebtSchedule{Debt: debt, Installments: installments, TotalInterest: domain.NewMoney(interest, currency)}, nil
+}
+
+// PlanDebtPayoff simulates paying MonthlyBudget a month across every open
+// debt, starting next month. Each debt gets its monthly payment and what is
+// left goes to one debt at a time: the highest rate first for the
+// avalanche, the smallest balance first for the snowball. A paid-off debt's
+// payment rolls over to the next one. All open debts must be in the
+// budget's currency.
+func (s *DebtService) PlanDebtPayoff(ctx context.Context, in dto.DebtPayoffInput) ([]dto.DebtPayoffPlan, error) {
+	userID := strings.TrimSpace(in.UserID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	strategies := []dto.DebtPayoffStrategy{dto.PayoffAvalanche, dto.PayoffSnowball}
+	if strategy := dto.DebtPayoffStrategy(strings.ToLower(strings.TrimSpace(in.Strategy))); strategy != "" {
+		if strategy != dto.PayoffAvalanche && strategy != dto.PayoffSnowball {
+			return nil, ErrValidation
+		}
+		strategies = []dto.DebtPayoffStrategy{strategy}
+	}
+	budget, ok := normalizeBalance(in.MonthlyBudget)
+	if !ok || budget.IsNegative() {
+		return nil, ErrValidation
+	}
+
+	statuses, err := s.ListDebts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var open []dto.DebtStatus
+	var minimum int64
+	for _, st := range statuses {
+		if st.Balance.MinorUnits == 0 {
+			continue
+		}
+		if len(open) > 0 && st.Balance.Currency != open[0].Balance.Currency {
+			return nil, ErrValidation
+		}
+		open = append(open, st)
+		minimum += monthlyPayment(st.Debt, st.Payment.MinorUnits)
+	}
+	if len(open) > 0 {
+		currency := open[0].Balance.Currency
+		if budget.MinorUnits == 0 {
+			budget = domain.NewMoney(minimum, currency)
+		}
+		if budget.Currency != currency || budget.MinorUnits < minimum {
+			return nil, ErrValidation
+		}
+	}
+
+	start := domain.PeriodOf(time.Now()).Next()
+	plans := make([]dto.DebtPayoffPlan, 0, len(strategies))
+	for _, strategy := range strategies {
+		plan, ok := planPayoff(open, budget, strategy, start)
+		if !ok {
+			return nil, ErrValidation
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// payments returns the user's debt payments by debt, oldest first.
+func (s *DebtService) payments(ctx context.Context, userID string) (map[string][]*domain.Expense, error) {
+	expenses, err := s.expenseRepo.ListExpensesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string][]*domain.Expense{}
+	for _, e := range expenses {
+		if e.DebtID != "" {
+			res[e.DebtID] = append(res[e.DebtID], e)
+		}
+	}
+	for _, payments := range res {
+		sort.SliceStable(payments, func(i, j int) bool { return payments[i].CreatedAt.Before(payments[j].CreatedAt) })
+	}
+	return res, nil
+}
+
+func applyDebtInput(debt *domain.Debt, in dto.DebtInput) error {
+	name := strings.TrimSpace(in.Name)
+	kind := domain.DebtKind(strings.ToLower(strings.TrimSpace(in.Kind)))
+	if kind == "" {
+		kind = domain.DebtKindLoan
+	}
+	frequency := strings.TrimSpace(in.Frequency)
+	if frequency == "" {
+		frequency = string(dto.RecurringMonthly)
+	}
+	if name == "" || !kind.Valid() || !isValidExpenseFrequency(frequency) || in.Term < 0 || in.StartDate.IsZero() ||
+		in.APR < 0 || math.IsNaN(in.APR) || math.IsInf(in.APR, 0) {
+		return ErrValidation
+	}
+	principal, err := normalizeAmount(in.Principal)
+	if err != nil {
+		return err
+	}
+	if in.Payment.Currency == "" {
+		in.Payment.Currency = principal.Currency
+	}
+	payment, ok := normalizeBalance(in.Payment)
+	if !ok || payment.IsNegative() || payment.Currency != principal.Currency || (payment.MinorUnits == 0 && in.Term == 0) {
+		return ErrValidation
+	}
+
+	debt.Name = name
+	debt.Kind = kind
+	debt.Principal = principal
+	debt.APR = in.APR
+	debt.Term = in.Term
+	debt.Frequency = frequency
+	debt.Payment = payment
+	debt.StartDate = in.StartDate.UTC()
+	if _, ok := amortize(debt, principal.MinorUnits, firstDue(debt)); !ok {
+		return ErrValidation
+	}
+	return nil
+}
+
+// debtStatus replays the debt from StartDate: interest is charged on each
+// due date up to now and the payments made by then are taken off.
+func debtStatus(debt *domain.Debt, payments []*domain.Expense, now time.Time) dto.DebtStatus {
+	currency := debt.Principal.Currency
+	rate := periodicRate(debt)
+	balance := debt.Principal.MinorUnits
+	var paid, accrued int64
+	i := 0
+	due := firstDue(debt)
+	for ; !due.After(now); due = advanceExpenseNextOccurrence(due, debt.Frequency) {
+		if balance <= 0 && i == len(payments) {
+			break
+		}
+		if balance > 0 {
+			interest := int64(math.Round(float64(balance) * rate))
+			balance += interest
+			accrued += interest
+		}
+		for ; i < len(payments) && !payments[i].CreatedAt.After(due); i++ {
+			balance -= payments[i].Amount.MinorUnits
+			paid += payments[i].Amount.MinorUnits
+		}
+	}
+	for ; i < len(payments); i++ {
+		balance -= payments[i].Amount.MinorUnits
+		paid += payments[i].Amount.MinorUnits
+	}
+	for !due.After(now) {
+		due = advanceExpenseNextOccurrence(due, debt.Frequency)
+	}
+	balance = max(balance, 0)
+
+	var scheduled int64
+	schedule, _ := amortize(debt, debt.Principal.MinorUnits, firstDue(debt))
+	for _, inst := range schedule {
+		if inst.DueDate.After(now) {
+			break
+		}
+		scheduled += inst.Payment.MinorUnits
+	}
+
+	status := dto.DebtStatus{
+		Debt:            debt,
+		Payment:         domain.NewMoney(scheduledPayment(debt), currency),
+		Balance:         domain.NewMoney(balance, currency),
+		Paid:            domain.NewMoney(paid, currency),
+		Scheduled:       domain.NewMoney(scheduled, currency),
+		InterestAccrued: domain.NewMoney(accrued, currency),
+	}
+	var remaining int64
+	switch {
+	case balance == 0 && len(payments) > 0:
+		status.PayoffDate = payments[len(payments)-1].CreatedAt
+	case balance == 0:
+		status.PayoffDate = debt.StartDate
+	default:
+		if rest, ok := amortize(debt, balance, due); ok {
+			for _, inst := range rest {
+				remaining += inst.Interest.MinorUnits
+			}
+			status.PayoffDate = rest[len(rest)-1].DueDate
+		}
+	}
+	status.RemainingInterest = domain.NewMoney(remaining, currency)
+	status.TotalInterest = domain.NewMoney(accrued+remaining, currency)
+	return status
+}
+
+// amortize lays out the payments that clear balance, the first due on
+// first. It reports false when the payment does not cover the interest or
+// the schedule would run past maxInstallments.
+func amortize(debt *domain.Debt, balance int64, first time.Time) ([]dto.DebtInstallment, bool) {
+	currency := debt.Principal.Currency
+	rate := periodicRate(debt)
+	payment := scheduledPayment(debt)
+
+	var res []dto.DebtInstallment
+	due := first
+	for n := 1; balance > 0; n++ {
+		interest := int64(math.Round(float64(balance) * rate))
+		if n > maxInstallments || payment <= interest {
+			return nil, false
+		}
+		pay := min(payment, balance+interest)
+		balance -= pay - interest
+		res = append(res, dto.DebtInstallment{
+			Number:    n,
+			DueDate:   due,
+			Payment:   domain.NewMoney(pay, currency),
+			Principal: domain.NewMoney(pay-interest, currency),
+			Interest:  domain.NewMoney(interest, currency),
+			Balance:   domain.NewMoney(balance, currency),
+		})
+		due = advanceExpenseNextOccurrence(due, debt.Frequency)
+	}
+	return res, true
+}
+
+// scheduledPayment is the debt's Payment or, when that is zero, the level
+// payment that clears Principal in Term payments, rounded up.
+func scheduledPayment(debt *domain.Debt) int64 {
+	if debt.Payment.MinorUnits > 0 || debt.Term <= 0 {
+		return debt.Payment.MinorUnits
+	}
+	principal, n := float64(debt.Principal.MinorUnits), float64(debt.Term)
+	rate := periodicRate(debt)
+	if rate == 0 {
+		return int64(math.Ceil(principal / n))
+	}
+	return int64(math.Ceil(principal * rate / (1 - math.Pow(1+rate, -n))))
+}
+
+func firstDue(debt *domain.Debt) time.Time {
+	return advanceExpenseNextOccurrence(debt.StartDate, debt.Frequency)
+}
+
+func periodicRate(debt *domain.Debt) float64 {
+	return debt.APR / 100 / float64(paymentsPerYear(debt.Frequency))
+}
+
+func paymentsPerYear(freq string) int {
+	switch freq {
+	case string(dto.RecurringWeekly):
+		return 52
+	case string(dto.RecurringBiWeekly):
+		return 26
+	case string(dto.RecurringAnnually):
+		return 1
+	default:
+		return 12
+	}
+}
+
+// monthlyPayment spreads a debt's payment over the months of a year.
+func monthlyPayment(debt *domain.Debt, payment int64) int64 {
+	return (payment*int64(paymentsPerYear(debt.Frequency)) + 11) / 12
+}
+
+type payoffDebt struct {
+	line     dto.DebtPayoffLine
+	balance  int64
+	minimum  int64
+	interest int64
+}
+
+// planPayoff runs the plan month by month, each month's payments made on
+// its first day. It reports false when the debts are not paid off within
+// maxPlanMonths.
+func planPayoff(open []dto.DebtStatus, budget domain.Money, strategy dto.DebtPayoffStrategy, start domain.Period) (dto.DebtPayoffPlan, bool) {
+	debts := make([]*payoffDebt, len(open))
+	for i, st := range open {
+		debts[i] = &payoffDebt{
+			line:    dto.DebtPayoffLine{Debt: st.Debt, Balance: st.Balance},
+			balance: st.Balance.MinorUnits,
+			minimum: monthlyPayment(st.Debt, st.Payment.MinorUnits),
+		}
+	}
+	sort.SliceStable(debts, func(i, j int) bool {
+		a, b := debts[i], debts[j]
+		if strategy == dto.PayoffAvalanche && a.line.Debt.APR != b.line.Debt.APR {
+			return a.line.Debt.APR > b.line.Debt.APR
+		}
+		if a.balance != b.balance {
+			return a.balance < b.balance
+		}
+		if a.line.Debt.APR != b.line.Debt.APR {
+			return a.line.Debt.APR > b.line.Debt.APR
+		}
+		return a.line.Debt.UID < b.line.Debt.UID
+	})
+
+	plan := dto.DebtPayoffPlan{Strategy: strategy, MonthlyBudget: budget}
+	remaining := len(debts)
+	period := start
+	for month := 1; remaining > 0; month, period = month+1, period.Next() {
+		if month > maxPlanMonths {
+			return dto.DebtPayoffPlan{}, false
+		}
+		left := budget.MinorUnits
+		for _, d := range debts {
+			interest := int64(math.Round(float64(d.balance) * d.line.Debt.APR / 100 / 12))
+			d.balance += interest
+			d.interest += interest
+		}
+		for _, d := range debts {
+			pay := min(d.minimum, d.balance, left)
+			d.balance -= pay
+			left -= pay
+		}
+		for _, d := range debts {
+			pay := min(d.balance, left)
+			d.balance -= pay
+			left -= pay
+		}
+		for _, d := range debts {
+			if d.balance == 0 && d.line.Months == 0 {
+				d.line.Months = month
+				d.line.PayoffDate = period.Start()
+				plan.Months = month
+				plan.PayoffDate = period.Start()
+				remaining--
+			}
+		}
+	}
+
+	var interest int64
+	plan.Debts = make([]dto.DebtPayoffLine, len(debts))
+	for i, d := range debts {
+		d.line.Order = i + 1
+		d.line.Interest = domain.NewMoney(d.interest, budget.Currency)
+		interest += d.interest
+		plan.Debts[i] = d.line
+	}
+	plan.TotalInterest = domain.NewMoney(interest, budget.Currency)
+	return plan, true
+}
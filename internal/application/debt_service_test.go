@@ -0,0 +1,109 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestDebtScheduleTracksPayments(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	debts := application.NewDebtService(db.DebtRepository, db.ExpenseRepository)
+	expenses := application.NewExpenseService(db.ExpenseRepository, db.CategoryRepository, db.AccountRepository, db.DebtRepository)
+	const userID = "u1"
+
+	// Two monthly payments have fallen due: on the first of last month and
+	// of this one.
+	start := domain.PeriodOf(time.Now()).Start().AddDate(0, -2, 0)
+	if _, err := debts.CreateDebt(ctx, dto.DebtInput{UserID: userID, Name: "Card", Kind: "credit", Principal: domain.NewMoney(100000, "USD"), APR: 24, Payment: domain.NewMoney(2000, "USD"), StartDate: start}); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("payment below interest: err = %v, want ErrValidation", err)
+	}
+	loan, err := debts.CreateDebt(ctx, dto.DebtInput{UserID: userID, Name: "Car", Principal: domain.NewMoney(1200000, "USD"), APR: 12, Term: 12, StartDate: start})
+	if err != nil {
+		t.Fatalf("CreateDebt: %v", err)
+	}
+
+	schedule, err := debts.GetDebtSchedule(ctx, userID, loan.UID)
+	if err != nil {
+		t.Fatalf("GetDebtSchedule: %v", err)
+	}
+	first, last := schedule.Installments[0], schedule.Installments[len(schedule.Installments)-1]
+	if len(schedule.Installments) != 12 || first.Payment != domain.NewMoney(106619, "USD") || first.Interest != domain.NewMoney(12000, "USD") ||
+		last.Balance != domain.NewMoney(0, "USD") || first.Status != dto.InstallmentMissed {
+		t.Fatalf("GetDebtSchedule = %+v", schedule)
+	}
+
+	if _, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: userID, Source: "car payment", DebtID: loan.UID, Amount: domain.NewMoney(100, "EUR")}); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("payment currency mismatch: err = %v, want ErrValidation", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: userID, Source: "car payment", DebtID: loan.UID, Amount: domain.NewMoney(106619, "USD")}); err != nil {
+			t.Fatalf("AddExpense: %v", err)
+		}
+	}
+
+	status, err := debts.GetDebt(ctx, userID, loan.UID)
+	if err != nil {
+		t.Fatalf("GetDebt: %v", err)
+	}
+	// Both payments came after their due dates, so a second month of
+	// interest accrued on the full balance.
+	if status.Paid != domain.NewMoney(213238, "USD") || status.Scheduled != domain.NewMoney(213238, "USD") ||
+		status.InterestAccrued != domain.NewMoney(24120, "USD") || status.Balance != domain.NewMoney(1010882, "USD") || status.PayoffDate.IsZero() {
+		t.Fatalf("GetDebt = %+v", status)
+	}
+	schedule, err = debts.GetDebtSchedule(ctx, userID, loan.UID)
+	if err != nil {
+		t.Fatalf("GetDebtSchedule: %v", err)
+	}
+	if schedule.Installments[1].Status != dto.InstallmentPaid || schedule.Installments[2].Status != dto.InstallmentUpcoming {
+		t.Fatalf("installment statuses = %s, %s", schedule.Installments[1].Status, schedule.Installments[2].Status)
+	}
+
+	if err := debts.DeleteDebt(ctx, userID, loan.UID); !errors.Is(err, application.ErrDebtInUse) {
+		t.Fatalf("DeleteDebt(with payments) = %v, want ErrDebtInUse", err)
+	}
+}
+
+func TestPlanDebtPayoffOrdersByStrategy(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	debts := application.NewDebtService(db.DebtRepository, db.ExpenseRepository)
+	const userID = "u1"
+	start := time.Now().UTC()
+
+	card, err := debts.CreateDebt(ctx, dto.DebtInput{UserID: userID, Name: "Card", Kind: "credit", Principal: domain.NewMoney(300000, "USD"), APR: 24, Payment: domain.NewMoney(10000, "USD"), StartDate: start})
+	if err != nil {
+		t.Fatalf("CreateDebt(card): %v", err)
+	}
+	loan, err := debts.CreateDebt(ctx, dto.DebtInput{UserID: userID, Name: "Loan", Principal: domain.NewMoney(100000, "USD"), APR: 6, Payment: domain.NewMoney(5000, "USD"), StartDate: start})
+	if err != nil {
+		t.Fatalf("CreateDebt(loan): %v", err)
+	}
+
+	if _, err := debts.PlanDebtPayoff(ctx, dto.DebtPayoffInput{UserID: userID, MonthlyBudget: domain.NewMoney(10000, "USD")}); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("budget below payments: err = %v, want ErrValidation", err)
+	}
+	plans, err := debts.PlanDebtPayoff(ctx, dto.DebtPayoffInput{UserID: userID, MonthlyBudget: domain.NewMoney(30000, "USD")})
+	if err != nil || len(plans) != 2 {
+		t.Fatalf("PlanDebtPayoff = %d plans, %v; want 2", len(plans), err)
+	}
+	avalanche, snowball := plans[0], plans[1]
+	if avalanche.Strategy != dto.PayoffAvalanche || avalanche.Debts[0].Debt.UID != card.UID || avalanche.Debts[1].Debt.UID != loan.UID {
+		t.Fatalf("avalanche order = %+v, want the card first", avalanche.Debts)
+	}
+	if snowball.Strategy != dto.PayoffSnowball || snowball.Debts[0].Debt.UID != loan.UID || snowball.Debts[1].Debt.UID != card.UID {
+		t.Fatalf("snowball order = %+v, want the loan first", snowball.Debts)
+	}
+	if avalanche.TotalInterest.MinorUnits >= snowball.TotalInterest.MinorUnits || snowball.Debts[0].Months >= avalanche.Debts[1].Months {
+		t.Fatalf("avalanche interest %s, snowball interest %s; want the avalanche cheaper and the snowball's first payoff sooner",
+			avalanche.TotalInterest, snowball.TotalInterest)
+	}
+}
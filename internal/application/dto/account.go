@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type AccountInput struct {
+	UserID         string
+	Name           string
+	Type           string
+	OpeningBalance domain.Money
+}
+
+// AccountEntry is one line of an account ledger. Kind is income, expense,
+// transfer_in or transfer_out. Amount is signed: money in is positive, money
+// out negative. Balance is the running balance after the
+// entry.
+type AccountEntry struct {
+	Kind    string
+	UID     string
+	Source  string
+	Date    time.Time
+	Amount  domain.Money
+	Balance domain.Money
+}
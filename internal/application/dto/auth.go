@@ -1,11 +1,17 @@
 package dto
 
+import "encoding/json"
+
+// LoginResponse carries the session tokens, or only a challenge token when the
+// user has two-factor authentication enabled.
 type LoginResponse struct {
-	AccessToken  string    `json:"access_token"`
-	RefreshToken string    `json:"refresh_token"`
-	ExpiresIn    int64     `json:"expires_in"`
-	TokenType    string    `json:"token_type"`
-	User         *UserInfo `json:"user"`
+	AccessToken       string    `json:"access_token,omitempty"`
+	RefreshToken      string    `json:"refresh_token,omitempty"`
+	ExpiresIn         int64     `json:"expires_in,omitempty"`
+	TokenType         string    `json:"token_type,omitempty"`
+	User              *UserInfo `json:"user,omitempty"`
+	TwoFactorRequired bool      `json:"two_factor_required,omitempty"`
+	ChallengeToken    string    `json:"challenge_token,omitempty"`
 }
 
 type RefreshTokenResponse struct {
@@ -21,3 +27,22 @@ type UserInfo struct {
 	DisplayName string  `json:"display_name"`
 	PhoneNumber *string `json:"phone_number,omitempty"`
 }
+
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// RecoveryCodes are shown to the user once; only their hashes are stored.
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// PasskeyCeremony starts a WebAuthn registration or login. Options are passed
+// to navigator.credentials.create() or get() as is, and the result is sent
+// back together with the session token.
+type PasskeyCeremony struct {
+	SessionToken string          `json:"session_token"`
+	Options      json.RawMessage `json:"options"`
+	ExpiresIn    int64           `json:"expires_in"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import "github.com/theHinneh/budgeting/internal/domain"
+
+type BudgetInput struct {
+	UserID     string
+	CategoryID string
+	Period     string
+	Planned    domain.Money
+}
+
+// BudgetLine is a budget with what was spent against it. Spent is in the
+// budget's currency; Percentage is Spent as a percentage of Planned.
+// Unconverted is set when a missing exchange rate kept the budget out of the
+// pool's Assigned or left its spending untotalled.
+type BudgetLine struct {
+	Budget       *domain.Budget
+	CategoryName string
+	Planned      domain.Money
+	Spent        domain.Money
+	Remaining    domain.Money
+	Percentage   float64
+	Unconverted  bool
+}
+
+// BudgetAllocationInput assigns Amount of unassigned money to a category's
+// budget in a period. A negative Amount returns money to the pool.
+type BudgetAllocationInput struct {
+	UserID     string
+	CategoryID string
+	Period     string
+	Amount     domain.Money
+}
+
+// BudgetPeriodView is a period's budgets together with the zero-based pool,
+// in the user's reporting currency: ToBeAssigned is Carried + Received +
+// Expected - Assigned, where Carried is what earlier periods left
+// unassigned and Expected is income sources' paydays still to come.
+// UnconvertedIncomes lists the incomes and income sources a missing
+// exchange rate kept out of the pool.
+type BudgetPeriodView struct {
+	Period       string
+	Lines        []BudgetLine
+	Carried      domain.Money
+	Received     domain.Money
+	Expected     domain.Money
+	Assigned     domain.Money
+	ToBeAssigned domain.Money
+
+	UnconvertedIncomes []string
+}
@@ -0,0 +1,8 @@
+package dto
+
+type CategoryInput struct {
+	UserID   string
+	Name     string
+	ParentID string
+	Kind     string
+}
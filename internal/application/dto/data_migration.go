@@ -0,0 +1,39 @@
+package dto
+
+type DataMigrationReport struct {
+	Users             int
+	SkippedUsers      int
+	Categories        int
+	Accounts          int
+	Incomes           int
+	IncomeSources     int
+	Expenses          int
+	Transfers         int
+	Budgets           int
+	Envelopes         int
+	EnvelopeMoves     int
+	SavingsGoals      int
+	Debts             int
+	Holdings          int
+	Valuations        int
+	NetWorthSnapshots int
+	RefreshTokens     int
+	SecurityEvents    int
+	TwoFactor         int
+	Passkeys          int
+	Credentials       int
+	ExchangeRates     int
+}
+
+type DataVerificationReport struct {
+	Users      int
+	Mismatches []DataMismatch
+}
+
+func (r *DataVerificationReport) OK() bool { return len(r.Mismatches) == 0 }
+
+type DataMismatch struct {
+	UserID string
+	Entity string
+	Detail string
+}
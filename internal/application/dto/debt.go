@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type DebtInput struct {
+	UserID    string
+	Name      string
+	Kind      string
+	Principal domain.Money
+	APR       float64
+	Term      int
+	Frequency string
+	Payment   domain.Money
+	StartDate time.Time
+}
+
+type InstallmentStatus string
+
+const (
+	InstallmentPaid     InstallmentStatus = "paid"
+	InstallmentPartial  InstallmentStatus = "partial"
+	InstallmentMissed   InstallmentStatus = "missed"
+	InstallmentUpcoming InstallmentStatus = "upcoming"
+)
+
+// DebtInstallment is one scheduled payment. Paid is how much of it the
+// recorded payments cover, applying them to installments in order.
+type DebtInstallment struct {
+	Number    int
+	DueDate   time.Time
+	Payment   domain.Money
+	Principal domain.Money
+	Interest  domain.Money
+	Balance   domain.Money
+	Paid      domain.Money
+	Status    InstallmentStatus
+}
+
+type DebtSchedule struct {
+	Debt          *domain.Debt
+	Installments  []DebtInstallment
+	TotalInterest domain.Money
+}
+
+// DebtStatus is where a debt stands today given the payments recorded
+// against it. PayoffDate and RemainingInterest assume the scheduled
+// payment from now on; PayoffDate is zero when that payment no longer
+// covers the interest.
+type DebtStatus struct {
+	Debt              *domain.Debt
+	Payment           domain.Money
+	Balance           domain.Money
+	Paid              domain.Money
+	Scheduled         domain.Money
+	InterestAccrued   domain.Money
+	RemainingInterest domain.Money
+	TotalInterest     domain.Money
+	PayoffDate        time.Time
+}
+
+type DebtPayoffStrategy string
+
+const (
+	PayoffAvalanche DebtPayoffStrategy = "avalanche"
+	PayoffSnowball  DebtPayoffStrategy = "snowball"
+)
+
+// DebtPayoffInput asks for a plan paying MonthlyBudget across all debts
+// each month. A zero budget means the sum of the current monthly payments.
+// An empty Strategy plans both.
+type DebtPayoffInput struct {
+	UserID        string
+	Strategy      string
+	MonthlyBudget domain.Money
+}
+
+type DebtPayoffPlan struct {
+	Strategy      DebtPayoffStrategy
+	MonthlyBudget domain.Money
+	Months        int
+	PayoffDate    time.Time
+	TotalInterest domain.Money
+	Debts         []DebtPayoffLine
+}
+
+// DebtPayoffLine is one debt in a plan, in the order the plan targets it.
+type DebtPayoffLine struct {
+	Debt       *domain.Debt
+	Order      int
+	Balance    domain.Money
+	Months     int
+	PayoffDate time.Time
+	Interest   domain.Money
+}
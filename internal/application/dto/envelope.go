@@ -0,0 +1,39 @@
+package dto
+
+import "github.com/theHinneh/budgeting/internal/domain"
+
+type EnvelopeInput struct {
+	UserID      string
+	Name        string
+	CategoryID  string
+	Allocation  domain.Money
+	Rollover    bool
+	StartPeriod string
+}
+
+type EnvelopeMoveInput struct {
+	UserID         string
+	FromEnvelopeID string
+	ToEnvelopeID   string
+	Period         string
+	Amount         domain.Money
+	Notes          string
+}
+
+// EnvelopeStatus is an envelope's money in one period: Available is
+// Carried + Allocated + MovedIn - MovedOut - Spent, all in the envelope's
+// currency. Carried is negative when the previous period was overspent.
+// Unconverted is set when a missing exchange rate left spending out of
+// Spent, or out of an earlier period that rolled over into Carried.
+type EnvelopeStatus struct {
+	Envelope  *domain.Envelope
+	Period    string
+	Carried   domain.Money
+	Allocated domain.Money
+	MovedIn   domain.Money
+	MovedOut  domain.Money
+	Spent     domain.Money
+	Available domain.Money
+
+	Unconverted bool
+}
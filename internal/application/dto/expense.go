@@ -1,12 +1,20 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
 
 type AddExpenseInput struct {
 	UserID              string
 	Source              string
-	Amount              float64
-	Currency            string
+	AccountID           string
+	CategoryID          string
+	DebtID              string
+	Tags                []string
+	Amount              domain.Money
+	Splits              []domain.ExpenseSplit
 	Notes               string
 	IsRecurring         bool
 	RecurrenceFrequency string
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+// HoldingInput creates or updates a holding. A positive Value, in major
+// units of the holding's currency, records a valuation on ValuedAt, today
+// when zero.
+type HoldingInput struct {
+	UserID   string
+	Name     string
+	Kind     string
+	Category string
+	Currency string
+	Value    float64
+	ValuedAt time.Time
+}
+
+// ValuationInput values a holding at Value major units of Currency, the
+// holding's when empty.
+type ValuationInput struct {
+	UserID    string
+	HoldingID string
+	Value     float64
+	Currency  string
+	Date      time.Time
+	Notes     string
+}
+
+// HoldingStatus is a holding with its latest valuation. ValuedAt is zero
+// when it has never been valued.
+type HoldingStatus struct {
+	Holding  *domain.Holding
+	Value    domain.Money
+	ValuedAt time.Time
+}
@@ -1,18 +1,21 @@
 package dto
 
+import "github.com/theHinneh/budgeting/internal/domain"
+
 type AddIncomeInput struct {
-	UserID   string
-	Source   string
-	Amount   float64
-	Currency string
-	Notes    string
+	UserID     string
+	Source     string
+	AccountID  string
+	CategoryID string
+	Tags       []string
+	Amount     domain.Money
+	Notes      string
 }
 
 type AddIncomeSourceInput struct {
 	UserID    string
 	Source    string
-	Amount    float64
-	Currency  string
+	Amount    domain.Money
 	Frequency PayFrequency
 	NextPayAt string
 	Notes     string
@@ -1,8 +1,82 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+// NetWorthResponse reports what the user owns less what they owe, in
+// Currency, the user's base currency. Items lists every account balance,
+// debt and holding that makes up TotalAssets and TotalLiabilities, each
+// converted at today's rate.
+//
+// CashFlow is total income less total expense, each entry converted at the
+// rate of the day it was recorded. Subtotals keep the unconverted income
+// and expense per original currency.
+//
+// Items and entries with no rate into Currency are left out of the totals:
+// such items are marked Unconverted, and the currencies of such incomes and
+// expenses are listed in UnconvertedCurrencies, with their Subtotals.
 type NetWorthResponse struct {
+	TotalAssets      float64            `json:"total_assets"`
+	TotalLiabilities float64            `json:"total_liabilities"`
+	NetWorth         float64            `json:"net_worth"`
+	TotalIncome      float64            `json:"total_income"`
+	TotalExpense     float64            `json:"total_expense"`
+	CashFlow         float64            `json:"cash_flow"`
+	Currency         string             `json:"currency"`
+	Items            []NetWorthItem     `json:"items"`
+	Subtotals        []CurrencySubtotal `json:"subtotals"`
+
+	UnconvertedCurrencies []string `json:"unconverted_currencies,omitempty"`
+}
+
+// NetWorthItem is one asset or liability. Source is account, debt or
+// holding and ID its UID. Value is in the response currency, Amount in the
+// item's own Currency; both are positive for liabilities too.
+type NetWorthItem struct {
+	Kind     string  `json:"kind"`
+	Source   string  `json:"source"`
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Category string  `json:"category"`
+	Value    float64 `json:"value"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+
+	Unconverted bool `json:"unconverted,omitempty"`
+}
+
+type CurrencySubtotal struct {
+	Currency     string  `json:"currency"`
 	TotalIncome  float64 `json:"total_income"`
 	TotalExpense float64 `json:"total_expense"`
-	NetWorth     float64 `json:"net_worth"`
-	Currency     string  `json:"currency"`
+	CashFlow     float64 `json:"cash_flow"`
+}
+
+type NetWorthInterval string
+
+const (
+	NetWorthDaily   NetWorthInterval = "day"
+	NetWorthWeekly  NetWorthInterval = "week"
+	NetWorthMonthly NetWorthInterval = "month"
+)
+
+// NetWorthHistoryInput selects snapshots dated From to To inclusive. To
+// defaults to today, From to a year before To and Interval to month.
+type NetWorthHistoryInput struct {
+	UserID   string
+	From     time.Time
+	To       time.Time
+	Interval string
+}
+
+// NetWorthHistory holds the last snapshot of each day, week or month in
+// the range, oldest first. Weeks start on Monday.
+type NetWorthHistory struct {
+	From      time.Time
+	To        time.Time
+	Interval  NetWorthInterval
+	Snapshots []*domain.NetWorthSnapshot
 }
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type SavingsGoalInput struct {
+	UserID     string
+	Name       string
+	AccountID  string
+	Target     domain.Money
+	TargetDate time.Time
+}
+
+// SavingsGoalStatus is a goal's progress. RequiredMonthly is what still has
+// to be saved each month, this one included, to reach the target on time;
+// once the target date has passed it is the whole remainder.
+type SavingsGoalStatus struct {
+	Goal            *domain.SavingsGoal
+	Saved           domain.Money
+	Remaining       domain.Money
+	Percentage      float64
+	MonthsLeft      int
+	RequiredMonthly domain.Money
+}
+
+// SavingsGoalProgress is a goal's progress at the end of a month:
+// Contributed is the net amount the linked account gained during it.
+type SavingsGoalProgress struct {
+	Period      string
+	Contributed domain.Money
+	Saved       domain.Money
+	Percentage  float64
+}
@@ -0,0 +1,8 @@
+package dto
+
+// TagUsage is a tag together with the number of records carrying it.
+type TagUsage struct {
+	Tag      string
+	Expenses int
+	Incomes  int
+}
@@ -0,0 +1,10 @@
+package dto
+
+// TransactionFilter narrows expense and income listings. Empty fields match
+// everything.
+type TransactionFilter struct {
+	// CategoryID matches the category and all of its subcategories.
+	CategoryID string
+	// Tag matches records carrying the tag.
+	Tag string
+}
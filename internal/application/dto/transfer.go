@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/theHinneh/budgeting/internal/domain"
+
+// TransferInput describes a transfer. Between accounts in different
+// currencies either ToAmount or Rate may be given; when both are zero the
+// rate is looked up.
+type TransferInput struct {
+	UserID        string
+	FromAccountID string
+	ToAccountID   string
+	Amount        domain.Money
+	ToAmount      domain.Money
+	Rate          float64
+	Notes         string
+}
@@ -1,18 +1,20 @@
 package dto
 
 type CreateUserInput struct {
-	UID         string
-	Username    string
-	Email       string
-	FirstName   string
-	LastName    string
-	PhoneNumber *string
+	UID          string
+	Username     string
+	Email        string
+	FirstName    string
+	LastName     string
+	PhoneNumber  *string
+	BaseCurrency *string
 }
 
 type UpdateUserInput struct {
-	Username    *string
-	Email       *string
-	FirstName   *string
-	LastName    *string
-	PhoneNumber *string
+	Username     *string
+	Email        *string
+	FirstName    *string
+	LastName     *string
+	PhoneNumber  *string
+	BaseCurrency *string
 }
@@ -0,0 +1,349 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type EnvelopeService struct {
+	repo         ports.EnvelopeRepoPort
+	categoryRepo ports.CategoryRepoPort
+	expenseRepo  ports.ExpenseRepoPort
+	rates        ports.ExchangeRateProvider
+}
+
+func NewEnvelopeService(repo ports.EnvelopeRepoPort, categoryRepo ports.CategoryRepoPort, expenseRepo ports.ExpenseRepoPort, rates ports.ExchangeRateProvider) *EnvelopeService {
+	return &EnvelopeService{repo: repo, categoryRepo: categoryRepo, expenseRepo: expenseRepo, rates: rates}
+}
+
+var _ ports.EnvelopeServicePort = (*EnvelopeService)(nil)
+
+// CreateEnvelope opens an envelope for an expense category. An envelope
+// also counts the category's subcategories, so no two envelopes may be on
+// the same category or on a category and one of its descendants; that way
+// no expense is counted twice. The
+// envelope starts in the current period unless StartPeriod says otherwise.
+func (s *EnvelopeService) CreateEnvelope(ctx context.Context, in dto.EnvelopeInput) (*domain.Envelope, error) {
+	userID := strings.TrimSpace(in.UserID)
+	name := strings.TrimSpace(in.Name)
+	allocation, ok := normalizeBalance(in.Allocation)
+	if userID == "" || name == "" || strings.TrimSpace(in.CategoryID) == "" || !ok || allocation.IsNegative() {
+		return nil, ErrValidation
+	}
+	start, err := periodOrCurrent(in.StartPeriod)
+	if err != nil {
+		return nil, err
+	}
+	categoryID, err := resolveCategory(ctx, s.categoryRepo, userID, in.CategoryID, domain.CategoryKindExpense)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureUnique(ctx, userID, name, categoryID, ""); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateEnvelope(ctx, &domain.Envelope{
+		UID:         uuid.NewString(),
+		UserID:      userID,
+		Name:        name,
+		CategoryID:  categoryID,
+		Allocation:  allocation,
+		Rollover:    in.Rollover,
+		StartPeriod: start.String(),
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	})
+}
+
+func (s *EnvelopeService) ListEnvelopes(ctx context.Context, userID string, period string) ([]dto.EnvelopeStatus, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	p, err := periodOrCurrent(period)
+	if err != nil {
+		return nil, err
+	}
+	envelopes, err := s.repo.ListEnvelopesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.statuses(ctx, userID, envelopes, p)
+}
+
+func (s *EnvelopeService) GetEnvelope(ctx context.Context, userID string, envelopeID string, period string) (*dto.EnvelopeStatus, error) {
+	userID = strings.TrimSpace(userID)
+	envelopeID = strings.TrimSpace(envelopeID)
+	if userID == "" || envelopeID == "" {
+		return nil, ErrValidation
+	}
+	p, err := periodOrCurrent(period)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := s.repo.GetEnvelope(ctx, userID, envelopeID)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := s.statuses(ctx, userID, []*domain.Envelope{envelope}, p)
+	if err != nil {
+		return nil, err
+	}
+	return &statuses[0], nil
+}
+
+// UpdateEnvelope renames an envelope, changes its allocation or rollover
+// setting. The allocation applies to every period since StartPeriod, so
+// carried balances are recomputed with it. The currency is fixed once the
+// envelope exists.
+func (s *EnvelopeService) UpdateEnvelope(ctx context.Context, userID string, envelopeID string, in dto.EnvelopeInput) (*domain.Envelope, error) {
+	userID = strings.TrimSpace(userID)
+	envelopeID = strings.TrimSpace(envelopeID)
+	name := strings.TrimSpace(in.Name)
+	allocation, ok := normalizeBalance(in.Allocation)
+	if userID == "" || envelopeID == "" || name == "" || !ok || allocation.IsNegative() {
+		return nil, ErrValidation
+	}
+	envelope, err := s.repo.GetEnvelope(ctx, userID, envelopeID)
+	if err != nil {
+		return nil, err
+	}
+	if allocation.Currency != envelope.Allocation.Currency {
+		return nil, ErrValidation
+	}
+	if strings.TrimSpace(in.CategoryID) != "" {
+		if envelope.CategoryID, err = resolveCategory(ctx, s.categoryRepo, userID, in.CategoryID, domain.CategoryKindExpense); err != nil {
+			return nil, err
+		}
+	}
+	if strings.TrimSpace(in.StartPeriod) != "" {
+		start, err := domain.ParsePeriod(strings.TrimSpace(in.StartPeriod))
+		if err != nil {
+			return nil, ErrValidation
+		}
+		envelope.StartPeriod = start.String()
+	}
+	if err := s.ensureUnique(ctx, userID, name, envelope.CategoryID, envelopeID); err != nil {
+		return nil, err
+	}
+
+	envelope.Name = name
+	envelope.Allocation = allocation
+	envelope.Rollover = in.Rollover
+	envelope.UpdatedAt = time.Now().UTC()
+	return s.repo.UpdateEnvelope(ctx, envelope)
+}
+
+// DeleteEnvelope removes the envelope. Moves into or out of it are kept, so
+// the other envelope's balance does not change.
+func (s *EnvelopeService) DeleteEnvelope(ctx context.Context, userID string, envelopeID string) error {
+	userID = strings.TrimSpace(userID)
+	envelopeID = strings.TrimSpace(envelopeID)
+	if userID == "" || envelopeID == "" {
+		return ErrValidation
+	}
+	return s.repo.DeleteEnvelope(ctx, userID, envelopeID)
+}
+
+// MoveBetweenEnvelopes moves money between two envelopes in the same
+// currency within a period, the current one by default. The source envelope
+// must have at least the amount available in that period.
+func (s *EnvelopeService) MoveBetweenEnvelopes(ctx context.Context, in dto.EnvelopeMoveInput) (*domain.EnvelopeMove, error) {
+	userID := strings.TrimSpace(in.UserID)
+	fromID := strings.TrimSpace(in.FromEnvelopeID)
+	toID := strings.TrimSpace(in.ToEnvelopeID)
+	if userID == "" || fromID == "" || toID == "" || fromID == toID {
+		return nil, ErrValidation
+	}
+	amount, err := normalizeAmount(in.Amount)
+	if err != nil {
+		return nil, err
+	}
+	period, err := periodOrCurrent(in.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := s.repo.GetEnvelope(ctx, userID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.repo.GetEnvelope(ctx, userID, toID)
+	if err != nil {
+		return nil, err
+	}
+	if from.Allocation.Currency != amount.Currency || to.Allocation.Currency != amount.Currency {
+		return nil, ErrValidation
+	}
+	if period.String() < from.StartPeriod || period.String() < to.StartPeriod {
+		return nil, ErrValidation
+	}
+
+	statuses, err := s.statuses(ctx, userID, []*domain.Envelope{from}, period)
+	if err != nil {
+		return nil, err
+	}
+	if statuses[0].Available.MinorUnits < amount.MinorUnits {
+		return nil, ErrInsufficientFunds
+	}
+
+	return s.repo.CreateEnvelopeMove(ctx, &domain.EnvelopeMove{
+		UID:            uuid.NewString(),
+		UserID:         userID,
+		FromEnvelopeID: fromID,
+		ToEnvelopeID:   toID,
+		Period:         period.String(),
+		Amount:         amount,
+		Notes:          strings.TrimSpace(in.Notes),
+		CreatedAt:      time.Now().UTC(),
+	})
+}
+
+// ListEnvelopeMoves returns the user's moves oldest first, only those in
+// period when it is not empty.
+func (s *EnvelopeService) ListEnvelopeMoves(ctx context.Context, userID string, period string) ([]*domain.EnvelopeMove, error) {
+	userID = strings.TrimSpace(userID)
+	period = strings.TrimSpace(period)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	if period != "" {
+		p, err := domain.ParsePeriod(period)
+		if err != nil {
+			return nil, ErrValidation
+		}
+		period = p.String()
+	}
+	moves, err := s.repo.ListEnvelopeMovesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if period == "" {
+		return moves, nil
+	}
+	var res []*domain.EnvelopeMove
+	for _, m := range moves {
+		if m.Period == period {
+			res = append(res, m)
+		}
+	}
+	return res, nil
+}
+
+// statuses works out each envelope's money in period by replaying every
+// period since the envelope started. An envelope that has not started yet
+// has nothing in it. Expenses with no rate into the envelope's currency are
+// left out and the status marked Unconverted.
+func (s *EnvelopeService) statuses(ctx context.Context, userID string, envelopes []*domain.Envelope, period domain.Period) ([]dto.EnvelopeStatus, error) {
+	res := make([]dto.EnvelopeStatus, 0, len(envelopes))
+	if len(envelopes) == 0 {
+		return res, nil
+	}
+
+	categories, err := s.categoryRepo.ListCategoriesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.expenseRepo.ListExpensesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	moves, err := s.repo.ListEnvelopeMovesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range envelopes {
+		currency := e.Allocation.Currency
+		zero := domain.NewMoney(0, currency)
+		status := dto.EnvelopeStatus{
+			Envelope: e, Period: period.String(),
+			Carried: zero, Allocated: zero, MovedIn: zero, MovedOut: zero, Spent: zero, Available: zero,
+		}
+		start, err := domain.ParsePeriod(e.StartPeriod)
+		if err != nil {
+			return nil, err
+		}
+		spent, missing, err := spentByPeriod(ctx, s.rates, expenses, categorySubtree(categories, e.CategoryID), currency)
+		if err != nil {
+			return nil, err
+		}
+
+		carried, carriedUnconverted := zero, false
+		for p := start; !period.Before(p); p = p.Next() {
+			status = dto.EnvelopeStatus{
+				Envelope: e, Period: p.String(),
+				Carried: carried, Allocated: e.Allocation, MovedIn: zero, MovedOut: zero, Spent: zero,
+				Unconverted: carriedUnconverted || missing[p],
+			}
+			if m, ok := spent[p]; ok {
+				status.Spent = m
+			}
+			for _, m := range moves {
+				if m.Period != status.Period || m.Amount.Currency != currency {
+					continue
+				}
+				switch e.UID {
+				case m.ToEnvelopeID:
+					status.MovedIn.MinorUnits += m.Amount.MinorUnits
+				case m.FromEnvelopeID:
+					status.MovedOut.MinorUnits += m.Amount.MinorUnits
+				}
+			}
+			status.Available = domain.NewMoney(
+				status.Carried.MinorUnits+status.Allocated.MinorUnits+status.MovedIn.MinorUnits-status.MovedOut.MinorUnits-status.Spent.MinorUnits,
+				currency)
+
+			carried, carriedUnconverted = zero, false
+			if e.Rollover {
+				carried, carriedUnconverted = status.Available, status.Unconverted
+			}
+		}
+		res = append(res, status)
+	}
+	return res, nil
+}
+
+// ensureUnique rejects a name already in use and a category that overlaps
+// another envelope's, that is the same category, an ancestor or a
+// descendant.
+func (s *EnvelopeService) ensureUnique(ctx context.Context, userID, name, categoryID, exceptID string) error {
+	envelopes, err := s.repo.ListEnvelopesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	categories, err := s.categoryRepo.ListCategoriesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	subtree := categorySubtree(categories, categoryID)
+	for _, e := range envelopes {
+		if e.UID == exceptID {
+			continue
+		}
+		if strings.EqualFold(e.Name, name) || subtree[e.CategoryID] || categorySubtree(categories, e.CategoryID)[categoryID] {
+			return ErrValidation
+		}
+	}
+	return nil
+}
+
+// periodOrCurrent parses a user-supplied period, defaulting to the current
+// one when it is empty.
+func periodOrCurrent(period string) (domain.Period, error) {
+	period = strings.TrimSpace(period)
+	if period == "" {
+		return domain.PeriodOf(time.Now()), nil
+	}
+	p, err := domain.ParsePeriod(period)
+	if err != nil {
+		return domain.Period{}, ErrValidation
+	}
+	return p, nil
+}
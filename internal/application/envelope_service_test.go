@@ -0,0 +1,203 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestEnvelopesRollOverAndMove(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	categories := application.NewCategoryService(db.CategoryRepository, db.ExpenseRepository, db.IncomeRepository, db.BudgetRepository, db.EnvelopeRepository)
+	envelopes := application.NewEnvelopeService(db.EnvelopeRepository, db.CategoryRepository, db.ExpenseRepository, nil)
+
+	const userID = "u1"
+	envelope := func(name string, allocation int64, rollover bool) *domain.Envelope {
+		t.Helper()
+		c, err := categories.CreateCategory(ctx, dto.CategoryInput{UserID: userID, Name: name, Kind: "expense"})
+		if err != nil {
+			t.Fatalf("CreateCategory(%s): %v", name, err)
+		}
+		e, err := envelopes.CreateEnvelope(ctx, dto.EnvelopeInput{
+			UserID: userID, Name: name, CategoryID: c.UID, Allocation: domain.NewMoney(allocation, "USD"), Rollover: rollover, StartPeriod: "2025-01",
+		})
+		if err != nil {
+			t.Fatalf("CreateEnvelope(%s): %v", name, err)
+		}
+		return e
+	}
+	groceries := envelope("Groceries", 30000, true)
+	fun := envelope("Fun", 10000, false)
+
+	n := 0
+	spend := func(e *domain.Envelope, amount int64, month time.Month) {
+		t.Helper()
+		n++
+		on := time.Date(2025, month, 10, 0, 0, 0, 0, time.UTC)
+		_, err := db.ExpenseRepository.CreateExpense(ctx, &domain.Expense{
+			UID: string(rune('a' + n)), UserID: userID, Source: "shop", CategoryID: e.CategoryID, Amount: domain.NewMoney(amount, "USD"), CreatedAt: on, UpdatedAt: on,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	spend(groceries, 25000, time.January)  // 50.00 left, carried
+	spend(groceries, 40000, time.February) // 50.00 + 300.00 - 400.00 = -50.00, carried
+	spend(fun, 2000, time.January)         // 80.00 left, dropped
+
+	available := func(e *domain.Envelope, period string) domain.Money {
+		t.Helper()
+		status, err := envelopes.GetEnvelope(ctx, userID, e.UID, period)
+		if err != nil {
+			t.Fatalf("GetEnvelope(%s, %s): %v", e.Name, period, err)
+		}
+		return status.Available
+	}
+	if got := available(groceries, "2025-02"); got != domain.NewMoney(-5000, "USD") {
+		t.Fatalf("groceries in February = %v, want -50.00", got)
+	}
+	if got := available(groceries, "2025-03"); got != domain.NewMoney(25000, "USD") {
+		t.Fatalf("groceries in March = %v, want 250.00 after the overspend", got)
+	}
+	if got := available(fun, "2025-02"); got != domain.NewMoney(10000, "USD") {
+		t.Fatalf("fun in February = %v, want 100.00 without rollover", got)
+	}
+	if got := available(fun, "2024-12"); !got.IsZero() {
+		t.Fatalf("fun before it started = %v, want 0", got)
+	}
+
+	move := func(amount int64) error {
+		_, err := envelopes.MoveBetweenEnvelopes(ctx, dto.EnvelopeMoveInput{
+			UserID: userID, FromEnvelopeID: fun.UID, ToEnvelopeID: groceries.UID, Period: "2025-02", Amount: domain.NewMoney(amount, "USD"),
+		})
+		return err
+	}
+	if err := move(10001); !errors.Is(err, application.ErrInsufficientFunds) {
+		t.Fatalf("moving more than available: err = %v, want ErrInsufficientFunds", err)
+	}
+	if err := move(5000); err != nil {
+		t.Fatalf("MoveBetweenEnvelopes: %v", err)
+	}
+	if got := available(groceries, "2025-03"); got != domain.NewMoney(30000, "USD") {
+		t.Fatalf("groceries in March after covering February = %v, want 300.00", got)
+	}
+	if got := available(fun, "2025-02"); got != domain.NewMoney(5000, "USD") {
+		t.Fatalf("fun in February after the move = %v, want 50.00", got)
+	}
+}
+
+func TestEnvelopesDoNotOverlapCategories(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	categories := application.NewCategoryService(db.CategoryRepository, db.ExpenseRepository, db.IncomeRepository, db.BudgetRepository, db.EnvelopeRepository)
+	envelopes := application.NewEnvelopeService(db.EnvelopeRepository, db.CategoryRepository, db.ExpenseRepository, nil)
+
+	const userID = "u1"
+	category := func(parentID, name string) string {
+		t.Helper()
+		c, err := categories.CreateCategory(ctx, dto.CategoryInput{UserID: userID, ParentID: parentID, Name: name, Kind: "expense"})
+		if err != nil {
+			t.Fatalf("CreateCategory(%s): %v", name, err)
+		}
+		return c.UID
+	}
+	food := category("", "Food")
+	groceries := category(food, "Groceries")
+	produce := category(groceries, "Produce")
+	dining := category(food, "Dining")
+	rent := category("", "Rent")
+
+	create := func(name, categoryID string) (*domain.Envelope, error) {
+		return envelopes.CreateEnvelope(ctx, dto.EnvelopeInput{UserID: userID, Name: name, CategoryID: categoryID, Allocation: domain.NewMoney(10000, "USD")})
+	}
+	groceriesEnvelope, err := create("Groceries", groceries)
+	if err != nil {
+		t.Fatalf("CreateEnvelope(groceries): %v", err)
+	}
+	for name, categoryID := range map[string]string{"same category": groceries, "ancestor": food, "descendant": produce} {
+		if _, err := create(name, categoryID); !errors.Is(err, application.ErrValidation) {
+			t.Fatalf("CreateEnvelope(%s) err = %v, want ErrValidation", name, err)
+		}
+	}
+	// A sibling shares no expenses with groceries.
+	if _, err := create("Dining", dining); err != nil {
+		t.Fatalf("CreateEnvelope(sibling): %v", err)
+	}
+	rentEnvelope, err := create("Rent", rent)
+	if err != nil {
+		t.Fatalf("CreateEnvelope(rent): %v", err)
+	}
+
+	if _, err := envelopes.UpdateEnvelope(ctx, userID, rentEnvelope.UID, dto.EnvelopeInput{Name: "Rent", CategoryID: food, Allocation: domain.NewMoney(10000, "USD")}); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("UpdateEnvelope(onto an ancestor) err = %v, want ErrValidation", err)
+	}
+	if _, err := envelopes.UpdateEnvelope(ctx, userID, groceriesEnvelope.UID, dto.EnvelopeInput{Name: "Groceries", CategoryID: produce, Allocation: domain.NewMoney(10000, "USD")}); err != nil {
+		t.Fatalf("UpdateEnvelope(onto its own descendant): %v", err)
+	}
+}
+
+func TestEnvelopesListWithSpendingWithoutARate(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	categories := application.NewCategoryService(db.CategoryRepository, db.ExpenseRepository, db.IncomeRepository, db.BudgetRepository, db.EnvelopeRepository)
+	envelopes := application.NewEnvelopeService(db.EnvelopeRepository, db.CategoryRepository, db.ExpenseRepository, nil)
+
+	const userID = "u1"
+	envelope := func(name string, rollover bool) *domain.Envelope {
+		t.Helper()
+		c, err := categories.CreateCategory(ctx, dto.CategoryInput{UserID: userID, Name: name, Kind: "expense"})
+		if err != nil {
+			t.Fatalf("CreateCategory(%s): %v", name, err)
+		}
+		e, err := envelopes.CreateEnvelope(ctx, dto.EnvelopeInput{
+			UserID: userID, Name: name, CategoryID: c.UID, Allocation: domain.NewMoney(30000, "USD"), Rollover: rollover, StartPeriod: "2025-01",
+		})
+		if err != nil {
+			t.Fatalf("CreateEnvelope(%s): %v", name, err)
+		}
+		return e
+	}
+	groceries := envelope("Groceries", true)
+	fun := envelope("Fun", false)
+
+	jan := time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)
+	feb := jan.AddDate(0, 1, 0)
+	// There are no rates at all, so the GHS expense cannot be counted.
+	for _, e := range []*domain.Expense{
+		{UID: "a", UserID: userID, Source: "shop", CategoryID: groceries.CategoryID, Amount: domain.NewMoney(5000, "GHS"), CreatedAt: jan, UpdatedAt: jan},
+		{UID: "b", UserID: userID, Source: "shop", CategoryID: groceries.CategoryID, Amount: domain.NewMoney(10000, "USD"), CreatedAt: feb, UpdatedAt: feb},
+		{UID: "c", UserID: userID, Source: "cinema", CategoryID: fun.CategoryID, Amount: domain.NewMoney(2000, "USD"), CreatedAt: feb, UpdatedAt: feb},
+	} {
+		if _, err := db.ExpenseRepository.CreateExpense(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	statuses, err := envelopes.ListEnvelopes(ctx, userID, "2025-02")
+	if err != nil {
+		t.Fatalf("ListEnvelopes: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("ListEnvelopes = %d envelopes, want 2", len(statuses))
+	}
+	for _, s := range statuses {
+		switch s.Envelope.UID {
+		case groceries.UID:
+			// January's unconverted spending rolled over into February.
+			if !s.Unconverted || s.Spent != domain.NewMoney(10000, "USD") || s.Available != domain.NewMoney(50000, "USD") {
+				t.Errorf("groceries = %+v, want 100.00 spent, 500.00 available and unconverted", s)
+			}
+		case fun.UID:
+			if s.Unconverted || s.Available != domain.NewMoney(28000, "USD") {
+				t.Errorf("fun = %+v, want 280.00 available and converted", s)
+			}
+		}
+	}
+}
@@ -1,7 +1,25 @@
 package application
 
 var (
-	ErrValidation = &ValidationError{msg: "invalid input"}
+	ErrValidation        = &ValidationError{msg: "invalid input"}
+	ErrCategoryInUse     = &ValidationError{msg: "category has subcategories, transactions or budgets"}
+	ErrAccountInUse      = &ValidationError{msg: "account has transactions or savings goals"}
+	ErrInsufficientFunds = &ValidationError{msg: "amount exceeds available funds"}
+	ErrDebtInUse         = &ValidationError{msg: "debt has payments"}
+
+	ErrInvalidTwoFactorCode      = &ValidationError{msg: "invalid two-factor code"}
+	ErrTwoFactorAlreadyEnabled   = &ValidationError{msg: "two-factor authentication is already enabled"}
+	ErrTwoFactorNotEnabled       = &ValidationError{msg: "two-factor authentication is not enabled"}
+	ErrTwoFactorNotEnrolled      = &ValidationError{msg: "two-factor authentication has not been enrolled"}
+	ErrInvalidTwoFactorChallenge = &ValidationError{msg: "two-factor challenge is invalid or expired"}
+
+	ErrPasskeysDisabled         = &ValidationError{msg: "passkeys are not configured"}
+	ErrInvalidPasskeyCeremony   = &ValidationError{msg: "passkey session is invalid or expired"}
+	ErrPasskeyVerification      = &ValidationError{msg: "passkey could not be verified"}
+	ErrPasskeyAlreadyRegistered = &ValidationError{msg: "passkey is already registered"}
+
+	ErrInvalidAccountUnlock  = &ValidationError{msg: "unlock link is invalid or expired"}
+	ErrPasswordLoginDisabled = &ValidationError{msg: "password login is not available with this auth provider"}
 )
 
 type ValidationError struct{ msg string }
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+// importBatchSize bounds how many rates are written per repository call.
+const importBatchSize = 500
+
+// ExchangeRateService imports historical rates and converts with them. A
+// pair that is not stored is served from its inverse or crossed through the
+// pivot currency, EUR for ECB reference rates.
+type ExchangeRateService struct {
+	repo  ports.ExchangeRateRepository
+	pivot string
+}
+
+func NewExchangeRateService(repo ports.ExchangeRateRepository, pivot string) *ExchangeRateService {
+	pivot, _ = domain.NormalizeCurrency(pivot)
+	return &ExchangeRateService{repo: repo, pivot: pivot}
+}
+
+var _ ports.ExchangeRateServicePort = (*ExchangeRateService)(nil)
+
+// ImportRates validates and stores rates, replacing earlier imports for the
+// same pair and day. Dates are truncated to the UTC day.
+func (s *ExchangeRateService) ImportRates(ctx context.Context, rates []domain.ExchangeRate) (int, error) {
+	clean := make([]domain.ExchangeRate, 0, len(rates))
+	for _, r := range rates {
+		base, okBase := domain.NormalizeCurrency(r.Base)
+		quote, okQuote := domain.NormalizeCurrency(r.Quote)
+		if !okBase || !okQuote || base == quote {
+			return 0, fmt.Errorf("%w: invalid currency pair %s/%s", ErrValidation, r.Base, r.Quote)
+		}
+		if r.Rate <= 0 || math.IsInf(r.Rate, 0) || math.IsNaN(r.Rate) {
+			return 0, fmt.Errorf("%w: invalid rate %v for %s/%s", ErrValidation, r.Rate, base, quote)
+		}
+		if r.Date.IsZero() {
+			return 0, fmt.Errorf("%w: rate for %s/%s has no date", ErrValidation, base, quote)
+		}
+		y, m, d := r.Date.UTC().Date()
+		clean = append(clean, domain.ExchangeRate{Base: base, Quote: quote, Date: time.Date(y, m, d, 0, 0, 0, 0, time.UTC), Rate: r.Rate})
+	}
+
+	for start := 0; start < len(clean); start += importBatchSize {
+		end := min(start+importBatchSize, len(clean))
+		if err := s.repo.SaveRates(ctx, clean[start:end]); err != nil {
+			return start, err
+		}
+	}
+	return len(clean), nil
+}
+
+func (s *ExchangeRateService) Rate(ctx context.Context, base, quote string, on time.Time) (float64, error) {
+	base, _ = domain.NormalizeCurrency(base)
+	quote, _ = domain.NormalizeCurrency(quote)
+	if base == quote {
+		return 1, nil
+	}
+
+	rate, err := s.direct(ctx, base, quote, on)
+	if !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		return rate, err
+	}
+	if s.pivot != "" && base != s.pivot && quote != s.pivot {
+		first, err := s.direct(ctx, base, s.pivot, on)
+		if err == nil {
+			var second float64
+			if second, err = s.direct(ctx, s.pivot, quote, on); err == nil {
+				return first * second, nil
+			}
+		}
+		if !errors.Is(err, domain.ErrExchangeRateNotFound) {
+			return 0, err
+		}
+	}
+	return 0, fmt.Errorf("%w: %s/%s on %s", domain.ErrExchangeRateNotFound, base, quote, on.Format("2006-01-02"))
+}
+
+// direct looks up base/quote and its inverse, preferring whichever was
+// quoted more recently.
+func (s *ExchangeRateService) direct(ctx context.Context, base, quote string, on time.Time) (float64, error) {
+	straight, err := s.repo.FindRate(ctx, base, quote, on)
+	if err != nil && !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		return 0, err
+	}
+	inverse, err := s.repo.FindRate(ctx, quote, base, on)
+	if err != nil && !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		return 0, err
+	}
+
+	switch {
+	case straight != nil && (inverse == nil || !inverse.Date.After(straight.Date)):
+		return straight.Rate, nil
+	case inverse != nil:
+		return 1 / inverse.Rate, nil
+	default:
+		return 0, domain.ErrExchangeRateNotFound
+	}
+}
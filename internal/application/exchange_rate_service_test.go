@@ -0,0 +1,55 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestExchangeRateServiceLookups(t *testing.T) {
+	ctx := context.Background()
+	day := func(n int) time.Time { return time.Date(2024, time.January, n, 0, 0, 0, 0, time.UTC) }
+	svc := application.NewExchangeRateService(memory.NewExchangeRateRepository(), "EUR")
+
+	n, err := svc.ImportRates(ctx, []domain.ExchangeRate{
+		{Base: "eur", Quote: "usd", Date: day(2).Add(16 * time.Hour), Rate: 1.10},
+		{Base: "EUR", Quote: "USD", Date: day(5), Rate: 1.20},
+		{Base: "EUR", Quote: "GBP", Date: day(2), Rate: 0.80},
+	})
+	if err != nil || n != 3 {
+		t.Fatalf("ImportRates = %d, %v", n, err)
+	}
+	if _, err := svc.ImportRates(ctx, []domain.ExchangeRate{{Base: "EUR", Quote: "USD", Date: day(1), Rate: -1}}); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("negative rate: err = %v, want ErrValidation", err)
+	}
+
+	for _, c := range []struct {
+		base, quote string
+		on          time.Time
+		want        float64
+	}{
+		{"EUR", "USD", day(4), 1.10},
+		{"EUR", "USD", day(6), 1.20},
+		{"USD", "EUR", day(3), 1 / 1.10},
+		{"USD", "GBP", day(3), 0.80 / 1.10},
+		{"GBP", "GBP", day(1), 1},
+	} {
+		got, err := svc.Rate(ctx, c.base, c.quote, c.on)
+		if err != nil || math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("Rate(%s/%s, %s) = %v, %v; want %v", c.base, c.quote, c.on.Format("2006-01-02"), got, err, c.want)
+		}
+	}
+
+	if _, err := svc.Rate(ctx, "EUR", "USD", day(1)); !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		t.Fatalf("before first rate: err = %v, want ErrExchangeRateNotFound", err)
+	}
+	if _, err := svc.Rate(ctx, "USD", "JPY", day(3)); !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		t.Fatalf("unknown pair: err = %v, want ErrExchangeRateNotFound", err)
+	}
+}
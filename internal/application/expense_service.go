@@ -12,11 +12,14 @@ import (
 )
 
 type ExpenseService struct {
-	repo ports.ExpenseRepoPort
+	repo         ports.ExpenseRepoPort
+	categoryRepo ports.CategoryRepoPort
+	accountRepo  ports.AccountRepoPort
+	debtRepo     ports.DebtRepoPort
 }
 
-func NewExpenseService(repo ports.ExpenseRepoPort) *ExpenseService {
-	return &ExpenseService{repo: repo}
+func NewExpenseService(repo ports.ExpenseRepoPort, categoryRepo ports.CategoryRepoPort, accountRepo ports.AccountRepoPort, debtRepo ports.DebtRepoPort) *ExpenseService {
+	return &ExpenseService{repo: repo, categoryRepo: categoryRepo, accountRepo: accountRepo, debtRepo: debtRepo}
 }
 
 var _ ports.ExpenseServicePort = (*ExpenseService)(nil)
@@ -24,20 +27,41 @@ var _ ports.ExpenseServicePort = (*ExpenseService)(nil)
 func (s *ExpenseService) AddExpense(ctx context.Context, in dto.AddExpenseInput) (*domain.Expense, error) {
 	userID := strings.TrimSpace(in.UserID)
 	source := strings.TrimSpace(in.Source)
-	currency := strings.TrimSpace(in.Currency)
-	if userID == "" || source == "" || in.Amount <= 0 {
+	amount, err := normalizeAmount(in.Amount)
+	if userID == "" || source == "" || err != nil {
 		return nil, ErrValidation
 	}
-	if currency == "" {
-		currency = "USD"
+	tags, ok := domain.NormalizeTags(in.Tags)
+	if !ok {
+		return nil, ErrValidation
+	}
+	categoryID, err := resolveCategory(ctx, s.categoryRepo, userID, in.CategoryID, domain.CategoryKindExpense)
+	if err != nil {
+		return nil, err
+	}
+	splits, err := s.resolveSplits(ctx, userID, categoryID, in.Splits, amount)
+	if err != nil {
+		return nil, err
+	}
+	accountID, err := resolveAccount(ctx, s.accountRepo, userID, in.AccountID, amount)
+	if err != nil {
+		return nil, err
+	}
+	debtID, err := s.resolveDebt(ctx, userID, in.DebtID, amount)
+	if err != nil {
+		return nil, err
 	}
 
 	expense := &domain.Expense{
 		UID:                 uuid.NewString(),
 		UserID:              userID,
 		Source:              source,
-		Amount:              in.Amount,
-		Currency:            currency,
+		AccountID:           accountID,
+		CategoryID:          categoryID,
+		DebtID:              debtID,
+		Tags:                tags,
+		Amount:              amount,
+		Splits:              splits,
 		Notes:               strings.TrimSpace(in.Notes),
 		IsRecurring:         in.IsRecurring,
 		RecurrenceFrequency: strings.TrimSpace(in.RecurrenceFrequency),
@@ -52,12 +76,30 @@ func (s *ExpenseService) AddExpense(ctx context.Context, in dto.AddExpenseInput)
 	return s.repo.CreateExpense(ctx, expense)
 }
 
-func (s *ExpenseService) ListExpenses(ctx context.Context, userID string) ([]*domain.Expense, error) {
+func (s *ExpenseService) ListExpenses(ctx context.Context, userID string, filter dto.TransactionFilter) ([]*domain.Expense, error) {
 	userID = strings.TrimSpace(userID)
 	if userID == "" {
 		return nil, ErrValidation
 	}
-	return s.repo.ListExpensesByUser(ctx, userID)
+	match, err := transactionMatcher(ctx, s.categoryRepo, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.repo.ListExpensesByUser(ctx, userID)
+	if err != nil || match == nil {
+		return expenses, err
+	}
+	res := make([]*domain.Expense, 0, len(expenses))
+	for _, e := range expenses {
+		for _, line := range e.Lines() {
+			if match(line.CategoryID, e.Tags) {
+				res = append(res, e)
+				break
+			}
+		}
+	}
+	return res, nil
 }
 
 func (s *ExpenseService) GetExpense(ctx context.Context, userID string, expenseID string) (*domain.Expense, error) {
@@ -73,23 +115,47 @@ func (s *ExpenseService) UpdateExpense(ctx context.Context, userID string, expen
 	userID = strings.TrimSpace(userID)
 	expenseID = strings.TrimSpace(expenseID)
 	source := strings.TrimSpace(in.Source)
-	currency := strings.TrimSpace(in.Currency)
+	amount, err := normalizeAmount(in.Amount)
 
-	if userID == "" || expenseID == "" || source == "" || in.Amount <= 0 {
+	if userID == "" || expenseID == "" || source == "" || err != nil {
 		return nil, ErrValidation
 	}
-	if currency == "" {
-		currency = "USD"
-	}
 
 	expense, err := s.repo.GetExpense(ctx, userID, expenseID)
 	if err != nil {
 		return nil, err
 	}
+	tags, ok := domain.NormalizeTags(in.Tags)
+	if !ok {
+		return nil, ErrValidation
+	}
+	categoryID, err := resolveCategory(ctx, s.categoryRepo, userID, in.CategoryID, domain.CategoryKindExpense)
+	if err != nil {
+		return nil, err
+	}
+	splits, err := s.resolveSplits(ctx, userID, categoryID, in.Splits, amount)
+	if err != nil {
+		return nil, err
+	}
+	accountID := in.AccountID
+	if strings.TrimSpace(accountID) == "" && expense.Amount.Currency == amount.Currency {
+		accountID = expense.AccountID
+	}
+	if accountID, err = resolveAccount(ctx, s.accountRepo, userID, accountID, amount); err != nil {
+		return nil, err
+	}
+	debtID, err := s.resolveDebt(ctx, userID, in.DebtID, amount)
+	if err != nil {
+		return nil, err
+	}
 
 	expense.Source = source
-	expense.Amount = in.Amount
-	expense.Currency = currency
+	expense.AccountID = accountID
+	expense.CategoryID = categoryID
+	expense.DebtID = debtID
+	expense.Tags = tags
+	expense.Amount = amount
+	expense.Splits = splits
 	expense.Notes = strings.TrimSpace(in.Notes)
 	expense.IsRecurring = in.IsRecurring
 	expense.RecurrenceFrequency = strings.TrimSpace(in.RecurrenceFrequency)
@@ -136,14 +202,18 @@ func (s *ExpenseService) ProcessDueExpenses(ctx context.Context, userID string,
 
 		if normalizedNext.Equal(normalizedNow) {
 			_, err := s.repo.CreateExpense(ctx, &domain.Expense{
-				UID:       uuid.NewString(),
-				UserID:    userID,
-				Source:    exp.Source,
-				Amount:    exp.Amount,
-				Currency:  exp.Currency,
-				Notes:     exp.Notes,
-				CreatedAt: time.Now().UTC(),
-				UpdatedAt: time.Now().UTC(),
+				UID:        uuid.NewString(),
+				UserID:     userID,
+				Source:     exp.Source,
+				AccountID:  exp.AccountID,
+				CategoryID: exp.CategoryID,
+				DebtID:     exp.DebtID,
+				Tags:       exp.Tags,
+				Amount:     exp.Amount,
+				Splits:     exp.Splits,
+				Notes:      exp.Notes,
+				CreatedAt:  time.Now().UTC(),
+				UpdatedAt:  time.Now().UTC(),
 			})
 			if err != nil {
 				return count, err
@@ -158,6 +228,59 @@ func (s *ExpenseService) ProcessDueExpenses(ctx context.Context, userID string,
 	return count, nil
 }
 
+// resolveDebt checks that a payment is on one of the user's debts and in
+// its currency. An empty debtID means the expense pays no debt.
+func (s *ExpenseService) resolveDebt(ctx context.Context, userID, debtID string, amount domain.Money) (string, error) {
+	debtID = strings.TrimSpace(debtID)
+	if debtID == "" {
+		return "", nil
+	}
+	debt, err := s.debtRepo.GetDebt(ctx, userID, debtID)
+	if err != nil {
+		return "", err
+	}
+	if debt.Principal.Currency != amount.Currency {
+		return "", ErrValidation
+	}
+	return debtID, nil
+}
+
+// resolveSplits checks that splits spread amount over the user's expense
+// categories. An expense is categorised either as a whole or by its splits,
+// so categoryID must be empty when there are splits. A split without a
+// currency takes the expense's.
+func (s *ExpenseService) resolveSplits(ctx context.Context, userID, categoryID string, splits []domain.ExpenseSplit, amount domain.Money) ([]domain.ExpenseSplit, error) {
+	if len(splits) == 0 {
+		return nil, nil
+	}
+	if categoryID != "" || len(splits) < 2 {
+		return nil, ErrValidation
+	}
+	res := make([]domain.ExpenseSplit, len(splits))
+	total := domain.NewMoney(0, amount.Currency)
+	for i, split := range splits {
+		if split.Amount.Currency == "" {
+			split.Amount.Currency = amount.Currency
+		}
+		splitAmount, err := normalizeAmount(split.Amount)
+		if err != nil || splitAmount.Currency != amount.Currency {
+			return nil, ErrValidation
+		}
+		splitCategoryID, err := resolveCategory(ctx, s.categoryRepo, userID, split.CategoryID, domain.CategoryKindExpense)
+		if err != nil {
+			return nil, err
+		}
+		if total, err = total.Add(splitAmount); err != nil {
+			return nil, err
+		}
+		res[i] = domain.ExpenseSplit{CategoryID: splitCategoryID, Amount: splitAmount, Notes: strings.TrimSpace(split.Notes)}
+	}
+	if total != amount {
+		return nil, ErrValidation
+	}
+	return res, nil
+}
+
 func isValidExpenseFrequency(freq string) bool {
 	switch freq {
 	case string(dto.RecurringWeekly), string(dto.RecurringBiWeekly), string(dto.RecurringMonthly), string(dto.RecurringAnnually):
@@ -0,0 +1,90 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestSplitExpensesAttributeEachSplit(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	categories := application.NewCategoryService(db.CategoryRepository, db.ExpenseRepository, db.IncomeRepository, db.BudgetRepository, db.EnvelopeRepository)
+	expenses := application.NewExpenseService(db.ExpenseRepository, db.CategoryRepository, db.AccountRepository, db.DebtRepository)
+	budgets := application.NewBudgetService(db.BudgetRepository, db.CategoryRepository, db.ExpenseRepository, db.IncomeRepository, db.UserRepository, nil)
+
+	const userID = "u1"
+	if _, err := db.UserRepository.CreateUser(ctx, domain.NewUser(userID, "ama", "ama@example.com", "Ama", "Mensah", nil)); err != nil {
+		t.Fatal(err)
+	}
+	category := func(name string) *domain.Category {
+		t.Helper()
+		c, err := categories.CreateCategory(ctx, dto.CategoryInput{UserID: userID, Name: name, Kind: "expense"})
+		if err != nil {
+			t.Fatalf("CreateCategory(%s): %v", name, err)
+		}
+		return c
+	}
+	food, household, pharmacy := category("Food"), category("Household"), category("Pharmacy")
+
+	receipt := dto.AddExpenseInput{UserID: userID, Source: "Supermarket", Amount: domain.NewMoney(10000, "USD"), Splits: []domain.ExpenseSplit{
+		{CategoryID: food.UID, Amount: domain.NewMoney(6000, "USD"), Notes: "weekly shop"},
+		{CategoryID: household.UID, Amount: domain.NewMoney(2500, "USD")},
+		{CategoryID: pharmacy.UID, Amount: domain.NewMoney(1500, "USD")},
+	}}
+	for name, in := range map[string]dto.AddExpenseInput{
+		"splits short of the total": {UserID: userID, Source: "Supermarket", Amount: domain.NewMoney(10001, "USD"), Splits: receipt.Splits},
+		"category and splits":       {UserID: userID, Source: "Supermarket", Amount: receipt.Amount, CategoryID: food.UID, Splits: receipt.Splits},
+		"a single split":            {UserID: userID, Source: "Supermarket", Amount: domain.NewMoney(6000, "USD"), Splits: receipt.Splits[:1]},
+	} {
+		if _, err := expenses.AddExpense(ctx, in); !errors.Is(err, application.ErrValidation) {
+			t.Errorf("%s: err = %v, want ErrValidation", name, err)
+		}
+	}
+	expense, err := expenses.AddExpense(ctx, receipt)
+	if err != nil {
+		t.Fatalf("AddExpense: %v", err)
+	}
+	if len(expense.Splits) != 3 || expense.CategoryID != "" || expense.Splits[0].Notes != "weekly shop" {
+		t.Fatalf("AddExpense = %+v", expense)
+	}
+
+	now := time.Now().UTC()
+	if _, err := db.IncomeRepository.CreateIncome(ctx, &domain.Income{UID: "salary", UserID: userID, Source: "salary", Amount: domain.NewMoney(100000, "USD"), CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	period := domain.PeriodOf(now).String()
+	for _, c := range []*domain.Category{food, pharmacy} {
+		if _, err := budgets.CreateBudget(ctx, dto.BudgetInput{UserID: userID, CategoryID: c.UID, Period: period, Planned: domain.NewMoney(50000, "USD")}); err != nil {
+			t.Fatalf("CreateBudget: %v", err)
+		}
+	}
+	view, err := budgets.GetBudgetPeriod(ctx, userID, period)
+	if err != nil {
+		t.Fatalf("GetBudgetPeriod: %v", err)
+	}
+	spent := map[string]domain.Money{}
+	for _, line := range view.Lines {
+		spent[line.Budget.CategoryID] = line.Spent
+	}
+	if spent[food.UID] != domain.NewMoney(6000, "USD") || spent[pharmacy.UID] != domain.NewMoney(1500, "USD") {
+		t.Fatalf("spent = %v, want 60.00 on food and 15.00 on pharmacy", spent)
+	}
+
+	list, err := expenses.ListExpenses(ctx, userID, dto.TransactionFilter{CategoryID: household.UID})
+	if err != nil {
+		t.Fatalf("ListExpenses: %v", err)
+	}
+	if len(list) != 1 || list[0].UID != expense.UID {
+		t.Fatalf("ListExpenses(household) = %+v, want the split receipt", list)
+	}
+	if err := categories.DeleteCategory(ctx, userID, household.UID); !errors.Is(err, application.ErrCategoryInUse) {
+		t.Fatalf("DeleteCategory(household): err = %v, want ErrCategoryInUse", err)
+	}
+}
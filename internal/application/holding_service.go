@@ -0,0 +1,269 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type HoldingService struct {
+	repo ports.HoldingRepoPort
+}
+
+func NewHoldingService(repo ports.HoldingRepoPort) *HoldingService {
+	return &HoldingService{repo: repo}
+}
+
+var _ ports.HoldingServicePort = (*HoldingService)(nil)
+
+// CreateHolding records an asset or a liability. Category defaults to
+// other and Currency to the default currency.
+func (s *HoldingService) CreateHolding(ctx context.Context, in dto.HoldingInput) (*dto.HoldingStatus, error) {
+	userID := strings.TrimSpace(in.UserID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	currency, ok := normalizeBalance(domain.NewMoney(0, in.Currency))
+	if !ok {
+		return nil, ErrValidation
+	}
+	now := time.Now().UTC()
+	holding := &domain.Holding{UID: uuid.NewString(), UserID: userID, Currency: currency.Currency, CreatedAt: now, UpdatedAt: now}
+	if err := applyHoldingInput(holding, in); err != nil {
+		return nil, err
+	}
+	var valuation *domain.Valuation
+	if in.Value > 0 {
+		var err error
+		if valuation, err = newValuation(holding, in.Value, "", in.ValuedAt, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	holding, err := s.repo.CreateHolding(ctx, holding)
+	if err != nil {
+		return nil, err
+	}
+	var valuations []*domain.Valuation
+	if valuation != nil {
+		if _, err := s.repo.CreateValuation(ctx, valuation); err != nil {
+			return nil, err
+		}
+		valuations = append(valuations, valuation)
+	}
+	return holdingStatus(holding, valuations, time.Now().UTC()), nil
+}
+
+func (s *HoldingService) ListHoldings(ctx context.Context, userID string, kind string) ([]dto.HoldingStatus, error) {
+	userID = strings.TrimSpace(userID)
+	holdingKind := domain.HoldingKind(strings.ToLower(strings.TrimSpace(kind)))
+	if userID == "" || (holdingKind != "" && !holdingKind.Valid()) {
+		return nil, ErrValidation
+	}
+	holdings, err := s.repo.ListHoldingsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	valuations, err := s.valuations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	res := make([]dto.HoldingStatus, 0, len(holdings))
+	for _, h := range holdings {
+		if holdingKind != "" && h.Kind != holdingKind {
+			continue
+		}
+		res = append(res, *holdingStatus(h, valuations[h.UID], now))
+	}
+	return res, nil
+}
+
+func (s *HoldingService) GetHolding(ctx context.Context, userID string, holdingID string) (*dto.HoldingStatus, error) {
+	userID = strings.TrimSpace(userID)
+	holdingID = strings.TrimSpace(holdingID)
+	if userID == "" || holdingID == "" {
+		return nil, ErrValidation
+	}
+	holding, err := s.repo.GetHolding(ctx, userID, holdingID)
+	if err != nil {
+		return nil, err
+	}
+	valuations, err := s.valuations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return holdingStatus(holding, valuations[holdingID], time.Now().UTC()), nil
+}
+
+// UpdateHolding renames or recategorises a holding and, when Value is
+// positive, records a new valuation. The currency is fixed once the
+// holding exists.
+func (s *HoldingService) UpdateHolding(ctx context.Context, userID string, holdingID string, in dto.HoldingInput) (*dto.HoldingStatus, error) {
+	userID = strings.TrimSpace(userID)
+	holdingID = strings.TrimSpace(holdingID)
+	if userID == "" || holdingID == "" {
+		return nil, ErrValidation
+	}
+	holding, err := s.repo.GetHolding(ctx, userID, holdingID)
+	if err != nil {
+		return nil, err
+	}
+	if currency := strings.TrimSpace(in.Currency); currency != "" {
+		if code, ok := domain.NormalizeCurrency(currency); !ok || code != holding.Currency {
+			return nil, ErrValidation
+		}
+	}
+	if err := applyHoldingInput(holding, in); err != nil {
+		return nil, err
+	}
+	var valuation *domain.Valuation
+	if in.Value > 0 {
+		if valuation, err = newValuation(holding, in.Value, "", in.ValuedAt, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	holding.UpdatedAt = time.Now().UTC()
+	holding, err = s.repo.UpdateHolding(ctx, holding)
+	if err != nil {
+		return nil, err
+	}
+	if valuation != nil {
+		if _, err := s.repo.CreateValuation(ctx, valuation); err != nil {
+			return nil, err
+		}
+	}
+	valuations, err := s.valuations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return holdingStatus(holding, valuations[holdingID], time.Now().UTC()), nil
+}
+
+func (s *HoldingService) DeleteHolding(ctx context.Context, userID string, holdingID string) error {
+	userID = strings.TrimSpace(userID)
+	holdingID = strings.TrimSpace(holdingID)
+	if userID == "" || holdingID == "" {
+		return ErrValidation
+	}
+	return s.repo.DeleteHolding(ctx, userID, holdingID)
+}
+
+// AddValuation records what the holding was worth on Date, today when
+// zero. The value may be zero, for a written-off car or a repaid loan, and
+// must be in the holding's currency.
+func (s *HoldingService) AddValuation(ctx context.Context, in dto.ValuationInput) (*domain.Valuation, error) {
+	userID := strings.TrimSpace(in.UserID)
+	holdingID := strings.TrimSpace(in.HoldingID)
+	if userID == "" || holdingID == "" {
+		return nil, ErrValidation
+	}
+	holding, err := s.repo.GetHolding(ctx, userID, holdingID)
+	if err != nil {
+		return nil, err
+	}
+	valuation, err := newValuation(holding, in.Value, in.Currency, in.Date, in.Notes)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.CreateValuation(ctx, valuation)
+}
+
+func (s *HoldingService) ListValuations(ctx context.Context, userID string, holdingID string) ([]*domain.Valuation, error) {
+	userID = strings.TrimSpace(userID)
+	holdingID = strings.TrimSpace(holdingID)
+	if userID == "" || holdingID == "" {
+		return nil, ErrValidation
+	}
+	if _, err := s.repo.GetHolding(ctx, userID, holdingID); err != nil {
+		return nil, err
+	}
+	valuations, err := s.valuations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	res := valuations[holdingID]
+	if res == nil {
+		res = []*domain.Valuation{}
+	}
+	return res, nil
+}
+
+// valuations maps each holding to its valuations, oldest first.
+func (s *HoldingService) valuations(ctx context.Context, userID string) (map[string][]*domain.Valuation, error) {
+	valuations, err := s.repo.ListValuationsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string][]*domain.Valuation)
+	for _, v := range valuations {
+		res[v.HoldingID] = append(res[v.HoldingID], v)
+	}
+	return res, nil
+}
+
+func applyHoldingInput(holding *domain.Holding, in dto.HoldingInput) error {
+	name := strings.TrimSpace(in.Name)
+	kind := domain.HoldingKind(strings.ToLower(strings.TrimSpace(in.Kind)))
+	category := domain.HoldingCategory(strings.ToLower(strings.TrimSpace(in.Category)))
+	if category == "" {
+		category = domain.HoldingCategoryOther
+	}
+	if name == "" || !kind.Valid() || !category.Valid() {
+		return ErrValidation
+	}
+	holding.Name = name
+	holding.Kind = kind
+	holding.Category = category
+	return nil
+}
+
+// newValuation values holding at amount major units of currency on date,
+// today when zero. currency defaults to the holding's and the amount is
+// only rounded to minor units once it is known.
+func newValuation(holding *domain.Holding, amount float64, currency string, date time.Time, notes string) (*domain.Valuation, error) {
+	if amount < 0 {
+		return nil, ErrValidation
+	}
+	if strings.TrimSpace(currency) == "" {
+		currency = holding.Currency
+	}
+	code, ok := domain.NormalizeCurrency(currency)
+	if !ok || code != holding.Currency {
+		return nil, ErrValidation
+	}
+	value := domain.MoneyFromFloat(amount, code)
+	now := time.Now().UTC()
+	if date.IsZero() {
+		date = now
+	}
+	return &domain.Valuation{
+		UID:       uuid.NewString(),
+		UserID:    holding.UserID,
+		HoldingID: holding.UID,
+		Value:     value,
+		Date:      date.UTC(),
+		Notes:     strings.TrimSpace(notes),
+		CreatedAt: now,
+	}, nil
+}
+
+// holdingStatus values holding at its latest valuation dated no later than
+// now. valuations must be oldest first.
+func holdingStatus(holding *domain.Holding, valuations []*domain.Valuation, now time.Time) *dto.HoldingStatus {
+	status := &dto.HoldingStatus{Holding: holding, Value: domain.NewMoney(0, holding.Currency)}
+	for _, v := range valuations {
+		if v.Date.After(now) {
+			break
+		}
+		status.Value = v.Value
+		status.ValuedAt = v.Date
+	}
+	return status
+}
@@ -0,0 +1,47 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestHoldingValuesUseTheHoldingsCurrencyScale(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	holdings := application.NewHoldingService(db.HoldingRepository)
+
+	const userID = "u1"
+	for _, c := range []struct {
+		currency       string
+		value, revalue float64
+		want, wantNext domain.Money
+	}{
+		{"JPY", 5000, 4800, domain.NewMoney(5000, "JPY"), domain.NewMoney(4800, "JPY")},
+		{"KWD", 12.345, 12.5, domain.NewMoney(12345, "KWD"), domain.NewMoney(12500, "KWD")},
+	} {
+		holding, err := holdings.CreateHolding(ctx, dto.HoldingInput{UserID: userID, Name: "Savings " + c.currency, Kind: "asset", Currency: c.currency})
+		if err != nil {
+			t.Fatalf("CreateHolding(%s): %v", c.currency, err)
+		}
+		// Neither request names a currency, so the value is in the holding's.
+		status, err := holdings.UpdateHolding(ctx, userID, holding.Holding.UID, dto.HoldingInput{Name: holding.Holding.Name, Kind: "asset", Value: c.value})
+		if err != nil {
+			t.Fatalf("UpdateHolding(%s): %v", c.currency, err)
+		}
+		if status.Value != c.want {
+			t.Errorf("UpdateHolding(%s) value = %+v, want %+v", c.currency, status.Value, c.want)
+		}
+		valuation, err := holdings.AddValuation(ctx, dto.ValuationInput{UserID: userID, HoldingID: holding.Holding.UID, Value: c.revalue})
+		if err != nil {
+			t.Fatalf("AddValuation(%s): %v", c.currency, err)
+		}
+		if valuation.Value != c.wantNext {
+			t.Errorf("AddValuation(%s) value = %+v, want %+v", c.currency, valuation.Value, c.wantNext)
+		}
+	}
+}
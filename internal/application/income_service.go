@@ -12,11 +12,13 @@ import (
 )
 
 type IncomeService struct {
-	repo ports.IncomeRepoPort
+	repo         ports.IncomeRepoPort
+	categoryRepo ports.CategoryRepoPort
+	accountRepo  ports.AccountRepoPort
 }
 
-func NewIncomeService(repo ports.IncomeRepoPort) *IncomeService {
-	return &IncomeService{repo: repo}
+func NewIncomeService(repo ports.IncomeRepoPort, categoryRepo ports.CategoryRepoPort, accountRepo ports.AccountRepoPort) *IncomeService {
+	return &IncomeService{repo: repo, categoryRepo: categoryRepo, accountRepo: accountRepo}
 }
 
 var _ ports.IncomeServicePort = (*IncomeService)(nil)
@@ -24,33 +26,59 @@ var _ ports.IncomeServicePort = (*IncomeService)(nil)
 func (s *IncomeService) AddIncome(ctx context.Context, in dto.AddIncomeInput) (*domain.Income, error) {
 	userID := strings.TrimSpace(in.UserID)
 	source := strings.TrimSpace(in.Source)
-	currency := strings.TrimSpace(in.Currency)
-	if userID == "" || source == "" || in.Amount <= 0 {
+	amount, err := normalizeAmount(in.Amount)
+	if userID == "" || source == "" || err != nil {
 		return nil, ErrValidation
 	}
-	if currency == "" {
-		currency = "USD"
+	tags, ok := domain.NormalizeTags(in.Tags)
+	if !ok {
+		return nil, ErrValidation
+	}
+	categoryID, err := resolveCategory(ctx, s.categoryRepo, userID, in.CategoryID, domain.CategoryKindIncome)
+	if err != nil {
+		return nil, err
+	}
+	accountID, err := resolveAccount(ctx, s.accountRepo, userID, in.AccountID, amount)
+	if err != nil {
+		return nil, err
 	}
 
 	income := &domain.Income{
-		UID:       uuid.NewString(),
-		UserID:    userID,
-		Source:    source,
-		Amount:    in.Amount,
-		Currency:  currency,
-		Notes:     strings.TrimSpace(in.Notes),
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
+		UID:        uuid.NewString(),
+		UserID:     userID,
+		Source:     source,
+		AccountID:  accountID,
+		CategoryID: categoryID,
+		Tags:       tags,
+		Amount:     amount,
+		Notes:      strings.TrimSpace(in.Notes),
+		CreatedAt:  time.Now().UTC(),
+		UpdatedAt:  time.Now().UTC(),
 	}
 	return s.repo.CreateIncome(ctx, income)
 }
 
-func (s *IncomeService) ListIncomes(ctx context.Context, userID string) ([]*domain.Income, error) {
+func (s *IncomeService) ListIncomes(ctx context.Context, userID string, filter dto.TransactionFilter) ([]*domain.Income, error) {
 	userID = strings.TrimSpace(userID)
 	if userID == "" {
 		return nil, ErrValidation
 	}
-	return s.repo.ListIncomesByUser(ctx, userID)
+	match, err := transactionMatcher(ctx, s.categoryRepo, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	incomes, err := s.repo.ListIncomesByUser(ctx, userID)
+	if err != nil || match == nil {
+		return incomes, err
+	}
+	res := make([]*domain.Income, 0, len(incomes))
+	for _, i := range incomes {
+		if match(i.CategoryID, i.Tags) {
+			res = append(res, i)
+		}
+	}
+	return res, nil
 }
 
 func (s *IncomeService) DeleteIncome(ctx context.Context, userID string, incomeID string) error {
@@ -78,14 +106,11 @@ func (s *IncomeService) DeleteIncome(ctx context.Context, userID string, incomeI
 func (s *IncomeService) AddIncomeSource(ctx context.Context, in dto.AddIncomeSourceInput) (*domain.IncomeSource, error) {
 	userID := strings.TrimSpace(in.UserID)
 	source := strings.TrimSpace(in.Source)
-	currency := strings.TrimSpace(in.Currency)
 	freq := strings.ToLower(string(in.Frequency))
-	if userID == "" || source == "" || in.Amount <= 0 || freq == "" {
+	amount, err := normalizeAmount(in.Amount)
+	if userID == "" || source == "" || err != nil || freq == "" {
 		return nil, ErrValidation
 	}
-	if currency == "" {
-		currency = "USD"
-	}
 	if !isValidFrequency(freq) {
 		return nil, ErrValidation
 	}
@@ -98,8 +123,7 @@ func (s *IncomeService) AddIncomeSource(ctx context.Context, in dto.AddIncomeSou
 		UID:       uuid.NewString(),
 		UserID:    userID,
 		Source:    source,
-		Amount:    in.Amount,
-		Currency:  currency,
+		Amount:    amount,
 		Frequency: freq,
 		NextPayAt: next,
 		Active:    true,
@@ -138,12 +162,16 @@ func (s *IncomeService) ProcessDueIncomes(ctx context.Context, userID string, no
 		normalizedNow := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
 		if normalizedNext.Equal(normalizedNow) {
-			_, err := s.repo.CreateIncome(ctx, &domain.Income{
+			accountID, err := resolveAccount(ctx, s.accountRepo, userID, "", src.Amount)
+			if err != nil {
+				return count, err
+			}
+			_, err = s.repo.CreateIncome(ctx, &domain.Income{
 				UID:       uuid.NewString(),
 				UserID:    userID,
 				Source:    src.Source,
+				AccountID: accountID,
 				Amount:    src.Amount,
-				Currency:  src.Currency,
 				Notes:     src.Notes,
 				CreatedAt: time.Now().UTC(),
 				UpdatedAt: time.Now().UTC(),
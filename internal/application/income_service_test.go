@@ -0,0 +1,77 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestIncomeSourcesPayOutWhenDue(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	incomes := application.NewIncomeService(db.IncomeRepository, db.CategoryRepository, db.AccountRepository)
+
+	const userID = "u1"
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for name, in := range map[string]dto.AddIncomeSourceInput{
+		"no source":         {UserID: userID, Amount: domain.NewMoney(100000, "USD"), Frequency: dto.PayMonthly},
+		"unknown frequency": {UserID: userID, Source: "Salary", Amount: domain.NewMoney(100000, "USD"), Frequency: "daily"},
+		"no amount":         {UserID: userID, Source: "Salary", Frequency: dto.PayMonthly},
+	} {
+		if _, err := incomes.AddIncomeSource(ctx, in); !errors.Is(err, application.ErrValidation) {
+			t.Errorf("%s: err = %v, want ErrValidation", name, err)
+		}
+	}
+
+	salary, err := incomes.AddIncomeSource(ctx, dto.AddIncomeSourceInput{
+		UserID: userID, Source: "Salary", Amount: domain.NewMoney(250000, "USD"), Frequency: dto.PayMonthly,
+		NextPayAt: today.Format("2006-01-02"),
+	})
+	if err != nil {
+		t.Fatalf("AddIncomeSource: %v", err)
+	}
+	if _, err := incomes.AddIncomeSource(ctx, dto.AddIncomeSourceInput{
+		UserID: userID, Source: "Freelance", Amount: domain.NewMoney(40000, "USD"), Frequency: dto.PayWeekly,
+		NextPayAt: today.AddDate(0, 0, 3).Format("2006-01-02"),
+	}); err != nil {
+		t.Fatalf("AddIncomeSource: %v", err)
+	}
+
+	paid, err := incomes.ProcessDueIncomes(ctx, userID, today)
+	if err != nil || paid != 1 {
+		t.Fatalf("ProcessDueIncomes = %d, %v, want 1 income paid", paid, err)
+	}
+	list, err := incomes.ListIncomes(ctx, userID, dto.TransactionFilter{})
+	if err != nil {
+		t.Fatalf("ListIncomes: %v", err)
+	}
+	if len(list) != 1 || list[0].Source != "Salary" || list[0].Amount != salary.Amount || list[0].AccountID == "" {
+		t.Fatalf("ListIncomes = %+v, want the salary paid into an account", list)
+	}
+
+	sources, err := incomes.ListIncomeSources(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListIncomeSources: %v", err)
+	}
+	for _, src := range sources {
+		if src.UID == salary.UID && !src.NextPayAt.Equal(today.AddDate(0, 1, 0)) {
+			t.Fatalf("salary next paid %v, want a month later", src.NextPayAt)
+		}
+	}
+	if paid, err := incomes.ProcessDueIncomes(ctx, userID, today); err != nil || paid != 0 {
+		t.Fatalf("ProcessDueIncomes(again) = %d, %v, want nothing paid twice", paid, err)
+	}
+
+	if err := incomes.DeleteIncome(ctx, userID, list[0].UID); err != nil {
+		t.Fatalf("DeleteIncome: %v", err)
+	}
+	if list, _ := incomes.ListIncomes(ctx, userID, dto.TransactionFilter{}); len(list) != 0 {
+		t.Fatalf("ListIncomes after delete = %+v", list)
+	}
+}
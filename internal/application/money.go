@@ -0,0 +1,40 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+// normalizeAmount checks that a user-supplied amount is positive and in a
+// well-formed currency, defaulting the currency when none was given.
+func normalizeAmount(m domain.Money) (domain.Money, error) {
+	if m.Currency == "" {
+		m.Currency = domain.DefaultCurrency
+	}
+	code, ok := domain.NormalizeCurrency(m.Currency)
+	if !ok || !m.IsPositive() {
+		return domain.Money{}, ErrValidation
+	}
+	m.Currency = code
+	return m, nil
+}
+
+// convertMoney expresses amount in currency at the rate of the given day.
+// rates may be nil when only one currency is in use.
+func convertMoney(ctx context.Context, rates ports.ExchangeRateProvider, amount domain.Money, currency string, on time.Time) (domain.Money, error) {
+	if amount.Currency == currency {
+		return amount, nil
+	}
+	if rates == nil {
+		return domain.Money{}, fmt.Errorf("convert %s to %s: %w", amount, currency, domain.ErrExchangeRateNotFound)
+	}
+	rate, err := rates.Rate(ctx, amount.Currency, currency, on)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("convert %s to %s: %w", amount, currency, err)
+	}
+	return amount.Convert(currency, rate), nil
+}
@@ -2,19 +2,29 @@ package application
 
 import (
 	"context"
+	"errors"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/theHinneh/budgeting/internal/application/dto"
 	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
 )
 
 type NetWorthService struct {
 	incomeRepo  ports.IncomeRepoPort
 	expenseRepo ports.ExpenseRepoPort
+	userRepo    ports.UserRepository
+	accounts    ports.AccountServicePort
+	debts       ports.DebtServicePort
+	holdings    ports.HoldingServicePort
+	history     ports.NetWorthSnapshotRepoPort
+	rates       ports.ExchangeRateProvider
 }
 
-func NewNetWorthService(incomeRepo ports.IncomeRepoPort, expenseRepo ports.ExpenseRepoPort) *NetWorthService {
-	return &NetWorthService{incomeRepo: incomeRepo, expenseRepo: expenseRepo}
+func NewNetWorthService(incomeRepo ports.IncomeRepoPort, expenseRepo ports.ExpenseRepoPort, userRepo ports.UserRepository, accounts ports.AccountServicePort, debts ports.DebtServicePort, holdings ports.HoldingServicePort, history ports.NetWorthSnapshotRepoPort, rates ports.ExchangeRateProvider) *NetWorthService {
+	return &NetWorthService{incomeRepo: incomeRepo, expenseRepo: expenseRepo, userRepo: userRepo, accounts: accounts, debts: debts, holdings: holdings, history: history, rates: rates}
 }
 
 var _ ports.NetWorthServicePort = (*NetWorthService)(nil)
@@ -25,6 +35,12 @@ func (s *NetWorthService) GetNetWorth(ctx context.Context, userID string) (*dto.
 		return nil, ErrValidation
 	}
 
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	currency := user.ReportingCurrency()
+
 	incomes, err := s.incomeRepo.ListIncomesByUser(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -35,29 +51,282 @@ func (s *NetWorthService) GetNetWorth(ctx context.Context, userID string) (*dto.
 		return nil, err
 	}
 
-	var totalIncome float64
+	subtotals := map[string]*subtotal{}
+	subtotalFor := func(c string) *subtotal {
+		if subtotals[c] == nil {
+			subtotals[c] = &subtotal{income: domain.NewMoney(0, c), expense: domain.NewMoney(0, c)}
+		}
+		return subtotals[c]
+	}
+
+	totalIncome := domain.NewMoney(0, currency)
 	for _, income := range incomes {
-		totalIncome += income.Amount
+		sub := subtotalFor(income.Amount.Currency)
+		if sub.income, err = sub.income.Add(income.Amount); err != nil {
+			return nil, err
+		}
+		converted, err := convertMoney(ctx, s.rates, income.Amount, currency, income.CreatedAt)
+		if errors.Is(err, domain.ErrExchangeRateNotFound) {
+			sub.unconverted = true
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if totalIncome, err = totalIncome.Add(converted); err != nil {
+			return nil, err
+		}
 	}
 
-	var totalExpense float64
+	totalExpense := domain.NewMoney(0, currency)
 	for _, expense := range expenses {
-		totalExpense += expense.Amount
+		sub := subtotalFor(expense.Amount.Currency)
+		if sub.expense, err = sub.expense.Add(expense.Amount); err != nil {
+			return nil, err
+		}
+		converted, err := convertMoney(ctx, s.rates, expense.Amount, currency, expense.CreatedAt)
+		if errors.Is(err, domain.ErrExchangeRateNotFound) {
+			sub.unconverted = true
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if totalExpense, err = totalExpense.Add(converted); err != nil {
+			return nil, err
+		}
 	}
 
-	netWorth := totalIncome - totalExpense
+	cashFlow, err := totalIncome.Sub(totalExpense)
+	if err != nil {
+		return nil, err
+	}
 
-	currency := "USD"
-	if len(incomes) > 0 {
-		currency = incomes[0].Currency
-	} else if len(expenses) > 0 {
-		currency = expenses[0].Currency
+	sheet, err := s.balanceSheet(ctx, userID, currency)
+	if err != nil {
+		return nil, err
 	}
+	netWorth, err := sheet.assets.Sub(sheet.liabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.NetWorthResponse{
+		TotalAssets:      sheet.assets.Float64(),
+		TotalLiabilities: sheet.liabilities.Float64(),
+		NetWorth:         netWorth.Float64(),
+		TotalIncome:      totalIncome.Float64(),
+		TotalExpense:     totalExpense.Float64(),
+		CashFlow:         cashFlow.Float64(),
+		Currency:         currency,
+		Items:            sheet.items,
+		Subtotals:        make([]dto.CurrencySubtotal, 0, len(subtotals)),
+	}
+	for c, sub := range subtotals {
+		net, err := sub.income.Sub(sub.expense)
+		if err != nil {
+			return nil, err
+		}
+		res.Subtotals = append(res.Subtotals, dto.CurrencySubtotal{
+			Currency:     c,
+			TotalIncome:  sub.income.Float64(),
+			TotalExpense: sub.expense.Float64(),
+			CashFlow:     net.Float64(),
+		})
+		if sub.unconverted {
+			res.UnconvertedCurrencies = append(res.UnconvertedCurrencies, c)
+		}
+	}
+	sort.Slice(res.Subtotals, func(i, j int) bool { return res.Subtotals[i].Currency < res.Subtotals[j].Currency })
+	sort.Strings(res.UnconvertedCurrencies)
+	return res, nil
+}
 
-	return &dto.NetWorthResponse{
-		TotalIncome:  totalIncome,
-		TotalExpense: totalExpense,
-		NetWorth:     netWorth,
-		Currency:     currency,
-	}, nil
+func (s *NetWorthService) SnapshotNetWorth(ctx context.Context, userID string, now time.Time) (*domain.NetWorthSnapshot, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sheet, err := s.balanceSheet(ctx, userID, user.ReportingCurrency())
+	if err != nil {
+		return nil, err
+	}
+	// A snapshot missing some items would show up as a drop in the history.
+	if sheet.unconverted {
+		return nil, domain.ErrExchangeRateNotFound
+	}
+
+	snapshot := &domain.NetWorthSnapshot{
+		UserID:           userID,
+		Date:             startOfDay(now),
+		TotalAssets:      sheet.assets,
+		TotalLiabilities: sheet.liabilities,
+		Accounts:         make([]domain.AccountBalance, len(sheet.accounts)),
+		CreatedAt:        time.Now().UTC(),
+	}
+	for i, a := range sheet.accounts {
+		snapshot.Accounts[i] = domain.AccountBalance{AccountID: a.UID, Name: a.Name, Balance: a.Balance}
+	}
+	if err := s.history.SaveNetWorthSnapshot(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetNetWorthHistory samples the stored snapshots at the end of each day,
+// week or month. Periods without a snapshot are left out rather than
+// filled in.
+func (s *NetWorthService) GetNetWorthHistory(ctx context.Context, in dto.NetWorthHistoryInput) (*dto.NetWorthHistory, error) {
+	userID := strings.TrimSpace(in.UserID)
+	interval := dto.NetWorthInterval(strings.ToLower(strings.TrimSpace(in.Interval)))
+	if interval == "" {
+		interval = dto.NetWorthMonthly
+	}
+	if userID == "" || (interval != dto.NetWorthDaily && interval != dto.NetWorthWeekly && interval != dto.NetWorthMonthly) {
+		return nil, ErrValidation
+	}
+	to := startOfDay(time.Now())
+	if !in.To.IsZero() {
+		to = startOfDay(in.To)
+	}
+	from := to.AddDate(-1, 0, 0)
+	if !in.From.IsZero() {
+		from = startOfDay(in.From)
+	}
+	if from.After(to) {
+		return nil, ErrValidation
+	}
+
+	snapshots, err := s.history.ListNetWorthSnapshots(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	res := &dto.NetWorthHistory{From: from, To: to, Interval: interval, Snapshots: []*domain.NetWorthSnapshot{}}
+	var last time.Time
+	for _, snapshot := range snapshots {
+		bucket := intervalStart(snapshot.Date, interval)
+		if n := len(res.Snapshots); n > 0 && bucket.Equal(last) {
+			res.Snapshots[n-1] = snapshot
+			continue
+		}
+		res.Snapshots = append(res.Snapshots, snapshot)
+		last = bucket
+	}
+	return res, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// intervalStart returns the first day of the day, week or month holding
+// day. Weeks start on Monday.
+func intervalStart(day time.Time, interval dto.NetWorthInterval) time.Time {
+	switch interval {
+	case dto.NetWorthWeekly:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case dto.NetWorthMonthly:
+		return domain.PeriodOf(day).Start()
+	default:
+		return day
+	}
+}
+
+type subtotal struct {
+	income      domain.Money
+	expense     domain.Money
+	unconverted bool
+}
+
+// balanceSheet is the user's assets and liabilities. unconverted is set
+// when some item had no rate and was left out of the totals.
+type balanceSheet struct {
+	assets      domain.Money
+	liabilities domain.Money
+	items       []dto.NetWorthItem
+	accounts    []*domain.Account
+	unconverted bool
+}
+
+// addItem counts amount, which is never negative, on sheet as an asset or
+// a liability converted into the sheet's currency at today's rate. An item
+// with no rate is listed as unconverted without counting it.
+func (s *NetWorthService) addItem(ctx context.Context, b *balanceSheet, kind domain.HoldingKind, item dto.NetWorthItem, amount domain.Money) error {
+	item.Kind = string(kind)
+	item.Amount = amount.Float64()
+	item.Currency = amount.Currency
+	converted, err := convertMoney(ctx, s.rates, amount, b.assets.Currency, time.Now().UTC())
+	if errors.Is(err, domain.ErrExchangeRateNotFound) {
+		item.Unconverted = true
+		b.unconverted = true
+		b.items = append(b.items, item)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if kind == domain.HoldingKindAsset {
+		b.assets, err = b.assets.Add(converted)
+	} else {
+		b.liabilities, err = b.liabilities.Add(converted)
+	}
+	if err != nil {
+		return err
+	}
+	item.Value = converted.Float64()
+	b.items = append(b.items, item)
+	return nil
+}
+
+// balanceSheet collects the user's assets and liabilities: account
+// balances, which are assets when positive and liabilities when overdrawn,
+// the outstanding balance of every debt and the latest value of every
+// holding.
+func (s *NetWorthService) balanceSheet(ctx context.Context, userID, currency string) (*balanceSheet, error) {
+	sheet := &balanceSheet{
+		assets:      domain.NewMoney(0, currency),
+		liabilities: domain.NewMoney(0, currency),
+		items:       []dto.NetWorthItem{},
+	}
+
+	accounts, err := s.accounts.ListAccounts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sheet.accounts = accounts
+	for _, a := range accounts {
+		kind, balance := domain.HoldingKindAsset, a.Balance
+		if balance.IsNegative() {
+			kind, balance = domain.HoldingKindLiability, balance.Neg()
+		}
+		item := dto.NetWorthItem{Source: "account", ID: a.UID, Name: a.Name, Category: string(a.Type)}
+		if err := s.addItem(ctx, sheet, kind, item, balance); err != nil {
+			return nil, err
+		}
+	}
+
+	debts, err := s.debts.ListDebts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range debts {
+		item := dto.NetWorthItem{Source: "debt", ID: d.Debt.UID, Name: d.Debt.Name, Category: string(d.Debt.Kind)}
+		if err := s.addItem(ctx, sheet, domain.HoldingKindLiability, item, d.Balance); err != nil {
+			return nil, err
+		}
+	}
+
+	holdings, err := s.holdings.ListHoldings(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range holdings {
+		item := dto.NetWorthItem{Source: "holding", ID: h.Holding.UID, Name: h.Holding.Name, Category: string(h.Holding.Category)}
+		if err := s.addItem(ctx, sheet, h.Holding.Kind, item, h.Value); err != nil {
+			return nil, err
+		}
+	}
+	return sheet, nil
 }
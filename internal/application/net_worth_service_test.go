@@ -0,0 +1,238 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+	"github.com/theHinneh/budgeting/internal/infrastructure/exchangerate"
+)
+
+func TestNetWorthFromAssetsAndLiabilities(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	rates, err := exchangerate.NewStaticProvider([]domain.ExchangeRate{{Base: "EUR", Quote: "USD", Rate: 1.1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts := application.NewAccountService(db.AccountRepository, db.ExpenseRepository, db.IncomeRepository, db.TransferRepository, db.SavingsGoalRepository)
+	expenses := application.NewExpenseService(db.ExpenseRepository, db.CategoryRepository, db.AccountRepository, db.DebtRepository)
+	debts := application.NewDebtService(db.DebtRepository, db.ExpenseRepository)
+	holdings := application.NewHoldingService(db.HoldingRepository)
+	netWorth := application.NewNetWorthService(db.IncomeRepository, db.ExpenseRepository, db.UserRepository, accounts, debts, holdings, db.NetWorthSnapshotRepository, rates)
+
+	user := domain.NewUser("u1", "ama", "ama@example.com", "Ama", "Mensah", nil)
+	if _, err := db.UserRepository.CreateUser(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	checking, err := accounts.CreateAccount(ctx, dto.AccountInput{UserID: user.UID, Name: "Checking", Type: "checking", OpeningBalance: domain.NewMoney(500000, "USD")})
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	if _, err := accounts.CreateAccount(ctx, dto.AccountInput{UserID: user.UID, Name: "Card", Type: "credit_card", OpeningBalance: domain.NewMoney(-30000, "USD")}); err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	if _, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: user.UID, AccountID: checking.UID, Source: "Rent", Amount: domain.NewMoney(20000, "USD")}); err != nil {
+		t.Fatalf("AddExpense: %v", err)
+	}
+	if _, err := debts.CreateDebt(ctx, dto.DebtInput{UserID: user.UID, Name: "Car", Principal: domain.NewMoney(1000000, "USD"), APR: 12, Term: 12, StartDate: time.Now().AddDate(0, 1, 0)}); err != nil {
+		t.Fatalf("CreateDebt: %v", err)
+	}
+
+	house, err := holdings.CreateHolding(ctx, dto.HoldingInput{UserID: user.UID, Name: "House", Kind: "asset", Category: "property", Currency: "EUR", Value: 200000})
+	if err != nil {
+		t.Fatalf("CreateHolding: %v", err)
+	}
+	if _, err := holdings.AddValuation(ctx, dto.ValuationInput{UserID: user.UID, HoldingID: house.Holding.UID, Value: 250000, Date: time.Now().AddDate(1, 0, 0)}); err != nil {
+		t.Fatalf("AddValuation: %v", err)
+	}
+	if _, err := holdings.CreateHolding(ctx, dto.HoldingInput{UserID: user.UID, Name: "Mortgage", Kind: "liability", Category: "loan", Value: 150000}); err != nil {
+		t.Fatalf("CreateHolding: %v", err)
+	}
+
+	nw, err := netWorth.GetNetWorth(ctx, user.UID)
+	if err != nil {
+		t.Fatalf("GetNetWorth: %v", err)
+	}
+	// Checking 4,800 and the house at 200,000 EUR; the future valuation does
+	// not count yet. Against them the card, the car loan and the mortgage.
+	if nw.TotalAssets != 224800 || nw.TotalLiabilities != 160300 || nw.NetWorth != 64500 {
+		t.Fatalf("GetNetWorth = %+v, want assets 224800, liabilities 160300, net worth 64500", nw)
+	}
+	if nw.CashFlow != -200 || nw.TotalExpense != 200 || len(nw.Items) != 5 {
+		t.Fatalf("GetNetWorth = %+v, want cash flow -200 over 5 items", nw)
+	}
+}
+
+func TestNetWorthConvertsCashFlowAcrossCurrencies(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	rates, err := exchangerate.NewStaticProvider([]domain.ExchangeRate{{Base: "EUR", Quote: "USD", Rate: 1.1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts := application.NewAccountService(db.AccountRepository, db.ExpenseRepository, db.IncomeRepository, db.TransferRepository, db.SavingsGoalRepository)
+	debts := application.NewDebtService(db.DebtRepository, db.ExpenseRepository)
+	holdings := application.NewHoldingService(db.HoldingRepository)
+	netWorth := application.NewNetWorthService(db.IncomeRepository, db.ExpenseRepository, db.UserRepository, accounts, debts, holdings, db.NetWorthSnapshotRepository, rates)
+
+	user := domain.NewUser("u1", "ama", "ama@example.com", "Ama", "Mensah", nil)
+	if _, err := db.UserRepository.CreateUser(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	on := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	for i, amount := range []domain.Money{domain.NewMoney(100000, "USD"), domain.NewMoney(50000, "EUR")} {
+		if _, err := db.IncomeRepository.CreateIncome(ctx, &domain.Income{UID: string(rune('a' + i)), UserID: user.UID, Source: "salary", Amount: amount, CreatedAt: on, UpdatedAt: on}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expense := func(id string, amount domain.Money) {
+		t.Helper()
+		if _, err := db.ExpenseRepository.CreateExpense(ctx, &domain.Expense{UID: id, UserID: user.UID, Source: "shop", Amount: amount, CreatedAt: on, UpdatedAt: on}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expense("x", domain.NewMoney(20000, "USD"))
+	expense("y", domain.NewMoney(10000, "EUR"))
+
+	nw, err := netWorth.GetNetWorth(ctx, user.UID)
+	if err != nil {
+		t.Fatalf("GetNetWorth: %v", err)
+	}
+	// 1,000 USD and 500 EUR in, 200 USD and 100 EUR out, at 1.1 USD a euro.
+	if nw.Currency != "USD" || nw.TotalIncome != 1550 || nw.TotalExpense != 310 || nw.CashFlow != 1240 {
+		t.Fatalf("GetNetWorth = %+v, want 1550 in, 310 out, 1240 cash flow in USD", nw)
+	}
+	want := []dto.CurrencySubtotal{
+		{Currency: "EUR", TotalIncome: 500, TotalExpense: 100, CashFlow: 400},
+		{Currency: "USD", TotalIncome: 1000, TotalExpense: 200, CashFlow: 800},
+	}
+	if len(nw.Subtotals) != len(want) || nw.Subtotals[0] != want[0] || nw.Subtotals[1] != want[1] {
+		t.Fatalf("Subtotals = %+v, want %+v", nw.Subtotals, want)
+	}
+
+	// There is no GHS rate: the expense stays out of the total but keeps its
+	// subtotal.
+	expense("z", domain.NewMoney(5000, "GHS"))
+	if nw, err = netWorth.GetNetWorth(ctx, user.UID); err != nil {
+		t.Fatalf("GetNetWorth with a GHS expense: %v", err)
+	}
+	if nw.TotalExpense != 310 || len(nw.UnconvertedCurrencies) != 1 || nw.UnconvertedCurrencies[0] != "GHS" {
+		t.Fatalf("GetNetWorth = %+v, want 310 out and GHS unconverted", nw)
+	}
+	if ghs := nw.Subtotals[1]; ghs != (dto.CurrencySubtotal{Currency: "GHS", TotalExpense: 50, CashFlow: -50}) {
+		t.Fatalf("GHS subtotal = %+v, want 50 out", ghs)
+	}
+}
+
+func TestNetWorthListsItemsWithoutARate(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	rates, err := exchangerate.NewStaticProvider([]domain.ExchangeRate{{Base: "EUR", Quote: "USD", Rate: 1.1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts := application.NewAccountService(db.AccountRepository, db.ExpenseRepository, db.IncomeRepository, db.TransferRepository, db.SavingsGoalRepository)
+	debts := application.NewDebtService(db.DebtRepository, db.ExpenseRepository)
+	holdings := application.NewHoldingService(db.HoldingRepository)
+	netWorth := application.NewNetWorthService(db.IncomeRepository, db.ExpenseRepository, db.UserRepository, accounts, debts, holdings, db.NetWorthSnapshotRepository, rates)
+
+	user := domain.NewUser("u1", "ama", "ama@example.com", "Ama", "Mensah", nil)
+	if _, err := db.UserRepository.CreateUser(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := accounts.CreateAccount(ctx, dto.AccountInput{UserID: user.UID, Name: "Checking", Type: "checking", OpeningBalance: domain.NewMoney(100000, "USD")}); err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	if _, err := holdings.CreateHolding(ctx, dto.HoldingInput{UserID: user.UID, Name: "Flat", Kind: "asset", Currency: "EUR", Value: 1000}); err != nil {
+		t.Fatalf("CreateHolding: %v", err)
+	}
+	// There is no GHS rate.
+	land, err := holdings.CreateHolding(ctx, dto.HoldingInput{UserID: user.UID, Name: "Land", Kind: "asset", Currency: "GHS", Value: 50000})
+	if err != nil {
+		t.Fatalf("CreateHolding: %v", err)
+	}
+
+	nw, err := netWorth.GetNetWorth(ctx, user.UID)
+	if err != nil {
+		t.Fatalf("GetNetWorth: %v", err)
+	}
+	if nw.TotalAssets != 2100 || nw.NetWorth != 2100 || len(nw.Items) != 3 {
+		t.Fatalf("GetNetWorth = %+v, want 2100 in assets over 3 items", nw)
+	}
+	for _, item := range nw.Items {
+		if unconverted := item.ID == land.Holding.UID; item.Unconverted != unconverted {
+			t.Errorf("item %s Unconverted = %v, want %v", item.Name, item.Unconverted, unconverted)
+		}
+	}
+
+	// A snapshot without the land would look like a loss in the history.
+	if _, err := netWorth.SnapshotNetWorth(ctx, user.UID, time.Now()); !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		t.Fatalf("SnapshotNetWorth err = %v, want ErrExchangeRateNotFound", err)
+	}
+}
+
+func TestNetWorthHistorySamplesSnapshots(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	accounts := application.NewAccountService(db.AccountRepository, db.ExpenseRepository, db.IncomeRepository, db.TransferRepository, db.SavingsGoalRepository)
+	expenses := application.NewExpenseService(db.ExpenseRepository, db.CategoryRepository, db.AccountRepository, db.DebtRepository)
+	debts := application.NewDebtService(db.DebtRepository, db.ExpenseRepository)
+	holdings := application.NewHoldingService(db.HoldingRepository)
+	netWorth := application.NewNetWorthService(db.IncomeRepository, db.ExpenseRepository, db.UserRepository, accounts, debts, holdings, db.NetWorthSnapshotRepository, nil)
+
+	user := domain.NewUser("u1", "ama", "ama@example.com", "Ama", "Mensah", nil)
+	if _, err := db.UserRepository.CreateUser(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	checking, err := accounts.CreateAccount(ctx, dto.AccountInput{UserID: user.UID, Name: "Checking", Type: "checking", OpeningBalance: domain.NewMoney(100000, "USD")})
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+
+	// Snapshots on 10 and 20 January and 5 February, spending 100 before
+	// each of the last two.
+	for i, at := range []time.Time{
+		time.Date(2026, time.January, 10, 23, 0, 0, 0, time.UTC),
+		time.Date(2026, time.January, 20, 23, 0, 0, 0, time.UTC),
+		time.Date(2026, time.February, 5, 23, 0, 0, 0, time.UTC),
+	} {
+		if i > 0 {
+			if _, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: user.UID, AccountID: checking.UID, Source: "Groceries", Amount: domain.NewMoney(10000, "USD")}); err != nil {
+				t.Fatalf("AddExpense: %v", err)
+			}
+		}
+		if _, err := netWorth.SnapshotNetWorth(ctx, user.UID, at); err != nil {
+			t.Fatalf("SnapshotNetWorth: %v", err)
+		}
+	}
+
+	from, to := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC)
+	history, err := netWorth.GetNetWorthHistory(ctx, dto.NetWorthHistoryInput{UserID: user.UID, From: from, To: to, Interval: "month"})
+	if err != nil {
+		t.Fatalf("GetNetWorthHistory: %v", err)
+	}
+	if len(history.Snapshots) != 2 || history.Snapshots[0].TotalAssets != domain.NewMoney(90000, "USD") || history.Snapshots[1].TotalAssets != domain.NewMoney(80000, "USD") {
+		t.Fatalf("monthly history = %+v, want the 20 January and 5 February snapshots", history.Snapshots)
+	}
+	if !history.Snapshots[0].Date.Equal(time.Date(2026, time.January, 20, 0, 0, 0, 0, time.UTC)) || len(history.Snapshots[0].Accounts) != 1 {
+		t.Fatalf("monthly history = %+v", history.Snapshots[0])
+	}
+
+	history, err = netWorth.GetNetWorthHistory(ctx, dto.NetWorthHistoryInput{UserID: user.UID, From: from, To: to, Interval: "day"})
+	if err != nil {
+		t.Fatalf("GetNetWorthHistory: %v", err)
+	}
+	if len(history.Snapshots) != 3 {
+		t.Fatalf("daily history has %d snapshots, want 3", len(history.Snapshots))
+	}
+
+	if _, err := netWorth.GetNetWorthHistory(ctx, dto.NetWorthHistoryInput{UserID: user.UID, From: to, To: from}); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("from after to: err = %v, want ErrValidation", err)
+	}
+}
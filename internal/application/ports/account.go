@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type AccountServicePort interface {
+	CreateAccount(ctx context.Context, in dto.AccountInput) (*domain.Account, error)
+	ListAccounts(ctx context.Context, userID string) ([]*domain.Account, error)
+	GetAccount(ctx context.Context, userID string, accountID string) (*domain.Account, error)
+	UpdateAccount(ctx context.Context, userID string, accountID string, in dto.AccountInput) (*domain.Account, error)
+	DeleteAccount(ctx context.Context, userID string, accountID string) error
+	ListAccountLedger(ctx context.Context, userID string, accountID string) ([]dto.AccountEntry, error)
+}
+
+type AccountRepoPort interface {
+	CreateAccount(ctx context.Context, account *domain.Account) (*domain.Account, error)
+	// ListAccountsByUser returns accounts oldest first.
+	ListAccountsByUser(ctx context.Context, userID string) ([]*domain.Account, error)
+	GetAccount(ctx context.Context, userID string, accountID string) (*domain.Account, error)
+	UpdateAccount(ctx context.Context, account *domain.Account) (*domain.Account, error)
+	DeleteAccount(ctx context.Context, userID string, accountID string) error
+}
@@ -12,14 +12,34 @@ type AuthServicePort interface {
 	Login(ctx context.Context, email, password string, deviceInfo, ipAddress, userAgent string) (*dto.LoginResponse, error)
 	RefreshToken(ctx context.Context, refreshToken string, deviceInfo, ipAddress, userAgent string) (*dto.RefreshTokenResponse, error)
 	Logout(ctx context.Context, refreshToken string) error
+	// UnlockAccount lifts a lockout with the token mailed to the account.
+	UnlockAccount(ctx context.Context, token, ipAddress, userAgent string) error
+
+	// Two-factor authentication
+	EnrollTwoFactor(ctx context.Context, userID, accountName string) (*dto.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(ctx context.Context, userID, code string) (*dto.RecoveryCodes, error)
+	DisableTwoFactor(ctx context.Context, userID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) (*dto.RecoveryCodes, error)
+	CompleteTwoFactorLogin(ctx context.Context, challengeToken, code string, deviceInfo, ipAddress, userAgent string) (*dto.LoginResponse, error)
+
+	// Passkeys
+	BeginPasskeyRegistration(ctx context.Context, userID string) (*dto.PasskeyCeremony, error)
+	FinishPasskeyRegistration(ctx context.Context, userID, sessionToken, name string, response []byte) (*domain.Passkey, error)
+	ListPasskeys(ctx context.Context, userID string) ([]*domain.Passkey, error)
+	DeletePasskey(ctx context.Context, userID, passkeyID string) error
+	BeginPasskeyLogin(ctx context.Context) (*dto.PasskeyCeremony, error)
+	FinishPasskeyLogin(ctx context.Context, sessionToken string, response []byte, deviceInfo, ipAddress, userAgent string) (*dto.LoginResponse, error)
 
 	// Session Management
 	GetUserSessions(ctx context.Context, userID string) ([]*domain.RefreshToken, error)
 	RevokeSession(ctx context.Context, sessionID string) error
 	RevokeAllUserSessions(ctx context.Context, userID string) error
+	GetSecurityEvents(ctx context.Context, userID string) ([]*domain.SecurityEvent, error)
 
 	// Token Management
 	ValidateRefreshToken(ctx context.Context, userID, tokenString string) (*domain.RefreshToken, error)
-	CreateRefreshToken(ctx context.Context, userID string, deviceInfo, ipAddress, userAgent string) (*domain.RefreshToken, error)
+	// CreateRefreshToken starts a new token family and returns the stored
+	// token together with the secret to hand to the client.
+	CreateRefreshToken(ctx context.Context, userID string, deviceInfo, ipAddress, userAgent string) (*domain.RefreshToken, string, error)
 	CleanupExpiredTokens(ctx context.Context) error
 }
@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type BudgetServicePort interface {
+	CreateBudget(ctx context.Context, in dto.BudgetInput) (*domain.Budget, error)
+	// ListBudgets returns the user's budgets, only those in period when it
+	// is not empty.
+	ListBudgets(ctx context.Context, userID string, period string) ([]*domain.Budget, error)
+	GetBudget(ctx context.Context, userID string, budgetID string) (*domain.Budget, error)
+	UpdateBudget(ctx context.Context, userID string, budgetID string, in dto.BudgetInput) (*domain.Budget, error)
+	DeleteBudget(ctx context.Context, userID string, budgetID string) error
+	GetBudgetPeriod(ctx context.Context, userID string, period string) (*dto.BudgetPeriodView, error)
+	// AllocateBudget moves money between the to-be-assigned pool and a
+	// category's budget, refusing to assign more than the pool holds.
+	AllocateBudget(ctx context.Context, in dto.BudgetAllocationInput) (*domain.Budget, error)
+}
+
+type BudgetRepoPort interface {
+	CreateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error)
+	// ListBudgetsByUser returns budgets ordered by period, then creation.
+	ListBudgetsByUser(ctx context.Context, userID string) ([]*domain.Budget, error)
+	GetBudget(ctx context.Context, userID string, budgetID string) (*domain.Budget, error)
+	UpdateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error)
+	DeleteBudget(ctx context.Context, userID string, budgetID string) error
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type CategoryServicePort interface {
+	CreateCategory(ctx context.Context, in dto.CategoryInput) (*domain.Category, error)
+	ListCategories(ctx context.Context, userID string) ([]*domain.Category, error)
+	GetCategory(ctx context.Context, userID string, categoryID string) (*domain.Category, error)
+	UpdateCategory(ctx context.Context, userID string, categoryID string, in dto.CategoryInput) (*domain.Category, error)
+	DeleteCategory(ctx context.Context, userID string, categoryID string) error
+	SeedDefaultCategories(ctx context.Context, userID string) ([]*domain.Category, error)
+}
+
+type CategoryRepoPort interface {
+	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	ListCategoriesByUser(ctx context.Context, userID string) ([]*domain.Category, error)
+	GetCategory(ctx context.Context, userID string, categoryID string) (*domain.Category, error)
+	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	DeleteCategory(ctx context.Context, userID string, categoryID string) error
+}
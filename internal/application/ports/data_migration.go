@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+)
+
+type DataMigrationServicePort interface {
+	Copy(ctx context.Context) (*dto.DataMigrationReport, error)
+	Verify(ctx context.Context) (*dto.DataVerificationReport, error)
+}
+
+// MigrationCheckpoint remembers which users have been fully copied so an
+// interrupted data migration can resume where it stopped.
+type MigrationCheckpoint interface {
+	CompletedUsers(ctx context.Context) (map[string]bool, error)
+	MarkUserCompleted(ctx context.Context, userID string) error
+}
@@ -1 +1,8 @@
 package ports
+
+import "context"
+
+type Database interface {
+	Ping(ctx context.Context) error
+	Close() error
+}
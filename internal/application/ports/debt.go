@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type DebtServicePort interface {
+	CreateDebt(ctx context.Context, in dto.DebtInput) (*domain.Debt, error)
+	ListDebts(ctx context.Context, userID string) ([]dto.DebtStatus, error)
+	GetDebt(ctx context.Context, userID string, debtID string) (*dto.DebtStatus, error)
+	UpdateDebt(ctx context.Context, userID string, debtID string, in dto.DebtInput) (*domain.Debt, error)
+	DeleteDebt(ctx context.Context, userID string, debtID string) error
+
+	GetDebtSchedule(ctx context.Context, userID string, debtID string) (*dto.DebtSchedule, error)
+	PlanDebtPayoff(ctx context.Context, in dto.DebtPayoffInput) ([]dto.DebtPayoffPlan, error)
+}
+
+type DebtRepoPort interface {
+	CreateDebt(ctx context.Context, debt *domain.Debt) (*domain.Debt, error)
+	// ListDebtsByUser returns debts oldest first.
+	ListDebtsByUser(ctx context.Context, userID string) ([]*domain.Debt, error)
+	GetDebt(ctx context.Context, userID string, debtID string) (*domain.Debt, error)
+	UpdateDebt(ctx context.Context, debt *domain.Debt) (*domain.Debt, error)
+	DeleteDebt(ctx context.Context, userID string, debtID string) error
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type EnvelopeServicePort interface {
+	CreateEnvelope(ctx context.Context, in dto.EnvelopeInput) (*domain.Envelope, error)
+	// ListEnvelopes returns the status of every envelope in period, the
+	// current period when empty.
+	ListEnvelopes(ctx context.Context, userID string, period string) ([]dto.EnvelopeStatus, error)
+	GetEnvelope(ctx context.Context, userID string, envelopeID string, period string) (*dto.EnvelopeStatus, error)
+	UpdateEnvelope(ctx context.Context, userID string, envelopeID string, in dto.EnvelopeInput) (*domain.Envelope, error)
+	DeleteEnvelope(ctx context.Context, userID string, envelopeID string) error
+
+	MoveBetweenEnvelopes(ctx context.Context, in dto.EnvelopeMoveInput) (*domain.EnvelopeMove, error)
+	ListEnvelopeMoves(ctx context.Context, userID string, period string) ([]*domain.EnvelopeMove, error)
+}
+
+type EnvelopeRepoPort interface {
+	CreateEnvelope(ctx context.Context, envelope *domain.Envelope) (*domain.Envelope, error)
+	// ListEnvelopesByUser returns envelopes oldest first.
+	ListEnvelopesByUser(ctx context.Context, userID string) ([]*domain.Envelope, error)
+	GetEnvelope(ctx context.Context, userID string, envelopeID string) (*domain.Envelope, error)
+	UpdateEnvelope(ctx context.Context, envelope *domain.Envelope) (*domain.Envelope, error)
+	DeleteEnvelope(ctx context.Context, userID string, envelopeID string) error
+
+	CreateEnvelopeMove(ctx context.Context, move *domain.EnvelopeMove) (*domain.EnvelopeMove, error)
+	// ListEnvelopeMovesByUser returns moves oldest first.
+	ListEnvelopeMovesByUser(ctx context.Context, userID string) ([]*domain.EnvelopeMove, error)
+}
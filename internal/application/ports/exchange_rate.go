@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type ExchangeRateProvider interface {
+	// Rate returns how many units of quote one unit of base bought on the
+	// given date. It returns domain.ErrExchangeRateNotFound when no rate is
+	// known.
+	Rate(ctx context.Context, base, quote string, on time.Time) (float64, error)
+}
+
+// ExchangeRateRepository stores one rate per (base, quote, date).
+type ExchangeRateRepository interface {
+	// SaveRates stores the rates, replacing any already stored for the same
+	// pair and date.
+	SaveRates(ctx context.Context, rates []domain.ExchangeRate) error
+	// FindRate returns the base/quote rate with the latest date on or before
+	// on, or domain.ErrExchangeRateNotFound.
+	FindRate(ctx context.Context, base, quote string, on time.Time) (*domain.ExchangeRate, error)
+	// ListRates returns every stored rate, ordered by pair and date.
+	ListRates(ctx context.Context) ([]domain.ExchangeRate, error)
+}
+
+type ExchangeRateServicePort interface {
+	ExchangeRateProvider
+	ImportRates(ctx context.Context, rates []domain.ExchangeRate) (int, error)
+}
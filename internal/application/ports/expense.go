@@ -10,7 +10,7 @@ import (
 
 type ExpenseServicePort interface {
 	AddExpense(ctx context.Context, in dto.AddExpenseInput) (*domain.Expense, error)
-	ListExpenses(ctx context.Context, userID string) ([]*domain.Expense, error)
+	ListExpenses(ctx context.Context, userID string, filter dto.TransactionFilter) ([]*domain.Expense, error)
 	GetExpense(ctx context.Context, userID string, expenseID string) (*domain.Expense, error)
 	UpdateExpense(ctx context.Context, userID string, expenseID string, in dto.AddExpenseInput) (*domain.Expense, error)
 	DeleteExpense(ctx context.Context, userID string, expenseID string) error
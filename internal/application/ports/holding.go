@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type HoldingServicePort interface {
+	CreateHolding(ctx context.Context, in dto.HoldingInput) (*dto.HoldingStatus, error)
+	// ListHoldings returns the user's holdings of kind, all of them when
+	// kind is empty.
+	ListHoldings(ctx context.Context, userID string, kind string) ([]dto.HoldingStatus, error)
+	GetHolding(ctx context.Context, userID string, holdingID string) (*dto.HoldingStatus, error)
+	UpdateHolding(ctx context.Context, userID string, holdingID string, in dto.HoldingInput) (*dto.HoldingStatus, error)
+	DeleteHolding(ctx context.Context, userID string, holdingID string) error
+
+	AddValuation(ctx context.Context, in dto.ValuationInput) (*domain.Valuation, error)
+	ListValuations(ctx context.Context, userID string, holdingID string) ([]*domain.Valuation, error)
+}
+
+type HoldingRepoPort interface {
+	CreateHolding(ctx context.Context, holding *domain.Holding) (*domain.Holding, error)
+	// ListHoldingsByUser returns holdings oldest first.
+	ListHoldingsByUser(ctx context.Context, userID string) ([]*domain.Holding, error)
+	GetHolding(ctx context.Context, userID string, holdingID string) (*domain.Holding, error)
+	UpdateHolding(ctx context.Context, holding *domain.Holding) (*domain.Holding, error)
+	// DeleteHolding deletes the holding together with its valuations.
+	DeleteHolding(ctx context.Context, userID string, holdingID string) error
+
+	CreateValuation(ctx context.Context, valuation *domain.Valuation) (*domain.Valuation, error)
+	// ListValuationsByUser returns valuations oldest first by Date.
+	ListValuationsByUser(ctx context.Context, userID string) ([]*domain.Valuation, error)
+}
@@ -10,7 +10,7 @@ import (
 
 type IncomeServicePort interface {
 	AddIncome(ctx context.Context, in dto.AddIncomeInput) (*domain.Income, error)
-	ListIncomes(ctx context.Context, userID string) ([]*domain.Income, error)
+	ListIncomes(ctx context.Context, userID string, filter dto.TransactionFilter) ([]*domain.Income, error)
 	DeleteIncome(ctx context.Context, userID string, incomeID string) error
 
 	AddIncomeSource(ctx context.Context, in dto.AddIncomeSourceInput) (*domain.IncomeSource, error)
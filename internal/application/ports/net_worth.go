@@ -2,10 +2,26 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
 )
 
 type NetWorthServicePort interface {
 	GetNetWorth(ctx context.Context, userID string) (*dto.NetWorthResponse, error)
+	// SnapshotNetWorth records the user's current net worth and account
+	// balances as of the day of now, replacing any snapshot already taken
+	// that day.
+	SnapshotNetWorth(ctx context.Context, userID string, now time.Time) (*domain.NetWorthSnapshot, error)
+	GetNetWorthHistory(ctx context.Context, in dto.NetWorthHistoryInput) (*dto.NetWorthHistory, error)
+}
+
+type NetWorthSnapshotRepoPort interface {
+	// SaveNetWorthSnapshot stores the snapshot, replacing the user's
+	// snapshot of the same day.
+	SaveNetWorthSnapshot(ctx context.Context, snapshot *domain.NetWorthSnapshot) error
+	// ListNetWorthSnapshots returns the user's snapshots dated from from to
+	// to inclusive, oldest first. A zero bound is open.
+	ListNetWorthSnapshots(ctx context.Context, userID string, from, to time.Time) ([]*domain.NetWorthSnapshot, error)
 }
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type SavingsGoalServicePort interface {
+	CreateSavingsGoal(ctx context.Context, in dto.SavingsGoalInput) (*dto.SavingsGoalStatus, error)
+	ListSavingsGoals(ctx context.Context, userID string) ([]dto.SavingsGoalStatus, error)
+	GetSavingsGoal(ctx context.Context, userID string, goalID string) (*dto.SavingsGoalStatus, error)
+	UpdateSavingsGoal(ctx context.Context, userID string, goalID string, in dto.SavingsGoalInput) (*dto.SavingsGoalStatus, error)
+	DeleteSavingsGoal(ctx context.Context, userID string, goalID string) error
+	// GetSavingsGoalHistory returns the goal's progress month by month, from
+	// the month it was created to the current one.
+	GetSavingsGoalHistory(ctx context.Context, userID string, goalID string) ([]dto.SavingsGoalProgress, error)
+}
+
+type SavingsGoalRepoPort interface {
+	CreateSavingsGoal(ctx context.Context, goal *domain.SavingsGoal) (*domain.SavingsGoal, error)
+	// ListSavingsGoalsByUser returns goals by target date, soonest first.
+	ListSavingsGoalsByUser(ctx context.Context, userID string) ([]*domain.SavingsGoal, error)
+	GetSavingsGoal(ctx context.Context, userID string, goalID string) (*domain.SavingsGoal, error)
+	UpdateSavingsGoal(ctx context.Context, goal *domain.SavingsGoal) (*domain.SavingsGoal, error)
+	DeleteSavingsGoal(ctx context.Context, userID string, goalID string) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+)
+
+type TagServicePort interface {
+	ListTags(ctx context.Context, userID string) ([]dto.TagUsage, error)
+	RenameTag(ctx context.Context, userID string, from string, to string) (int, error)
+	MergeTags(ctx context.Context, userID string, from []string, into string) (int, error)
+}
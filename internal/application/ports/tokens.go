@@ -2,6 +2,8 @@ package ports
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/theHinneh/budgeting/internal/domain"
 )
@@ -11,18 +13,123 @@ type RefreshTokenRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.RefreshToken, error)
 	GetByUserID(ctx context.Context, userID string) ([]*domain.RefreshToken, error)
 	GetValidToken(ctx context.Context, userID, tokenHash string) (*domain.RefreshToken, error)
+	// GetByTokenHash returns the token whatever its state, so that a revoked
+	// token being presented again can be told apart from an unknown one.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	// RotateToken revokes an active token in favour of replacedBy. It fails
+	// with codes.FailedPrecondition if the token was already revoked.
+	RotateToken(ctx context.Context, id, replacedBy string) error
 	RevokeToken(ctx context.Context, id string) error
+	RevokeFamily(ctx context.Context, familyID string) error
 	RevokeAllUserTokens(ctx context.Context, userID string) error
 	DeleteExpiredTokens(ctx context.Context) error
 	DeleteToken(ctx context.Context, id string) error
 }
 
+// SecurityEventRepoPort stores the security audit log. CreateSecurityEvent is
+// idempotent on the event ID.
+type SecurityEventRepoPort interface {
+	CreateSecurityEvent(ctx context.Context, event *domain.SecurityEvent) error
+	ListSecurityEvents(ctx context.Context, userID string) ([]*domain.SecurityEvent, error)
+}
+
+// TwoFactorRepoPort stores TOTP enrollments and pending login challenges.
+// UseTOTPStep, UseRecoveryCode and DeleteTwoFactorChallenge are atomic so that
+// each code and challenge is accepted at most once.
+type TwoFactorRepoPort interface {
+	SaveTwoFactor(ctx context.Context, tf *domain.TwoFactor) error
+	GetTwoFactor(ctx context.Context, userID string) (*domain.TwoFactor, error)
+	DeleteTwoFactor(ctx context.Context, userID string) error
+	// UseTOTPStep fails with codes.FailedPrecondition unless step is later
+	// than the last used one.
+	UseTOTPStep(ctx context.Context, userID string, step int64) error
+	// UseRecoveryCode removes the code, failing with codes.NotFound if the
+	// user has no such code.
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) error
+
+	CreateTwoFactorChallenge(ctx context.Context, c *domain.TwoFactorChallenge) error
+	GetTwoFactorChallenge(ctx context.Context, tokenHash string) (*domain.TwoFactorChallenge, error)
+	// RecordChallengeAttempt counts a failed code and returns the attempts so far.
+	RecordChallengeAttempt(ctx context.Context, tokenHash string) (int, error)
+	DeleteTwoFactorChallenge(ctx context.Context, tokenHash string) error
+	DeleteExpiredTwoFactorChallenges(ctx context.Context) error
+}
+
+// PasskeyRepoPort stores users' passkeys and the ceremonies in flight.
+// TakePasskeyCeremony is atomic so that each ceremony is finished at most once.
+type PasskeyRepoPort interface {
+	// CreatePasskey fails with codes.AlreadyExists if the credential ID is
+	// already registered.
+	CreatePasskey(ctx context.Context, p *domain.Passkey) error
+	ListPasskeys(ctx context.Context, userID string) ([]*domain.Passkey, error)
+	// UpdatePasskeyUsage records a successful login with the passkey.
+	UpdatePasskeyUsage(ctx context.Context, id string, signCount uint32, backupState bool, usedAt time.Time) error
+	DeletePasskey(ctx context.Context, userID, id string) error
+
+	CreatePasskeyCeremony(ctx context.Context, c *domain.PasskeyCeremony) error
+	// TakePasskeyCeremony deletes the ceremony and returns it, failing with
+	// codes.NotFound if it does not exist.
+	TakePasskeyCeremony(ctx context.Context, tokenHash string) (*domain.PasskeyCeremony, error)
+	DeleteExpiredPasskeyCeremonies(ctx context.Context) error
+}
+
+// PasskeyVerifier runs the WebAuthn registration and login ceremonies. It
+// keeps no state: the session returned by a Begin call is stored by the
+// caller and handed back to the matching Finish call.
+type PasskeyVerifier interface {
+	BeginRegistration(user *domain.User, existing []*domain.Passkey) (options json.RawMessage, session []byte, err error)
+	FinishRegistration(user *domain.User, existing []*domain.Passkey, session, response []byte) (*domain.Passkey, error)
+	BeginLogin() (options json.RawMessage, session []byte, err error)
+	// FinishLogin verifies an assertion made with one of the passkeys that
+	// lookup returns for the user the authenticator names. It returns the
+	// passkey with its new counter, and cloned is set when the counter did
+	// not advance.
+	FinishLogin(session, response []byte, lookup func(userID string) ([]*domain.Passkey, error)) (passkey *domain.Passkey, cloned bool, err error)
+}
+
+// LoginAttemptRepoPort keeps the failed login counters, keyed by account or
+// client IP, where every instance of the server sees them, and the tokens that
+// unlock a locked account.
+type LoginAttemptRepoPort interface {
+	// RecordLoginFailure atomically counts a failure for key at the given
+	// time, starting the count over if the previous failure was before since,
+	// and returns the updated counter.
+	RecordLoginFailure(ctx context.Context, key string, at, since time.Time) (*domain.LoginAttempts, error)
+	// GetLoginAttempts fails with codes.NotFound if key has no failures.
+	GetLoginAttempts(ctx context.Context, key string) (*domain.LoginAttempts, error)
+	LockLogin(ctx context.Context, key string, until time.Time) error
+	// ResetLoginAttempts forgets the failures and any lock of key.
+	ResetLoginAttempts(ctx context.Context, key string) error
+	// DeleteStaleLoginAttempts removes the counters whose last failure and
+	// lock both ended before the given time.
+	DeleteStaleLoginAttempts(ctx context.Context, before time.Time) error
+
+	CreateAccountUnlock(ctx context.Context, u *domain.AccountUnlock) error
+	// TakeAccountUnlock deletes the unlock token and returns it, failing with
+	// codes.NotFound if it does not exist.
+	TakeAccountUnlock(ctx context.Context, tokenHash string) (*domain.AccountUnlock, error)
+	DeleteExpiredAccountUnlocks(ctx context.Context) error
+}
+
+// AuthMailer sends the emails that are part of signing in.
+type AuthMailer interface {
+	// SendAccountUnlock tells the owner of email that failed logins locked
+	// the account until lockedUntil, and how to lift the lock with token.
+	SendAccountUnlock(ctx context.Context, email, token string, lockedUntil time.Time) error
+}
+
 type TokenAuthenticator interface {
 	CreateCustomToken(ctx context.Context, userID string) (string, error)
 	VerifyIDToken(ctx context.Context, idToken string) (string, error) // Returns userID
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
 }
 
+// PasswordVerifier checks a user's password server side. Providers such as
+// Firebase do not implement it, which turns password login off.
+type PasswordVerifier interface {
+	VerifyPassword(ctx context.Context, email, password string) (*domain.User, error)
+}
+
 type TokenGenerator interface {
 	GenerateSecureToken() (string, error)
 	HashToken(token string) string
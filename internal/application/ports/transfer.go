@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type TransferServicePort interface {
+	CreateTransfer(ctx context.Context, in dto.TransferInput) (*domain.Transfer, error)
+	ListTransfers(ctx context.Context, userID string) ([]*domain.Transfer, error)
+	GetTransfer(ctx context.Context, userID string, transferID string) (*domain.Transfer, error)
+	DeleteTransfer(ctx context.Context, userID string, transferID string) error
+}
+
+type TransferRepoPort interface {
+	CreateTransfer(ctx context.Context, transfer *domain.Transfer) (*domain.Transfer, error)
+	// ListTransfersByUser returns transfers newest first.
+	ListTransfersByUser(ctx context.Context, userID string) ([]*domain.Transfer, error)
+	GetTransfer(ctx context.Context, userID string, transferID string) (*domain.Transfer, error)
+	DeleteTransfer(ctx context.Context, userID string, transferID string) error
+}
@@ -49,3 +49,11 @@ type UserAuthenticator interface {
 	UpdatePassword(ctx context.Context, uid string, newPassword string) error
 	GeneratePasswordResetLink(ctx context.Context, email string) (string, error)
 }
+
+type CredentialRepository interface {
+	CreateCredential(ctx context.Context, c *domain.Credential) error
+	GetCredential(ctx context.Context, uid string) (*domain.Credential, error)
+	GetCredentialByEmail(ctx context.Context, email string) (*domain.Credential, error)
+	UpdateCredential(ctx context.Context, c *domain.Credential) error
+	DeleteCredential(ctx context.Context, uid string) error
+}
@@ -0,0 +1,233 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type SavingsGoalService struct {
+	repo           ports.SavingsGoalRepoPort
+	accountService ports.AccountServicePort
+}
+
+func NewSavingsGoalService(repo ports.SavingsGoalRepoPort, accountService ports.AccountServicePort) *SavingsGoalService {
+	return &SavingsGoalService{repo: repo, accountService: accountService}
+}
+
+var _ ports.SavingsGoalServicePort = (*SavingsGoalService)(nil)
+
+// CreateSavingsGoal sets a target for one of the user's accounts. The target
+// must be in the account's currency.
+func (s *SavingsGoalService) CreateSavingsGoal(ctx context.Context, in dto.SavingsGoalInput) (*dto.SavingsGoalStatus, error) {
+	userID := strings.TrimSpace(in.UserID)
+	name := strings.TrimSpace(in.Name)
+	accountID := strings.TrimSpace(in.AccountID)
+	if userID == "" || name == "" || accountID == "" || in.TargetDate.IsZero() {
+		return nil, ErrValidation
+	}
+	target, err := normalizeAmount(in.Target)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.accountService.GetAccount(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Currency != target.Currency {
+		return nil, ErrValidation
+	}
+
+	goal, err := s.repo.CreateSavingsGoal(ctx, &domain.SavingsGoal{
+		UID:        uuid.NewString(),
+		UserID:     userID,
+		Name:       name,
+		AccountID:  accountID,
+		Target:     target,
+		TargetDate: in.TargetDate.UTC(),
+		CreatedAt:  time.Now().UTC(),
+		UpdatedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return goalStatus(goal, account.Balance, time.Now()), nil
+}
+
+func (s *SavingsGoalService) ListSavingsGoals(ctx context.Context, userID string) ([]dto.SavingsGoalStatus, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	goals, err := s.repo.ListSavingsGoalsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]dto.SavingsGoalStatus, 0, len(goals))
+	if len(goals) == 0 {
+		return res, nil
+	}
+
+	accounts, err := s.accountService.ListAccounts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	balances := make(map[string]domain.Money, len(accounts))
+	for _, a := range accounts {
+		balances[a.UID] = a.Balance
+	}
+	now := time.Now()
+	for _, g := range goals {
+		saved, ok := balances[g.AccountID]
+		if !ok {
+			saved = domain.NewMoney(0, g.Target.Currency)
+		}
+		res = append(res, *goalStatus(g, saved, now))
+	}
+	return res, nil
+}
+
+func (s *SavingsGoalService) GetSavingsGoal(ctx context.Context, userID string, goalID string) (*dto.SavingsGoalStatus, error) {
+	userID = strings.TrimSpace(userID)
+	goalID = strings.TrimSpace(goalID)
+	if userID == "" || goalID == "" {
+		return nil, ErrValidation
+	}
+	goal, err := s.repo.GetSavingsGoal(ctx, userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.accountService.GetAccount(ctx, userID, goal.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return goalStatus(goal, account.Balance, time.Now()), nil
+}
+
+// UpdateSavingsGoal replaces the goal's name, target and date, and moves it
+// to another account when AccountID is set.
+func (s *SavingsGoalService) UpdateSavingsGoal(ctx context.Context, userID string, goalID string, in dto.SavingsGoalInput) (*dto.SavingsGoalStatus, error) {
+	userID = strings.TrimSpace(userID)
+	goalID = strings.TrimSpace(goalID)
+	name := strings.TrimSpace(in.Name)
+	if userID == "" || goalID == "" || name == "" || in.TargetDate.IsZero() {
+		return nil, ErrValidation
+	}
+	target, err := normalizeAmount(in.Target)
+	if err != nil {
+		return nil, err
+	}
+	goal, err := s.repo.GetSavingsGoal(ctx, userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+	if accountID := strings.TrimSpace(in.AccountID); accountID != "" {
+		goal.AccountID = accountID
+	}
+	account, err := s.accountService.GetAccount(ctx, userID, goal.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Currency != target.Currency {
+		return nil, ErrValidation
+	}
+
+	goal.Name = name
+	goal.Target = target
+	goal.TargetDate = in.TargetDate.UTC()
+	goal.UpdatedAt = time.Now().UTC()
+	if goal, err = s.repo.UpdateSavingsGoal(ctx, goal); err != nil {
+		return nil, err
+	}
+	return goalStatus(goal, account.Balance, time.Now()), nil
+}
+
+func (s *SavingsGoalService) DeleteSavingsGoal(ctx context.Context, userID string, goalID string) error {
+	userID = strings.TrimSpace(userID)
+	goalID = strings.TrimSpace(goalID)
+	if userID == "" || goalID == "" {
+		return ErrValidation
+	}
+	return s.repo.DeleteSavingsGoal(ctx, userID, goalID)
+}
+
+func (s *SavingsGoalService) GetSavingsGoalHistory(ctx context.Context, userID string, goalID string) ([]dto.SavingsGoalProgress, error) {
+	userID = strings.TrimSpace(userID)
+	goalID = strings.TrimSpace(goalID)
+	if userID == "" || goalID == "" {
+		return nil, ErrValidation
+	}
+	goal, err := s.repo.GetSavingsGoal(ctx, userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.accountService.GetAccount(ctx, userID, goal.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.accountService.ListAccountLedger(ctx, userID, goal.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	first := domain.PeriodOf(goal.CreatedAt)
+	current := domain.PeriodOf(time.Now())
+	if current.Before(first) {
+		current = first
+	}
+	saved := account.OpeningBalance.MinorUnits
+	i := 0
+	for ; i < len(entries) && entries[i].Date.Before(first.Start()); i++ {
+		saved = entries[i].Balance.MinorUnits
+	}
+
+	var history []dto.SavingsGoalProgress
+	for p := first; !current.Before(p); p = p.Next() {
+		var contributed int64
+		for ; i < len(entries) && entries[i].Date.Before(p.End()); i++ {
+			contributed += entries[i].Amount.MinorUnits
+			saved = entries[i].Balance.MinorUnits
+		}
+		history = append(history, dto.SavingsGoalProgress{
+			Period:      p.String(),
+			Contributed: domain.NewMoney(contributed, account.Currency),
+			Saved:       domain.NewMoney(saved, account.Currency),
+			Percentage:  percentage(domain.NewMoney(saved, account.Currency), goal.Target),
+		})
+	}
+	return history, nil
+}
+
+// goalStatus works out the goal's progress given what the linked account
+// holds now.
+func goalStatus(goal *domain.SavingsGoal, saved domain.Money, now time.Time) *dto.SavingsGoalStatus {
+	currency := goal.Target.Currency
+	remaining := goal.Target.MinorUnits - saved.MinorUnits
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	from, to := domain.PeriodOf(now), domain.PeriodOf(goal.TargetDate)
+	monthsLeft := 0
+	if !to.Before(from) {
+		monthsLeft = (to.Year-from.Year)*12 + int(to.Month-from.Month) + 1
+	}
+	required := remaining
+	if monthsLeft > 1 {
+		required = (remaining + int64(monthsLeft) - 1) / int64(monthsLeft)
+	}
+
+	return &dto.SavingsGoalStatus{
+		Goal:            goal,
+		Saved:           saved,
+		Remaining:       domain.NewMoney(remaining, currency),
+		Percentage:      percentage(saved, goal.Target),
+		MonthsLeft:      monthsLeft,
+		RequiredMonthly: domain.NewMoney(required, currency),
+	}
+}
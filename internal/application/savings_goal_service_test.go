@@ -0,0 +1,64 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestSavingsGoalTracksContributions(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	accounts := application.NewAccountService(db.AccountRepository, db.ExpenseRepository, db.IncomeRepository, db.TransferRepository, db.SavingsGoalRepository)
+	incomes := application.NewIncomeService(db.IncomeRepository, db.CategoryRepository, db.AccountRepository)
+	goals := application.NewSavingsGoalService(db.SavingsGoalRepository, accounts)
+	const userID = "u1"
+
+	savings, err := accounts.CreateAccount(ctx, dto.AccountInput{UserID: userID, Name: "Savings", Type: "savings", OpeningBalance: domain.NewMoney(100000, "USD")})
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	// Due in the fourth month counting this one.
+	due := domain.PeriodOf(time.Now()).Start().AddDate(0, 3, 14)
+	in := dto.SavingsGoalInput{UserID: userID, Name: "Holiday", AccountID: savings.UID, Target: domain.NewMoney(500000, "USD"), TargetDate: due}
+
+	if _, err := goals.CreateSavingsGoal(ctx, dto.SavingsGoalInput{UserID: userID, Name: "Car", AccountID: savings.UID, Target: domain.NewMoney(100, "EUR"), TargetDate: due}); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("currency mismatch: err = %v, want ErrValidation", err)
+	}
+	status, err := goals.CreateSavingsGoal(ctx, in)
+	if err != nil {
+		t.Fatalf("CreateSavingsGoal: %v", err)
+	}
+	if status.MonthsLeft != 4 || status.Remaining != domain.NewMoney(400000, "USD") || status.RequiredMonthly != domain.NewMoney(100000, "USD") {
+		t.Fatalf("CreateSavingsGoal = %+v, want 4 months left and 1000.00 a month", status)
+	}
+
+	if _, err := incomes.AddIncome(ctx, dto.AddIncomeInput{UserID: userID, Source: "bonus", AccountID: savings.UID, Amount: domain.NewMoney(200000, "USD")}); err != nil {
+		t.Fatalf("AddIncome: %v", err)
+	}
+	status, err = goals.GetSavingsGoal(ctx, userID, status.Goal.UID)
+	if err != nil {
+		t.Fatalf("GetSavingsGoal: %v", err)
+	}
+	if status.Saved != domain.NewMoney(300000, "USD") || status.Percentage != 60 || status.RequiredMonthly != domain.NewMoney(50000, "USD") {
+		t.Fatalf("GetSavingsGoal after contribution = %+v, want 3000.00 saved and 500.00 a month", status)
+	}
+
+	history, err := goals.GetSavingsGoalHistory(ctx, userID, status.Goal.UID)
+	if err != nil {
+		t.Fatalf("GetSavingsGoalHistory: %v", err)
+	}
+	if len(history) != 1 || history[0].Contributed != domain.NewMoney(200000, "USD") || history[0].Saved != domain.NewMoney(300000, "USD") {
+		t.Fatalf("GetSavingsGoalHistory = %+v", history)
+	}
+
+	if err := accounts.DeleteAccount(ctx, userID, savings.UID); !errors.Is(err, application.ErrAccountInUse) {
+		t.Fatalf("DeleteAccount(linked to goal) = %v, want ErrAccountInUse", err)
+	}
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+// TagService works on tags across all of a user's expenses and incomes.
+// Tags have no storage of their own; they exist while a record carries them.
+type TagService struct {
+	expenseRepo ports.ExpenseRepoPort
+	incomeRepo  ports.IncomeRepoPort
+}
+
+func NewTagService(expenseRepo ports.ExpenseRepoPort, incomeRepo ports.IncomeRepoPort) *TagService {
+	return &TagService{expenseRepo: expenseRepo, incomeRepo: incomeRepo}
+}
+
+var _ ports.TagServicePort = (*TagService)(nil)
+
+func (s *TagService) ListTags(ctx context.Context, userID string) ([]dto.TagUsage, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+
+	usage := map[string]*dto.TagUsage{}
+	count := func(tag string) *dto.TagUsage {
+		u, ok := usage[tag]
+		if !ok {
+			u = &dto.TagUsage{Tag: tag}
+			usage[tag] = u
+		}
+		return u
+	}
+
+	expenses, err := s.expenseRepo.ListExpensesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range expenses {
+		for _, t := range e.Tags {
+			count(t).Expenses++
+		}
+	}
+
+	incomes, err := s.incomeRepo.ListIncomesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range incomes {
+		for _, t := range i.Tags {
+			count(t).Incomes++
+		}
+	}
+
+	res := make([]dto.TagUsage, 0, len(usage))
+	for _, u := range usage {
+		res = append(res, *u)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Tag < res[j].Tag })
+	return res, nil
+}
+
+// RenameTag replaces from with to on every record. Renaming onto a tag that
+// is already in use merges the two.
+func (s *TagService) RenameTag(ctx context.Context, userID string, from string, to string) (int, error) {
+	return s.MergeTags(ctx, userID, []string{from}, to)
+}
+
+// MergeTags replaces every tag in from with into and returns the number of
+// records that changed.
+func (s *TagService) MergeTags(ctx context.Context, userID string, from []string, into string) (int, error) {
+	userID = strings.TrimSpace(userID)
+	into, ok := domain.NormalizeTag(into)
+	if userID == "" || !ok || len(from) == 0 {
+		return 0, ErrValidation
+	}
+	replace := make(map[string]bool, len(from))
+	for _, t := range from {
+		t, ok := domain.NormalizeTag(t)
+		if !ok {
+			return 0, ErrValidation
+		}
+		if t != into {
+			replace[t] = true
+		}
+	}
+	if len(replace) == 0 {
+		return 0, nil
+	}
+
+	changed := 0
+	now := time.Now().UTC()
+
+	expenses, err := s.expenseRepo.ListExpensesByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range expenses {
+		tags, ok := replaceTags(e.Tags, replace, into)
+		if !ok {
+			continue
+		}
+		e.Tags = tags
+		e.UpdatedAt = now
+		if _, err := s.expenseRepo.UpdateExpense(ctx, e); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+
+	incomes, err := s.incomeRepo.ListIncomesByUser(ctx, userID)
+	if err != nil {
+		return changed, err
+	}
+	for _, i := range incomes {
+		tags, ok := replaceTags(i.Tags, replace, into)
+		if !ok {
+			continue
+		}
+		i.Tags = tags
+		i.UpdatedAt = now
+		if _, err := s.incomeRepo.CreateIncome(ctx, i); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// replaceTags returns a new tag list with every tag in replace swapped for
+// into, and reports whether anything was replaced.
+func replaceTags(tags []string, replace map[string]bool, into string) ([]string, bool) {
+	next := make([]string, 0, len(tags))
+	found := false
+	for _, t := range tags {
+		if replace[t] {
+			found = true
+			t = into
+		}
+		next = append(next, t)
+	}
+	if !found {
+		return nil, false
+	}
+	next, _ = domain.NormalizeTags(next)
+	return next, true
+}
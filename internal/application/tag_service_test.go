@@ -0,0 +1,71 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestTagsFilterRenameAndMerge(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	expenses := application.NewExpenseService(db.ExpenseRepository, db.CategoryRepository, db.AccountRepository, db.DebtRepository)
+	incomes := application.NewIncomeService(db.IncomeRepository, db.CategoryRepository, db.AccountRepository)
+	tags := application.NewTagService(db.ExpenseRepository, db.IncomeRepository)
+	const userID = "u1"
+
+	add := func(source string, tags ...string) {
+		t.Helper()
+		if _, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: userID, Source: source, Amount: domain.NewMoney(100, "USD"), Tags: tags}); err != nil {
+			t.Fatalf("AddExpense(%s): %v", source, err)
+		}
+	}
+	add("flight", "Vacation 2026", "travel")
+	add("hotel", "vacation-2026", "Vacation 2026")
+	add("taxi", "trip")
+	if _, err := incomes.AddIncome(ctx, dto.AddIncomeInput{UserID: userID, Source: "refund", Amount: domain.NewMoney(50, "USD"), Tags: []string{"trip"}}); err != nil {
+		t.Fatalf("AddIncome: %v", err)
+	}
+	if _, err := expenses.AddExpense(ctx, dto.AddExpenseInput{UserID: userID, Source: "bad", Amount: domain.NewMoney(1, "USD"), Tags: []string{"a,b"}}); !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("tag with comma: err = %v, want ErrValidation", err)
+	}
+
+	list, err := expenses.ListExpenses(ctx, userID, dto.TransactionFilter{Tag: "VACATION 2026"})
+	if err != nil || len(list) != 2 {
+		t.Fatalf("ListExpenses(tag) = %d, %v; want 2", len(list), err)
+	}
+
+	n, err := tags.MergeTags(ctx, userID, []string{"trip", "vacation-2026"}, "Travel")
+	if err != nil || n != 4 {
+		t.Fatalf("MergeTags = %d, %v; want 4", n, err)
+	}
+	n, err = tags.RenameTag(ctx, userID, "nothing", "travel")
+	if err != nil || n != 0 {
+		t.Fatalf("RenameTag(unused) = %d, %v; want 0", n, err)
+	}
+
+	usage, err := tags.ListTags(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	want := []dto.TagUsage{{Tag: "travel", Expenses: 3, Incomes: 1}}
+	if !reflect.DeepEqual(usage, want) {
+		t.Fatalf("ListTags = %+v, want %+v", usage, want)
+	}
+
+	list, err = expenses.ListExpenses(ctx, userID, dto.TransactionFilter{Tag: "travel"})
+	if err != nil || len(list) != 3 {
+		t.Fatalf("ListExpenses(travel) = %d, %v; want 3", len(list), err)
+	}
+	for _, e := range list {
+		if !reflect.DeepEqual(e.Tags, []string{"travel"}) {
+			t.Fatalf("%s tags = %v, want [travel]", e.Source, e.Tags)
+		}
+	}
+}
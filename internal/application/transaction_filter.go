@@ -0,0 +1,37 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+// transactionMatcher turns filter into a predicate over a record's category
+// and tags. It returns a nil predicate when the filter matches everything.
+func transactionMatcher(ctx context.Context, categoryRepo ports.CategoryRepoPort, userID string, filter dto.TransactionFilter) (func(categoryID string, tags []string) bool, error) {
+	categories, err := categoryFilter(ctx, categoryRepo, userID, filter.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := ""
+	if strings.TrimSpace(filter.Tag) != "" {
+		var ok bool
+		if tag, ok = domain.NormalizeTag(filter.Tag); !ok {
+			return nil, ErrValidation
+		}
+	}
+
+	if categories == nil && tag == "" {
+		return nil, nil
+	}
+	return func(categoryID string, tags []string) bool {
+		if categories != nil && !categories[categoryID] {
+			return false
+		}
+		return tag == "" || domain.HasTag(tags, tag)
+	}, nil
+}
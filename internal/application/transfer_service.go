@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type TransferService struct {
+	repo        ports.TransferRepoPort
+	accountRepo ports.AccountRepoPort
+	rates       ports.ExchangeRateProvider
+}
+
+func NewTransferService(repo ports.TransferRepoPort, accountRepo ports.AccountRepoPort, rates ports.ExchangeRateProvider) *TransferService {
+	return &TransferService{repo: repo, accountRepo: accountRepo, rates: rates}
+}
+
+var _ ports.TransferServicePort = (*TransferService)(nil)
+
+func (s *TransferService) CreateTransfer(ctx context.Context, in dto.TransferInput) (*domain.Transfer, error) {
+	userID := strings.TrimSpace(in.UserID)
+	fromID := strings.TrimSpace(in.FromAccountID)
+	toID := strings.TrimSpace(in.ToAccountID)
+	amount, err := normalizeAmount(in.Amount)
+	if userID == "" || fromID == "" || toID == "" || fromID == toID || err != nil || in.Rate < 0 {
+		return nil, ErrValidation
+	}
+
+	from, err := s.accountRepo.GetAccount(ctx, userID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.accountRepo.GetAccount(ctx, userID, toID)
+	if err != nil {
+		return nil, err
+	}
+	if amount.Currency != from.Currency {
+		return nil, ErrValidation
+	}
+
+	now := time.Now().UTC()
+	toAmount, rate, err := s.convert(ctx, amount, to.Currency, in, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateTransfer(ctx, &domain.Transfer{
+		UID:           uuid.NewString(),
+		UserID:        userID,
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        amount,
+		ToAmount:      toAmount,
+		Rate:          rate,
+		Notes:         strings.TrimSpace(in.Notes),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+}
+
+// convert works out what arrives in currency, preferring an explicit
+// ToAmount, then an explicit Rate, then the exchange rate on the day.
+func (s *TransferService) convert(ctx context.Context, amount domain.Money, currency string, in dto.TransferInput, on time.Time) (domain.Money, float64, error) {
+	if amount.Currency == currency {
+		if !in.ToAmount.IsZero() && in.ToAmount != amount {
+			return domain.Money{}, 0, ErrValidation
+		}
+		return amount, 1, nil
+	}
+
+	if !in.ToAmount.IsZero() {
+		toAmount, err := normalizeAmount(in.ToAmount)
+		if err != nil || toAmount.Currency != currency {
+			return domain.Money{}, 0, ErrValidation
+		}
+		return toAmount, toAmount.Float64() / amount.Float64(), nil
+	}
+
+	rate := in.Rate
+	if rate == 0 {
+		if s.rates == nil {
+			return domain.Money{}, 0, ErrValidation
+		}
+		var err error
+		if rate, err = s.rates.Rate(ctx, amount.Currency, currency, on); err != nil {
+			return domain.Money{}, 0, fmt.Errorf("convert %s to %s: %w", amount, currency, err)
+		}
+	}
+	toAmount := amount.Convert(currency, rate)
+	if !toAmount.IsPositive() {
+		return domain.Money{}, 0, ErrValidation
+	}
+	return toAmount, rate, nil
+}
+
+func (s *TransferService) ListTransfers(ctx context.Context, userID string) ([]*domain.Transfer, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrValidation
+	}
+	return s.repo.ListTransfersByUser(ctx, userID)
+}
+
+func (s *TransferService) GetTransfer(ctx context.Context, userID string, transferID string) (*domain.Transfer, error) {
+	userID = strings.TrimSpace(userID)
+	transferID = strings.TrimSpace(transferID)
+	if userID == "" || transferID == "" {
+		return nil, ErrValidation
+	}
+	return s.repo.GetTransfer(ctx, userID, transferID)
+}
+
+func (s *TransferService) DeleteTransfer(ctx context.Context, userID string, transferID string) error {
+	userID = strings.TrimSpace(userID)
+	transferID = strings.TrimSpace(transferID)
+	if userID == "" || transferID == "" {
+		return ErrValidation
+	}
+	return s.repo.DeleteTransfer(ctx, userID, transferID)
+}
@@ -0,0 +1,91 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+	"github.com/theHinneh/budgeting/internal/infrastructure/exchangerate"
+)
+
+func TestTransfersMoveBalancesNotTotals(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	rates, err := exchangerate.NewStaticProvider([]domain.ExchangeRate{{Base: "USD", Quote: "EUR", Rate: 0.9}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts := application.NewAccountService(db.AccountRepository, db.ExpenseRepository, db.IncomeRepository, db.TransferRepository, db.SavingsGoalRepository)
+	transfers := application.NewTransferService(db.TransferRepository, db.AccountRepository, rates)
+	debts := application.NewDebtService(db.DebtRepository, db.ExpenseRepository)
+	holdings := application.NewHoldingService(db.HoldingRepository)
+	netWorth := application.NewNetWorthService(db.IncomeRepository, db.ExpenseRepository, db.UserRepository, accounts, debts, holdings, db.NetWorthSnapshotRepository, rates)
+
+	user := domain.NewUser("u1", "ama", "ama@example.com", "Ama", "Mensah", nil)
+	if _, err := db.UserRepository.CreateUser(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	open := func(name string, opening domain.Money) *domain.Account {
+		t.Helper()
+		a, err := accounts.CreateAccount(ctx, dto.AccountInput{UserID: user.UID, Name: name, Type: "checking", OpeningBalance: opening})
+		if err != nil {
+			t.Fatalf("CreateAccount(%s): %v", name, err)
+		}
+		return a
+	}
+	checking := open("Checking", domain.NewMoney(100000, "USD"))
+	savings := open("Savings", domain.NewMoney(0, "USD"))
+	euro := open("Euro", domain.NewMoney(0, "EUR"))
+
+	transfer := func(to *domain.Account, in dto.TransferInput) *domain.Transfer {
+		t.Helper()
+		in.UserID, in.FromAccountID, in.ToAccountID = user.UID, checking.UID, to.UID
+		tr, err := transfers.CreateTransfer(ctx, in)
+		if err != nil {
+			t.Fatalf("CreateTransfer: %v", err)
+		}
+		return tr
+	}
+	transfer(savings, dto.TransferInput{Amount: domain.NewMoney(20000, "USD")})
+	looked := transfer(euro, dto.TransferInput{Amount: domain.NewMoney(10000, "USD")})
+	given := transfer(euro, dto.TransferInput{Amount: domain.NewMoney(10000, "USD"), ToAmount: domain.NewMoney(9250, "EUR")})
+	if looked.ToAmount != domain.NewMoney(9000, "EUR") || looked.Rate != 0.9 {
+		t.Fatalf("looked-up transfer = %+v", looked)
+	}
+	if math.Abs(given.Rate-0.925) > 1e-9 {
+		t.Fatalf("recorded rate = %v, want 0.925", given.Rate)
+	}
+
+	_, err = transfers.CreateTransfer(ctx, dto.TransferInput{UserID: user.UID, FromAccountID: checking.UID, ToAccountID: checking.UID, Amount: domain.NewMoney(1, "USD")})
+	if !errors.Is(err, application.ErrValidation) {
+		t.Fatalf("transfer to same account: err = %v, want ErrValidation", err)
+	}
+
+	list, err := accounts.ListAccounts(ctx, user.UID)
+	if err != nil {
+		t.Fatalf("ListAccounts: %v", err)
+	}
+	want := map[string]domain.Money{
+		checking.UID: domain.NewMoney(60000, "USD"),
+		savings.UID:  domain.NewMoney(20000, "USD"),
+		euro.UID:     domain.NewMoney(18250, "EUR"),
+	}
+	for _, a := range list {
+		if a.Balance != want[a.UID] {
+			t.Errorf("%s balance = %s, want %s", a.Name, a.Balance, want[a.UID])
+		}
+	}
+
+	nw, err := netWorth.GetNetWorth(ctx, user.UID)
+	if err != nil {
+		t.Fatalf("GetNetWorth: %v", err)
+	}
+	if nw.TotalIncome != 0 || nw.TotalExpense != 0 {
+		t.Fatalf("transfers counted in totals: %+v", nw)
+	}
+}
@@ -8,15 +8,18 @@ import (
 	"github.com/theHinneh/budgeting/internal/application/dto"
 	"github.com/theHinneh/budgeting/internal/application/ports"
 	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
+	"go.uber.org/zap"
 )
 
 type UserService struct {
 	userRepo      ports.UserRepository
 	authenticator ports.UserAuthenticator
+	categories    ports.CategoryServicePort
 }
 
-func NewUserService(userRepo ports.UserRepository, authenticator ports.UserAuthenticator) *UserService {
-	return &UserService{userRepo: userRepo, authenticator: authenticator}
+func NewUserService(userRepo ports.UserRepository, authenticator ports.UserAuthenticator, categories ports.CategoryServicePort) *UserService {
+	return &UserService{userRepo: userRepo, authenticator: authenticator, categories: categories}
 }
 
 var _ ports.UserServicePort = (*UserService)(nil)
@@ -28,11 +31,24 @@ func (s *UserService) CreateUser(ctx context.Context, in dto.CreateUserInput) (s
 	}
 
 	user := domain.NewUser(in.UID, in.Username, in.Email, in.FirstName, in.LastName, in.PhoneNumber)
+	if in.BaseCurrency != nil {
+		code, ok := domain.NormalizeCurrency(*in.BaseCurrency)
+		if !ok {
+			return "", ErrValidation
+		}
+		user.BaseCurrency = code
+	}
 
 	_, err := s.userRepo.CreateUser(ctx, user)
 	if err != nil {
 		return "", err
 	}
+
+	// A missing default tree is not worth failing sign-up over; it can be
+	// seeded again through the categories endpoint.
+	if _, err := s.categories.SeedDefaultCategories(ctx, in.UID); err != nil {
+		logger.Error("Failed to seed default categories", zap.String("user_id", in.UID), zap.Error(err))
+	}
 	return in.UID, nil
 }
 
@@ -42,6 +58,13 @@ func (s *UserService) GetUser(ctx context.Context, uid string) (*domain.User, er
 
 func (s *UserService) UpdateUser(ctx context.Context, uid string, in dto.UpdateUserInput) (*domain.User, error) {
 	uid = strings.TrimSpace(uid)
+	if in.BaseCurrency != nil {
+		code, ok := domain.NormalizeCurrency(*in.BaseCurrency)
+		if !ok {
+			return nil, ErrValidation
+		}
+		in.BaseCurrency = &code
+	}
 	updates := map[string]interface{}{
 		"UpdatedAt": time.Now().UTC(),
 	}
@@ -60,6 +83,9 @@ func (s *UserService) UpdateUser(ctx context.Context, uid string, in dto.UpdateU
 	if in.PhoneNumber != nil {
 		updates["PhoneNumber"] = in.PhoneNumber
 	}
+	if in.BaseCurrency != nil {
+		updates["BaseCurrency"] = *in.BaseCurrency
+	}
 
 	user, err := s.userRepo.GetUser(ctx, uid)
 	if err != nil {
@@ -81,6 +107,9 @@ func (s *UserService) UpdateUser(ctx context.Context, uid string, in dto.UpdateU
 	if in.PhoneNumber != nil {
 		user.PhoneNumber = in.PhoneNumber
 	}
+	if in.BaseCurrency != nil {
+		user.BaseCurrency = *in.BaseCurrency
+	}
 	user.UpdatedAt = time.Now().UTC()
 
 	updatedUser, err := s.userRepo.UpdateUser(ctx, user)
@@ -0,0 +1,77 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/application"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+)
+
+func TestUserLifecycleWithLocalAuth(t *testing.T) {
+	ctx := context.Background()
+	auth, db, sessions := newLocalAuthService(t)
+	categories := application.NewCategoryService(db.CategoryRepository, db.ExpenseRepository, db.IncomeRepository, db.BudgetRepository, db.EnvelopeRepository)
+	users := application.NewUserService(db.UserRepository, auth, categories)
+
+	uid, err := auth.CreateAuthUser(ctx, "Ama@Example.com", "secret123", "Ama Mensah", nil)
+	if err != nil {
+		t.Fatalf("CreateAuthUser: %v", err)
+	}
+	if _, err := auth.CreateAuthUser(ctx, "ama@example.com", "other", "", nil); err == nil {
+		t.Fatal("CreateAuthUser accepted an email that is already in use")
+	}
+	if _, err := users.CreateUser(ctx, dto.CreateUserInput{UID: uid, Username: "ama", Email: "ama@example.com", FirstName: "Ama", LastName: "Mensah"}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if tree, err := categories.ListCategories(ctx, uid); err != nil || len(tree) == 0 {
+		t.Fatalf("ListCategories after sign-up = %d categories, %v, want the defaults", len(tree), err)
+	}
+
+	login := func(email, password string) error {
+		t.Helper()
+		_, err := sessions.Login(ctx, email, password, "", "", "")
+		return err
+	}
+	if err := login("ama@example.com", "secret123"); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if err := login("ama@example.com", "wrong"); err == nil {
+		t.Fatal("Login with a wrong password succeeded")
+	}
+
+	email := "ama.mensah@example.com"
+	if _, err := users.UpdateUser(ctx, uid, dto.UpdateUserInput{Email: &email}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if err := login(email, "secret123"); err != nil {
+		t.Fatalf("Login with the new email: %v", err)
+	}
+	if err := login("ama@example.com", "secret123"); err == nil {
+		t.Fatal("Login with the old email succeeded")
+	}
+
+	if err := users.ChangePassword(ctx, uid, "n3w-secret"); err != nil {
+		t.Fatalf("ChangePassword: %v", err)
+	}
+	if err := login(email, "secret123"); err == nil {
+		t.Fatal("Login with the old password succeeded")
+	}
+	if err := login(email, "n3w-secret"); err != nil {
+		t.Fatalf("Login with the new password: %v", err)
+	}
+
+	if _, err := users.ForgotPassword(ctx, email); err == nil {
+		t.Fatal("ForgotPassword returned a reset link in local auth mode")
+	}
+
+	if err := users.DeleteUser(ctx, uid); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if err := login(email, "n3w-secret"); err == nil {
+		t.Fatal("Login after DeleteUser succeeded")
+	}
+	if _, err := users.GetUser(ctx, uid); err == nil {
+		t.Fatal("GetUser after DeleteUser found the user")
+	}
+}
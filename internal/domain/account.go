@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+type AccountType string
+
+const (
+	AccountTypeChecking   AccountType = "checking"
+	AccountTypeSavings    AccountType = "savings"
+	AccountTypeCash       AccountType = "cash"
+	AccountTypeCreditCard AccountType = "credit_card"
+)
+
+func (t AccountType) Valid() bool {
+	switch t {
+	case AccountTypeChecking, AccountTypeSavings, AccountTypeCash, AccountTypeCreditCard:
+		return true
+	default:
+		return false
+	}
+}
+
+// Account is where money is held or owed. Every transaction on the account
+// is in its Currency.
+type Account struct {
+	UID            string
+	UserID         string
+	Name           string
+	Type           AccountType
+	Currency       string
+	OpeningBalance Money
+	// Balance is OpeningBalance plus incomes minus expenses on the account.
+	// It is computed from transactions and never stored; a credit card in
+	// use has a negative balance.
+	Balance   Money
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// Budget is the amount planned for an expense category, including its
+// subcategories, in one period. Period is stored as YYYY-MM.
+type Budget struct {
+	UID        string
+	UserID     string
+	CategoryID string
+	Period     string
+	Planned    Money
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
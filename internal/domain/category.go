@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+type CategoryKind string
+
+const (
+	CategoryKindExpense CategoryKind = "expense"
+	CategoryKindIncome  CategoryKind = "income"
+)
+
+func (k CategoryKind) Valid() bool {
+	return k == CategoryKindExpense || k == CategoryKindIncome
+}
+
+// Category classifies expenses or incomes. Categories form a tree per user;
+// ParentID is empty for top-level categories.
+type Category struct {
+	UID       string
+	UserID    string
+	ParentID  string
+	Name      string
+	Kind      CategoryKind
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CategoryTemplate describes a top-level category and the names of its
+// children.
+type CategoryTemplate struct {
+	Name     string
+	Kind     CategoryKind
+	Children []string
+}
+
+// DefaultCategories is the tree every new user starts with.
+var DefaultCategories = []CategoryTemplate{
+	{Name: "Housing", Kind: CategoryKindExpense, Children: []string{"Rent", "Mortgage", "Utilities", "Maintenance"}},
+	{Name: "Food", Kind: CategoryKindExpense, Children: []string{"Groceries", "Dining Out"}},
+	{Name: "Transport", Kind: CategoryKindExpense, Children: []string{"Fuel", "Public Transit", "Parking", "Vehicle Maintenance"}},
+	{Name: "Health", Kind: CategoryKindExpense, Children: []string{"Medical", "Insurance", "Fitness"}},
+	{Name: "Personal", Kind: CategoryKindExpense, Children: []string{"Clothing", "Education", "Personal Care"}},
+	{Name: "Entertainment", Kind: CategoryKindExpense, Children: []string{"Subscriptions", "Travel", "Hobbies"}},
+	{Name: "Debt Payments", Kind: CategoryKindExpense},
+	{Name: "Gifts & Donations", Kind: CategoryKindExpense},
+	{Name: "Salary", Kind: CategoryKindIncome},
+	{Name: "Business", Kind: CategoryKindIncome},
+	{Name: "Investments", Kind: CategoryKindIncome, Children: []string{"Interest", "Dividends"}},
+	{Name: "Other Income", Kind: CategoryKindIncome, Children: []string{"Gifts", "Refunds"}},
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// Credential holds the login details of a user managed by the local auth
+// provider. It is kept apart from User so profile reads never expose the hash.
+type Credential struct {
+	UID          string
+	Email        string
+	DisplayName  string
+	PhoneNumber  *string
+	PasswordHash string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
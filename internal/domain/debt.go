@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+type DebtKind string
+
+const (
+	DebtKindLoan   DebtKind = "loan"
+	DebtKindCredit DebtKind = "credit"
+)
+
+func (k DebtKind) Valid() bool {
+	switch k {
+	case DebtKindLoan, DebtKindCredit:
+		return true
+	default:
+		return false
+	}
+}
+
+// Debt is money the user owes: an installment loan or a revolving credit
+// balance. Payments on it are expenses whose DebtID names it.
+type Debt struct {
+	UID       string
+	UserID    string
+	Name      string
+	Kind      DebtKind
+	Principal Money
+	// APR is the nominal annual rate as a percentage, 6.5 for 6.5%.
+	APR float64
+	// Term is the number of payments, 0 when there is no fixed term.
+	Term int
+	// Frequency is how often a payment is due, one of the expense
+	// recurrence frequencies.
+	Frequency string
+	// Payment is the scheduled payment. When zero it is the level payment
+	// that clears Principal in Term payments.
+	Payment Money
+	// StartDate is when Principal was owed; the first payment is due one
+	// Frequency later.
+	StartDate time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Envelope sets aside Allocation for an expense category, including its
+// subcategories, every period from StartPeriod on. With Rollover, whatever
+// is left at the end of a period, or overspent, carries into the next one;
+// without it every period starts from the allocation alone.
+type Envelope struct {
+	UID         string
+	UserID      string
+	Name        string
+	CategoryID  string
+	Allocation  Money
+	Rollover    bool
+	StartPeriod string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// EnvelopeMove moves money from one envelope to another within a period.
+type EnvelopeMove struct {
+	UID            string
+	UserID         string
+	FromEnvelopeID string
+	ToEnvelopeID   string
+	Period         string
+	Amount         Money
+	Notes          string
+	CreatedAt      time.Time
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
+// ExchangeRate says how many units of Quote one unit of Base buys on Date.
+type ExchangeRate struct {
+	Base  string
+	Quote string
+	Date  time.Time
+	Rate  float64
+}
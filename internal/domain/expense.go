@@ -2,12 +2,18 @@ package domain
 
 import "time"
 
+// Expense is money spent. A split expense spreads Amount over Splits, each
+// with its own category, and has no CategoryID of its own.
 type Expense struct {
 	UID                 string
 	UserID              string
 	Source              string
-	Amount              float64
-	Currency            string
+	AccountID           string
+	CategoryID          string
+	DebtID              string
+	Tags                []string
+	Amount              Money
+	Splits              []ExpenseSplit
 	Notes               string
 	IsRecurring         bool
 	RecurrenceFrequency string
@@ -15,3 +21,20 @@ type Expense struct {
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 }
+
+// ExpenseSplit is the part of a split expense that belongs to one
+// category. It is in the expense's currency.
+type ExpenseSplit struct {
+	CategoryID string
+	Amount     Money
+	Notes      string
+}
+
+// Lines returns the expense as category lines: its splits, or a single line
+// for the whole amount when it is not split.
+func (e *Expense) Lines() []ExpenseSplit {
+	if len(e.Splits) > 0 {
+		return e.Splits
+	}
+	return []ExpenseSplit{{CategoryID: e.CategoryID, Amount: e.Amount}}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+type HoldingKind string
+
+const (
+	HoldingKindAsset     HoldingKind = "asset"
+	HoldingKindLiability HoldingKind = "liability"
+)
+
+func (k HoldingKind) Valid() bool {
+	switch k {
+	case HoldingKindAsset, HoldingKindLiability:
+		return true
+	default:
+		return false
+	}
+}
+
+type HoldingCategory string
+
+const (
+	HoldingCategoryProperty   HoldingCategory = "property"
+	HoldingCategoryVehicle    HoldingCategory = "vehicle"
+	HoldingCategoryInvestment HoldingCategory = "investment"
+	HoldingCategoryCash       HoldingCategory = "cash"
+	HoldingCategoryLoan       HoldingCategory = "loan"
+	HoldingCategoryOther      HoldingCategory = "other"
+)
+
+func (c HoldingCategory) Valid() bool {
+	switch c {
+	case HoldingCategoryProperty, HoldingCategoryVehicle, HoldingCategoryInvestment, HoldingCategoryCash, HoldingCategoryLoan, HoldingCategoryOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// Holding is something the user owns or owes outside their accounts and
+// debts, such as a house, a car or a loan from family. Its value is the
+// latest Valuation, always in Currency.
+type Holding struct {
+	UID       string
+	UserID    string
+	Name      string
+	Kind      HoldingKind
+	Category  HoldingCategory
+	Currency  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Valuation is what a holding was worth, or how much of it was owed, on a
+// day. Value is never negative.
+type Valuation struct {
+	UID       string
+	UserID    string
+	HoldingID string
+	Value     Money
+	Date      time.Time
+	Notes     string
+	CreatedAt time.Time
+}
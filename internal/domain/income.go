@@ -1,14 +1,30 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Income struct {
-	UID       string
-	UserID    string
-	Source    string
-	Amount    float64
-	Currency  string
-	Notes     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	UID        string
+	UserID     string
+	Source     string
+	AccountID  string
+	CategoryID string
+	Tags       []string
+	Amount     Money
+	Notes      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// MarshalJSON keeps the wire format from before Money was introduced: Amount
+// is a number in major units with Currency next to it.
+func (i Income) MarshalJSON() ([]byte, error) {
+	type plain Income
+	return json.Marshal(struct {
+		plain
+		Amount   float64
+		Currency string
+	}{plain(i), i.Amount.Float64(), i.Amount.Currency})
 }
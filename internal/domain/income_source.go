@@ -1,13 +1,15 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type IncomeSource struct {
 	UID       string
 	UserID    string
 	Source    string
-	Amount    float64
-	Currency  string
+	Amount    Money
 	Frequency string
 	NextPayAt time.Time
 	Active    bool
@@ -15,3 +17,13 @@ type IncomeSource struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// MarshalJSON flattens Amount the same way as Income.MarshalJSON.
+func (s IncomeSource) MarshalJSON() ([]byte, error) {
+	type plain IncomeSource
+	return json.Marshal(struct {
+		plain
+		Amount   float64
+		Currency string
+	}{plain(s), s.Amount.Float64(), s.Amount.Currency})
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// LoginAttempts counts the failed logins for one key, an account's email or a
+// client IP, since LastFailureAt fell out of the counting window.
+// LockedUntil is set while further logins for the key are refused.
+type LoginAttempts struct {
+	Key           string     `json:"key" firestore:"key"`
+	Failures      int        `json:"failures" firestore:"failures"`
+	LastFailureAt time.Time  `json:"last_failure_at" firestore:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty" firestore:"locked_until,omitempty"`
+}
+
+// AccountUnlock is a single-use token, mailed to the owner of a locked
+// account, that lifts the lock before it expires on its own.
+type AccountUnlock struct {
+	TokenHash string    `json:"token_hash" firestore:"token_hash"`
+	Email     string    `json:"email" firestore:"email"`
+	UserID    string    `json:"user_id" firestore:"user_id"`
+	ExpiresAt time.Time `json:"expires_at" firestore:"expires_at"`
+	CreatedAt time.Time `json:"created_at" firestore:"created_at"`
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const DefaultCurrency = "USD"
+
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Money is an exact amount in the minor units (cents, pence, yen...) of an
+// ISO 4217 currency.
+type Money struct {
+	MinorUnits int64
+	Currency   string
+}
+
+// currencyExponents lists the ISO 4217 currencies whose minor unit is not
+// 1/100 of the major unit.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyExponent returns the number of decimal places of the currency's
+// minor unit.
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// NormalizeCurrency upper-cases code and reports whether it looks like an
+// ISO 4217 alphabetic code.
+func NormalizeCurrency(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return code, false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return code, false
+		}
+	}
+	return code, true
+}
+
+func NewMoney(minorUnits int64, currency string) Money {
+	return Money{MinorUnits: minorUnits, Currency: strings.ToUpper(currency)}
+}
+
+// MoneyFromFloat rounds amount, given in major units, to the nearest minor
+// unit of currency.
+func MoneyFromFloat(amount float64, currency string) Money {
+	scale := math.Pow10(CurrencyExponent(currency))
+	return NewMoney(int64(math.Round(amount*scale)), currency)
+}
+
+// Float64 returns the amount in major units. It is meant for presentation
+// only; arithmetic should stay in Money.
+func (m Money) Float64() float64 {
+	return float64(m.MinorUnits) / math.Pow10(CurrencyExponent(m.Currency))
+}
+
+func (m Money) IsZero() bool     { return m.MinorUnits == 0 }
+func (m Money) IsPositive() bool { return m.MinorUnits > 0 }
+func (m Money) IsNegative() bool { return m.MinorUnits < 0 }
+
+func (m Money) Neg() Money {
+	return Money{MinorUnits: -m.MinorUnits, Currency: m.Currency}
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return Money{MinorUnits: m.MinorUnits + o.MinorUnits, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	return m.Add(o.Neg())
+}
+
+// Convert expresses m in currency, given rate units of currency per unit of
+// m.Currency, rounded to the nearest minor unit.
+func (m Money) Convert(currency string, rate float64) Money {
+	shift := math.Pow10(CurrencyExponent(currency) - CurrencyExponent(m.Currency))
+	return NewMoney(int64(math.Round(float64(m.MinorUnits)*rate*shift)), currency)
+}
+
+// String formats the amount with the currency's exponent, e.g. "12.30 USD".
+func (m Money) String() string {
+	exp := CurrencyExponent(m.Currency)
+	sign := ""
+	units := m.MinorUnits
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	if exp == 0 {
+		return fmt.Sprintf("%s%d %s", sign, units, m.Currency)
+	}
+	scale := int64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, units/scale, exp, units%scale, m.Currency)
+}
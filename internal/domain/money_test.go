@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+func TestMoneyFromFloat(t *testing.T) {
+	cases := []struct {
+		amount   float64
+		currency string
+		want     int64
+	}{
+		{19.99, "USD", 1999},
+		{0.1 + 0.2, "usd", 30},
+		{1234, "JPY", 1234},
+		{1.2345, "KWD", 1235},
+		{-5.005, "EUR", -501},
+	}
+	for _, c := range cases {
+		got := domain.MoneyFromFloat(c.amount, c.currency)
+		if got.MinorUnits != c.want {
+			t.Errorf("MoneyFromFloat(%v, %s) = %d minor units, want %d", c.amount, c.currency, got.MinorUnits, c.want)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	total := domain.NewMoney(0, "USD")
+	for i := 0; i < 10; i++ {
+		var err error
+		total, err = total.Add(domain.MoneyFromFloat(0.1, "USD"))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if total != domain.NewMoney(100, "USD") || total.Float64() != 1 {
+		t.Fatalf("ten dimes = %v, want exactly 1.00 USD", total)
+	}
+
+	if _, err := total.Add(domain.NewMoney(1, "EUR")); !errors.Is(err, domain.ErrCurrencyMismatch) {
+		t.Fatalf("adding EUR to USD: err = %v, want ErrCurrencyMismatch", err)
+	}
+
+	diff, err := domain.NewMoney(500, "GBP").Sub(domain.NewMoney(1250, "GBP"))
+	if err != nil || diff.String() != "-7.50 GBP" {
+		t.Fatalf("Sub = %v (%v), want -7.50 GBP", diff, err)
+	}
+	if s := domain.NewMoney(1500, "JPY").String(); s != "1500 JPY" {
+		t.Fatalf("String() = %q", s)
+	}
+	if s := domain.NewMoney(1005, "BHD").String(); s != "1.005 BHD" {
+		t.Fatalf("String() = %q", s)
+	}
+}
+
+func TestMoneyConvert(t *testing.T) {
+	cases := []struct {
+		in   domain.Money
+		to   string
+		rate float64
+		want domain.Money
+	}{
+		{domain.NewMoney(1000, "EUR"), "USD", 1.0825, domain.NewMoney(1083, "USD")},
+		{domain.NewMoney(1500, "JPY"), "USD", 0.0067, domain.NewMoney(1005, "USD")},
+		{domain.NewMoney(1005, "USD"), "JPY", 149.3, domain.NewMoney(1500, "JPY")},
+		{domain.NewMoney(-250, "GBP"), "BHD", 0.48, domain.NewMoney(-1200, "BHD")},
+	}
+	for _, c := range cases {
+		if got := c.in.Convert(c.to, c.rate); got != c.want {
+			t.Errorf("%v at %v = %v, want %v", c.in, c.rate, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// NetWorthSnapshot is a user's net worth as it stood on Date, a day at
+// midnight UTC. The totals are in the user's reporting currency of the
+// time. There is at most one snapshot per user and day.
+type NetWorthSnapshot struct {
+	UserID           string
+	Date             time.Time
+	TotalAssets      Money
+	TotalLiabilities Money
+	Accounts         []AccountBalance
+	CreatedAt        time.Time
+}
+
+func (s *NetWorthSnapshot) NetWorth() (Money, error) {
+	return s.TotalAssets.Sub(s.TotalLiabilities)
+}
+
+// AccountBalance is an account's balance, in its own currency, on the day
+// of a snapshot.
+type AccountBalance struct {
+	AccountID string
+	Name      string
+	Balance   Money
+}
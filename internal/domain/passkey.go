@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// Passkey is a WebAuthn credential registered by a user. ID is the base64url
+// encoded credential ID chosen by the authenticator and PublicKey the COSE
+// encoded key that verifies its assertions.
+type Passkey struct {
+	ID              string   `json:"id" firestore:"id"`
+	UserID          string   `json:"user_id" firestore:"user_id"`
+	Name            string   `json:"name" firestore:"name"`
+	PublicKey       []byte   `json:"public_key" firestore:"public_key"`
+	AttestationType string   `json:"attestation_type" firestore:"attestation_type"`
+	AAGUID          []byte   `json:"aaguid,omitempty" firestore:"aaguid,omitempty"`
+	Transports      []string `json:"transports,omitempty" firestore:"transports,omitempty"`
+	// SignCount is the last signature counter seen. Synced passkeys always
+	// report zero; a counter that goes backwards points to a cloned key.
+	SignCount      uint32     `json:"sign_count" firestore:"sign_count"`
+	BackupEligible bool       `json:"backup_eligible" firestore:"backup_eligible"`
+	BackupState    bool       `json:"backup_state" firestore:"backup_state"`
+	CreatedAt      time.Time  `json:"created_at" firestore:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty" firestore:"last_used_at,omitempty"`
+}
+
+// PasskeyCeremony is the server side state of a WebAuthn registration or
+// login between its begin and finish requests. UserID is empty for logins,
+// where the user is only known once the authenticator answers.
+type PasskeyCeremony struct {
+	TokenHash string    `json:"token_hash" firestore:"token_hash"`
+	UserID    string    `json:"user_id,omitempty" firestore:"user_id,omitempty"`
+	Session   []byte    `json:"session" firestore:"session"`
+	ExpiresAt time.Time `json:"expires_at" firestore:"expires_at"`
+	CreatedAt time.Time `json:"created_at" firestore:"created_at"`
+}
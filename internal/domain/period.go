@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Period is a calendar month, the unit budgets are planned in.
+type Period struct {
+	Year  int
+	Month time.Month
+}
+
+// PeriodOf returns the period containing t, in UTC.
+func PeriodOf(t time.Time) Period {
+	t = t.UTC()
+	return Period{Year: t.Year(), Month: t.Month()}
+}
+
+// ParsePeriod parses a period written as YYYY-MM.
+func ParsePeriod(s string) (Period, error) {
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid period %q, want YYYY-MM", s)
+	}
+	return PeriodOf(t), nil
+}
+
+func (p Period) String() string { return fmt.Sprintf("%04d-%02d", p.Year, int(p.Month)) }
+
+// Start is the first instant of the period.
+func (p Period) Start() time.Time { return time.Date(p.Year, p.Month, 1, 0, 0, 0, 0, time.UTC) }
+
+// End is the first instant after the period.
+func (p Period) End() time.Time { return p.Start().AddDate(0, 1, 0) }
+
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start()) && t.Before(p.End())
+}
+
+func (p Period) Next() Period { return PeriodOf(p.End()) }
+
+func (p Period) Prev() Period { return PeriodOf(p.Start().AddDate(0, -1, 0)) }
+
+func (p Period) Before(o Period) bool { return p.Start().Before(o.Start()) }
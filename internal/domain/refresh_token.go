@@ -4,10 +4,16 @@ import (
 	"time"
 )
 
+// RefreshToken is a stored refresh token. Only the SHA-256 hash of the secret
+// handed to the client is kept. Every refresh rotates the token: the old one
+// is revoked with ReplacedBy pointing at its successor, and both share the
+// FamilyID of the login that started the chain.
 type RefreshToken struct {
 	ID         string     `json:"id" firestore:"id"`
 	UserID     string     `json:"user_id" firestore:"user_id"`
 	TokenHash  string     `json:"token_hash" firestore:"token_hash"`
+	FamilyID   string     `json:"family_id" firestore:"family_id"`
+	ReplacedBy string     `json:"replaced_by,omitempty" firestore:"replaced_by,omitempty"`
 	IsRevoked  bool       `json:"is_revoked" firestore:"is_revoked"`
 	ExpiresAt  time.Time  `json:"expires_at" firestore:"expires_at"`
 	CreatedAt  time.Time  `json:"created_at" firestore:"created_at"`
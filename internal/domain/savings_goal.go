@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// SavingsGoal is an amount to have saved in an account by a date. Progress
+// is the linked account's balance.
+type SavingsGoal struct {
+	UID        string
+	UserID     string
+	Name       string
+	AccountID  string
+	Target     Money
+	TargetDate time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type SecurityEventType string
+
+const (
+	// SecurityEventRefreshTokenReuse records that an already rotated refresh
+	// token was presented, which means it was most likely stolen.
+	SecurityEventRefreshTokenReuse SecurityEventType = "refresh_token_reuse"
+	// SecurityEventPasskeyCloned records a passkey assertion whose signature
+	// counter did not advance, so a copy of the key may be in use.
+	SecurityEventPasskeyCloned SecurityEventType = "passkey_cloned"
+	// SecurityEventAccountLocked records that failed logins locked the
+	// account and an unlock email was sent.
+	SecurityEventAccountLocked SecurityEventType = "account_locked"
+	// SecurityEventAccountUnlocked records that the unlock email was used.
+	SecurityEventAccountUnlocked SecurityEventType = "account_unlocked"
+)
+
+// SecurityEvent is an audit record of something suspicious on a user's
+// account.
+type SecurityEvent struct {
+	ID        string            `json:"id" firestore:"id"`
+	UserID    string            `json:"user_id" firestore:"user_id"`
+	Type      SecurityEventType `json:"type" firestore:"type"`
+	Details   string            `json:"details,omitempty" firestore:"details,omitempty"`
+	IPAddress string            `json:"ip_address,omitempty" firestore:"ip_address,omitempty"`
+	UserAgent string            `json:"user_agent,omitempty" firestore:"user_agent,omitempty"`
+	CreatedAt time.Time         `json:"created_at" firestore:"created_at"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const MaxTagLength = 64
+
+// NormalizeTag lower-cases tag, trims it and joins inner whitespace with
+// dashes, so "Vacation 2026" and "vacation-2026" are the same tag. It reports
+// whether the result is a usable tag.
+func NormalizeTag(tag string) (string, bool) {
+	tag = strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+		return tag, false
+	}
+	for _, r := range tag {
+		if r == ',' || unicode.IsControl(r) {
+			return tag, false
+		}
+	}
+	return tag, true
+}
+
+// NormalizeTags normalizes every tag and returns them sorted without
+// duplicates. It reports false if any tag is unusable.
+func NormalizeTags(tags []string) ([]string, bool) {
+	if len(tags) == 0 {
+		return nil, true
+	}
+	seen := make(map[string]bool, len(tags))
+	res := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t, ok := NormalizeTag(t)
+		if !ok {
+			return nil, false
+		}
+		if !seen[t] {
+			seen[t] = true
+			res = append(res, t)
+		}
+	}
+	sort.Strings(res)
+	return res, true
+}
+
+// HasTag reports whether tags, as returned by NormalizeTags, contains tag.
+func HasTag(tags []string, tag string) bool {
+	i := sort.SearchStrings(tags, tag)
+	return i < len(tags) && tags[i] == tag
+}
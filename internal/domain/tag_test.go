@@ -0,0 +1,24 @@
+package domain_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	got, ok := domain.NormalizeTags([]string{" Tax Deductible ", "vacation-2026", "tax-deductible", "Vacation\t2026"})
+	if !ok || !reflect.DeepEqual(got, []string{"tax-deductible", "vacation-2026"}) {
+		t.Fatalf("NormalizeTags = %v, %v", got, ok)
+	}
+	for _, bad := range []string{"", "  ", "a,b", strings.Repeat("x", domain.MaxTagLength+1)} {
+		if _, ok := domain.NormalizeTag(bad); ok {
+			t.Errorf("NormalizeTag(%q) accepted", bad)
+		}
+	}
+	if !domain.HasTag(got, "vacation-2026") || domain.HasTag(got, "vacation") {
+		t.Fatalf("HasTag gave wrong answers for %v", got)
+	}
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Transfer moves money between two of a user's accounts. It is neither
+// income nor expense: it changes account balances but not totals.
+//
+// Amount leaves FromAccountID in that account's currency and ToAmount
+// arrives in ToAccountID's currency. Rate is ToAmount per unit of Amount,
+// recorded at the time of the transfer; it is 1 when both currencies match.
+type Transfer struct {
+	UID           string
+	UserID        string
+	FromAccountID string
+	ToAccountID   string
+	Amount        Money
+	ToAmount      Money
+	Rate          float64
+	Notes         string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
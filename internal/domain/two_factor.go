@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// TwoFactor is a user's TOTP enrollment. It is created disabled by enrollment
+// and enabled once the user proves their authenticator works. Only hashes of
+// the recovery codes are kept, and each one can be used once.
+type TwoFactor struct {
+	UserID        string   `json:"user_id" firestore:"user_id"`
+	Secret        string   `json:"secret" firestore:"secret"` // base32 TOTP secret
+	Enabled       bool     `json:"enabled" firestore:"enabled"`
+	RecoveryCodes []string `json:"recovery_codes" firestore:"recovery_codes"`
+	// LastUsedStep is the last accepted TOTP time step, so a code cannot be
+	// replayed within its validity window.
+	LastUsedStep int64      `json:"last_used_step" firestore:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at" firestore:"created_at"`
+	EnabledAt    *time.Time `json:"enabled_at,omitempty" firestore:"enabled_at,omitempty"`
+}
+
+// TwoFactorChallenge is handed out by a password login for a user with 2FA
+// enabled and traded for tokens together with a valid code.
+type TwoFactorChallenge struct {
+	TokenHash string    `json:"token_hash" firestore:"token_hash"`
+	UserID    string    `json:"user_id" firestore:"user_id"`
+	Email     string    `json:"email" firestore:"email"`
+	Attempts  int       `json:"attempts" firestore:"attempts"`
+	ExpiresAt time.Time `json:"expires_at" firestore:"expires_at"`
+	CreatedAt time.Time `json:"created_at" firestore:"created_at"`
+}
@@ -15,6 +15,7 @@ type User struct {
 	ProviderID    string // e.g., "password", "google", "github"
 	PhotoURL      string
 	EmailVerified bool
+	BaseCurrency  string // currency reports are converted into; empty means DefaultCurrency
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
@@ -30,5 +31,14 @@ func NewUser(uid, username, email, firstName, lastName string, phoneNumber *stri
 		CreatedAt:     time.Now().UTC(),
 		UpdatedAt:     time.Now().UTC(),
 		EmailVerified: true,
+		BaseCurrency:  DefaultCurrency,
 	}
 }
+
+// ReportingCurrency returns the currency the user's totals are expressed in.
+func (u *User) ReportingCurrency() string {
+	if u == nil || u.BaseCurrency == "" {
+		return DefaultCurrency
+	}
+	return u.BaseCurrency
+}
@@ -0,0 +1,103 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type AccountRequest struct {
+	Name           string  `json:"name" binding:"required"`
+	Type           string  `json:"type" binding:"omitempty,oneof=checking savings cash credit_card"`
+	Currency       string  `json:"currency,omitempty"`
+	OpeningBalance float64 `json:"opening_balance"`
+}
+
+func (r *AccountRequest) ToInput(userID string) dto.AccountInput {
+	return dto.AccountInput{
+		UserID:         userID,
+		Name:           r.Name,
+		Type:           r.Type,
+		OpeningBalance: toMoney(r.OpeningBalance, r.Currency),
+	}
+}
+
+type AccountResponse struct {
+	UID            string    `json:"uid"`
+	UserID         string    `json:"user_id"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	Currency       string    `json:"currency"`
+	OpeningBalance float64   `json:"opening_balance"`
+	Balance        float64   `json:"balance"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func NewAccountResponse(account *domain.Account) *AccountResponse {
+	if account == nil {
+		return nil
+	}
+	return &AccountResponse{
+		UID:            account.UID,
+		UserID:         account.UserID,
+		Name:           account.Name,
+		Type:           string(account.Type),
+		Currency:       account.Currency,
+		OpeningBalance: account.OpeningBalance.Float64(),
+		Balance:        account.Balance.Float64(),
+		CreatedAt:      account.CreatedAt,
+		UpdatedAt:      account.UpdatedAt,
+	}
+}
+
+type ListAccountResponse struct {
+	Accounts []*AccountResponse `json:"accounts"`
+	Count    int                `json:"count"`
+}
+
+func NewListAccountResponse(accounts []*domain.Account) *ListAccountResponse {
+	resps := make([]*AccountResponse, len(accounts))
+	for i, account := range accounts {
+		resps[i] = NewAccountResponse(account)
+	}
+	return &ListAccountResponse{
+		Accounts: resps,
+		Count:    len(resps),
+	}
+}
+
+type AccountEntryResponse struct {
+	Kind     string    `json:"kind"`
+	UID      string    `json:"uid"`
+	Source   string    `json:"source"`
+	Date     time.Time `json:"date"`
+	Amount   float64   `json:"amount"`
+	Balance  float64   `json:"balance"`
+	Currency string    `json:"currency"`
+}
+
+type ListAccountEntryResponse struct {
+	Entries []*AccountEntryResponse `json:"entries"`
+	Count   int                     `json:"count"`
+}
+
+func NewListAccountEntryResponse(entries []dto.AccountEntry) *ListAccountEntryResponse {
+	resps := make([]*AccountEntryResponse, len(entries))
+	for i, e := range entries {
+		resps[i] = &AccountEntryResponse{
+			Kind:     e.Kind,
+			UID:      e.UID,
+			Source:   e.Source,
+			Date:     e.Date,
+			Amount:   e.Amount.Float64(),
+			Balance:  e.Balance.Float64(),
+			Currency: e.Amount.Currency,
+		}
+	}
+	return &ListAccountEntryResponse{
+		Entries: resps,
+		Count:   len(resps),
+	}
+}
@@ -1,5 +1,12 @@
 package dtos
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
@@ -45,6 +52,103 @@ type SessionInfo struct {
 	IsCurrent  bool   `json:"is_current"`
 }
 
+type UnlockAccountRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
 type RevokeSessionRequest struct {
 	SessionID string `json:"session_id" binding:"required"`
 }
+
+type SecurityEventResponse struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Details   string `json:"details,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ListSecurityEventResponse struct {
+	Events []*SecurityEventResponse `json:"events"`
+	Count  int                      `json:"count"`
+}
+
+func NewListSecurityEventResponse(events []*domain.SecurityEvent) *ListSecurityEventResponse {
+	resps := make([]*SecurityEventResponse, len(events))
+	for i, e := range events {
+		resps[i] = &SecurityEventResponse{
+			ID:        e.ID,
+			Type:      string(e.Type),
+			Details:   e.Details,
+			IPAddress: e.IPAddress,
+			UserAgent: e.UserAgent,
+			CreatedAt: e.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return &ListSecurityEventResponse{
+		Events: resps,
+		Count:  len(resps),
+	}
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type FinishPasskeyRegistrationRequest struct {
+	SessionToken string          `json:"session_token" binding:"required"`
+	Name         string          `json:"name"`
+	Credential   json.RawMessage `json:"credential" binding:"required"`
+}
+
+type FinishPasskeyLoginRequest struct {
+	SessionToken string          `json:"session_token" binding:"required"`
+	Credential   json.RawMessage `json:"credential" binding:"required"`
+}
+
+type PasskeyResponse struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Transports     []string `json:"transports,omitempty"`
+	BackupEligible bool     `json:"backup_eligible"`
+	BackupState    bool     `json:"backup_state"`
+	CreatedAt      string   `json:"created_at"`
+	LastUsedAt     string   `json:"last_used_at,omitempty"`
+}
+
+type ListPasskeyResponse struct {
+	Passkeys []*PasskeyResponse `json:"passkeys"`
+	Count    int                `json:"count"`
+}
+
+func NewPasskeyResponse(p *domain.Passkey) *PasskeyResponse {
+	resp := &PasskeyResponse{
+		ID:             p.ID,
+		Name:           p.Name,
+		Transports:     p.Transports,
+		BackupEligible: p.BackupEligible,
+		BackupState:    p.BackupState,
+		CreatedAt:      p.CreatedAt.Format(time.RFC3339),
+	}
+	if p.LastUsedAt != nil {
+		resp.LastUsedAt = p.LastUsedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func NewListPasskeyResponse(passkeys []*domain.Passkey) *ListPasskeyResponse {
+	resps := make([]*PasskeyResponse, len(passkeys))
+	for i, p := range passkeys {
+		resps[i] = NewPasskeyResponse(p)
+	}
+	return &ListPasskeyResponse{
+		Passkeys: resps,
+		Count:    len(resps),
+	}
+}
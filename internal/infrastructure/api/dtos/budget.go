@@ -0,0 +1,139 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type BudgetRequest struct {
+	CategoryID string  `json:"category_id"`
+	Period     string  `json:"period"`
+	Planned    float64 `json:"planned" binding:"required,gt=0"`
+	Currency   string  `json:"currency,omitempty"`
+}
+
+func (r *BudgetRequest) ToInput(userID string) dto.BudgetInput {
+	return dto.BudgetInput{
+		UserID:     userID,
+		CategoryID: r.CategoryID,
+		Period:     r.Period,
+		Planned:    toMoney(r.Planned, r.Currency),
+	}
+}
+
+type BudgetAllocationRequest struct {
+	CategoryID string  `json:"category_id" binding:"required"`
+	Amount     float64 `json:"amount" binding:"required"`
+	Currency   string  `json:"currency,omitempty"`
+}
+
+func (r *BudgetAllocationRequest) ToInput(userID, period string) dto.BudgetAllocationInput {
+	return dto.BudgetAllocationInput{
+		UserID:     userID,
+		CategoryID: r.CategoryID,
+		Period:     period,
+		Amount:     toMoney(r.Amount, r.Currency),
+	}
+}
+
+type BudgetResponse struct {
+	UID        string    `json:"uid"`
+	UserID     string    `json:"user_id"`
+	CategoryID string    `json:"category_id"`
+	Period     string    `json:"period"`
+	Planned    float64   `json:"planned"`
+	Currency   string    `json:"currency"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func NewBudgetResponse(budget *domain.Budget) *BudgetResponse {
+	if budget == nil {
+		return nil
+	}
+	return &BudgetResponse{
+		UID:        budget.UID,
+		UserID:     budget.UserID,
+		CategoryID: budget.CategoryID,
+		Period:     budget.Period,
+		Planned:    budget.Planned.Float64(),
+		Currency:   budget.Planned.Currency,
+		CreatedAt:  budget.CreatedAt,
+		UpdatedAt:  budget.UpdatedAt,
+	}
+}
+
+type ListBudgetResponse struct {
+	Budgets []*BudgetResponse `json:"budgets"`
+	Count   int               `json:"count"`
+}
+
+func NewListBudgetResponse(budgets []*domain.Budget) *ListBudgetResponse {
+	resps := make([]*BudgetResponse, len(budgets))
+	for i, budget := range budgets {
+		resps[i] = NewBudgetResponse(budget)
+	}
+	return &ListBudgetResponse{
+		Budgets: resps,
+		Count:   len(resps),
+	}
+}
+
+type BudgetLineResponse struct {
+	BudgetID     string  `json:"budget_id"`
+	CategoryID   string  `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Currency     string  `json:"currency"`
+	Planned      float64 `json:"planned"`
+	Spent        float64 `json:"spent"`
+	Remaining    float64 `json:"remaining"`
+	Percentage   float64 `json:"percentage"`
+	Unconverted  bool    `json:"unconverted,omitempty"`
+}
+
+type BudgetPeriodResponse struct {
+	Period       string                `json:"period"`
+	Lines        []*BudgetLineResponse `json:"lines"`
+	Currency     string                `json:"currency"`
+	Carried      float64               `json:"carried"`
+	Received     float64               `json:"received"`
+	Expected     float64               `json:"expected"`
+	Assigned     float64               `json:"assigned"`
+	ToBeAssigned float64               `json:"to_be_assigned"`
+
+	UnconvertedIncomes []string `json:"unconverted_incomes,omitempty"`
+}
+
+func NewBudgetPeriodResponse(view *dto.BudgetPeriodView) *BudgetPeriodResponse {
+	if view == nil {
+		return nil
+	}
+	lines := make([]*BudgetLineResponse, len(view.Lines))
+	for i, l := range view.Lines {
+		lines[i] = &BudgetLineResponse{
+			BudgetID:     l.Budget.UID,
+			CategoryID:   l.Budget.CategoryID,
+			CategoryName: l.CategoryName,
+			Currency:     l.Planned.Currency,
+			Planned:      l.Planned.Float64(),
+			Spent:        l.Spent.Float64(),
+			Remaining:    l.Remaining.Float64(),
+			Percentage:   l.Percentage,
+			Unconverted:  l.Unconverted,
+		}
+	}
+	return &BudgetPeriodResponse{
+		Period:       view.Period,
+		Lines:        lines,
+		Currency:     view.ToBeAssigned.Currency,
+		Carried:      view.Carried.Float64(),
+		Received:     view.Received.Float64(),
+		Expected:     view.Expected.Float64(),
+		Assigned:     view.Assigned.Float64(),
+		ToBeAssigned: view.ToBeAssigned.Float64(),
+
+		UnconvertedIncomes: view.UnconvertedIncomes,
+	}
+}
@@ -0,0 +1,66 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type CategoryRequest struct {
+	Name     string `json:"name" binding:"required"`
+	ParentID string `json:"parent_id,omitempty"`
+	Kind     string `json:"kind,omitempty" binding:"omitempty,oneof=expense income"`
+}
+
+type CategoryResponse struct {
+	UID       string              `json:"uid"`
+	UserID    string              `json:"user_id"`
+	ParentID  string              `json:"parent_id,omitempty"`
+	Name      string              `json:"name"`
+	Kind      string              `json:"kind"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	Children  []*CategoryResponse `json:"children,omitempty"`
+}
+
+func NewCategoryResponse(category *domain.Category) *CategoryResponse {
+	if category == nil {
+		return nil
+	}
+	return &CategoryResponse{
+		UID:       category.UID,
+		UserID:    category.UserID,
+		ParentID:  category.ParentID,
+		Name:      category.Name,
+		Kind:      string(category.Kind),
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+type ListCategoryResponse struct {
+	Categories []*CategoryResponse `json:"categories"`
+	Count      int                 `json:"count"`
+}
+
+// NewCategoryTreeResponse nests every category under its parent. Categories
+// whose parent is missing are listed at the top level.
+func NewCategoryTreeResponse(categories []*domain.Category) *ListCategoryResponse {
+	byID := make(map[string]*CategoryResponse, len(categories))
+	for _, c := range categories {
+		byID[c.UID] = NewCategoryResponse(c)
+	}
+	roots := make([]*CategoryResponse, 0)
+	for _, c := range categories {
+		resp := byID[c.UID]
+		if parent, ok := byID[c.ParentID]; ok {
+			parent.Children = append(parent.Children, resp)
+			continue
+		}
+		roots = append(roots, resp)
+	}
+	return &ListCategoryResponse{
+		Categories: roots,
+		Count:      len(categories),
+	}
+}
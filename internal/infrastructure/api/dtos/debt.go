@@ -0,0 +1,244 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type DebtRequest struct {
+	Name      string  `json:"name" binding:"required"`
+	Kind      string  `json:"kind,omitempty"`
+	Principal float64 `json:"principal" binding:"required,gt=0"`
+	Currency  string  `json:"currency,omitempty"`
+	APR       float64 `json:"apr" binding:"gte=0"`
+	Term      int     `json:"term" binding:"gte=0"`
+	Frequency string  `json:"frequency,omitempty"`
+	Payment   float64 `json:"payment" binding:"gte=0"`
+	StartDate string  `json:"start_date,omitempty"`
+}
+
+// ToInput builds the service input. StartDate defaults to today; an
+// unparseable one is left zero and rejected by the service.
+func (r *DebtRequest) ToInput(userID string) dto.DebtInput {
+	start := time.Now().UTC()
+	if r.StartDate != "" {
+		start, _ = time.Parse("2006-01-02", r.StartDate)
+	}
+	return dto.DebtInput{
+		UserID:    userID,
+		Name:      r.Name,
+		Kind:      r.Kind,
+		Principal: toMoney(r.Principal, r.Currency),
+		APR:       r.APR,
+		Term:      r.Term,
+		Frequency: r.Frequency,
+		Payment:   toMoney(r.Payment, r.Currency),
+		StartDate: start,
+	}
+}
+
+type DebtResponse struct {
+	UID       string    `json:"uid"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	Principal float64   `json:"principal"`
+	Currency  string    `json:"currency"`
+	APR       float64   `json:"apr"`
+	Term      int       `json:"term"`
+	Frequency string    `json:"frequency"`
+	Payment   float64   `json:"payment"`
+	StartDate time.Time `json:"start_date"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewDebtResponse(debt *domain.Debt) *DebtResponse {
+	if debt == nil {
+		return nil
+	}
+	return &DebtResponse{
+		UID:       debt.UID,
+		UserID:    debt.UserID,
+		Name:      debt.Name,
+		Kind:      string(debt.Kind),
+		Principal: debt.Principal.Float64(),
+		Currency:  debt.Principal.Currency,
+		APR:       debt.APR,
+		Term:      debt.Term,
+		Frequency: debt.Frequency,
+		Payment:   debt.Payment.Float64(),
+		StartDate: debt.StartDate,
+		CreatedAt: debt.CreatedAt,
+		UpdatedAt: debt.UpdatedAt,
+	}
+}
+
+type DebtStatusResponse struct {
+	*DebtResponse
+	ScheduledPayment  float64    `json:"scheduled_payment"`
+	Balance           float64    `json:"balance"`
+	Paid              float64    `json:"paid"`
+	Scheduled         float64    `json:"scheduled"`
+	InterestAccrued   float64    `json:"interest_accrued"`
+	RemainingInterest float64    `json:"remaining_interest"`
+	TotalInterest     float64    `json:"total_interest"`
+	PayoffDate        *time.Time `json:"payoff_date"`
+}
+
+func NewDebtStatusResponse(status *dto.DebtStatus) *DebtStatusResponse {
+	if status == nil {
+		return nil
+	}
+	res := &DebtStatusResponse{
+		DebtResponse:      NewDebtResponse(status.Debt),
+		ScheduledPayment:  status.Payment.Float64(),
+		Balance:           status.Balance.Float64(),
+		Paid:              status.Paid.Float64(),
+		Scheduled:         status.Scheduled.Float64(),
+		InterestAccrued:   status.InterestAccrued.Float64(),
+		RemainingInterest: status.RemainingInterest.Float64(),
+		TotalInterest:     status.TotalInterest.Float64(),
+	}
+	if !status.PayoffDate.IsZero() {
+		res.PayoffDate = &status.PayoffDate
+	}
+	return res
+}
+
+type ListDebtStatusResponse struct {
+	Debts []*DebtStatusResponse `json:"debts"`
+	Count int                   `json:"count"`
+}
+
+func NewListDebtStatusResponse(statuses []dto.DebtStatus) *ListDebtStatusResponse {
+	resps := make([]*DebtStatusResponse, len(statuses))
+	for i := range statuses {
+		resps[i] = NewDebtStatusResponse(&statuses[i])
+	}
+	return &ListDebtStatusResponse{
+		Debts: resps,
+		Count: len(resps),
+	}
+}
+
+type DebtInstallmentResponse struct {
+	Number    int       `json:"number"`
+	DueDate   time.Time `json:"due_date"`
+	Payment   float64   `json:"payment"`
+	Principal float64   `json:"principal"`
+	Interest  float64   `json:"interest"`
+	Balance   float64   `json:"balance"`
+	Paid      float64   `json:"paid"`
+	Status    string    `json:"status"`
+}
+
+type DebtScheduleResponse struct {
+	Debt          *DebtResponse              `json:"debt"`
+	Currency      string                     `json:"currency"`
+	TotalInterest float64                    `json:"total_interest"`
+	Installments  []*DebtInstallmentResponse `json:"installments"`
+	Count         int                        `json:"count"`
+}
+
+func NewDebtScheduleResponse(schedule *dto.DebtSchedule) *DebtScheduleResponse {
+	if schedule == nil {
+		return nil
+	}
+	installments := make([]*DebtInstallmentResponse, len(schedule.Installments))
+	for i, inst := range schedule.Installments {
+		installments[i] = &DebtInstallmentResponse{
+			Number:    inst.Number,
+			DueDate:   inst.DueDate,
+			Payment:   inst.Payment.Float64(),
+			Principal: inst.Principal.Float64(),
+			Interest:  inst.Interest.Float64(),
+			Balance:   inst.Balance.Float64(),
+			Paid:      inst.Paid.Float64(),
+			Status:    string(inst.Status),
+		}
+	}
+	return &DebtScheduleResponse{
+		Debt:          NewDebtResponse(schedule.Debt),
+		Currency:      schedule.TotalInterest.Currency,
+		TotalInterest: schedule.TotalInterest.Float64(),
+		Installments:  installments,
+		Count:         len(installments),
+	}
+}
+
+type DebtPayoffLineResponse struct {
+	Order      int       `json:"order"`
+	DebtID     string    `json:"debt_id"`
+	Name       string    `json:"name"`
+	APR        float64   `json:"apr"`
+	Balance    float64   `json:"balance"`
+	Months     int       `json:"months"`
+	PayoffDate time.Time `json:"payoff_date"`
+	Interest   float64   `json:"interest"`
+}
+
+type DebtPayoffPlanResponse struct {
+	Strategy      string                    `json:"strategy"`
+	MonthlyBudget float64                   `json:"monthly_budget"`
+	Currency      string                    `json:"currency"`
+	Months        int                       `json:"months"`
+	PayoffDate    *time.Time                `json:"payoff_date"`
+	TotalInterest float64                   `json:"total_interest"`
+	Debts         []*DebtPayoffLineResponse `json:"debts"`
+}
+
+type ListDebtPayoffPlanResponse struct {
+	Plans []*DebtPayoffPlanResponse `json:"plans"`
+	Count int                       `json:"count"`
+}
+
+func NewListDebtPayoffPlanResponse(plans []dto.DebtPayoffPlan) *ListDebtPayoffPlanResponse {
+	resps := make([]*DebtPayoffPlanResponse, len(plans))
+	for i, plan := range plans {
+		lines := make([]*DebtPayoffLineResponse, len(plan.Debts))
+		for j, line := range plan.Debts {
+			lines[j] = &DebtPayoffLineResponse{
+				Order:      line.Order,
+				DebtID:     line.Debt.UID,
+				Name:       line.Debt.Name,
+				APR:        line.Debt.APR,
+				Balance:    line.Balance.Float64(),
+				Months:     line.Months,
+				PayoffDate: line.PayoffDate,
+				Interest:   line.Interest.Float64(),
+			}
+		}
+		resps[i] = &DebtPayoffPlanResponse{
+			Strategy:      string(plan.Strategy),
+			MonthlyBudget: plan.MonthlyBudget.Float64(),
+			Currency:      plan.MonthlyBudget.Currency,
+			Months:        plan.Months,
+			TotalInterest: plan.TotalInterest.Float64(),
+			Debts:         lines,
+		}
+		if !plan.PayoffDate.IsZero() {
+			resps[i].PayoffDate = &plans[i].PayoffDate
+		}
+	}
+	return &ListDebtPayoffPlanResponse{
+		Plans: resps,
+		Count: len(resps),
+	}
+}
+
+type DebtPayoffRequest struct {
+	Strategy      string  `form:"strategy"`
+	MonthlyBudget float64 `form:"monthly_budget" binding:"gte=0"`
+	Currency      string  `form:"currency"`
+}
+
+func (r *DebtPayoffRequest) ToInput(userID string) dto.DebtPayoffInput {
+	return dto.DebtPayoffInput{
+		UserID:        userID,
+		Strategy:      r.Strategy,
+		MonthlyBudget: toMoney(r.MonthlyBudget, r.Currency),
+	}
+}
@@ -0,0 +1,174 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type EnvelopeRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	CategoryID  string  `json:"category_id"`
+	Allocation  float64 `json:"allocation" binding:"gte=0"`
+	Currency    string  `json:"currency,omitempty"`
+	Rollover    *bool   `json:"rollover,omitempty"`
+	StartPeriod string  `json:"start_period,omitempty"`
+}
+
+// ToInput builds the service input. Rollover defaults to on, which is how
+// envelope budgeting usually works.
+func (r *EnvelopeRequest) ToInput(userID string) dto.EnvelopeInput {
+	rollover := true
+	if r.Rollover != nil {
+		rollover = *r.Rollover
+	}
+	return dto.EnvelopeInput{
+		UserID:      userID,
+		Name:        r.Name,
+		CategoryID:  r.CategoryID,
+		Allocation:  toMoney(r.Allocation, r.Currency),
+		Rollover:    rollover,
+		StartPeriod: r.StartPeriod,
+	}
+}
+
+type EnvelopeResponse struct {
+	UID         string    `json:"uid"`
+	UserID      string    `json:"user_id"`
+	Name        string    `json:"name"`
+	CategoryID  string    `json:"category_id"`
+	Allocation  float64   `json:"allocation"`
+	Currency    string    `json:"currency"`
+	Rollover    bool      `json:"rollover"`
+	StartPeriod string    `json:"start_period"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewEnvelopeResponse(envelope *domain.Envelope) *EnvelopeResponse {
+	if envelope == nil {
+		return nil
+	}
+	return &EnvelopeResponse{
+		UID:         envelope.UID,
+		UserID:      envelope.UserID,
+		Name:        envelope.Name,
+		CategoryID:  envelope.CategoryID,
+		Allocation:  envelope.Allocation.Float64(),
+		Currency:    envelope.Allocation.Currency,
+		Rollover:    envelope.Rollover,
+		StartPeriod: envelope.StartPeriod,
+		CreatedAt:   envelope.CreatedAt,
+		UpdatedAt:   envelope.UpdatedAt,
+	}
+}
+
+type EnvelopeStatusResponse struct {
+	*EnvelopeResponse
+	Period    string  `json:"period"`
+	Carried   float64 `json:"carried"`
+	Allocated float64 `json:"allocated"`
+	MovedIn   float64 `json:"moved_in"`
+	MovedOut  float64 `json:"moved_out"`
+	Spent     float64 `json:"spent"`
+	Available float64 `json:"available"`
+
+	Unconverted bool `json:"unconverted,omitempty"`
+}
+
+func NewEnvelopeStatusResponse(status *dto.EnvelopeStatus) *EnvelopeStatusResponse {
+	if status == nil {
+		return nil
+	}
+	return &EnvelopeStatusResponse{
+		EnvelopeResponse: NewEnvelopeResponse(status.Envelope),
+		Period:           status.Period,
+		Carried:          status.Carried.Float64(),
+		Allocated:        status.Allocated.Float64(),
+		MovedIn:          status.MovedIn.Float64(),
+		MovedOut:         status.MovedOut.Float64(),
+		Spent:            status.Spent.Float64(),
+		Available:        status.Available.Float64(),
+		Unconverted:      status.Unconverted,
+	}
+}
+
+type ListEnvelopeStatusResponse struct {
+	Envelopes []*EnvelopeStatusResponse `json:"envelopes"`
+	Count     int                       `json:"count"`
+}
+
+func NewListEnvelopeStatusResponse(statuses []dto.EnvelopeStatus) *ListEnvelopeStatusResponse {
+	resps := make([]*EnvelopeStatusResponse, len(statuses))
+	for i := range statuses {
+		resps[i] = NewEnvelopeStatusResponse(&statuses[i])
+	}
+	return &ListEnvelopeStatusResponse{
+		Envelopes: resps,
+		Count:     len(resps),
+	}
+}
+
+type EnvelopeMoveRequest struct {
+	FromEnvelopeID string  `json:"from_envelope_id" binding:"required"`
+	ToEnvelopeID   string  `json:"to_envelope_id" binding:"required"`
+	Amount         float64 `json:"amount" binding:"required,gt=0"`
+	Currency       string  `json:"currency,omitempty"`
+	Period         string  `json:"period,omitempty"`
+	Notes          string  `json:"notes,omitempty"`
+}
+
+func (r *EnvelopeMoveRequest) ToInput(userID string) dto.EnvelopeMoveInput {
+	return dto.EnvelopeMoveInput{
+		UserID:         userID,
+		FromEnvelopeID: r.FromEnvelopeID,
+		ToEnvelopeID:   r.ToEnvelopeID,
+		Period:         r.Period,
+		Amount:         toMoney(r.Amount, r.Currency),
+		Notes:          r.Notes,
+	}
+}
+
+type EnvelopeMoveResponse struct {
+	UID            string    `json:"uid"`
+	FromEnvelopeID string    `json:"from_envelope_id"`
+	ToEnvelopeID   string    `json:"to_envelope_id"`
+	Period         string    `json:"period"`
+	Amount         float64   `json:"amount"`
+	Currency       string    `json:"currency"`
+	Notes          string    `json:"notes"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func NewEnvelopeMoveResponse(move *domain.EnvelopeMove) *EnvelopeMoveResponse {
+	if move == nil {
+		return nil
+	}
+	return &EnvelopeMoveResponse{
+		UID:            move.UID,
+		FromEnvelopeID: move.FromEnvelopeID,
+		ToEnvelopeID:   move.ToEnvelopeID,
+		Period:         move.Period,
+		Amount:         move.Amount.Float64(),
+		Currency:       move.Amount.Currency,
+		Notes:          move.Notes,
+		CreatedAt:      move.CreatedAt,
+	}
+}
+
+type ListEnvelopeMoveResponse struct {
+	Moves []*EnvelopeMoveResponse `json:"moves"`
+	Count int                     `json:"count"`
+}
+
+func NewListEnvelopeMoveResponse(moves []*domain.EnvelopeMove) *ListEnvelopeMoveResponse {
+	resps := make([]*EnvelopeMoveResponse, len(moves))
+	for i, move := range moves {
+		resps[i] = NewEnvelopeMoveResponse(move)
+	}
+	return &ListEnvelopeMoveResponse{
+		Moves: resps,
+		Count: len(resps),
+	}
+}
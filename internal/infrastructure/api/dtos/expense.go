@@ -7,13 +7,26 @@ import (
 )
 
 type AddExpenseRequest struct {
-	Source              string  `json:"source" binding:"required"`
-	Amount              float64 `json:"amount" binding:"required,gt=0"`
-	Currency            string  `json:"currency,omitempty"`
-	Notes               string  `json:"notes,omitempty"`
-	IsRecurring         bool    `json:"is_recurring,omitempty"`
-	RecurrenceFrequency string  `json:"recurrence_frequency,omitempty"`
-	NextOccurrenceDate  string  `json:"next_occurrence_date,omitempty"`
+	Source              string                `json:"source" binding:"required"`
+	AccountID           string                `json:"account_id,omitempty"`
+	CategoryID          string                `json:"category_id,omitempty"`
+	DebtID              string                `json:"debt_id,omitempty"`
+	Tags                []string              `json:"tags,omitempty"`
+	Amount              float64               `json:"amount" binding:"required,gt=0"`
+	Currency            string                `json:"currency,omitempty"`
+	Splits              []ExpenseSplitRequest `json:"splits,omitempty" binding:"omitempty,dive"`
+	Notes               string                `json:"notes,omitempty"`
+	IsRecurring         bool                  `json:"is_recurring,omitempty"`
+	RecurrenceFrequency string                `json:"recurrence_frequency,omitempty"`
+	NextOccurrenceDate  string                `json:"next_occurrence_date,omitempty"`
+}
+
+// ExpenseSplitRequest is one category's part of a split expense, in the
+// expense's currency.
+type ExpenseSplitRequest struct {
+	CategoryID string  `json:"category_id"`
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+	Notes      string  `json:"notes,omitempty"`
 }
 
 func (r *AddExpenseRequest) ToDomain() *domain.Expense {
@@ -25,8 +38,12 @@ func (r *AddExpenseRequest) ToDomain() *domain.Expense {
 
 	expense := &domain.Expense{
 		Source:              r.Source,
-		Amount:              r.Amount,
-		Currency:            r.Currency,
+		AccountID:           r.AccountID,
+		CategoryID:          r.CategoryID,
+		DebtID:              r.DebtID,
+		Tags:                r.Tags,
+		Amount:              toMoney(r.Amount, r.Currency),
+		Splits:              toSplits(r.Splits, r.Currency),
 		Notes:               r.Notes,
 		IsRecurring:         r.IsRecurring,
 		RecurrenceFrequency: r.RecurrenceFrequency,
@@ -36,30 +53,55 @@ func (r *AddExpenseRequest) ToDomain() *domain.Expense {
 	return expense
 }
 
+func toSplits(splits []ExpenseSplitRequest, currency string) []domain.ExpenseSplit {
+	if len(splits) == 0 {
+		return nil
+	}
+	res := make([]domain.ExpenseSplit, len(splits))
+	for i, s := range splits {
+		res[i] = domain.ExpenseSplit{CategoryID: s.CategoryID, Amount: toMoney(s.Amount, currency), Notes: s.Notes}
+	}
+	return res
+}
+
 type ExpenseResponse struct {
-	UID                 string    `json:"uid"`
-	UserID              string    `json:"user_id"`
-	Source              string    `json:"source"`
-	Amount              float64   `json:"amount"`
-	Currency            string    `json:"currency,omitempty"`
-	Notes               string    `json:"notes,omitempty"`
-	IsRecurring         bool      `json:"is_recurring"`
-	RecurrenceFrequency string    `json:"recurrence_frequency,omitempty"`
-	NextOccurrenceDate  time.Time `json:"next_occurrence_date,omitempty"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	UID                 string                  `json:"uid"`
+	UserID              string                  `json:"user_id"`
+	Source              string                  `json:"source"`
+	AccountID           string                  `json:"account_id,omitempty"`
+	CategoryID          string                  `json:"category_id,omitempty"`
+	DebtID              string                  `json:"debt_id,omitempty"`
+	Tags                []string                `json:"tags,omitempty"`
+	Amount              float64                 `json:"amount"`
+	Currency            string                  `json:"currency,omitempty"`
+	Splits              []*ExpenseSplitResponse `json:"splits,omitempty"`
+	Notes               string                  `json:"notes,omitempty"`
+	IsRecurring         bool                    `json:"is_recurring"`
+	RecurrenceFrequency string                  `json:"recurrence_frequency,omitempty"`
+	NextOccurrenceDate  time.Time               `json:"next_occurrence_date,omitempty"`
+	CreatedAt           time.Time               `json:"created_at"`
+	UpdatedAt           time.Time               `json:"updated_at"`
 }
 
 func NewExpenseResponse(expense *domain.Expense) *ExpenseResponse {
 	if expense == nil {
 		return nil
 	}
+	var splits []*ExpenseSplitResponse
+	for _, s := range expense.Splits {
+		splits = append(splits, &ExpenseSplitResponse{CategoryID: s.CategoryID, Amount: s.Amount.Float64(), Notes: s.Notes})
+	}
 	return &ExpenseResponse{
 		UID:                 expense.UID,
 		UserID:              expense.UserID,
 		Source:              expense.Source,
-		Amount:              expense.Amount,
-		Currency:            expense.Currency,
+		AccountID:           expense.AccountID,
+		CategoryID:          expense.CategoryID,
+		DebtID:              expense.DebtID,
+		Tags:                expense.Tags,
+		Amount:              expense.Amount.Float64(),
+		Currency:            expense.Amount.Currency,
+		Splits:              splits,
 		Notes:               expense.Notes,
 		IsRecurring:         expense.IsRecurring,
 		RecurrenceFrequency: expense.RecurrenceFrequency,
@@ -69,6 +111,12 @@ func NewExpenseResponse(expense *domain.Expense) *ExpenseResponse {
 	}
 }
 
+type ExpenseSplitResponse struct {
+	CategoryID string  `json:"category_id,omitempty"`
+	Amount     float64 `json:"amount"`
+	Notes      string  `json:"notes,omitempty"`
+}
+
 type ListExpenseResponse struct {
 	Expenses []*ExpenseResponse `json:"expenses"`
 	Count    int                `json:"count"`
@@ -0,0 +1,149 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type HoldingRequest struct {
+	Name     string  `json:"name" binding:"required"`
+	Kind     string  `json:"kind" binding:"required"`
+	Category string  `json:"category"`
+	Currency string  `json:"currency,omitempty"`
+	Value    float64 `json:"value" binding:"gte=0"`
+	ValuedAt string  `json:"valued_at"`
+}
+
+// ToInput builds the service input. The value stays in major units until
+// the service knows the holding's currency. An unparseable valuation date
+// is left zero and taken as today.
+func (r *HoldingRequest) ToInput(userID string) dto.HoldingInput {
+	valuedAt, _ := time.Parse("2006-01-02", r.ValuedAt)
+	return dto.HoldingInput{
+		UserID:   userID,
+		Name:     r.Name,
+		Kind:     r.Kind,
+		Category: r.Category,
+		Currency: r.Currency,
+		Value:    r.Value,
+		ValuedAt: valuedAt,
+	}
+}
+
+type HoldingListQuery struct {
+	Kind string `form:"kind"`
+}
+
+type HoldingResponse struct {
+	UID       string     `json:"uid"`
+	UserID    string     `json:"user_id"`
+	Name      string     `json:"name"`
+	Kind      string     `json:"kind"`
+	Category  string     `json:"category"`
+	Currency  string     `json:"currency"`
+	Value     float64    `json:"value"`
+	ValuedAt  *time.Time `json:"valued_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func NewHoldingResponse(status *dto.HoldingStatus) *HoldingResponse {
+	if status == nil || status.Holding == nil {
+		return nil
+	}
+	holding := status.Holding
+	res := &HoldingResponse{
+		UID:       holding.UID,
+		UserID:    holding.UserID,
+		Name:      holding.Name,
+		Kind:      string(holding.Kind),
+		Category:  string(holding.Category),
+		Currency:  holding.Currency,
+		Value:     status.Value.Float64(),
+		CreatedAt: holding.CreatedAt,
+		UpdatedAt: holding.UpdatedAt,
+	}
+	if !status.ValuedAt.IsZero() {
+		res.ValuedAt = &status.ValuedAt
+	}
+	return res
+}
+
+type ListHoldingResponse struct {
+	Holdings []*HoldingResponse `json:"holdings"`
+	Count    int                `json:"count"`
+}
+
+func NewListHoldingResponse(statuses []dto.HoldingStatus) *ListHoldingResponse {
+	resps := make([]*HoldingResponse, len(statuses))
+	for i := range statuses {
+		resps[i] = NewHoldingResponse(&statuses[i])
+	}
+	return &ListHoldingResponse{
+		Holdings: resps,
+		Count:    len(resps),
+	}
+}
+
+type ValuationRequest struct {
+	Value    float64 `json:"value" binding:"gte=0"`
+	Currency string  `json:"currency,omitempty"`
+	Date     string  `json:"date"`
+	Notes    string  `json:"notes"`
+}
+
+// ToInput builds the service input. See HoldingRequest.ToInput.
+func (r *ValuationRequest) ToInput(userID, holdingID string) dto.ValuationInput {
+	date, _ := time.Parse("2006-01-02", r.Date)
+	return dto.ValuationInput{
+		UserID:    userID,
+		HoldingID: holdingID,
+		Value:     r.Value,
+		Currency:  r.Currency,
+		Date:      date,
+		Notes:     r.Notes,
+	}
+}
+
+type ValuationResponse struct {
+	UID       string    `json:"uid"`
+	HoldingID string    `json:"holding_id"`
+	Value     float64   `json:"value"`
+	Currency  string    `json:"currency"`
+	Date      time.Time `json:"date"`
+	Notes     string    `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewValuationResponse(v *domain.Valuation) *ValuationResponse {
+	if v == nil {
+		return nil
+	}
+	return &ValuationResponse{
+		UID:       v.UID,
+		HoldingID: v.HoldingID,
+		Value:     v.Value.Float64(),
+		Currency:  v.Value.Currency,
+		Date:      v.Date,
+		Notes:     v.Notes,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+type ListValuationResponse struct {
+	Valuations []*ValuationResponse `json:"valuations"`
+	Count      int                  `json:"count"`
+}
+
+func NewListValuationResponse(valuations []*domain.Valuation) *ListValuationResponse {
+	resps := make([]*ValuationResponse, len(valuations))
+	for i, v := range valuations {
+		resps[i] = NewValuationResponse(v)
+	}
+	return &ListValuationResponse{
+		Valuations: resps,
+		Count:      len(resps),
+	}
+}
@@ -7,30 +7,38 @@ import (
 )
 
 type AddIncomeRequest struct {
-	Source   string  `json:"source" binding:"required"`
-	Amount   float64 `json:"amount" binding:"required,gt=0"`
-	Currency string  `json:"currency,omitempty"`
-	Notes    string  `json:"notes,omitempty"`
+	Source     string   `json:"source" binding:"required"`
+	AccountID  string   `json:"account_id,omitempty"`
+	CategoryID string   `json:"category_id,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Amount     float64  `json:"amount" binding:"required,gt=0"`
+	Currency   string   `json:"currency,omitempty"`
+	Notes      string   `json:"notes,omitempty"`
 }
 
 func (r *AddIncomeRequest) ToDomain() *domain.Income {
 	return &domain.Income{
-		Source:   r.Source,
-		Amount:   r.Amount,
-		Currency: r.Currency,
-		Notes:    r.Notes,
+		Source:     r.Source,
+		AccountID:  r.AccountID,
+		CategoryID: r.CategoryID,
+		Tags:       r.Tags,
+		Amount:     toMoney(r.Amount, r.Currency),
+		Notes:      r.Notes,
 	}
 }
 
 type IncomeResponse struct {
-	UID       string    `json:"uid"`
-	UserID    string    `json:"user_id"`
-	Source    string    `json:"source"`
-	Amount    float64   `json:"amount"`
-	Currency  string    `json:"currency,omitempty"`
-	Notes     string    `json:"notes,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	UID        string    `json:"uid"`
+	UserID     string    `json:"user_id"`
+	Source     string    `json:"source"`
+	AccountID  string    `json:"account_id,omitempty"`
+	CategoryID string    `json:"category_id,omitempty"`
+	Tags       []string  `json:"tags,omitempty"`
+	Amount     float64   `json:"amount"`
+	Currency   string    `json:"currency,omitempty"`
+	Notes      string    `json:"notes,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 func NewIncomeResponse(income *domain.Income) *IncomeResponse {
@@ -38,14 +46,17 @@ func NewIncomeResponse(income *domain.Income) *IncomeResponse {
 		return nil
 	}
 	return &IncomeResponse{
-		UID:       income.UID,
-		UserID:    income.UserID,
-		Source:    income.Source,
-		Amount:    income.Amount,
-		Currency:  income.Currency,
-		Notes:     income.Notes,
-		CreatedAt: income.CreatedAt,
-		UpdatedAt: income.UpdatedAt,
+		UID:        income.UID,
+		UserID:     income.UserID,
+		Source:     income.Source,
+		AccountID:  income.AccountID,
+		CategoryID: income.CategoryID,
+		Tags:       income.Tags,
+		Amount:     income.Amount.Float64(),
+		Currency:   income.Amount.Currency,
+		Notes:      income.Notes,
+		CreatedAt:  income.CreatedAt,
+		UpdatedAt:  income.UpdatedAt,
 	}
 }
 
@@ -72,8 +83,7 @@ type AddIncomeSourceRequest struct {
 func (r *AddIncomeSourceRequest) ToDomain() *domain.IncomeSource {
 	return &domain.IncomeSource{
 		Source:    r.Source,
-		Amount:    r.Amount,
-		Currency:  r.Currency,
+		Amount:    toMoney(r.Amount, r.Currency),
 		Frequency: r.Frequency,
 		Notes:     r.Notes,
 	}
@@ -106,8 +116,8 @@ func NewIncomeSourceResponse(source *domain.IncomeSource) *IncomeSourceResponse
 		UID:       source.UID,
 		UserID:    source.UserID,
 		Source:    source.Source,
-		Amount:    source.Amount,
-		Currency:  source.Currency,
+		Amount:    source.Amount.Float64(),
+		Currency:  source.Amount.Currency,
 		Frequency: source.Frequency,
 		NextPayAt: source.NextPayAt,
 		Active:    source.Active,
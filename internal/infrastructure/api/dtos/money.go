@@ -0,0 +1,12 @@
+package dtos
+
+import "github.com/theHinneh/budgeting/internal/domain"
+
+// toMoney converts a JSON amount in major units to Money. The currency has to
+// be known first because it decides how many decimals are kept.
+func toMoney(amount float64, currency string) domain.Money {
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	return domain.MoneyFromFloat(amount, currency)
+}
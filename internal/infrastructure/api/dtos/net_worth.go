@@ -0,0 +1,89 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+)
+
+type NetWorthHistoryQuery struct {
+	From     string `form:"from"`
+	To       string `form:"to"`
+	Interval string `form:"interval"`
+}
+
+// ToInput builds the service input. Dates are YYYY-MM-DD; an empty bound
+// takes the service default.
+func (q *NetWorthHistoryQuery) ToInput(userID string) (dto.NetWorthHistoryInput, error) {
+	in := dto.NetWorthHistoryInput{UserID: userID, Interval: q.Interval}
+	var err error
+	if q.From != "" {
+		if in.From, err = time.Parse("2006-01-02", q.From); err != nil {
+			return in, err
+		}
+	}
+	if q.To != "" {
+		if in.To, err = time.Parse("2006-01-02", q.To); err != nil {
+			return in, err
+		}
+	}
+	return in, nil
+}
+
+type AccountBalanceResponse struct {
+	AccountID string  `json:"account_id"`
+	Name      string  `json:"name"`
+	Balance   float64 `json:"balance"`
+	Currency  string  `json:"currency"`
+}
+
+type NetWorthPointResponse struct {
+	Date             string                    `json:"date"`
+	TotalAssets      float64                   `json:"total_assets"`
+	TotalLiabilities float64                   `json:"total_liabilities"`
+	NetWorth         float64                   `json:"net_worth"`
+	Currency         string                    `json:"currency"`
+	Accounts         []*AccountBalanceResponse `json:"accounts"`
+}
+
+type NetWorthHistoryResponse struct {
+	From     string                   `json:"from"`
+	To       string                   `json:"to"`
+	Interval string                   `json:"interval"`
+	Points   []*NetWorthPointResponse `json:"points"`
+	Count    int                      `json:"count"`
+}
+
+func NewNetWorthHistoryResponse(history *dto.NetWorthHistory) (*NetWorthHistoryResponse, error) {
+	points := make([]*NetWorthPointResponse, len(history.Snapshots))
+	for i, s := range history.Snapshots {
+		netWorth, err := s.NetWorth()
+		if err != nil {
+			return nil, err
+		}
+		accounts := make([]*AccountBalanceResponse, len(s.Accounts))
+		for j, a := range s.Accounts {
+			accounts[j] = &AccountBalanceResponse{
+				AccountID: a.AccountID,
+				Name:      a.Name,
+				Balance:   a.Balance.Float64(),
+				Currency:  a.Balance.Currency,
+			}
+		}
+		points[i] = &NetWorthPointResponse{
+			Date:             s.Date.Format("2006-01-02"),
+			TotalAssets:      s.TotalAssets.Float64(),
+			TotalLiabilities: s.TotalLiabilities.Float64(),
+			NetWorth:         netWorth.Float64(),
+			Currency:         s.TotalAssets.Currency,
+			Accounts:         accounts,
+		}
+	}
+	return &NetWorthHistoryResponse{
+		From:     history.From.Format("2006-01-02"),
+		To:       history.To.Format("2006-01-02"),
+		Interval: string(history.Interval),
+		Points:   points,
+		Count:    len(points),
+	}, nil
+}
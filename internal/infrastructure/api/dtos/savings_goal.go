@@ -0,0 +1,114 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+)
+
+type SavingsGoalRequest struct {
+	Name       string  `json:"name" binding:"required"`
+	AccountID  string  `json:"account_id"`
+	Target     float64 `json:"target" binding:"required,gt=0"`
+	Currency   string  `json:"currency,omitempty"`
+	TargetDate string  `json:"target_date" binding:"required"`
+}
+
+// ToInput builds the service input. An unparseable target date is left
+// zero and rejected by the service.
+func (r *SavingsGoalRequest) ToInput(userID string) dto.SavingsGoalInput {
+	targetDate, _ := time.Parse("2006-01-02", r.TargetDate)
+	return dto.SavingsGoalInput{
+		UserID:     userID,
+		Name:       r.Name,
+		AccountID:  r.AccountID,
+		Target:     toMoney(r.Target, r.Currency),
+		TargetDate: targetDate,
+	}
+}
+
+type SavingsGoalResponse struct {
+	UID             string    `json:"uid"`
+	UserID          string    `json:"user_id"`
+	Name            string    `json:"name"`
+	AccountID       string    `json:"account_id"`
+	Target          float64   `json:"target"`
+	Currency        string    `json:"currency"`
+	TargetDate      time.Time `json:"target_date"`
+	Saved           float64   `json:"saved"`
+	Remaining       float64   `json:"remaining"`
+	Percentage      float64   `json:"percentage"`
+	MonthsLeft      int       `json:"months_left"`
+	RequiredMonthly float64   `json:"required_monthly"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func NewSavingsGoalResponse(status *dto.SavingsGoalStatus) *SavingsGoalResponse {
+	if status == nil || status.Goal == nil {
+		return nil
+	}
+	goal := status.Goal
+	return &SavingsGoalResponse{
+		UID:             goal.UID,
+		UserID:          goal.UserID,
+		Name:            goal.Name,
+		AccountID:       goal.AccountID,
+		Target:          goal.Target.Float64(),
+		Currency:        goal.Target.Currency,
+		TargetDate:      goal.TargetDate,
+		Saved:           status.Saved.Float64(),
+		Remaining:       status.Remaining.Float64(),
+		Percentage:      status.Percentage,
+		MonthsLeft:      status.MonthsLeft,
+		RequiredMonthly: status.RequiredMonthly.Float64(),
+		CreatedAt:       goal.CreatedAt,
+		UpdatedAt:       goal.UpdatedAt,
+	}
+}
+
+type ListSavingsGoalResponse struct {
+	Goals []*SavingsGoalResponse `json:"goals"`
+	Count int                    `json:"count"`
+}
+
+func NewListSavingsGoalResponse(statuses []dto.SavingsGoalStatus) *ListSavingsGoalResponse {
+	resps := make([]*SavingsGoalResponse, len(statuses))
+	for i := range statuses {
+		resps[i] = NewSavingsGoalResponse(&statuses[i])
+	}
+	return &ListSavingsGoalResponse{
+		Goals: resps,
+		Count: len(resps),
+	}
+}
+
+type SavingsGoalProgressResponse struct {
+	Period      string  `json:"period"`
+	Contributed float64 `json:"contributed"`
+	Saved       float64 `json:"saved"`
+	Percentage  float64 `json:"percentage"`
+	Currency    string  `json:"currency"`
+}
+
+type ListSavingsGoalProgressResponse struct {
+	History []*SavingsGoalProgressResponse `json:"history"`
+	Count   int                            `json:"count"`
+}
+
+func NewListSavingsGoalProgressResponse(history []dto.SavingsGoalProgress) *ListSavingsGoalProgressResponse {
+	resps := make([]*SavingsGoalProgressResponse, len(history))
+	for i, p := range history {
+		resps[i] = &SavingsGoalProgressResponse{
+			Period:      p.Period,
+			Contributed: p.Contributed.Float64(),
+			Saved:       p.Saved.Float64(),
+			Percentage:  p.Percentage,
+			Currency:    p.Saved.Currency,
+		}
+	}
+	return &ListSavingsGoalProgressResponse{
+		History: resps,
+		Count:   len(resps),
+	}
+}
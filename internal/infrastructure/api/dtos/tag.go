@@ -0,0 +1,34 @@
+package dtos
+
+import "github.com/theHinneh/budgeting/internal/application/dto"
+
+type RenameTagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type MergeTagsRequest struct {
+	From []string `json:"from" binding:"required,min=1"`
+	Into string   `json:"into" binding:"required"`
+}
+
+type TagResponse struct {
+	Tag      string `json:"tag"`
+	Expenses int    `json:"expenses"`
+	Incomes  int    `json:"incomes"`
+}
+
+type ListTagResponse struct {
+	Tags  []*TagResponse `json:"tags"`
+	Count int            `json:"count"`
+}
+
+func NewListTagResponse(tags []dto.TagUsage) *ListTagResponse {
+	resps := make([]*TagResponse, len(tags))
+	for i, t := range tags {
+		resps[i] = &TagResponse{Tag: t.Tag, Expenses: t.Expenses, Incomes: t.Incomes}
+	}
+	return &ListTagResponse{
+		Tags:  resps,
+		Count: len(resps),
+	}
+}
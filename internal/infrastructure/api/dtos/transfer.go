@@ -0,0 +1,85 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type TransferRequest struct {
+	FromAccountID string  `json:"from_account_id" binding:"required"`
+	ToAccountID   string  `json:"to_account_id" binding:"required"`
+	Amount        float64 `json:"amount" binding:"required,gt=0"`
+	Currency      string  `json:"currency,omitempty"`
+	ToAmount      float64 `json:"to_amount,omitempty" binding:"gte=0"`
+	ToCurrency    string  `json:"to_currency,omitempty"`
+	Rate          float64 `json:"rate,omitempty" binding:"gte=0"`
+	Notes         string  `json:"notes,omitempty"`
+}
+
+func (r *TransferRequest) ToInput(userID string) dto.TransferInput {
+	in := dto.TransferInput{
+		UserID:        userID,
+		FromAccountID: r.FromAccountID,
+		ToAccountID:   r.ToAccountID,
+		Amount:        toMoney(r.Amount, r.Currency),
+		Rate:          r.Rate,
+		Notes:         r.Notes,
+	}
+	if r.ToAmount > 0 {
+		in.ToAmount = toMoney(r.ToAmount, r.ToCurrency)
+	}
+	return in
+}
+
+type TransferResponse struct {
+	UID           string    `json:"uid"`
+	UserID        string    `json:"user_id"`
+	FromAccountID string    `json:"from_account_id"`
+	ToAccountID   string    `json:"to_account_id"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	ToAmount      float64   `json:"to_amount"`
+	ToCurrency    string    `json:"to_currency"`
+	Rate          float64   `json:"rate"`
+	Notes         string    `json:"notes,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func NewTransferResponse(transfer *domain.Transfer) *TransferResponse {
+	if transfer == nil {
+		return nil
+	}
+	return &TransferResponse{
+		UID:           transfer.UID,
+		UserID:        transfer.UserID,
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        transfer.Amount.Float64(),
+		Currency:      transfer.Amount.Currency,
+		ToAmount:      transfer.ToAmount.Float64(),
+		ToCurrency:    transfer.ToAmount.Currency,
+		Rate:          transfer.Rate,
+		Notes:         transfer.Notes,
+		CreatedAt:     transfer.CreatedAt,
+		UpdatedAt:     transfer.UpdatedAt,
+	}
+}
+
+type ListTransferResponse struct {
+	Transfers []*TransferResponse `json:"transfers"`
+	Count     int                 `json:"count"`
+}
+
+func NewListTransferResponse(transfers []*domain.Transfer) *ListTransferResponse {
+	resps := make([]*TransferResponse, len(transfers))
+	for i, transfer := range transfers {
+		resps[i] = NewTransferResponse(transfer)
+	}
+	return &ListTransferResponse{
+		Transfers: resps,
+		Count:     len(resps),
+	}
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+)
+
+type AccountHandler struct {
+	accountService ports.AccountServicePort
+	cfg            *config.Configuration
+}
+
+func NewAccountHandler(accountService ports.AccountServicePort, cfg *config.Configuration) *AccountHandler {
+	if accountService == nil || cfg == nil {
+		return nil
+	}
+	return &AccountHandler{accountService: accountService, cfg: cfg}
+}
+
+func (h *AccountHandler) CreateAccount(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "create account")
+	if !ok {
+		return
+	}
+
+	var req dtos.AccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	account, err := h.accountService.CreateAccount(c.Request.Context(), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to create account", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessWithStatusResponse(c, http.StatusCreated, "Account created successfully", dtos.NewAccountResponse(account))
+}
+
+func (h *AccountHandler) ListAccounts(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list accounts")
+	if !ok {
+		return
+	}
+
+	accounts, err := h.accountService.ListAccounts(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list accounts", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListAccountResponse(accounts))
+}
+
+func (h *AccountHandler) GetAccount(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get account")
+	if !ok {
+		return
+	}
+
+	account, err := h.accountService.GetAccount(c.Request.Context(), userID, c.Param("accountID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get account", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewAccountResponse(account))
+}
+
+func (h *AccountHandler) UpdateAccount(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "update account")
+	if !ok {
+		return
+	}
+
+	var req dtos.AccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	account, err := h.accountService.UpdateAccount(c.Request.Context(), userID, c.Param("accountID"), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to update account", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewAccountResponse(account))
+}
+
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "delete account")
+	if !ok {
+		return
+	}
+
+	accountID := c.Param("accountID")
+	if err := h.accountService.DeleteAccount(c.Request.Context(), userID, accountID); err != nil {
+		response.ErrorResponse(c, "Failed to delete account", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Account deleted successfully", gin.H{"user_id": userID, "account_id": accountID})
+}
+
+func (h *AccountHandler) ListAccountTransactions(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list account transactions")
+	if !ok {
+		return
+	}
+
+	entries, err := h.accountService.ListAccountLedger(c.Request.Context(), userID, c.Param("accountID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list account transactions", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListAccountEntryResponse(entries))
+}
@@ -1,28 +1,32 @@
 package http
 
 import (
-	"context"
+	"errors"
+	"strconv"
+	"strings"
 	"time"
 
-	firebase "firebase.google.com/go/v4"
 	"github.com/gin-gonic/gin"
 	"github.com/theHinneh/budgeting/internal/application/ports"
 	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
 	"github.com/theHinneh/budgeting/internal/infrastructure/config"
 	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type AuthHandler struct {
-	firebaseApp *firebase.App
+	tokenAuth   ports.TokenAuthenticator
 	authService ports.AuthServicePort
+	userService ports.UserServicePort
 	cfg         *config.Configuration
 }
 
-func NewAuthHandler(app *firebase.App, authService ports.AuthServicePort, cfg *config.Configuration) *AuthHandler {
-	if app == nil || authService == nil || cfg == nil {
+func NewAuthHandler(tokenAuth ports.TokenAuthenticator, authService ports.AuthServicePort, userService ports.UserServicePort, cfg *config.Configuration) *AuthHandler {
+	if tokenAuth == nil || authService == nil || userService == nil || cfg == nil {
 		return nil
 	}
-	return &AuthHandler{firebaseApp: app, authService: authService, cfg: cfg}
+	return &AuthHandler{tokenAuth: tokenAuth, authService: authService, userService: userService, cfg: cfg}
 }
 
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -38,13 +42,45 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	loginResponse, err := h.authService.Login(c.Request.Context(), req.Email, req.Password, deviceInfo, ipAddress, userAgent)
 	if err != nil {
-		response.ErrorResponse(c, "Login failed", err, h.cfg.IsDevelopment())
+		if h.loginThrottled(c, err) {
+			return
+		}
+		response.UnauthorizedResponse(c, "Login failed", err, h.cfg.IsDevelopment())
 		return
 	}
 
 	response.SuccessResponseData(c, loginResponse)
 }
 
+// loginThrottled answers with 429 and a Retry-After header if err says that
+// failed logins are holding the caller back.
+func (h *AuthHandler) loginThrottled(c *gin.Context, err error) bool {
+	if status.Code(err) != codes.ResourceExhausted {
+		return false
+	}
+	var throttled interface{ RetryAfter() time.Duration }
+	if errors.As(err, &throttled) {
+		c.Header("Retry-After", strconv.Itoa(int(throttled.RetryAfter().Seconds())))
+	}
+	response.TooManyRequestsResponse(c, "Too many failed login attempts", err, h.cfg.IsDevelopment())
+	return true
+}
+
+func (h *AuthHandler) UnlockAccount(c *gin.Context) {
+	var req dtos.UnlockAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	if err := h.authService.UnlockAccount(c.Request.Context(), req.Token, c.ClientIP(), c.GetHeader("User-Agent")); err != nil {
+		response.ErrorResponse(c, "Failed to unlock account", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponse(c, "Account unlocked", gin.H{})
+}
+
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req dtos.RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -58,7 +94,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	refreshResponse, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken, deviceInfo, ipAddress, userAgent)
 	if err != nil {
-		response.ErrorResponse(c, "Token refresh failed", err, h.cfg.IsDevelopment())
+		response.UnauthorizedResponse(c, "Token refresh failed", err, h.cfg.IsDevelopment())
 		return
 	}
 
@@ -72,19 +108,14 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	authClient, err := h.firebaseApp.Auth(context.Background())
-	if err != nil {
-		response.ErrorResponse(c, "Failed to get Firebase Auth client", err, h.cfg.IsDevelopment())
-		return
-	}
-
-	token, err := authClient.VerifyIDToken(c.Request.Context(), req.RefreshToken)
-	if err != nil {
-		response.ErrorResponse(c, "Invalid refresh token", err, h.cfg.IsDevelopment())
+	userID, exists := c.Get("firebaseUID")
+	if !exists {
+		response.ErrorResponse(c, "User not authenticated", nil, h.cfg.IsDevelopment())
 		return
 	}
+	uid := userID.(string)
 
-	refreshToken, err := h.authService.ValidateRefreshToken(c.Request.Context(), token.UID, req.RefreshToken)
+	refreshToken, err := h.authService.ValidateRefreshToken(c.Request.Context(), uid, req.RefreshToken)
 	if err != nil {
 		response.ErrorResponse(c, "Invalid or expired refresh token", err, h.cfg.IsDevelopment())
 		return
@@ -96,7 +127,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	}
 
 	response.SuccessResponse(c, "Successfully logged out", gin.H{
-		"user_id": token.UID,
+		"user_id": uid,
 		"message": "Refresh token has been invalidated",
 	})
 }
@@ -108,13 +139,7 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 		return
 	}
 
-	authClient, err := h.firebaseApp.Auth(context.Background())
-	if err != nil {
-		response.ErrorResponse(c, "Failed to get Firebase Auth client", err, h.cfg.IsDevelopment())
-		return
-	}
-
-	user, err := authClient.GetUser(c.Request.Context(), userID.(string))
+	user, err := h.userService.GetUser(c.Request.Context(), userID.(string))
 	if err != nil {
 		response.ErrorResponse(c, "Failed to get user information", err, h.cfg.IsDevelopment())
 		return
@@ -123,8 +148,8 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 	userInfo := dtos.UserInfo{
 		UID:         user.UID,
 		Email:       user.Email,
-		DisplayName: user.DisplayName,
-		PhoneNumber: &user.PhoneNumber,
+		DisplayName: strings.TrimSpace(user.FirstName + " " + user.LastName),
+		PhoneNumber: user.PhoneNumber,
 	}
 
 	response.SuccessResponseData(c, userInfo)
@@ -162,6 +187,22 @@ func (h *AuthHandler) GetUserSessions(c *gin.Context) {
 	response.SuccessResponseData(c, sessions)
 }
 
+func (h *AuthHandler) GetSecurityEvents(c *gin.Context) {
+	userID, exists := c.Get("firebaseUID")
+	if !exists {
+		response.ErrorResponse(c, "User not authenticated", nil, h.cfg.IsDevelopment())
+		return
+	}
+
+	events, err := h.authService.GetSecurityEvents(c.Request.Context(), userID.(string))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get security events", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponseData(c, dtos.NewListSecurityEventResponse(events))
+}
+
 func (h *AuthHandler) RevokeSession(c *gin.Context) {
 	_, exists := c.Get("firebaseUID")
 	if !exists {
@@ -201,3 +242,212 @@ func (h *AuthHandler) RevokeAllSessions(c *gin.Context) {
 		"user_id": userID.(string),
 	})
 }
+
+func (h *AuthHandler) CompleteTwoFactorLogin(c *gin.Context) {
+	var req dtos.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	deviceInfo := c.GetHeader("User-Agent")
+	ipAddress := c.ClientIP()
+	userAgent := c.GetHeader("User-Agent")
+
+	loginResponse, err := h.authService.CompleteTwoFactorLogin(c.Request.Context(), req.ChallengeToken, req.Code, deviceInfo, ipAddress, userAgent)
+	if err != nil {
+		if h.loginThrottled(c, err) {
+			return
+		}
+		response.UnauthorizedResponse(c, "Two-factor login failed", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponseData(c, loginResponse)
+}
+
+func (h *AuthHandler) EnrollTwoFactor(c *gin.Context) {
+	userID, exists := c.Get("firebaseUID")
+	if !exists {
+		response.ErrorResponse(c, "User not authenticated", nil, h.cfg.IsDevelopment())
+		return
+	}
+
+	user, err := h.userService.GetUser(c.Request.Context(), userID.(string))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get user information", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	enrollment, err := h.authService.EnrollTwoFactor(c.Request.Context(), user.UID, user.Email)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to enroll two-factor authentication", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponseData(c, enrollment)
+}
+
+func (h *AuthHandler) ConfirmTwoFactor(c *gin.Context) {
+	userID, req, ok := h.bindTwoFactorCode(c)
+	if !ok {
+		return
+	}
+
+	recoveryCodes, err := h.authService.ConfirmTwoFactor(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to enable two-factor authentication", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponseData(c, recoveryCodes)
+}
+
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	userID, req, ok := h.bindTwoFactorCode(c)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.DisableTwoFactor(c.Request.Context(), userID, req.Code); err != nil {
+		response.ErrorResponse(c, "Failed to disable two-factor authentication", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponse(c, "Two-factor authentication disabled", gin.H{})
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, req, ok := h.bindTwoFactorCode(c)
+	if !ok {
+		return
+	}
+
+	recoveryCodes, err := h.authService.RegenerateRecoveryCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to regenerate recovery codes", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponseData(c, recoveryCodes)
+}
+
+func (h *AuthHandler) bindTwoFactorCode(c *gin.Context) (string, dtos.TwoFactorCodeRequest, bool) {
+	var req dtos.TwoFactorCodeRequest
+	userID, exists := c.Get("firebaseUID")
+	if !exists {
+		response.ErrorResponse(c, "User not authenticated", nil, h.cfg.IsDevelopment())
+		return "", req, false
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "invalid request body", err, h.cfg.IsDevelopment())
+		return "", req, false
+	}
+	return userID.(string), req, true
+}
+
+func (h *AuthHandler) BeginPasskeyRegistration(c *gin.Context) {
+	userID, exists := c.Get("firebaseUID")
+	if !exists {
+		response.ErrorResponse(c, "User not authenticated", nil, h.cfg.IsDevelopment())
+		return
+	}
+
+	ceremony, err := h.authService.BeginPasskeyRegistration(c.Request.Context(), userID.(string))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to start passkey registration", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponseData(c, ceremony)
+}
+
+func (h *AuthHandler) FinishPasskeyRegistration(c *gin.Context) {
+	userID, exists := c.Get("firebaseUID")
+	if !exists {
+		response.ErrorResponse(c, "User not authenticated", nil, h.cfg.IsDevelopment())
+		return
+	}
+
+	var req dtos.FinishPasskeyRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	passkey, err := h.authService.FinishPasskeyRegistration(c.Request.Context(), userID.(string), req.SessionToken, req.Name, req.Credential)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to register passkey", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.CreatedResponseData(c, dtos.NewPasskeyResponse(passkey))
+}
+
+func (h *AuthHandler) ListPasskeys(c *gin.Context) {
+	userID, exists := c.Get("firebaseUID")
+	if !exists {
+		response.ErrorResponse(c, "User not authenticated", nil, h.cfg.IsDevelopment())
+		return
+	}
+
+	passkeys, err := h.authService.ListPasskeys(c.Request.Context(), userID.(string))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list passkeys", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponseData(c, dtos.NewListPasskeyResponse(passkeys))
+}
+
+func (h *AuthHandler) DeletePasskey(c *gin.Context) {
+	userID, exists := c.Get("firebaseUID")
+	if !exists {
+		response.ErrorResponse(c, "User not authenticated", nil, h.cfg.IsDevelopment())
+		return
+	}
+
+	passkeyID := c.Param("passkeyID")
+	if err := h.authService.DeletePasskey(c.Request.Context(), userID.(string), passkeyID); err != nil {
+		if status.Code(err) == codes.NotFound {
+			response.NotFoundResponse(c, "Passkey not found", err, h.cfg.IsDevelopment())
+			return
+		}
+		response.ErrorResponse(c, "Failed to delete passkey", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponse(c, "Passkey deleted", gin.H{
+		"passkey_id": passkeyID,
+	})
+}
+
+func (h *AuthHandler) BeginPasskeyLogin(c *gin.Context) {
+	ceremony, err := h.authService.BeginPasskeyLogin(c.Request.Context())
+	if err != nil {
+		response.ErrorResponse(c, "Failed to start passkey login", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponseData(c, ceremony)
+}
+
+func (h *AuthHandler) FinishPasskeyLogin(c *gin.Context) {
+	var req dtos.FinishPasskeyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	deviceInfo := c.GetHeader("User-Agent")
+	ipAddress := c.ClientIP()
+	userAgent := c.GetHeader("User-Agent")
+
+	loginResponse, err := h.authService.FinishPasskeyLogin(c.Request.Context(), req.SessionToken, req.Credential, deviceInfo, ipAddress, userAgent)
+	if err != nil {
+		response.UnauthorizedResponse(c, "Passkey login failed", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	response.SuccessResponseData(c, loginResponse)
+}
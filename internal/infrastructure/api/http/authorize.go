@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/middleware"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+)
+
+// authorize checks that the :id path parameter names the authenticated
+// user and returns it.
+func authorize(c *gin.Context, cfg *config.Configuration, action string) (string, bool) {
+	requestedUserID := c.Param("id")
+	if strings.TrimSpace(requestedUserID) == "" {
+		response.ErrorResponse(c, "User ID is required", nil, cfg.IsDevelopment())
+		return "", false
+	}
+
+	authUID, exists := c.Get(middleware.FirebaseUIDKey)
+	if !exists {
+		response.ErrorResponse(c, "authenticated user ID not found in context", nil, cfg.IsDevelopment())
+		return "", false
+	}
+
+	if requestedUserID != authUID.(string) {
+		response.ErrorResponse(c, "unauthorized access to "+action, nil, cfg.IsDevelopment())
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return "", false
+	}
+	return requestedUserID, true
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+)
+
+type BudgetHandler struct {
+	budgetService ports.BudgetServicePort
+	cfg           *config.Configuration
+}
+
+func NewBudgetHandler(budgetService ports.BudgetServicePort, cfg *config.Configuration) *BudgetHandler {
+	if budgetService == nil || cfg == nil {
+		return nil
+	}
+	return &BudgetHandler{budgetService: budgetService, cfg: cfg}
+}
+
+func (h *BudgetHandler) CreateBudget(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "create budget")
+	if !ok {
+		return
+	}
+
+	var req dtos.BudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	budget, err := h.budgetService.CreateBudget(c.Request.Context(), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to create budget", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessWithStatusResponse(c, http.StatusCreated, "Budget created successfully", dtos.NewBudgetResponse(budget))
+}
+
+func (h *BudgetHandler) ListBudgets(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list budgets")
+	if !ok {
+		return
+	}
+
+	budgets, err := h.budgetService.ListBudgets(c.Request.Context(), userID, c.Query("period"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list budgets", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListBudgetResponse(budgets))
+}
+
+func (h *BudgetHandler) GetBudget(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get budget")
+	if !ok {
+		return
+	}
+
+	budget, err := h.budgetService.GetBudget(c.Request.Context(), userID, c.Param("budgetID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get budget", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewBudgetResponse(budget))
+}
+
+func (h *BudgetHandler) UpdateBudget(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "update budget")
+	if !ok {
+		return
+	}
+
+	var req dtos.BudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	budget, err := h.budgetService.UpdateBudget(c.Request.Context(), userID, c.Param("budgetID"), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to update budget", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewBudgetResponse(budget))
+}
+
+func (h *BudgetHandler) DeleteBudget(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "delete budget")
+	if !ok {
+		return
+	}
+
+	budgetID := c.Param("budgetID")
+	if err := h.budgetService.DeleteBudget(c.Request.Context(), userID, budgetID); err != nil {
+		response.ErrorResponse(c, "Failed to delete budget", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Budget deleted successfully", gin.H{"user_id": userID, "budget_id": budgetID})
+}
+
+func (h *BudgetHandler) GetBudgetPeriod(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get budget period")
+	if !ok {
+		return
+	}
+
+	view, err := h.budgetService.GetBudgetPeriod(c.Request.Context(), userID, c.Param("period"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get budget period", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewBudgetPeriodResponse(view))
+}
+
+func (h *BudgetHandler) AllocateBudget(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "allocate budget")
+	if !ok {
+		return
+	}
+
+	var req dtos.BudgetAllocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	budget, err := h.budgetService.AllocateBudget(c.Request.Context(), req.ToInput(userID, c.Param("period")))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to allocate budget", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewBudgetResponse(budget))
+}
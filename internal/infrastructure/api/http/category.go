@@ -0,0 +1,131 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+)
+
+type CategoryHandler struct {
+	categoryService ports.CategoryServicePort
+	cfg             *config.Configuration
+}
+
+func NewCategoryHandler(categoryService ports.CategoryServicePort, cfg *config.Configuration) *CategoryHandler {
+	if categoryService == nil || cfg == nil {
+		return nil
+	}
+	return &CategoryHandler{categoryService: categoryService, cfg: cfg}
+}
+
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "create category")
+	if !ok {
+		return
+	}
+
+	var req dtos.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(c.Request.Context(), dto.CategoryInput{
+		UserID:   userID,
+		Name:     req.Name,
+		ParentID: req.ParentID,
+		Kind:     req.Kind,
+	})
+	if err != nil {
+		response.ErrorResponse(c, "Failed to create category", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessWithStatusResponse(c, http.StatusCreated, "Category created successfully", dtos.NewCategoryResponse(category))
+}
+
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list categories")
+	if !ok {
+		return
+	}
+
+	categories, err := h.categoryService.ListCategories(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list categories", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewCategoryTreeResponse(categories))
+}
+
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get category")
+	if !ok {
+		return
+	}
+
+	category, err := h.categoryService.GetCategory(c.Request.Context(), userID, c.Param("categoryID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get category", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewCategoryResponse(category))
+}
+
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "update category")
+	if !ok {
+		return
+	}
+
+	var req dtos.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(c.Request.Context(), userID, c.Param("categoryID"), dto.CategoryInput{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+		Kind:     req.Kind,
+	})
+	if err != nil {
+		response.ErrorResponse(c, "Failed to update category", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewCategoryResponse(category))
+}
+
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "delete category")
+	if !ok {
+		return
+	}
+
+	categoryID := c.Param("categoryID")
+	if err := h.categoryService.DeleteCategory(c.Request.Context(), userID, categoryID); err != nil {
+		response.ErrorResponse(c, "Failed to delete category", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Category deleted successfully", gin.H{"user_id": userID, "category_id": categoryID})
+}
+
+// SeedDefaultCategories adds any missing part of the default category tree,
+// for users created before categories existed.
+func (h *CategoryHandler) SeedDefaultCategories(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "seed categories")
+	if !ok {
+		return
+	}
+
+	created, err := h.categoryService.SeedDefaultCategories(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to seed default categories", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Default categories seeded", gin.H{"created": len(created)})
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+)
+
+type DebtHandler struct {
+	debtService ports.DebtServicePort
+	cfg         *config.Configuration
+}
+
+func NewDebtHandler(debtService ports.DebtServicePort, cfg *config.Configuration) *DebtHandler {
+	if debtService == nil || cfg == nil {
+		return nil
+	}
+	return &DebtHandler{debtService: debtService, cfg: cfg}
+}
+
+func (h *DebtHandler) CreateDebt(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "create debt")
+	if !ok {
+		return
+	}
+
+	var req dtos.DebtRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	debt, err := h.debtService.CreateDebt(c.Request.Context(), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to create debt", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessWithStatusResponse(c, http.StatusCreated, "Debt created successfully", dtos.NewDebtResponse(debt))
+}
+
+func (h *DebtHandler) ListDebts(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list debts")
+	if !ok {
+		return
+	}
+
+	statuses, err := h.debtService.ListDebts(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list debts", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListDebtStatusResponse(statuses))
+}
+
+func (h *DebtHandler) GetDebt(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get debt")
+	if !ok {
+		return
+	}
+
+	status, err := h.debtService.GetDebt(c.Request.Context(), userID, c.Param("debtID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get debt", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewDebtStatusResponse(status))
+}
+
+func (h *DebtHandler) UpdateDebt(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "update debt")
+	if !ok {
+		return
+	}
+
+	var req dtos.DebtRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	debt, err := h.debtService.UpdateDebt(c.Request.Context(), userID, c.Param("debtID"), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to update debt", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewDebtResponse(debt))
+}
+
+func (h *DebtHandler) DeleteDebt(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "delete debt")
+	if !ok {
+		return
+	}
+
+	debtID := c.Param("debtID")
+	if err := h.debtService.DeleteDebt(c.Request.Context(), userID, debtID); err != nil {
+		response.ErrorResponse(c, "Failed to delete debt", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Debt deleted successfully", gin.H{"user_id": userID, "debt_id": debtID})
+}
+
+func (h *DebtHandler) GetDebtSchedule(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get debt schedule")
+	if !ok {
+		return
+	}
+
+	schedule, err := h.debtService.GetDebtSchedule(c.Request.Context(), userID, c.Param("debtID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get debt schedule", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewDebtScheduleResponse(schedule))
+}
+
+func (h *DebtHandler) PlanDebtPayoff(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "plan debt payoff")
+	if !ok {
+		return
+	}
+
+	var req dtos.DebtPayoffRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ErrorResponse(c, "Invalid query parameters", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	plans, err := h.debtService.PlanDebtPayoff(c.Request.Context(), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to plan debt payoff", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListDebtPayoffPlanResponse(plans))
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+)
+
+type EnvelopeHandler struct {
+	envelopeService ports.EnvelopeServicePort
+	cfg             *config.Configuration
+}
+
+func NewEnvelopeHandler(envelopeService ports.EnvelopeServicePort, cfg *config.Configuration) *EnvelopeHandler {
+	if envelopeService == nil || cfg == nil {
+		return nil
+	}
+	return &EnvelopeHandler{envelopeService: envelopeService, cfg: cfg}
+}
+
+func (h *EnvelopeHandler) CreateEnvelope(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "create envelope")
+	if !ok {
+		return
+	}
+
+	var req dtos.EnvelopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	envelope, err := h.envelopeService.CreateEnvelope(c.Request.Context(), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to create envelope", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessWithStatusResponse(c, http.StatusCreated, "Envelope created successfully", dtos.NewEnvelopeResponse(envelope))
+}
+
+func (h *EnvelopeHandler) ListEnvelopes(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list envelopes")
+	if !ok {
+		return
+	}
+
+	statuses, err := h.envelopeService.ListEnvelopes(c.Request.Context(), userID, c.Query("period"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list envelopes", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListEnvelopeStatusResponse(statuses))
+}
+
+func (h *EnvelopeHandler) GetEnvelope(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get envelope")
+	if !ok {
+		return
+	}
+
+	status, err := h.envelopeService.GetEnvelope(c.Request.Context(), userID, c.Param("envelopeID"), c.Query("period"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get envelope", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewEnvelopeStatusResponse(status))
+}
+
+func (h *EnvelopeHandler) UpdateEnvelope(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "update envelope")
+	if !ok {
+		return
+	}
+
+	var req dtos.EnvelopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	envelope, err := h.envelopeService.UpdateEnvelope(c.Request.Context(), userID, c.Param("envelopeID"), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to update envelope", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewEnvelopeResponse(envelope))
+}
+
+func (h *EnvelopeHandler) DeleteEnvelope(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "delete envelope")
+	if !ok {
+		return
+	}
+
+	envelopeID := c.Param("envelopeID")
+	if err := h.envelopeService.DeleteEnvelope(c.Request.Context(), userID, envelopeID); err != nil {
+		response.ErrorResponse(c, "Failed to delete envelope", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Envelope deleted successfully", gin.H{"user_id": userID, "envelope_id": envelopeID})
+}
+
+func (h *EnvelopeHandler) MoveBetweenEnvelopes(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "move between envelopes")
+	if !ok {
+		return
+	}
+
+	var req dtos.EnvelopeMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	move, err := h.envelopeService.MoveBetweenEnvelopes(c.Request.Context(), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to move money between envelopes", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessWithStatusResponse(c, http.StatusCreated, "Money moved successfully", dtos.NewEnvelopeMoveResponse(move))
+}
+
+func (h *EnvelopeHandler) ListEnvelopeMoves(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list envelope moves")
+	if !ok {
+		return
+	}
+
+	moves, err := h.envelopeService.ListEnvelopeMoves(c.Request.Context(), userID, c.Query("period"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list envelope moves", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListEnvelopeMoveResponse(moves))
+}
@@ -53,8 +53,12 @@ func (h *ExpenseHandler) AddExpense(c *gin.Context) {
 	input := dto.AddExpenseInput{
 		UserID:              requestedUserID,
 		Source:              req.ToDomain().Source,
+		AccountID:           req.ToDomain().AccountID,
+		CategoryID:          req.ToDomain().CategoryID,
+		DebtID:              req.ToDomain().DebtID,
+		Tags:                req.ToDomain().Tags,
 		Amount:              req.ToDomain().Amount,
-		Currency:            req.ToDomain().Currency,
+		Splits:              req.ToDomain().Splits,
 		Notes:               req.ToDomain().Notes,
 		IsRecurring:         req.ToDomain().IsRecurring,
 		RecurrenceFrequency: req.ToDomain().RecurrenceFrequency,
@@ -90,7 +94,10 @@ func (h *ExpenseHandler) ListExpenses(c *gin.Context) {
 		return
 	}
 
-	expenses, err := h.expenseService.ListExpenses(c.Request.Context(), requestedUserID)
+	expenses, err := h.expenseService.ListExpenses(c.Request.Context(), requestedUserID, dto.TransactionFilter{
+		CategoryID: c.Query("category_id"),
+		Tag:        c.Query("tag"),
+	})
 	if err != nil {
 		response.ErrorResponse(c, "Failed to list expenses", err, h.cfg.IsDevelopment())
 		return
@@ -168,8 +175,12 @@ func (h *ExpenseHandler) UpdateExpense(c *gin.Context) {
 
 	input := dto.AddExpenseInput{
 		Source:              req.ToDomain().Source,
+		AccountID:           req.ToDomain().AccountID,
+		CategoryID:          req.ToDomain().CategoryID,
+		DebtID:              req.ToDomain().DebtID,
+		Tags:                req.ToDomain().Tags,
 		Amount:              req.ToDomain().Amount,
-		Currency:            req.ToDomain().Currency,
+		Splits:              req.ToDomain().Splits,
 		Notes:               req.ToDomain().Notes,
 		IsRecurring:         req.ToDomain().IsRecurring,
 		RecurrenceFrequency: req.ToDomain().RecurrenceFrequency,
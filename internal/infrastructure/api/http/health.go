@@ -3,21 +3,21 @@ package http
 import (
 	"time"
 
-	"cloud.google.com/go/firestore"
 	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/application/ports"
 	"github.com/theHinneh/budgeting/internal/infrastructure/config"
 	"github.com/theHinneh/budgeting/internal/infrastructure/response"
 )
 
 type HealthHandler struct {
-	config          *config.Configuration
-	firestoreClient *firestore.Client
+	config   *config.Configuration
+	database ports.Database
 }
 
-func NewHealthHandler(cfg *config.Configuration, firestoreClient *firestore.Client) *HealthHandler {
+func NewHealthHandler(cfg *config.Configuration, database ports.Database) *HealthHandler {
 	return &HealthHandler{
-		config:          cfg,
-		firestoreClient: firestoreClient,
+		config:   cfg,
+		database: database,
 	}
 }
 
@@ -36,10 +36,8 @@ func (repo *HealthHandler) HealthCheck(ctx *gin.Context) {
 	}
 	healthData["environment"] = env
 
-	if repo.firestoreClient != nil {
-		healthDocRef := repo.firestoreClient.Collection("health_check").Doc("status")
-		_, err := healthDocRef.Set(ctx.Request.Context(), map[string]interface{}{"last_checked": time.Now().UTC()})
-		if err != nil {
+	if repo.database != nil {
+		if err := repo.database.Ping(ctx.Request.Context()); err != nil {
 			healthData["database"] = gin.H{
 				"status":  "unhealthy",
 				"details": err.Error(),
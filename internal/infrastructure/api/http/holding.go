@@ -0,0 +1,145 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+)
+
+type HoldingHandler struct {
+	holdingService ports.HoldingServicePort
+	cfg            *config.Configuration
+}
+
+func NewHoldingHandler(holdingService ports.HoldingServicePort, cfg *config.Configuration) *HoldingHandler {
+	if holdingService == nil || cfg == nil {
+		return nil
+	}
+	return &HoldingHandler{holdingService: holdingService, cfg: cfg}
+}
+
+func (h *HoldingHandler) CreateHolding(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "create holding")
+	if !ok {
+		return
+	}
+
+	var req dtos.HoldingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	status, err := h.holdingService.CreateHolding(c.Request.Context(), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to create holding", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessWithStatusResponse(c, http.StatusCreated, "Holding created successfully", dtos.NewHoldingResponse(status))
+}
+
+func (h *HoldingHandler) ListHoldings(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list holdings")
+	if !ok {
+		return
+	}
+
+	var query dtos.HoldingListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, "Invalid query parameters", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	statuses, err := h.holdingService.ListHoldings(c.Request.Context(), userID, query.Kind)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list holdings", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListHoldingResponse(statuses))
+}
+
+func (h *HoldingHandler) GetHolding(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get holding")
+	if !ok {
+		return
+	}
+
+	status, err := h.holdingService.GetHolding(c.Request.Context(), userID, c.Param("holdingID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get holding", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewHoldingResponse(status))
+}
+
+func (h *HoldingHandler) UpdateHolding(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "update holding")
+	if !ok {
+		return
+	}
+
+	var req dtos.HoldingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	status, err := h.holdingService.UpdateHolding(c.Request.Context(), userID, c.Param("holdingID"), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to update holding", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewHoldingResponse(status))
+}
+
+func (h *HoldingHandler) DeleteHolding(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "delete holding")
+	if !ok {
+		return
+	}
+
+	holdingID := c.Param("holdingID")
+	if err := h.holdingService.DeleteHolding(c.Request.Context(), userID, holdingID); err != nil {
+		response.ErrorResponse(c, "Failed to delete holding", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Holding deleted successfully", gin.H{"user_id": userID, "holding_id": holdingID})
+}
+
+func (h *HoldingHandler) AddValuation(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "add valuation")
+	if !ok {
+		return
+	}
+
+	var req dtos.ValuationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	valuation, err := h.holdingService.AddValuation(c.Request.Context(), req.ToInput(userID, c.Param("holdingID")))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to add valuation", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessWithStatusResponse(c, http.StatusCreated, "Valuation added successfully", dtos.NewValuationResponse(valuation))
+}
+
+func (h *HoldingHandler) ListValuations(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list valuations")
+	if !ok {
+		return
+	}
+
+	valuations, err := h.holdingService.ListValuations(c.Request.Context(), userID, c.Param("holdingID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list valuations", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListValuationResponse(valuations))
+}
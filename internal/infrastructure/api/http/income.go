@@ -50,11 +50,13 @@ func (h *IncomeHandler) AddIncome(c *gin.Context) {
 		return
 	}
 	income, err := h.Service.AddIncome(c.Request.Context(), dto.AddIncomeInput{
-		UserID:   requestedUserID,
-		Source:   req.ToDomain().Source,
-		Amount:   req.ToDomain().Amount,
-		Currency: req.ToDomain().Currency,
-		Notes:    req.ToDomain().Notes,
+		UserID:     requestedUserID,
+		Source:     req.ToDomain().Source,
+		AccountID:  req.ToDomain().AccountID,
+		CategoryID: req.ToDomain().CategoryID,
+		Tags:       req.ToDomain().Tags,
+		Amount:     req.ToDomain().Amount,
+		Notes:      req.ToDomain().Notes,
 	})
 	if err != nil {
 		response.ErrorResponse(c, "failed to add income", err, h.cfg.IsDevelopment())
@@ -95,8 +97,7 @@ func (h *IncomeHandler) AddIncomeSource(c *gin.Context) {
 	src, err := h.Service.AddIncomeSource(c.Request.Context(), dto.AddIncomeSourceInput{
 		UserID:    requestedUserID,
 		Source:    req.Source,
-		Amount:    req.Amount,
-		Currency:  req.Currency,
+		Amount:    req.ToDomain().Amount,
 		Frequency: dto.PayFrequency(req.Frequency),
 		NextPayAt: req.NextPayAt,
 		Notes:     req.Notes,
@@ -127,7 +128,10 @@ func (h *IncomeHandler) ListIncomes(c *gin.Context) {
 		return
 	}
 
-	incomes, err := h.Service.ListIncomes(c.Request.Context(), requestedUserID)
+	incomes, err := h.Service.ListIncomes(c.Request.Context(), requestedUserID, dto.TransactionFilter{
+		CategoryID: c.Query("category_id"),
+		Tag:        c.Query("tag"),
+	})
 	if err != nil {
 		response.ErrorResponse(c, "failed to list incomes", err, h.cfg.IsDevelopment())
 		return
@@ -58,8 +58,7 @@ func (h *IncomeSourceHandler) AddIncomeSource(c *gin.Context) {
 	src, err := h.Service.AddIncomeSource(c.Request.Context(), dto.AddIncomeSourceInput{
 		UserID:    requestedUserID,
 		Source:    req.Source,
-		Amount:    req.Amount,
-		Currency:  req.Currency,
+		Amount:    req.ToDomain().Amount,
 		Frequency: dto.PayFrequency(req.Frequency),
 		NextPayAt: req.NextPayAt,
 		Notes:     req.Notes,
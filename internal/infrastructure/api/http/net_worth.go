@@ -1,12 +1,9 @@
 package http
 
 import (
-	"net/http"
-	"strings"
-
 	"github.com/gin-gonic/gin"
 	"github.com/theHinneh/budgeting/internal/application/ports"
-	"github.com/theHinneh/budgeting/internal/infrastructure/api/middleware"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
 	"github.com/theHinneh/budgeting/internal/infrastructure/config"
 	"github.com/theHinneh/budgeting/internal/infrastructure/response"
 )
@@ -24,28 +21,45 @@ func NewNetWorthHandler(service ports.NetWorthServicePort, cfg *config.Configura
 }
 
 func (h *NetWorthHandler) GetNetWorth(c *gin.Context) {
-	requestedUserID := c.Param("id")
-	if strings.TrimSpace(requestedUserID) == "" {
-		response.ErrorResponse(c, "User ID is required", nil, h.cfg.IsDevelopment())
+	userID, ok := authorize(c, h.cfg, "get net worth")
+	if !ok {
 		return
 	}
 
-	authUID, exists := c.Get(middleware.FirebaseUIDKey)
-	if !exists {
-		response.ErrorResponse(c, "authenticated user ID not found in context", nil, h.cfg.IsDevelopment())
+	netWorth, err := h.service.GetNetWorth(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get net worth", err, h.cfg.IsDevelopment())
 		return
 	}
+	response.SuccessResponseData(c, netWorth)
+}
 
-	if requestedUserID != authUID.(string) {
-		response.ErrorResponse(c, "unauthorized access to get net worth", nil, h.cfg.IsDevelopment())
-		c.AbortWithStatus(http.StatusUnauthorized)
+func (h *NetWorthHandler) GetNetWorthHistory(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get net worth history")
+	if !ok {
 		return
 	}
 
-	netWorth, err := h.service.GetNetWorth(c.Request.Context(), requestedUserID)
+	var query dtos.NetWorthHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c, "Invalid query parameters", err, h.cfg.IsDevelopment())
+		return
+	}
+	in, err := query.ToInput(userID)
 	if err != nil {
-		response.ErrorResponse(c, "Failed to get net worth", err, h.cfg.IsDevelopment())
+		response.ErrorResponse(c, "Invalid query parameters", err, h.cfg.IsDevelopment())
 		return
 	}
-	response.SuccessResponseData(c, netWorth)
+
+	history, err := h.service.GetNetWorthHistory(c.Request.Context(), in)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get net worth history", err, h.cfg.IsDevelopment())
+		return
+	}
+	res, err := dtos.NewNetWorthHistoryResponse(history)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get net worth history", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, res)
 }
@@ -1,9 +1,9 @@
 package http
 
 import (
+	"fmt"
 	"time"
 
-	firebase "firebase.google.com/go/v4"
 	"github.com/gin-gonic/gin"
 	"github.com/theHinneh/budgeting/internal/application/ports"
 	middleware2 "github.com/theHinneh/budgeting/internal/infrastructure/api/middleware"
@@ -12,30 +12,42 @@ import (
 
 func NewRouter(
 	healthHandler *HealthHandler, userService ports.UserServicePort, incomeService ports.IncomeServicePort,
-	expenseService ports.ExpenseServicePort, netWorthService ports.NetWorthServicePort, firebaseApp *firebase.App,
+	expenseService ports.ExpenseServicePort, categoryService ports.CategoryServicePort, tagService ports.TagServicePort,
+	accountService ports.AccountServicePort, transferService ports.TransferServicePort,
+	budgetService ports.BudgetServicePort, envelopeService ports.EnvelopeServicePort, savingsGoalService ports.SavingsGoalServicePort, debtService ports.DebtServicePort, holdingService ports.HoldingServicePort, netWorthService ports.NetWorthServicePort,
+	tokenAuth ports.TokenAuthenticator, userAuthenticator ports.UserAuthenticator,
 	authService ports.AuthServicePort, cfg *config.Configuration,
-) *gin.Engine {
+) (*gin.Engine, error) {
 	router := gin.Default()
 
 	serverConfig := cfg.GetServerConfig()
+	// Client addresses key the login throttling, so a forwarded address is
+	// only believed from a configured proxy.
+	if err := router.SetTrustedProxies(serverConfig.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
 	router.Use(middleware2.CORS(serverConfig.CORSOrigin))
 	router.Use(middleware2.RateLimit(60, time.Minute))
 
 	registerHealthRoutes(router, healthHandler)
 
-	userHandler := NewUserHandler(userService, firebaseApp, cfg)
-	authHandler := NewAuthHandler(firebaseApp, authService, cfg)
+	userHandler := NewUserHandler(userService, userAuthenticator, cfg)
+	authHandler := NewAuthHandler(tokenAuth, authService, userService, cfg)
 
 	publicV1 := router.Group("/v1")
 	{
 		publicV1.POST("/users", userHandler.CreateUser)
 		publicV1.POST("/auth/login", authHandler.Login)
 		publicV1.POST("/auth/refresh", authHandler.RefreshToken)
+		publicV1.POST("/auth/unlock", authHandler.UnlockAccount)
+		publicV1.POST("/auth/2fa/login", authHandler.CompleteTwoFactorLogin)
+		publicV1.POST("/auth/passkeys/login/begin", authHandler.BeginPasskeyLogin)
+		publicV1.POST("/auth/passkeys/login/finish", authHandler.FinishPasskeyLogin)
 		publicV1.POST("/auth/forgot-password", userHandler.ForgotPassword)
 	}
 
 	v1 := router.Group("/v1")
-	v1.Use(middleware2.FirebaseAuthentication(firebaseApp, cfg))
+	v1.Use(middleware2.Authentication(tokenAuth, cfg))
 	{
 
 		userRoutes := v1.Group("/users")
@@ -53,6 +65,15 @@ func NewRouter(
 			authRoutes.GET("/sessions", authHandler.GetUserSessions)
 			authRoutes.POST("/sessions/revoke", authHandler.RevokeSession)
 			authRoutes.POST("/sessions/revoke-all", authHandler.RevokeAllSessions)
+			authRoutes.GET("/security-events", authHandler.GetSecurityEvents)
+			authRoutes.POST("/2fa/enroll", authHandler.EnrollTwoFactor)
+			authRoutes.POST("/2fa/verify", authHandler.ConfirmTwoFactor)
+			authRoutes.POST("/2fa/disable", authHandler.DisableTwoFactor)
+			authRoutes.POST("/2fa/recovery-codes", authHandler.RegenerateRecoveryCodes)
+			authRoutes.GET("/passkeys", authHandler.ListPasskeys)
+			authRoutes.POST("/passkeys/register/begin", authHandler.BeginPasskeyRegistration)
+			authRoutes.POST("/passkeys/register/finish", authHandler.FinishPasskeyRegistration)
+			authRoutes.DELETE("/passkeys/:passkeyID", authHandler.DeletePasskey)
 		}
 
 		incomeHandler := NewIncomeHandler(incomeService, cfg)
@@ -81,14 +102,113 @@ func NewRouter(
 			expenseRoutes.DELETE("/:expenseID", expenseHandler.DeleteExpense)
 		}
 
+		categoryHandler := NewCategoryHandler(categoryService, cfg)
+		categoryRoutes := v1.Group("/users/:id/categories")
+		{
+			categoryRoutes.POST("", categoryHandler.CreateCategory)
+			categoryRoutes.GET("", categoryHandler.ListCategories)
+			categoryRoutes.POST("/defaults", categoryHandler.SeedDefaultCategories)
+			categoryRoutes.GET("/:categoryID", categoryHandler.GetCategory)
+			categoryRoutes.PUT("/:categoryID", categoryHandler.UpdateCategory)
+			categoryRoutes.DELETE("/:categoryID", categoryHandler.DeleteCategory)
+		}
+
+		tagHandler := NewTagHandler(tagService, cfg)
+		tagRoutes := v1.Group("/users/:id/tags")
+		{
+			tagRoutes.GET("", tagHandler.ListTags)
+			tagRoutes.POST("/merge", tagHandler.MergeTags)
+			tagRoutes.PUT("/:tag", tagHandler.RenameTag)
+		}
+
+		accountHandler := NewAccountHandler(accountService, cfg)
+		accountRoutes := v1.Group("/users/:id/accounts")
+		{
+			accountRoutes.POST("", accountHandler.CreateAccount)
+			accountRoutes.GET("", accountHandler.ListAccounts)
+			accountRoutes.GET("/:accountID", accountHandler.GetAccount)
+			accountRoutes.PUT("/:accountID", accountHandler.UpdateAccount)
+			accountRoutes.DELETE("/:accountID", accountHandler.DeleteAccount)
+			accountRoutes.GET("/:accountID/transactions", accountHandler.ListAccountTransactions)
+		}
+
+		transferHandler := NewTransferHandler(transferService, cfg)
+		transferRoutes := v1.Group("/users/:id/transfers")
+		{
+			transferRoutes.POST("", transferHandler.CreateTransfer)
+			transferRoutes.GET("", transferHandler.ListTransfers)
+			transferRoutes.GET("/:transferID", transferHandler.GetTransfer)
+			transferRoutes.DELETE("/:transferID", transferHandler.DeleteTransfer)
+		}
+
+		budgetHandler := NewBudgetHandler(budgetService, cfg)
+		budgetRoutes := v1.Group("/users/:id/budgets")
+		{
+			budgetRoutes.POST("", budgetHandler.CreateBudget)
+			budgetRoutes.GET("", budgetHandler.ListBudgets)
+			budgetRoutes.GET("/periods/:period", budgetHandler.GetBudgetPeriod)
+			budgetRoutes.POST("/periods/:period/allocations", budgetHandler.AllocateBudget)
+			budgetRoutes.GET("/:budgetID", budgetHandler.GetBudget)
+			budgetRoutes.PUT("/:budgetID", budgetHandler.UpdateBudget)
+			budgetRoutes.DELETE("/:budgetID", budgetHandler.DeleteBudget)
+		}
+
+		envelopeHandler := NewEnvelopeHandler(envelopeService, cfg)
+		envelopeRoutes := v1.Group("/users/:id/envelopes")
+		{
+			envelopeRoutes.POST("", envelopeHandler.CreateEnvelope)
+			envelopeRoutes.GET("", envelopeHandler.ListEnvelopes)
+			envelopeRoutes.POST("/moves", envelopeHandler.MoveBetweenEnvelopes)
+			envelopeRoutes.GET("/moves", envelopeHandler.ListEnvelopeMoves)
+			envelopeRoutes.GET("/:envelopeID", envelopeHandler.GetEnvelope)
+			envelopeRoutes.PUT("/:envelopeID", envelopeHandler.UpdateEnvelope)
+			envelopeRoutes.DELETE("/:envelopeID", envelopeHandler.DeleteEnvelope)
+		}
+
+		savingsGoalHandler := NewSavingsGoalHandler(savingsGoalService, cfg)
+		savingsGoalRoutes := v1.Group("/users/:id/goals")
+		{
+			savingsGoalRoutes.POST("", savingsGoalHandler.CreateSavingsGoal)
+			savingsGoalRoutes.GET("", savingsGoalHandler.ListSavingsGoals)
+			savingsGoalRoutes.GET("/:goalID", savingsGoalHandler.GetSavingsGoal)
+			savingsGoalRoutes.PUT("/:goalID", savingsGoalHandler.UpdateSavingsGoal)
+			savingsGoalRoutes.DELETE("/:goalID", savingsGoalHandler.DeleteSavingsGoal)
+			savingsGoalRoutes.GET("/:goalID/history", savingsGoalHandler.GetSavingsGoalHistory)
+		}
+
+		debtHandler := NewDebtHandler(debtService, cfg)
+		debtRoutes := v1.Group("/users/:id/debts")
+		{
+			debtRoutes.POST("", debtHandler.CreateDebt)
+			debtRoutes.GET("", debtHandler.ListDebts)
+			debtRoutes.GET("/payoff-plans", debtHandler.PlanDebtPayoff)
+			debtRoutes.GET("/:debtID", debtHandler.GetDebt)
+			debtRoutes.PUT("/:debtID", debtHandler.UpdateDebt)
+			debtRoutes.DELETE("/:debtID", debtHandler.DeleteDebt)
+			debtRoutes.GET("/:debtID/schedule", debtHandler.GetDebtSchedule)
+		}
+
+		holdingHandler := NewHoldingHandler(holdingService, cfg)
+		holdingRoutes := v1.Group("/users/:id/holdings")
+		{
+			holdingRoutes.POST("", holdingHandler.CreateHolding)
+			holdingRoutes.GET("", holdingHandler.ListHoldings)
+			holdingRoutes.GET("/:holdingID", holdingHandler.GetHolding)
+			holdingRoutes.PUT("/:holdingID", holdingHandler.UpdateHolding)
+			holdingRoutes.DELETE("/:holdingID", holdingHandler.DeleteHolding)
+			holdingRoutes.POST("/:holdingID/valuations", holdingHandler.AddValuation)
+			holdingRoutes.GET("/:holdingID/valuations", holdingHandler.ListValuations)
+		}
+
 		netWorthHandler := NewNetWorthHandler(netWorthService, cfg)
 		netWorthRoutes := v1.Group("/users/:id/net-worth")
 		{
 			netWorthRoutes.GET("", netWorthHandler.GetNetWorth)
+			netWorthRoutes.GET("/history", netWorthHandler.GetNetWorthHistory)
 		}
 	}
 
-	return router
+	return router, nil
 }
 
 func registerHealthRoutes(router *gin.Engine, healthHandler *HealthHandler) {
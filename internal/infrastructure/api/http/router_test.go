@@ -0,0 +1,111 @@
+package http
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/theHinneh/budgeting/internal/application/dto"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loginRecorder remembers the client address each login was attempted from
+// and fails it with err, or as a wrong password when err is nil.
+type loginRecorder struct {
+	ports.AuthServicePort
+	ips []string
+	err error
+}
+
+func (r *loginRecorder) Login(ctx context.Context, email, password string, deviceInfo, ipAddress, userAgent string) (*dto.LoginResponse, error) {
+	r.ips = append(r.ips, ipAddress)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return nil, errors.New("invalid email or password")
+}
+
+// throttledError stands in for the service's error while logins are held
+// back.
+type throttledError struct{}
+
+func (throttledError) Error() string             { return "too many failed login attempts" }
+func (throttledError) RetryAfter() time.Duration { return 90 * time.Second }
+func (throttledError) GRPCStatus() *status.Status {
+	return status.New(codes.ResourceExhausted, "too many failed login attempts")
+}
+
+func newTestRouter(t *testing.T, trustedProxies string, auth ports.AuthServicePort) *gin.Engine {
+	t.Helper()
+	v := viper.New()
+	v.Set("SERVER_TRUSTED_PROXIES", trustedProxies)
+	// The auth handler is only built when every dependency is set.
+	tokenAuth := struct{ ports.TokenAuthenticator }{}
+	users := struct{ ports.UserServicePort }{}
+	router, err := NewRouter(nil, users, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, tokenAuth, nil, auth, &config.Configuration{V: v})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+	return router
+}
+
+func loginRequest() *nethttp.Request {
+	req := httptest.NewRequest(nethttp.MethodPost, "/v1/auth/login", strings.NewReader(`{"email":"ama@example.com","password":"wrong"}`))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestLoginClientIPIgnoresUntrustedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, c := range []struct {
+		name           string
+		trustedProxies string
+		want           string
+	}{
+		{"no trusted proxies", "", "192.0.2.10"},
+		{"from a trusted proxy", "192.0.2.0/24", "203.0.113.7"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			auth := &loginRecorder{}
+			router := newTestRouter(t, c.trustedProxies, auth)
+
+			req := loginRequest()
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+			req.RemoteAddr = "192.0.2.10:54321"
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if len(auth.ips) != 1 || auth.ips[0] != c.want {
+				t.Fatalf("Login saw client addresses %v, want [%s]", auth.ips, c.want)
+			}
+		})
+	}
+}
+
+func TestThrottledLoginIsTooManyRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newTestRouter(t, "", &loginRecorder{err: throttledError{}})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, loginRequest())
+	if rec.Code != nethttp.StatusTooManyRequests || rec.Header().Get("Retry-After") != "90" {
+		t.Fatalf("throttled login = %d with Retry-After %q, want 429 and 90", rec.Code, rec.Header().Get("Retry-After"))
+	}
+}
+
+func TestNewRouterRejectsInvalidTrustedProxies(t *testing.T) {
+	v := viper.New()
+	v.Set("SERVER_TRUSTED_PROXIES", "not-an-address")
+	if _, err := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &config.Configuration{V: v}); err == nil {
+		t.Fatal("NewRouter accepted an invalid trusted proxy")
+	}
+}
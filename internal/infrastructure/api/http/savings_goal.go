@@ -0,0 +1,119 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+)
+
+type SavingsGoalHandler struct {
+	savingsGoalService ports.SavingsGoalServicePort
+	cfg                *config.Configuration
+}
+
+func NewSavingsGoalHandler(savingsGoalService ports.SavingsGoalServicePort, cfg *config.Configuration) *SavingsGoalHandler {
+	if savingsGoalService == nil || cfg == nil {
+		return nil
+	}
+	return &SavingsGoalHandler{savingsGoalService: savingsGoalService, cfg: cfg}
+}
+
+func (h *SavingsGoalHandler) CreateSavingsGoal(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "create savings goal")
+	if !ok {
+		return
+	}
+
+	var req dtos.SavingsGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	status, err := h.savingsGoalService.CreateSavingsGoal(c.Request.Context(), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to create savings goal", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessWithStatusResponse(c, http.StatusCreated, "Savings goal created successfully", dtos.NewSavingsGoalResponse(status))
+}
+
+func (h *SavingsGoalHandler) ListSavingsGoals(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list savings goals")
+	if !ok {
+		return
+	}
+
+	statuses, err := h.savingsGoalService.ListSavingsGoals(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list savings goals", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListSavingsGoalResponse(statuses))
+}
+
+func (h *SavingsGoalHandler) GetSavingsGoal(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get savings goal")
+	if !ok {
+		return
+	}
+
+	status, err := h.savingsGoalService.GetSavingsGoal(c.Request.Context(), userID, c.Param("goalID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get savings goal", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewSavingsGoalResponse(status))
+}
+
+func (h *SavingsGoalHandler) UpdateSavingsGoal(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "update savings goal")
+	if !ok {
+		return
+	}
+
+	var req dtos.SavingsGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	status, err := h.savingsGoalService.UpdateSavingsGoal(c.Request.Context(), userID, c.Param("goalID"), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to update savings goal", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewSavingsGoalResponse(status))
+}
+
+func (h *SavingsGoalHandler) DeleteSavingsGoal(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "delete savings goal")
+	if !ok {
+		return
+	}
+
+	goalID := c.Param("goalID")
+	if err := h.savingsGoalService.DeleteSavingsGoal(c.Request.Context(), userID, goalID); err != nil {
+		response.ErrorResponse(c, "Failed to delete savings goal", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Savings goal deleted successfully", gin.H{"user_id": userID, "goal_id": goalID})
+}
+
+func (h *SavingsGoalHandler) GetSavingsGoalHistory(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get savings goal history")
+	if !ok {
+		return
+	}
+
+	history, err := h.savingsGoalService.GetSavingsGoalHistory(c.Request.Context(), userID, c.Param("goalID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get savings goal history", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListSavingsGoalProgressResponse(history))
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+)
+
+type TagHandler struct {
+	tagService ports.TagServicePort
+	cfg        *config.Configuration
+}
+
+func NewTagHandler(tagService ports.TagServicePort, cfg *config.Configuration) *TagHandler {
+	if tagService == nil || cfg == nil {
+		return nil
+	}
+	return &TagHandler{tagService: tagService, cfg: cfg}
+}
+
+func (h *TagHandler) ListTags(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list tags")
+	if !ok {
+		return
+	}
+
+	tags, err := h.tagService.ListTags(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list tags", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListTagResponse(tags))
+}
+
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "rename tag")
+	if !ok {
+		return
+	}
+
+	var req dtos.RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	updated, err := h.tagService.RenameTag(c.Request.Context(), userID, c.Param("tag"), req.Name)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to rename tag", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Tag renamed successfully", gin.H{"updated": updated})
+}
+
+func (h *TagHandler) MergeTags(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "merge tags")
+	if !ok {
+		return
+	}
+
+	var req dtos.MergeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	updated, err := h.tagService.MergeTags(c.Request.Context(), userID, req.From, req.Into)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to merge tags", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Tags merged successfully", gin.H{"updated": updated})
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/api/dtos"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/response"
+)
+
+type TransferHandler struct {
+	transferService ports.TransferServicePort
+	cfg             *config.Configuration
+}
+
+func NewTransferHandler(transferService ports.TransferServicePort, cfg *config.Configuration) *TransferHandler {
+	if transferService == nil || cfg == nil {
+		return nil
+	}
+	return &TransferHandler{transferService: transferService, cfg: cfg}
+}
+
+func (h *TransferHandler) CreateTransfer(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "create transfer")
+	if !ok {
+		return
+	}
+
+	var req dtos.TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorResponse(c, "Invalid request body", err, h.cfg.IsDevelopment())
+		return
+	}
+
+	transfer, err := h.transferService.CreateTransfer(c.Request.Context(), req.ToInput(userID))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to create transfer", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessWithStatusResponse(c, http.StatusCreated, "Transfer created successfully", dtos.NewTransferResponse(transfer))
+}
+
+func (h *TransferHandler) ListTransfers(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "list transfers")
+	if !ok {
+		return
+	}
+
+	transfers, err := h.transferService.ListTransfers(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(c, "Failed to list transfers", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewListTransferResponse(transfers))
+}
+
+func (h *TransferHandler) GetTransfer(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "get transfer")
+	if !ok {
+		return
+	}
+
+	transfer, err := h.transferService.GetTransfer(c.Request.Context(), userID, c.Param("transferID"))
+	if err != nil {
+		response.ErrorResponse(c, "Failed to get transfer", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponseData(c, dtos.NewTransferResponse(transfer))
+}
+
+func (h *TransferHandler) DeleteTransfer(c *gin.Context) {
+	userID, ok := authorize(c, h.cfg, "delete transfer")
+	if !ok {
+		return
+	}
+
+	transferID := c.Param("transferID")
+	if err := h.transferService.DeleteTransfer(c.Request.Context(), userID, transferID); err != nil {
+		response.ErrorResponse(c, "Failed to delete transfer", err, h.cfg.IsDevelopment())
+		return
+	}
+	response.SuccessResponse(c, "Transfer deleted successfully", gin.H{"user_id": userID, "transfer_id": transferID})
+}
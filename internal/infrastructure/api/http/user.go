@@ -5,8 +5,6 @@ import (
 	"net/http"
 	"strings"
 
-	firebase "firebase.google.com/go/v4"
-	fbAuth "firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
 	"github.com/theHinneh/budgeting/internal/application/dto"
 	"github.com/theHinneh/budgeting/internal/application/ports"
@@ -18,33 +16,35 @@ import (
 )
 
 type UserHandler struct {
-	Service     ports.UserServicePort
-	firebaseApp *firebase.App
-	cfg         *config.Configuration
+	Service       ports.UserServicePort
+	authenticator ports.UserAuthenticator
+	cfg           *config.Configuration
 }
 
-func NewUserHandler(svc ports.UserServicePort, app *firebase.App, cfg *config.Configuration) *UserHandler {
-	if svc == nil || app == nil || cfg == nil {
+func NewUserHandler(svc ports.UserServicePort, authenticator ports.UserAuthenticator, cfg *config.Configuration) *UserHandler {
+	if svc == nil || authenticator == nil || cfg == nil {
 		return nil
 	}
-	return &UserHandler{Service: svc, firebaseApp: app, cfg: cfg}
+	return &UserHandler{Service: svc, authenticator: authenticator, cfg: cfg}
 }
 
 type createUserRequest struct {
-	Username    string  `json:"username" binding:"required"`
-	Email       string  `json:"email" binding:"required,email"`
-	FirstName   string  `json:"firstname" binding:"required"`
-	LastName    string  `json:"lastname" binding:"required"`
-	PhoneNumber *string `json:"phone_number"`
-	Password    string  `json:"password,omitempty" binding:"required,min=6"`
+	Username     string  `json:"username" binding:"required"`
+	Email        string  `json:"email" binding:"required,email"`
+	FirstName    string  `json:"firstname" binding:"required"`
+	LastName     string  `json:"lastname" binding:"required"`
+	PhoneNumber  *string `json:"phone_number"`
+	BaseCurrency *string `json:"base_currency"`
+	Password     string  `json:"password,omitempty" binding:"required,min=6"`
 }
 
 type updateUserRequest struct {
-	Username    *string `json:"username"`
-	Email       *string `json:"email"`
-	FirstName   *string `json:"firstname"`
-	LastName    *string `json:"lastname"`
-	PhoneNumber *string `json:"phone_number"`
+	Username     *string `json:"username"`
+	Email        *string `json:"email"`
+	FirstName    *string `json:"firstname"`
+	LastName     *string `json:"lastname"`
+	PhoneNumber  *string `json:"phone_number"`
+	BaseCurrency *string `json:"base_currency"`
 }
 
 func (h *UserHandler) CreateUser(c *gin.Context) {
@@ -54,38 +54,25 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	authClient, err := h.firebaseApp.Auth(context.Background())
-	if err != nil {
-		response.ErrorResponse(c, "Failed to get Firebase Auth client", err, h.cfg.IsDevelopment())
-		return
-	}
-
 	displayName := strings.TrimSpace(req.FirstName + " " + req.LastName)
-	params := (&fbAuth.UserToCreate{}).
-		Email(req.Email).
-		Password(req.Password).
-		DisplayName(displayName)
-	if req.PhoneNumber != nil && strings.TrimSpace(*req.PhoneNumber) != "" {
-		params = params.PhoneNumber(*req.PhoneNumber)
-	}
-
-	fbUser, err := authClient.CreateUser(c.Request.Context(), params)
+	authUID, err := h.authenticator.CreateAuthUser(c.Request.Context(), req.Email, req.Password, displayName, req.PhoneNumber)
 	if err != nil {
-		response.ErrorResponse(c, "Failed to create Firebase Auth user", err, h.cfg.IsDevelopment())
+		response.ErrorResponse(c, "Failed to create auth user", err, h.cfg.IsDevelopment())
 		return
 	}
 
 	uid, err := h.Service.CreateUser(c.Request.Context(), dto.CreateUserInput{
-		UID:         fbUser.UID,
-		Username:    strings.TrimSpace(req.Username),
-		Email:       strings.TrimSpace(req.Email),
-		FirstName:   strings.TrimSpace(req.FirstName),
-		LastName:    strings.TrimSpace(req.LastName),
-		PhoneNumber: req.PhoneNumber,
+		UID:          authUID,
+		Username:     strings.TrimSpace(req.Username),
+		Email:        strings.TrimSpace(req.Email),
+		FirstName:    strings.TrimSpace(req.FirstName),
+		LastName:     strings.TrimSpace(req.LastName),
+		PhoneNumber:  req.PhoneNumber,
+		BaseCurrency: req.BaseCurrency,
 	})
 	if err != nil {
 
-		_ = authClient.DeleteUser(context.Background(), fbUser.UID)
+		_ = h.authenticator.DeleteAuthUser(context.Background(), authUID)
 		response.ErrorResponse(c, "failed to create user profile", err, h.cfg.IsDevelopment())
 		return
 	}
@@ -152,11 +139,12 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	ctx := c.Request.Context()
 	user, err := h.Service.UpdateUser(ctx, requestedUID, dto.UpdateUserInput{
-		Username:    req.Username,
-		Email:       req.Email,
-		FirstName:   req.FirstName,
-		LastName:    req.LastName,
-		PhoneNumber: req.PhoneNumber,
+		Username:     req.Username,
+		Email:        req.Email,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		PhoneNumber:  req.PhoneNumber,
+		BaseCurrency: req.BaseCurrency,
 	})
 	if err != nil {
 		response.ErrorResponse(c, "failed to update user", err, h.cfg.IsDevelopment())
@@ -0,0 +1,267 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errInvalidCredentials = status.Error(codes.Unauthenticated, "invalid email or password")
+
+// Authenticator is a self-contained replacement for Firebase Auth. Users and
+// Argon2id password hashes live in the configured database and access tokens
+// are JWTs signed with an Ed25519 or RSA key from AUTH_JWT_SIGNING_KEY_FILE.
+type Authenticator struct {
+	credentials ports.CredentialRepository
+	keys        *keySet
+	issuer      string
+	ttl         time.Duration
+	params      argon2Params
+	// dummyHash is verified against when the email is unknown so that a
+	// failed lookup takes as long as a wrong password.
+	dummyHash string
+}
+
+var (
+	_ ports.UserAuthenticator  = (*Authenticator)(nil)
+	_ ports.TokenAuthenticator = (*Authenticator)(nil)
+	_ ports.PasswordVerifier   = (*Authenticator)(nil)
+)
+
+// NewAuthenticator loads the signing keys named in cfg. Without a signing key
+// file an ephemeral Ed25519 key is generated, which is fine for development
+// but invalidates every token on restart.
+func NewAuthenticator(credentials ports.CredentialRepository, cfg config.AuthConfig) (*Authenticator, error) {
+	if credentials == nil {
+		return nil, fmt.Errorf("local auth requires a credential repository")
+	}
+
+	var (
+		keys *keySet
+		err  error
+	)
+	if cfg.SigningKeyFile != "" {
+		keys, err = loadKeySet(cfg.SigningKeyFile, cfg.VerificationKeyFiles)
+	} else {
+		keys, err = generateKeySet()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load JWT signing keys: %w", err)
+	}
+
+	return newAuthenticator(credentials, keys, cfg, defaultArgon2Params)
+}
+
+func newAuthenticator(credentials ports.CredentialRepository, keys *keySet, cfg config.AuthConfig, params argon2Params) (*Authenticator, error) {
+	dummyHash, err := hashPassword(uuid.NewString(), params)
+	if err != nil {
+		return nil, err
+	}
+	return &Authenticator{
+		credentials: credentials,
+		keys:        keys,
+		issuer:      cfg.JWTIssuer,
+		ttl:         cfg.AccessTokenTTL,
+		params:      params,
+		dummyHash:   dummyHash,
+	}, nil
+}
+
+func (a *Authenticator) CreateAuthUser(ctx context.Context, email, password, displayName string, phone *string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || password == "" {
+		return "", fmt.Errorf("email and password are required")
+	}
+
+	if _, err := a.credentials.GetCredentialByEmail(ctx, email); err == nil {
+		return "", status.Errorf(codes.AlreadyExists, "email already in use")
+	} else if status.Code(err) != codes.NotFound {
+		return "", err
+	}
+
+	hash, err := hashPassword(password, a.params)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	cred := &domain.Credential{
+		UID:          uuid.NewString(),
+		Email:        email,
+		DisplayName:  displayName,
+		PhoneNumber:  normalizePhone(phone),
+		PasswordHash: hash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := a.credentials.CreateCredential(ctx, cred); err != nil {
+		return "", err
+	}
+	return cred.UID, nil
+}
+
+func (a *Authenticator) GetAuthUser(ctx context.Context, uid string) error {
+	_, err := a.credentials.GetCredential(ctx, uid)
+	return err
+}
+
+func (a *Authenticator) UpdateAuthUser(ctx context.Context, uid string, email *string, displayName *string, phone *string) error {
+	cred, err := a.credentials.GetCredential(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	if email != nil {
+		newEmail := strings.ToLower(strings.TrimSpace(*email))
+		if existing, err := a.credentials.GetCredentialByEmail(ctx, newEmail); err == nil && existing.UID != uid {
+			return status.Errorf(codes.AlreadyExists, "email already in use")
+		} else if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		cred.Email = newEmail
+		changed = true
+	}
+	if displayName != nil {
+		cred.DisplayName = *displayName
+		changed = true
+	}
+	if p := normalizePhone(phone); p != nil {
+		cred.PhoneNumber = p
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	cred.UpdatedAt = time.Now().UTC()
+	return a.credentials.UpdateCredential(ctx, cred)
+}
+
+func (a *Authenticator) DeleteAuthUser(ctx context.Context, uid string) error {
+	return a.credentials.DeleteCredential(ctx, uid)
+}
+
+func (a *Authenticator) UpdatePassword(ctx context.Context, uid string, newPassword string) error {
+	cred, err := a.credentials.GetCredential(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if newPassword == "" {
+		return fmt.Errorf("password is required")
+	}
+	hash, err := hashPassword(newPassword, a.params)
+	if err != nil {
+		return err
+	}
+	cred.PasswordHash = hash
+	cred.UpdatedAt = time.Now().UTC()
+	return a.credentials.UpdateCredential(ctx, cred)
+}
+
+func (a *Authenticator) GeneratePasswordResetLink(ctx context.Context, email string) (string, error) {
+	return "", errors.New("password reset links are not available in local auth mode")
+}
+
+func (a *Authenticator) CreateCustomToken(ctx context.Context, userID string) (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Subject:   userID,
+		Issuer:    a.issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(a.ttl)),
+		ID:        uuid.NewString(),
+	}
+	return a.keys.sign(claims)
+}
+
+func (a *Authenticator) VerifyIDToken(ctx context.Context, idToken string) (string, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(idToken, &claims, a.keys.keyFunc,
+		jwt.WithValidMethods(a.keys.algorithms()),
+		jwt.WithIssuer(a.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return "", err
+	}
+	if claims.Subject == "" {
+		return "", fmt.Errorf("token has no subject")
+	}
+	return claims.Subject, nil
+}
+
+func (a *Authenticator) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	cred, err := a.credentials.GetCredentialByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return nil, err
+	}
+	return credentialUser(cred), nil
+}
+
+// VerifyPassword checks the password against the stored hash. Legacy bcrypt
+// hashes and hashes made with weaker parameters are upgraded on success; an
+// upgrade that cannot be saved is logged and tried again on the next login.
+func (a *Authenticator) VerifyPassword(ctx context.Context, email, password string) (*domain.User, error) {
+	cred, err := a.credentials.GetCredentialByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if status.Code(err) == codes.NotFound {
+		_, _, _ = verifyPassword(password, a.dummyHash, a.params)
+		return nil, errInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ok, rehash, err := verifyPassword(password, cred.PasswordHash, a.params)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errInvalidCredentials
+	}
+
+	if rehash {
+		if hash, err := hashPassword(password, a.params); err == nil {
+			cred.PasswordHash = hash
+			cred.UpdatedAt = time.Now().UTC()
+			if err := a.credentials.UpdateCredential(ctx, cred); err != nil {
+				logger.Warn("failed to upgrade password hash", zap.String("uid", cred.UID), zap.Error(err))
+			}
+		}
+	}
+	return credentialUser(cred), nil
+}
+
+func credentialUser(cred *domain.Credential) *domain.User {
+	return &domain.User{
+		UID:           cred.UID,
+		Username:      cred.DisplayName,
+		Email:         cred.Email,
+		FirstName:     cred.DisplayName,
+		PhoneNumber:   cred.PhoneNumber,
+		ProviderID:    "password",
+		EmailVerified: true,
+		CreatedAt:     cred.CreatedAt,
+		UpdatedAt:     cred.UpdatedAt,
+	}
+}
+
+func normalizePhone(phone *string) *string {
+	if phone == nil || strings.TrimSpace(*phone) == "" {
+		return nil
+	}
+	p := strings.TrimSpace(*phone)
+	return &p
+}
@@ -0,0 +1,193 @@
+package local
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testArgon2Params keeps hashing cheap in tests.
+var testArgon2Params = argon2Params{memory: 1024, time: 1, threads: 1, saltLen: 16, keyLen: 32}
+
+var testAuthConfig = config.AuthConfig{JWTIssuer: "budgeting", AccessTokenTTL: time.Hour}
+
+func writeKey(t *testing.T, dir, name string) string {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, name)
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func newTestAuthenticator(t *testing.T, signingKey string, verificationKeys ...string) (*Authenticator, *memory.CredentialRepository) {
+	t.Helper()
+	keys, err := loadKeySet(signingKey, verificationKeys)
+	if err != nil {
+		t.Fatalf("loadKeySet: %v", err)
+	}
+	creds := memory.NewCredentialRepository()
+	a, err := newAuthenticator(creds, keys, testAuthConfig, testArgon2Params)
+	if err != nil {
+		t.Fatalf("newAuthenticator: %v", err)
+	}
+	return a, creds
+}
+
+func TestVerifyPassword(t *testing.T) {
+	ctx := context.Background()
+	a, creds := newTestAuthenticator(t, writeKey(t, t.TempDir(), "current.pem"))
+
+	uid, err := a.CreateAuthUser(ctx, "Ama@Example.com", "correct horse", "Ama", nil)
+	if err != nil {
+		t.Fatalf("CreateAuthUser: %v", err)
+	}
+	cred, _ := creds.GetCredential(ctx, uid)
+	if cred.PasswordHash[:10] != "$argon2id$" {
+		t.Fatalf("hash = %q, want argon2id", cred.PasswordHash)
+	}
+
+	user, err := a.VerifyPassword(ctx, "ama@example.com", "correct horse")
+	if err != nil || user.UID != uid {
+		t.Fatalf("VerifyPassword = %v, %v", user, err)
+	}
+	if _, err := a.VerifyPassword(ctx, "ama@example.com", "wrong"); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("wrong password err = %v, want Unauthenticated", err)
+	}
+	if _, err := a.VerifyPassword(ctx, "kofi@example.com", "correct horse"); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("unknown email err = %v, want Unauthenticated", err)
+	}
+
+	// Legacy bcrypt hashes keep working and are upgraded on the next login.
+	legacy, _ := bcrypt.GenerateFromPassword([]byte("old secret"), bcrypt.MinCost)
+	cred.PasswordHash = string(legacy)
+	if err := creds.UpdateCredential(ctx, cred); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.VerifyPassword(ctx, "ama@example.com", "old secret"); err != nil {
+		t.Fatalf("VerifyPassword legacy: %v", err)
+	}
+	cred, _ = creds.GetCredential(ctx, uid)
+	if cred.PasswordHash[:10] != "$argon2id$" {
+		t.Fatalf("legacy hash not upgraded: %q", cred.PasswordHash)
+	}
+	if _, err := a.VerifyPassword(ctx, "ama@example.com", "old secret"); err != nil {
+		t.Fatalf("VerifyPassword after upgrade: %v", err)
+	}
+}
+
+// failingUpdates is a credential store that cannot save changes.
+type failingUpdates struct {
+	ports.CredentialRepository
+}
+
+func (failingUpdates) UpdateCredential(ctx context.Context, cred *domain.Credential) error {
+	return status.Error(codes.Unavailable, "store is read-only")
+}
+
+func TestVerifyPasswordSurvivesAFailedUpgrade(t *testing.T) {
+	logger.InitZaplogger(nil)
+	ctx := context.Background()
+	keys, err := loadKeySet(writeKey(t, t.TempDir(), "current.pem"), nil)
+	if err != nil {
+		t.Fatalf("loadKeySet: %v", err)
+	}
+	creds := memory.NewCredentialRepository()
+	legacy, _ := bcrypt.GenerateFromPassword([]byte("old secret"), bcrypt.MinCost)
+	if err := creds.CreateCredential(ctx, &domain.Credential{UID: "u1", Email: "ama@example.com", PasswordHash: string(legacy)}); err != nil {
+		t.Fatal(err)
+	}
+	a, err := newAuthenticator(failingUpdates{creds}, keys, testAuthConfig, testArgon2Params)
+	if err != nil {
+		t.Fatalf("newAuthenticator: %v", err)
+	}
+
+	for range 2 {
+		if user, err := a.VerifyPassword(ctx, "ama@example.com", "old secret"); err != nil || user.UID != "u1" {
+			t.Fatalf("VerifyPassword = %v, %v, want the user despite the failed upgrade", user, err)
+		}
+	}
+	if cred, _ := creds.GetCredential(ctx, "u1"); cred.PasswordHash != string(legacy) {
+		t.Fatalf("hash = %q, want the legacy hash kept", cred.PasswordHash)
+	}
+}
+
+func TestUpdateAuthUserRejectsTakenEmail(t *testing.T) {
+	ctx := context.Background()
+	a, _ := newTestAuthenticator(t, writeKey(t, t.TempDir(), "current.pem"))
+
+	ama, err := a.CreateAuthUser(ctx, "ama@example.com", "correct horse", "Ama", nil)
+	if err != nil {
+		t.Fatalf("CreateAuthUser: %v", err)
+	}
+	if _, err := a.CreateAuthUser(ctx, "kofi@example.com", "battery staple", "Kofi", nil); err != nil {
+		t.Fatalf("CreateAuthUser: %v", err)
+	}
+
+	taken := " Kofi@Example.com"
+	if err := a.UpdateAuthUser(ctx, ama, &taken, nil, nil); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("UpdateAuthUser(taken email) err = %v, want AlreadyExists", err)
+	}
+	if user, err := a.VerifyPassword(ctx, "kofi@example.com", "battery staple"); err != nil || user.UID == ama {
+		t.Fatalf("VerifyPassword(kofi) = %v, %v, want Kofi's account", user, err)
+	}
+
+	// Keeping one's own email is not a clash.
+	own := "AMA@example.com"
+	if err := a.UpdateAuthUser(ctx, ama, &own, nil, nil); err != nil {
+		t.Fatalf("UpdateAuthUser(own email): %v", err)
+	}
+}
+
+func TestAccessTokensSurviveKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	oldKey := writeKey(t, dir, "old.pem")
+	newKey := writeKey(t, dir, "new.pem")
+
+	before, _ := newTestAuthenticator(t, oldKey)
+	token, err := before.CreateCustomToken(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("CreateCustomToken: %v", err)
+	}
+
+	rotated, _ := newTestAuthenticator(t, newKey, oldKey)
+	if uid, err := rotated.VerifyIDToken(ctx, token); err != nil || uid != "user-1" {
+		t.Fatalf("VerifyIDToken after rotation = %q, %v", uid, err)
+	}
+	fresh, err := rotated.CreateCustomToken(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("CreateCustomToken: %v", err)
+	}
+	if _, err := before.VerifyIDToken(ctx, fresh); err == nil {
+		t.Fatal("token signed with an unknown key was accepted")
+	}
+
+	retired, _ := newTestAuthenticator(t, newKey)
+	if _, err := retired.VerifyIDToken(ctx, token); err == nil {
+		t.Fatal("token signed with a dropped key was accepted")
+	}
+}
@@ -0,0 +1,202 @@
+package local
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRSAKeyBits = 2048
+
+// verificationKey is a public key that access tokens may be signed with,
+// identified by the kid header of the token.
+type verificationKey struct {
+	method jwt.SigningMethod
+	public crypto.PublicKey
+}
+
+// keySet signs with a single current key and verifies with the current key
+// plus any retired keys, so keys can be rotated without invalidating tokens
+// that are still in flight.
+type keySet struct {
+	kid     string
+	method  jwt.SigningMethod
+	signer  crypto.Signer
+	byKeyID map[string]verificationKey
+}
+
+func newKeySet(signer crypto.Signer) (*keySet, error) {
+	method, err := signingMethod(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	kid, err := keyID(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &keySet{
+		kid:     kid,
+		method:  method,
+		signer:  signer,
+		byKeyID: map[string]verificationKey{kid: {method: method, public: signer.Public()}},
+	}, nil
+}
+
+// generateKeySet creates a key set around a fresh Ed25519 key. Tokens signed
+// with it stop verifying once the process exits.
+func generateKeySet() (*keySet, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newKeySet(priv)
+}
+
+// loadKeySet reads the PEM encoded signing key and the retired keys that are
+// still accepted for verification. Retired keys may be public or private.
+func loadKeySet(signingKeyFile string, verificationKeyFiles []string) (*keySet, error) {
+	block, err := readPEM(signingKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", signingKeyFile, err)
+	}
+	ks, err := newKeySet(signer)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", signingKeyFile, err)
+	}
+
+	for _, file := range verificationKeyFiles {
+		block, err := readPEM(file)
+		if err != nil {
+			return nil, err
+		}
+		public, err := parsePublicKey(block)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if err := ks.addVerificationKey(public); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return ks, nil
+}
+
+func (ks *keySet) addVerificationKey(public crypto.PublicKey) error {
+	method, err := signingMethod(public)
+	if err != nil {
+		return err
+	}
+	kid, err := keyID(public)
+	if err != nil {
+		return err
+	}
+	ks.byKeyID[kid] = verificationKey{method: method, public: public}
+	return nil
+}
+
+func (ks *keySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.method, claims)
+	token.Header["kid"] = ks.kid
+	return token.SignedString(ks.signer)
+}
+
+// keyFunc resolves the verification key from the token's kid and rejects
+// tokens whose alg does not match that key.
+func (ks *keySet) keyFunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	key, ok := ks.byKeyID[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if t.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+	}
+	return key.public, nil
+}
+
+func (ks *keySet) algorithms() []string {
+	return []string{jwt.SigningMethodEdDSA.Alg(), jwt.SigningMethodRS256.Alg()}
+}
+
+func signingMethod(public crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch k := public.(type) {
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA keys must be at least %d bits", minRSAKeyBits)
+		}
+		return jwt.SigningMethodRS256, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use Ed25519 or RSA", public)
+	}
+}
+
+// keyID derives a stable kid from the public key so the same key file always
+// yields the same identifier across restarts and instances.
+func keyID(public crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func readPEM(file string) (*pem.Block, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", file)
+	}
+	return block, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q for a signing key", block.Type)
+	}
+}
+
+func parsePublicKey(block *pem.Block) (crypto.PublicKey, error) {
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		signer, err := parsePrivateKey(block)
+		if err != nil {
+			return nil, err
+		}
+		return signer.Public(), nil
+	}
+}
@@ -0,0 +1,105 @@
+package local
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// argon2Params are the Argon2id cost settings used for new hashes. Stored
+// hashes carry their own parameters, so raising these only affects new and
+// rehashed passwords.
+type argon2Params struct {
+	memory  uint32 // KiB
+	time    uint32
+	threads uint8
+	saltLen uint32
+	keyLen  uint32
+}
+
+// defaultArgon2Params follows the second recommended option of RFC 9106.
+var defaultArgon2Params = argon2Params{
+	memory:  64 * 1024,
+	time:    3,
+	threads: 4,
+	saltLen: 16,
+	keyLen:  32,
+}
+
+var errMalformedHash = errors.New("malformed password hash")
+
+// hashPassword returns the PHC string encoding of an Argon2id hash, e.g.
+// $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>.
+func hashPassword(password string, p argon2Params) (string, error) {
+	salt := make([]byte, p.saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, p.time, p.memory, p.threads, p.keyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.memory, p.time, p.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyPassword reports whether password matches the encoded hash and whether
+// the hash should be replaced because it is a legacy bcrypt hash or was made
+// with weaker parameters than p.
+func verifyPassword(password, encoded string, p argon2Params) (ok, rehash bool, err error) {
+	if strings.HasPrefix(encoded, "$2") {
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, false, nil
+		}
+		return err == nil, true, err
+	}
+
+	stored, salt, key, err := decodeHash(encoded)
+	if err != nil {
+		return false, false, err
+	}
+	other := argon2.IDKey([]byte(password), salt, stored.time, stored.memory, stored.threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return false, false, nil
+	}
+	weaker := stored.memory < p.memory || stored.time < p.time || stored.threads < p.threads || uint32(len(key)) < p.keyLen
+	return true, weaker, nil
+}
+
+func decodeHash(encoded string) (argon2Params, []byte, []byte, error) {
+	var p argon2Params
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return p, nil, nil, errMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return p, nil, nil, errMalformedHash
+	}
+	if version != argon2.Version {
+		return p, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return p, nil, nil, errMalformedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, errMalformedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, errMalformedHash
+	}
+	p.saltLen = uint32(len(salt))
+	p.keyLen = uint32(len(key))
+	return p, salt, key, nil
+}
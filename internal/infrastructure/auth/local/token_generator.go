@@ -0,0 +1,28 @@
+package local
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/theHinneh/budgeting/internal/application/ports"
+)
+
+type TokenGenerator struct{}
+
+func NewTokenGenerator() ports.TokenGenerator {
+	return &TokenGenerator{}
+}
+
+func (g *TokenGenerator) GenerateSecureToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
+
+func (g *TokenGenerator) HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
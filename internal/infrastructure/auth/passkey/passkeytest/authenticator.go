@@ -0,0 +1,244 @@
+// Package passkeytest provides a software WebAuthn authenticator, so passkey
+// registration and login can be exercised end to end without a browser.
+package passkeytest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+// Authenticator flags, see §6.1 of the WebAuthn spec.
+const (
+	flagUserPresent    = 0x01
+	flagUserVerified   = 0x04
+	flagBackupEligible = 0x08
+	flagBackupState    = 0x10
+	flagAttestedData   = 0x40
+)
+
+// Authenticator answers the options produced by the server the way
+// navigator.credentials.create() and get() would, with "none" attestation and
+// ES256 keys held in memory.
+type Authenticator struct {
+	origin string
+	// Counting makes assertions carry an increasing signature counter like a
+	// hardware security key. By default the counter stays at zero, as it does
+	// for synced passkeys.
+	Counting    bool
+	credentials []*credential
+}
+
+type credential struct {
+	id         []byte
+	rpID       string
+	userHandle []byte
+	key        *ecdsa.PrivateKey
+	counter    uint32
+}
+
+// New returns an authenticator whose responses claim to come from origin,
+// e.g. "https://app.example.com".
+func New(origin string) *Authenticator {
+	return &Authenticator{origin: origin}
+}
+
+// Clone copies the authenticator including its private keys and counters, as
+// an attacker who extracted the keys would.
+func (a *Authenticator) Clone() *Authenticator {
+	cp := &Authenticator{origin: a.origin, Counting: a.Counting}
+	for _, c := range a.credentials {
+		dup := *c
+		cp.credentials = append(cp.credentials, &dup)
+	}
+	return cp
+}
+
+type creationOptions struct {
+	PublicKey struct {
+		RP struct {
+			ID string `json:"id"`
+		} `json:"rp"`
+		User struct {
+			ID string `json:"id"`
+		} `json:"user"`
+		Challenge          string       `json:"challenge"`
+		ExcludeCredentials []descriptor `json:"excludeCredentials"`
+	} `json:"publicKey"`
+}
+
+type requestOptions struct {
+	PublicKey struct {
+		Challenge        string       `json:"challenge"`
+		RPID             string       `json:"rpId"`
+		AllowCredentials []descriptor `json:"allowCredentials"`
+	} `json:"publicKey"`
+}
+
+type descriptor struct {
+	ID string `json:"id"`
+}
+
+// Register creates a credential for the creation options and returns the
+// PublicKeyCredential JSON to send back to the server.
+func (a *Authenticator) Register(options []byte) ([]byte, error) {
+	var opts creationOptions
+	if err := json.Unmarshal(options, &opts); err != nil {
+		return nil, err
+	}
+	rpID := opts.PublicKey.RP.ID
+	for _, excluded := range opts.PublicKey.ExcludeCredentials {
+		for _, c := range a.credentials {
+			if c.rpID == rpID && b64(c.id) == excluded.ID {
+				return nil, fmt.Errorf("authenticator already holds an excluded credential")
+			}
+		}
+	}
+	userHandle, err := base64.RawURLEncoding.DecodeString(opts.PublicKey.User.ID)
+	if err != nil {
+		return nil, fmt.Errorf("decode user handle: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	c := &credential{id: make([]byte, 32), rpID: rpID, userHandle: userHandle, key: key}
+	if _, err := rand.Read(c.id); err != nil {
+		return nil, err
+	}
+
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  1, // P-256
+		XCoord: key.X.FillBytes(make([]byte, 32)),
+		YCoord: key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	authData := a.authenticatorData(c, flagAttestedData)
+	authData = append(authData, make([]byte, 16)...) // zero AAGUID
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(c.id)))
+	authData = append(authData, c.id...)
+	authData = append(authData, publicKey...)
+
+	attestation, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+	if err != nil {
+		return nil, err
+	}
+	clientData, err := a.clientData("webauthn.create", opts.PublicKey.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	a.credentials = append(a.credentials, c)
+	return json.Marshal(map[string]any{
+		"id":                      b64(c.id),
+		"rawId":                   b64(c.id),
+		"type":                    "public-key",
+		"authenticatorAttachment": "platform",
+		"clientExtensionResults":  map[string]any{},
+		"response": map[string]any{
+			"clientDataJSON":    b64(clientData),
+			"attestationObject": b64(attestation),
+			"transports":        []string{"internal"},
+		},
+	})
+}
+
+// Login signs the request options with the first credential it holds for the
+// relying party and returns the PublicKeyCredential JSON.
+func (a *Authenticator) Login(options []byte) ([]byte, error) {
+	var opts requestOptions
+	if err := json.Unmarshal(options, &opts); err != nil {
+		return nil, err
+	}
+	c := a.find(opts.PublicKey.RPID, opts.PublicKey.AllowCredentials)
+	if c == nil {
+		return nil, fmt.Errorf("no credential for %q", opts.PublicKey.RPID)
+	}
+	if a.Counting {
+		c.counter++
+	}
+
+	authData := a.authenticatorData(c, 0)
+	clientData, err := a.clientData("webauthn.get", opts.PublicKey.Challenge)
+	if err != nil {
+		return nil, err
+	}
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, c.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]any{
+		"id":                      b64(c.id),
+		"rawId":                   b64(c.id),
+		"type":                    "public-key",
+		"authenticatorAttachment": "platform",
+		"clientExtensionResults":  map[string]any{},
+		"response": map[string]any{
+			"clientDataJSON":    b64(clientData),
+			"authenticatorData": b64(authData),
+			"signature":         b64(signature),
+			"userHandle":        b64(c.userHandle),
+		},
+	})
+}
+
+func (a *Authenticator) find(rpID string, allowed []descriptor) *credential {
+	for _, c := range a.credentials {
+		if c.rpID != rpID {
+			continue
+		}
+		if len(allowed) == 0 {
+			return c
+		}
+		for _, d := range allowed {
+			if d.ID == b64(c.id) {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// authenticatorData returns the RP ID hash, flags and counter. The user is
+// always present and verified, and the credential is a backed up passkey.
+func (a *Authenticator) authenticatorData(c *credential, extraFlags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(c.rpID))
+	data := append([]byte(nil), rpIDHash[:]...)
+	data = append(data, flagUserPresent|flagUserVerified|flagBackupEligible|flagBackupState|extraFlags)
+	return binary.BigEndian.AppendUint32(data, c.counter)
+}
+
+func (a *Authenticator) clientData(ceremony, challenge string) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"type":        ceremony,
+		"challenge":   challenge,
+		"origin":      a.origin,
+		"crossOrigin": false,
+	})
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
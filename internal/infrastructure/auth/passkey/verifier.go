@@ -0,0 +1,222 @@
+package passkey
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+)
+
+// maxUserHandle is the WebAuthn limit on the user handle, which is the UID.
+const maxUserHandle = 64
+
+// Verifier runs the WebAuthn ceremonies for one relying party with
+// go-webauthn. Credentials are created as discoverable passkeys and every
+// ceremony requires user verification, so a login needs no password.
+type Verifier struct {
+	webAuthn *webauthn.WebAuthn
+}
+
+var _ ports.PasskeyVerifier = (*Verifier)(nil)
+
+func NewVerifier(cfg config.AuthConfig) (*Verifier, error) {
+	if cfg.PasskeyRPID == "" {
+		return nil, fmt.Errorf("passkeys require a relying party ID")
+	}
+	w, err := webauthn.New(&webauthn.Config{
+		RPID:                  cfg.PasskeyRPID,
+		RPDisplayName:         cfg.PasskeyRPName,
+		RPOrigins:             cfg.PasskeyOrigins,
+		AttestationPreference: protocol.PreferNoAttestation,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			UserVerification:   protocol.VerificationRequired,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{webAuthn: w}, nil
+}
+
+func (v *Verifier) BeginRegistration(user *domain.User, existing []*domain.Passkey) (json.RawMessage, []byte, error) {
+	u, err := newUser(user, existing)
+	if err != nil {
+		return nil, nil, err
+	}
+	creation, session, err := v.webAuthn.BeginRegistration(u,
+		webauthn.WithExclusions(webauthn.Credentials(u.credentials).CredentialDescriptors()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return marshalCeremony(creation, session)
+}
+
+func (v *Verifier) FinishRegistration(user *domain.User, existing []*domain.Passkey, session, response []byte) (*domain.Passkey, error) {
+	u, err := newUser(user, existing)
+	if err != nil {
+		return nil, err
+	}
+	var data webauthn.SessionData
+	if err := json.Unmarshal(session, &data); err != nil {
+		return nil, fmt.Errorf("decode passkey session: %w", err)
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return nil, describe(err)
+	}
+	credential, err := v.webAuthn.CreateCredential(u, data, parsed)
+	if err != nil {
+		return nil, describe(err)
+	}
+	return &domain.Passkey{
+		ID:              base64.RawURLEncoding.EncodeToString(credential.ID),
+		UserID:          user.UID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		AAGUID:          credential.Authenticator.AAGUID,
+		Transports:      transports(credential.Transport),
+		SignCount:       credential.Authenticator.SignCount,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+	}, nil
+}
+
+func (v *Verifier) BeginLogin() (json.RawMessage, []byte, error) {
+	assertion, session, err := v.webAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		return nil, nil, err
+	}
+	return marshalCeremony(assertion, session)
+}
+
+func (v *Verifier) FinishLogin(session, response []byte, lookup func(userID string) ([]*domain.Passkey, error)) (*domain.Passkey, bool, error) {
+	var data webauthn.SessionData
+	if err := json.Unmarshal(session, &data); err != nil {
+		return nil, false, fmt.Errorf("decode passkey session: %w", err)
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return nil, false, describe(err)
+	}
+
+	var passkeys []*domain.Passkey
+	handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+		found, err := lookup(string(userHandle))
+		if err != nil {
+			return nil, err
+		}
+		passkeys = found
+		return newUser(&domain.User{UID: string(userHandle)}, passkeys)
+	}
+	_, credential, err := v.webAuthn.ValidatePasskeyLogin(handler, data, parsed)
+	if err != nil {
+		return nil, false, describe(err)
+	}
+
+	for _, p := range passkeys {
+		if p.ID != base64.RawURLEncoding.EncodeToString(credential.ID) {
+			continue
+		}
+		used := *p
+		used.SignCount = parsed.Response.AuthenticatorData.Counter
+		used.BackupState = credential.Flags.BackupState
+		return &used, credential.Authenticator.CloneWarning, nil
+	}
+	return nil, false, fmt.Errorf("passkey %x not found", credential.ID)
+}
+
+// user adapts a domain user and their passkeys to webauthn.User. The user
+// handle is the UID, which the authenticator hands back on login.
+type user struct {
+	id          []byte
+	name        string
+	displayName string
+	credentials []webauthn.Credential
+}
+
+func newUser(u *domain.User, passkeys []*domain.Passkey) (*user, error) {
+	if u.UID == "" || len(u.UID) > maxUserHandle {
+		return nil, fmt.Errorf("user ID cannot be used as a passkey user handle")
+	}
+	wu := &user{
+		id:          []byte(u.UID),
+		name:        u.Email,
+		displayName: strings.TrimSpace(u.FirstName + " " + u.LastName),
+	}
+	if wu.name == "" {
+		wu.name = u.UID
+	}
+	if wu.displayName == "" {
+		wu.displayName = wu.name
+	}
+	for _, p := range passkeys {
+		if p.UserID != u.UID {
+			continue
+		}
+		id, err := base64.RawURLEncoding.DecodeString(p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("decode passkey ID: %w", err)
+		}
+		credential := webauthn.Credential{
+			ID:              id,
+			PublicKey:       p.PublicKey,
+			AttestationType: p.AttestationType,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: p.BackupEligible,
+				BackupState:    p.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    p.AAGUID,
+				SignCount: p.SignCount,
+			},
+		}
+		for _, t := range p.Transports {
+			credential.Transport = append(credential.Transport, protocol.AuthenticatorTransport(t))
+		}
+		wu.credentials = append(wu.credentials, credential)
+	}
+	return wu, nil
+}
+
+func (u *user) WebAuthnID() []byte                         { return u.id }
+func (u *user) WebAuthnName() string                       { return u.name }
+func (u *user) WebAuthnDisplayName() string                { return u.displayName }
+func (u *user) WebAuthnCredentials() []webauthn.Credential { return u.credentials }
+
+func marshalCeremony(options any, session *webauthn.SessionData) (json.RawMessage, []byte, error) {
+	rawOptions, err := json.Marshal(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	rawSession, err := json.Marshal(session)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rawOptions, rawSession, nil
+}
+
+func transports(ts []protocol.AuthenticatorTransport) []string {
+	var out []string
+	for _, t := range ts {
+		out = append(out, string(t))
+	}
+	return out
+}
+
+// describe keeps the detail go-webauthn puts next to its short error type.
+func describe(err error) error {
+	var perr *protocol.Error
+	if errors.As(err, &perr) && perr.DevInfo != "" {
+		return fmt.Errorf("%s: %s", perr.Details, perr.DevInfo)
+	}
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -18,6 +19,10 @@ const (
 type ServerConfig struct {
 	Port       string
 	CORSOrigin string
+	// TrustedProxies lists the proxy addresses or CIDRs whose
+	// X-Forwarded-For header is believed. With none, the client address is
+	// always the peer of the connection.
+	TrustedProxies []string
 }
 
 type DatabaseConfig struct {
@@ -37,6 +42,41 @@ type DatabaseConfig struct {
 	PgSSLCert          string
 	PgSSLKey           string
 	PgSSLRootCert      string
+	AutoMigrate        bool
+}
+
+type AuthConfig struct {
+	Provider             string
+	SigningKeyFile       string   // PEM encoded Ed25519 or RSA private key
+	VerificationKeyFiles []string // retired keys still accepted during rotation
+	JWTIssuer            string
+	AccessTokenTTL       time.Duration
+	// Passkeys are offered when PasskeyRPID, the domain the credentials are
+	// scoped to, is set. PasskeyOrigins lists the origins allowed to use them,
+	// e.g. "https://app.example.com".
+	PasskeyRPID    string
+	PasskeyRPName  string
+	PasskeyOrigins []string
+	// AccountUnlockURL is the page linked from the email sent when failed
+	// logins lock an account; the unlock token is added as ?token=.
+	AccountUnlockURL string
+}
+
+// MailConfig is the SMTP relay for outgoing email. No email is sent while
+// Host is empty.
+type MailConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type ExchangeRateConfig struct {
+	Provider      string // "database", "static" or "file"
+	RatesFile     string
+	StaticRates   string // e.g. "EUR/USD=1.08,USD/GHS=15.5"
+	PivotCurrency string // cross currency for pairs not stored in the database
 }
 
 type Configuration struct {
@@ -83,13 +123,30 @@ func (c *Configuration) GetServerConfig() ServerConfig {
 		return ""
 	}
 
-	return ServerConfig{
+	cfg := ServerConfig{
 		Port:       getStr("SERVER_PORT", "server.port"),
 		CORSOrigin: getStr("CORS_ALLOW_ORIGIN", "server.cors_allow_origin"),
 	}
+	for _, proxy := range strings.Split(getStr("SERVER_TRUSTED_PROXIES", "server.trusted_proxies"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			cfg.TrustedProxies = append(cfg.TrustedProxies, proxy)
+		}
+	}
+	return cfg
 }
 
 func (c *Configuration) GetDatabaseConfig() DatabaseConfig {
+	return c.databaseConfig("DB_", "database.")
+}
+
+// GetTargetDatabaseConfig describes the backend that `server migrate-data`
+// copies into. It reads the same keys as GetDatabaseConfig with a TARGET_
+// (or target_database.) prefix.
+func (c *Configuration) GetTargetDatabaseConfig() DatabaseConfig {
+	return c.databaseConfig("TARGET_DB_", "target_database.")
+}
+
+func (c *Configuration) databaseConfig(envPrefix, keyPrefix string) DatabaseConfig {
 	getStr := func(primary string, fallbacks ...string) string {
 		if v := c.V.GetString(primary); v != "" {
 			return v
@@ -125,23 +182,129 @@ func (c *Configuration) GetDatabaseConfig() DatabaseConfig {
 	}
 
 	return DatabaseConfig{
-		Driver:             getStr("DB_DRIVER", "database.driver"),
-		Host:               getStr("DB_HOST", "database.host"),
-		Port:               getStr("DB_PORT", "database.port"),
-		Name:               getStr("DB_NAME", "database.name"),
-		User:               getStr("DB_USER", "database.user"),
-		Password:           getStr("DB_PASSWORD", "database.password"),
-		SSLMode:            getStr("DB_SSLMODE", "database.sslmode"),
-		MaxOpenConnections: getInt("DB_MAX_OPEN_CONNS", "database.max_open_connections"),
-		MaxIdleConnections: getInt("DB_MAX_IDLE_CONNS", "database.max_idle_connections"),
-		ConnMaxLifetime:    getDur("DB_CONN_MAX_LIFETIME", "database.connection_max_lifetime"),
-		ConnTimeout:        getDur("DB_CONN_TIMEOUT", "database.connection_timeout"),
-		PgSchema:           getStr("DB_PG_SCHEMA", "database.postgres.schema"),
-		PgSearchPath:       getStr("DB_PG_SEARCH_PATH", "database.postgres.search_path"),
-		PgSSLCert:          getStr("DB_PG_SSLCERT", "database.postgres.sslcert"),
-		PgSSLKey:           getStr("DB_PG_SSLKEY", "database.postgres.sslkey"),
-		PgSSLRootCert:      getStr("DB_PG_SSLROOTCERT", "database.postgres.sslrootcert"),
+		Driver:             getStr(envPrefix+"DRIVER", keyPrefix+"driver"),
+		Host:               getStr(envPrefix+"HOST", keyPrefix+"host"),
+		Port:               getStr(envPrefix+"PORT", keyPrefix+"port"),
+		Name:               getStr(envPrefix+"NAME", keyPrefix+"name"),
+		User:               getStr(envPrefix+"USER", keyPrefix+"user"),
+		Password:           getStr(envPrefix+"PASSWORD", keyPrefix+"password"),
+		SSLMode:            getStr(envPrefix+"SSLMODE", keyPrefix+"sslmode"),
+		MaxOpenConnections: getInt(envPrefix+"MAX_OPEN_CONNS", keyPrefix+"max_open_connections"),
+		MaxIdleConnections: getInt(envPrefix+"MAX_IDLE_CONNS", keyPrefix+"max_idle_connections"),
+		ConnMaxLifetime:    getDur(envPrefix+"CONN_MAX_LIFETIME", keyPrefix+"connection_max_lifetime"),
+		ConnTimeout:        getDur(envPrefix+"CONN_TIMEOUT", keyPrefix+"connection_timeout"),
+		PgSchema:           getStr(envPrefix+"PG_SCHEMA", keyPrefix+"postgres.schema"),
+		PgSearchPath:       getStr(envPrefix+"PG_SEARCH_PATH", keyPrefix+"postgres.search_path"),
+		PgSSLCert:          getStr(envPrefix+"PG_SSLCERT", keyPrefix+"postgres.sslcert"),
+		PgSSLKey:           getStr(envPrefix+"PG_SSLKEY", keyPrefix+"postgres.sslkey"),
+		PgSSLRootCert:      getStr(envPrefix+"PG_SSLROOTCERT", keyPrefix+"postgres.sslrootcert"),
+		AutoMigrate:        c.V.GetBool(envPrefix+"AUTO_MIGRATE") || c.V.GetBool(keyPrefix+"auto_migrate"),
+	}
+}
+
+func (c *Configuration) GetAuthConfig() AuthConfig {
+	getStr := func(primary string, fallbacks ...string) string {
+		if v := c.V.GetString(primary); v != "" {
+			return v
+		}
+		for _, fb := range fallbacks {
+			if v := c.V.GetString(fb); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	getDur := func(primary string, fallbacks ...string) time.Duration {
+		if v := c.V.GetDuration(primary); v != 0 {
+			return v
+		}
+		for _, fb := range fallbacks {
+			if v := c.V.GetDuration(fb); v != 0 {
+				return v
+			}
+		}
+		return 0
+	}
+
+	cfg := AuthConfig{
+		Provider:         getStr("AUTH_PROVIDER", "auth.provider"),
+		SigningKeyFile:   getStr("AUTH_JWT_SIGNING_KEY_FILE", "auth.jwt_signing_key_file"),
+		JWTIssuer:        getStr("AUTH_JWT_ISSUER", "auth.jwt_issuer"),
+		AccessTokenTTL:   getDur("AUTH_ACCESS_TOKEN_TTL", "auth.access_token_ttl"),
+		PasskeyRPID:      getStr("AUTH_PASSKEY_RP_ID", "auth.passkey_rp_id"),
+		PasskeyRPName:    getStr("AUTH_PASSKEY_RP_NAME", "auth.passkey_rp_name"),
+		AccountUnlockURL: getStr("AUTH_ACCOUNT_UNLOCK_URL", "auth.account_unlock_url"),
+	}
+	for _, file := range strings.Split(getStr("AUTH_JWT_VERIFICATION_KEY_FILES", "auth.jwt_verification_key_files"), ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			cfg.VerificationKeyFiles = append(cfg.VerificationKeyFiles, file)
+		}
+	}
+	for _, origin := range strings.Split(getStr("AUTH_PASSKEY_ORIGINS", "auth.passkey_origins"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			cfg.PasskeyOrigins = append(cfg.PasskeyOrigins, origin)
+		}
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = "firebase"
+	}
+	if cfg.JWTIssuer == "" {
+		cfg.JWTIssuer = "budgeting"
+	}
+	if cfg.AccessTokenTTL == 0 {
+		cfg.AccessTokenTTL = time.Hour
+	}
+	if cfg.PasskeyRPName == "" {
+		cfg.PasskeyRPName = "Budgeting"
+	}
+	return cfg
+}
+
+func (c *Configuration) GetMailConfig() MailConfig {
+	getStr := func(primary, fallback string) string {
+		if v := c.V.GetString(primary); v != "" {
+			return v
+		}
+		return c.V.GetString(fallback)
+	}
+
+	cfg := MailConfig{
+		Host:     getStr("SMTP_HOST", "mail.host"),
+		Port:     getStr("SMTP_PORT", "mail.port"),
+		Username: getStr("SMTP_USERNAME", "mail.username"),
+		Password: getStr("SMTP_PASSWORD", "mail.password"),
+		From:     getStr("SMTP_FROM", "mail.from"),
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	if cfg.From == "" {
+		cfg.From = cfg.Username
+	}
+	return cfg
+}
+
+func (c *Configuration) GetExchangeRateConfig() ExchangeRateConfig {
+	getStr := func(primary, fallback string) string {
+		if v := c.V.GetString(primary); v != "" {
+			return v
+		}
+		return c.V.GetString(fallback)
+	}
+
+	cfg := ExchangeRateConfig{
+		Provider:      getStr("FX_PROVIDER", "exchange_rates.provider"),
+		RatesFile:     getStr("FX_RATES_FILE", "exchange_rates.file"),
+		StaticRates:   getStr("FX_STATIC_RATES", "exchange_rates.static"),
+		PivotCurrency: getStr("FX_PIVOT_CURRENCY", "exchange_rates.pivot_currency"),
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = "database"
+	}
+	if cfg.PivotCurrency == "" {
+		cfg.PivotCurrency = "EUR"
 	}
+	return cfg
 }
 
 func (c *Configuration) GetPathToConfig() string {
@@ -0,0 +1,1299 @@
+// Package dbtest holds the repository conformance suite. Every storage
+// backend runs it from its own _test.go file so that behaviour the services
+// rely on (ordering, due-date queries, revocation) stays identical across
+// drivers.
+//
+// Each test uses freshly generated user IDs, so the suite can run against a
+// shared database such as a Postgres instance or the Firestore emulator.
+package dbtest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Backend is the set of repositories under test. Credentials may be nil for
+// backends that do not support the local auth provider.
+type Backend struct {
+	Users          ports.UserRepository
+	Incomes        ports.IncomeRepoPort
+	Expenses       ports.ExpenseRepoPort
+	RefreshTokens  ports.RefreshTokenRepository
+	SecurityEvents ports.SecurityEventRepoPort
+	TwoFactor      ports.TwoFactorRepoPort
+	Passkeys       ports.PasskeyRepoPort
+	LoginAttempts  ports.LoginAttemptRepoPort
+	Credentials    ports.CredentialRepository
+	Categories     ports.CategoryRepoPort
+	Accounts       ports.AccountRepoPort
+	Transfers      ports.TransferRepoPort
+	Budgets        ports.BudgetRepoPort
+	Envelopes      ports.EnvelopeRepoPort
+	SavingsGoals   ports.SavingsGoalRepoPort
+	Debts          ports.DebtRepoPort
+	Holdings       ports.HoldingRepoPort
+	NetWorth       ports.NetWorthSnapshotRepoPort
+	ExchangeRates  ports.ExchangeRateRepository
+}
+
+// Run executes the whole suite against the backend returned by newBackend.
+func Run(t *testing.T, newBackend func(t *testing.T) Backend) {
+	t.Run("Users", func(t *testing.T) { testUsers(t, newBackend(t).Users) })
+	t.Run("Incomes", func(t *testing.T) { testIncomes(t, newBackend(t).Incomes) })
+	t.Run("IncomeSources", func(t *testing.T) { testIncomeSources(t, newBackend(t).Incomes) })
+	t.Run("Expenses", func(t *testing.T) { testExpenses(t, newBackend(t).Expenses) })
+	t.Run("RefreshTokens", func(t *testing.T) { testRefreshTokens(t, newBackend(t).RefreshTokens) })
+	t.Run("RefreshTokenRotation", func(t *testing.T) { testRefreshTokenRotation(t, newBackend(t).RefreshTokens) })
+	t.Run("SecurityEvents", func(t *testing.T) { testSecurityEvents(t, newBackend(t).SecurityEvents) })
+	t.Run("TwoFactor", func(t *testing.T) { testTwoFactor(t, newBackend(t).TwoFactor) })
+	t.Run("Passkeys", func(t *testing.T) { testPasskeys(t, newBackend(t).Passkeys) })
+	t.Run("LoginAttempts", func(t *testing.T) { testLoginAttempts(t, newBackend(t).LoginAttempts) })
+	t.Run("Credentials", func(t *testing.T) {
+		b := newBackend(t)
+		if b.Credentials == nil {
+			t.Skip("backend has no credential repository")
+		}
+		testCredentials(t, b.Credentials)
+	})
+	t.Run("Categories", func(t *testing.T) { testCategories(t, newBackend(t).Categories) })
+	t.Run("Accounts", func(t *testing.T) { testAccounts(t, newBackend(t).Accounts) })
+	t.Run("Transfers", func(t *testing.T) { testTransfers(t, newBackend(t).Transfers) })
+	t.Run("Budgets", func(t *testing.T) { testBudgets(t, newBackend(t).Budgets) })
+	t.Run("Envelopes", func(t *testing.T) { testEnvelopes(t, newBackend(t).Envelopes) })
+	t.Run("SavingsGoals", func(t *testing.T) { testSavingsGoals(t, newBackend(t).SavingsGoals) })
+	t.Run("Debts", func(t *testing.T) { testDebts(t, newBackend(t).Debts) })
+	t.Run("Holdings", func(t *testing.T) { testHoldings(t, newBackend(t).Holdings) })
+	t.Run("NetWorthSnapshots", func(t *testing.T) { testNetWorthSnapshots(t, newBackend(t).NetWorth) })
+	t.Run("ExchangeRates", func(t *testing.T) { testExchangeRates(t, newBackend(t).ExchangeRates) })
+}
+
+// base is a fixed, second-aligned instant so timestamps survive the
+// precision of every backend.
+var base = time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+func newID() string { return uuid.NewString() }
+
+func requireNoError(t *testing.T, err error, what string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", what, err)
+	}
+}
+
+func requireNotFound(t *testing.T, err error, what string) {
+	t.Helper()
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("%s: expected NotFound, got %v", what, err)
+	}
+}
+
+func sameInstant(a, b time.Time) bool {
+	return a.Truncate(time.Millisecond).Equal(b.Truncate(time.Millisecond))
+}
+
+func testUsers(t *testing.T, repo ports.UserRepository) {
+	ctx := context.Background()
+	phone := "+233200000000"
+	u := domain.NewUser(newID(), "Kofi", "Kofi-"+newID()+"@Example.com", "Kofi", "Boateng", &phone)
+	u.CreatedAt, u.UpdatedAt = base, base
+
+	_, err := repo.CreateUser(ctx, u)
+	requireNoError(t, err, "CreateUser")
+
+	got, err := repo.GetUser(ctx, u.UID)
+	requireNoError(t, err, "GetUser")
+	if got.Email != u.Email || got.FirstName != "Kofi" || got.PhoneNumber == nil || *got.PhoneNumber != phone {
+		t.Fatalf("GetUser returned %+v", got)
+	}
+	if !sameInstant(got.CreatedAt, base) {
+		t.Fatalf("CreatedAt = %v, want %v", got.CreatedAt, base)
+	}
+
+	byEmail, err := repo.GetUserByEmail(ctx, u.Email)
+	requireNoError(t, err, "GetUserByEmail")
+	if byEmail.UID != u.UID {
+		t.Fatalf("GetUserByEmail returned uid %q, want %q", byEmail.UID, u.UID)
+	}
+
+	_, err = repo.GetUser(ctx, newID())
+	requireNotFound(t, err, "GetUser(missing)")
+
+	got.LastName = "Mensah"
+	got.BaseCurrency = "GHS"
+	got.UpdatedAt = base.Add(time.Hour)
+	_, err = repo.UpdateUser(ctx, got)
+	requireNoError(t, err, "UpdateUser")
+	got, err = repo.GetUser(ctx, u.UID)
+	requireNoError(t, err, "GetUser after update")
+	if got.LastName != "Mensah" || got.BaseCurrency != "GHS" || !sameInstant(got.CreatedAt, base) {
+		t.Fatalf("UpdateUser did not persist: %+v", got)
+	}
+
+	missing := *u
+	missing.UID = newID()
+	_, err = repo.UpdateUser(ctx, &missing)
+	requireNotFound(t, err, "UpdateUser(missing)")
+
+	ids, err := repo.ListAllUserIDs(ctx)
+	requireNoError(t, err, "ListAllUserIDs")
+	if !contains(ids, u.UID) {
+		t.Fatalf("ListAllUserIDs does not contain %q", u.UID)
+	}
+
+	requireNoError(t, repo.DeleteUser(ctx, u.UID), "DeleteUser")
+	_, err = repo.GetUser(ctx, u.UID)
+	requireNotFound(t, err, "GetUser after delete")
+}
+
+func testIncomes(t *testing.T, repo ports.IncomeRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		inc := &domain.Income{
+			UID:        newID(),
+			UserID:     userID,
+			Source:     "salary",
+			CategoryID: "cat-salary",
+			Tags:       []string{"payroll", "work"},
+			AccountID:  "acct-checking",
+			Amount:     domain.NewMoney(int64(10000*(i+1)), "GHS"),
+			CreatedAt:  base.Add(time.Duration(i) * time.Hour),
+			UpdatedAt:  base.Add(time.Duration(i) * time.Hour),
+		}
+		_, err := repo.CreateIncome(ctx, inc)
+		requireNoError(t, err, "CreateIncome")
+		ids = append(ids, inc.UID)
+	}
+	_, err := repo.CreateIncome(ctx, &domain.Income{UID: newID(), UserID: newID(), Source: "other", Amount: domain.NewMoney(1, "GHS"), CreatedAt: base, UpdatedAt: base})
+	requireNoError(t, err, "CreateIncome(other user)")
+
+	list, err := repo.ListIncomesByUser(ctx, userID)
+	requireNoError(t, err, "ListIncomesByUser")
+	if len(list) != 3 {
+		t.Fatalf("ListIncomesByUser returned %d incomes, want 3", len(list))
+	}
+	for i, want := range []string{ids[2], ids[1], ids[0]} {
+		if list[i].UID != want {
+			t.Fatalf("ListIncomesByUser[%d] = %q, want %q (newest first)", i, list[i].UID, want)
+		}
+	}
+
+	got, err := repo.GetIncome(ctx, userID, ids[1])
+	requireNoError(t, err, "GetIncome")
+	if got.Amount != domain.NewMoney(20000, "GHS") || got.CategoryID != "cat-salary" || !equal(got.Tags, []string{"payroll", "work"}) || got.AccountID != "acct-checking" {
+		t.Fatalf("GetIncome returned %+v", got)
+	}
+
+	_, err = repo.GetIncome(ctx, userID, newID())
+	requireNotFound(t, err, "GetIncome(missing)")
+
+	requireNoError(t, repo.DeleteIncome(ctx, userID, ids[1]), "DeleteIncome")
+	list, err = repo.ListIncomesByUser(ctx, userID)
+	requireNoError(t, err, "ListIncomesByUser after delete")
+	if len(list) != 2 {
+		t.Fatalf("ListIncomesByUser after delete returned %d incomes, want 2", len(list))
+	}
+}
+
+func testIncomeSources(t *testing.T, repo ports.IncomeRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+	now := base
+
+	mk := func(source string, next time.Time, active bool) *domain.IncomeSource {
+		src := &domain.IncomeSource{
+			UID:       newID(),
+			UserID:    userID,
+			Source:    source,
+			Amount:    domain.NewMoney(50000, "USD"),
+			Frequency: "monthly",
+			NextPayAt: next,
+			Active:    active,
+			CreatedAt: base,
+			UpdatedAt: base,
+		}
+		_, err := repo.CreateIncomeSource(ctx, src)
+		requireNoError(t, err, "CreateIncomeSource")
+		return src
+	}
+
+	due := mk("consulting", now.Add(-time.Hour), true)
+	dueExact := mk("bonus", now, true)
+	mk("rent", now.Add(24*time.Hour), true)
+	mk("archived", now.Add(-48*time.Hour), false)
+
+	list, err := repo.ListIncomeSourcesByUser(ctx, userID)
+	requireNoError(t, err, "ListIncomeSourcesByUser")
+	var names []string
+	for _, s := range list {
+		names = append(names, s.Source)
+	}
+	if want := []string{"archived", "bonus", "consulting", "rent"}; !equal(names, want) {
+		t.Fatalf("ListIncomeSourcesByUser order = %v, want %v", names, want)
+	}
+
+	dueList, err := repo.ListDueIncomeSources(ctx, userID, now)
+	requireNoError(t, err, "ListDueIncomeSources")
+	var dueIDs []string
+	for _, s := range dueList {
+		dueIDs = append(dueIDs, s.UID)
+	}
+	if len(dueIDs) != 2 || !contains(dueIDs, due.UID) || !contains(dueIDs, dueExact.UID) {
+		t.Fatalf("ListDueIncomeSources = %v, want active sources due at or before now", dueIDs)
+	}
+
+	next := now.AddDate(0, 1, 0)
+	requireNoError(t, repo.UpdateIncomeSource(ctx, userID, due.UID, map[string]interface{}{
+		"NextPayAt": next,
+		"Amount":    domain.NewMoney(65000, "JPY"),
+		"UpdatedAt": now,
+	}), "UpdateIncomeSource")
+	dueList, err = repo.ListDueIncomeSources(ctx, userID, now)
+	requireNoError(t, err, "ListDueIncomeSources after update")
+	if len(dueList) != 1 || dueList[0].UID != dueExact.UID {
+		t.Fatalf("advanced source is still due: %+v", dueList)
+	}
+	list, err = repo.ListIncomeSourcesByUser(ctx, userID)
+	requireNoError(t, err, "ListIncomeSourcesByUser after update")
+	for _, s := range list {
+		if s.UID == due.UID && s.Amount != domain.NewMoney(65000, "JPY") {
+			t.Fatalf("UpdateIncomeSource Amount = %v, want 65000 JPY", s.Amount)
+		}
+	}
+
+	requireNoError(t, repo.DeleteIncomeSource(ctx, userID, "rent"), "DeleteIncomeSource")
+	list, err = repo.ListIncomeSourcesByUser(ctx, userID)
+	requireNoError(t, err, "ListIncomeSourcesByUser after delete")
+	if len(list) != 3 {
+		t.Fatalf("ListIncomeSourcesByUser after delete returned %d sources, want 3", len(list))
+	}
+}
+
+func testExpenses(t *testing.T, repo ports.ExpenseRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+	now := base
+
+	mk := func(source string, offset time.Duration, recurring bool, next time.Time) *domain.Expense {
+		e := &domain.Expense{
+			UID:                 newID(),
+			UserID:              userID,
+			Source:              source,
+			Amount:              domain.NewMoney(4250, "EUR"),
+			IsRecurring:         recurring,
+			RecurrenceFrequency: "monthly",
+			NextOccurrenceDate:  next,
+			CreatedAt:           base.Add(offset),
+			UpdatedAt:           base.Add(offset),
+		}
+		_, err := repo.CreateExpense(ctx, e)
+		requireNoError(t, err, "CreateExpense")
+		return e
+	}
+
+	rent := mk("rent", 0, true, now.Add(-time.Hour))
+	gym := mk("gym", time.Hour, true, now.Add(time.Hour))
+	coffee := mk("coffee", 2*time.Hour, false, now.Add(-time.Hour))
+
+	list, err := repo.ListExpensesByUser(ctx, userID)
+	requireNoError(t, err, "ListExpensesByUser")
+	if len(list) != 3 || list[0].UID != coffee.UID || list[1].UID != gym.UID || list[2].UID != rent.UID {
+		t.Fatalf("ListExpensesByUser is not newest first")
+	}
+
+	recurring, err := repo.ListRecurringExpenses(ctx, userID, now)
+	requireNoError(t, err, "ListRecurringExpenses")
+	if len(recurring) != 1 || recurring[0].UID != rent.UID {
+		t.Fatalf("ListRecurringExpenses = %+v, want only the due recurring expense", recurring)
+	}
+
+	next := now.AddDate(0, 1, 0)
+	requireNoError(t, repo.UpdateExpenseRecurringStatus(ctx, userID, rent.UID, next), "UpdateExpenseRecurringStatus")
+	got, err := repo.GetExpense(ctx, userID, rent.UID)
+	requireNoError(t, err, "GetExpense")
+	if !sameInstant(got.NextOccurrenceDate, next) {
+		t.Fatalf("NextOccurrenceDate = %v, want %v", got.NextOccurrenceDate, next)
+	}
+
+	got.Notes = "landlord"
+	got.CategoryID = "cat-rent"
+	got.Tags = []string{"home", "tax-deductible"}
+	got.AccountID = "acct-card"
+	got.DebtID = "debt-car"
+	got.Amount = domain.NewMoney(5000, "EUR")
+	_, err = repo.UpdateExpense(ctx, got)
+	requireNoError(t, err, "UpdateExpense")
+	got, err = repo.GetExpense(ctx, userID, rent.UID)
+	requireNoError(t, err, "GetExpense after update")
+	if got.Notes != "landlord" || got.CategoryID != "cat-rent" || !equal(got.Tags, []string{"home", "tax-deductible"}) || got.AccountID != "acct-card" || got.DebtID != "debt-car" ||
+		got.Amount != domain.NewMoney(5000, "EUR") {
+		t.Fatalf("UpdateExpense did not persist: %+v", got)
+	}
+
+	splits := []domain.ExpenseSplit{
+		{CategoryID: "cat-food", Amount: domain.NewMoney(3000, "EUR"), Notes: "bread"},
+		{CategoryID: "cat-pharmacy", Amount: domain.NewMoney(2000, "EUR")},
+	}
+	got.CategoryID = ""
+	got.Splits = splits
+	_, err = repo.UpdateExpense(ctx, got)
+	requireNoError(t, err, "UpdateExpense(splits)")
+	got, err = repo.GetExpense(ctx, userID, rent.UID)
+	requireNoError(t, err, "GetExpense after split")
+	if len(got.Splits) != 2 || got.Splits[0] != splits[0] || got.Splits[1] != splits[1] || got.CategoryID != "" {
+		t.Fatalf("UpdateExpense did not persist splits: %+v", got.Splits)
+	}
+	got, err = repo.GetExpense(ctx, userID, coffee.UID)
+	requireNoError(t, err, "GetExpense(unsplit)")
+	if len(got.Splits) != 0 {
+		t.Fatalf("unsplit expense has splits %+v", got.Splits)
+	}
+
+	_, err = repo.GetExpense(ctx, userID, newID())
+	requireNotFound(t, err, "GetExpense(missing)")
+
+	requireNoError(t, repo.DeleteExpense(ctx, userID, gym.UID), "DeleteExpense")
+	requireNotFound(t, repo.DeleteExpense(ctx, userID, gym.UID), "DeleteExpense(missing)")
+}
+
+func testRefreshTokens(t *testing.T, repo ports.RefreshTokenRepository) {
+	ctx := context.Background()
+	userID := newID()
+	otherUserID := newID()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	mk := func(uid, hash string, expiresAt time.Time) *domain.RefreshToken {
+		tok := &domain.RefreshToken{
+			UserID:    uid,
+			TokenHash: hash,
+			ExpiresAt: expiresAt,
+			CreatedAt: now,
+		}
+		requireNoError(t, repo.Create(ctx, tok), "Create")
+		if tok.ID == "" {
+			t.Fatalf("Create did not assign an ID")
+		}
+		return tok
+	}
+
+	active := mk(userID, "hash-active-"+newID(), now.Add(time.Hour))
+	second := mk(userID, "hash-second-"+newID(), now.Add(time.Hour))
+	expired := mk(userID, "hash-expired-"+newID(), now.Add(-time.Hour))
+	other := mk(otherUserID, "hash-other-"+newID(), now.Add(time.Hour))
+
+	got, err := repo.GetValidToken(ctx, userID, active.TokenHash)
+	requireNoError(t, err, "GetValidToken")
+	if got.ID != active.ID {
+		t.Fatalf("GetValidToken returned %q, want %q", got.ID, active.ID)
+	}
+
+	if _, err := repo.GetValidToken(ctx, userID, expired.TokenHash); err == nil {
+		t.Fatalf("GetValidToken returned an expired token")
+	}
+	if _, err := repo.GetValidToken(ctx, otherUserID, active.TokenHash); err == nil {
+		t.Fatalf("GetValidToken returned another user's token")
+	}
+
+	list, err := repo.GetByUserID(ctx, userID)
+	requireNoError(t, err, "GetByUserID")
+	if len(list) != 3 {
+		t.Fatalf("GetByUserID returned %d tokens, want 3", len(list))
+	}
+
+	requireNoError(t, repo.RevokeToken(ctx, active.ID), "RevokeToken")
+	if _, err := repo.GetValidToken(ctx, userID, active.TokenHash); err == nil {
+		t.Fatalf("GetValidToken returned a revoked token")
+	}
+	revoked, err := repo.GetByID(ctx, active.ID)
+	requireNoError(t, err, "GetByID")
+	if !revoked.IsRevoked || revoked.RevokedAt == nil {
+		t.Fatalf("revoked token = %+v, want IsRevoked and RevokedAt set", revoked)
+	}
+
+	requireNoError(t, repo.RevokeAllUserTokens(ctx, userID), "RevokeAllUserTokens")
+	if _, err := repo.GetValidToken(ctx, userID, second.TokenHash); err == nil {
+		t.Fatalf("RevokeAllUserTokens left a token valid")
+	}
+	if _, err := repo.GetValidToken(ctx, otherUserID, other.TokenHash); err != nil {
+		t.Fatalf("RevokeAllUserTokens revoked another user's token: %v", err)
+	}
+
+	requireNoError(t, repo.DeleteExpiredTokens(ctx), "DeleteExpiredTokens")
+	if _, err := repo.GetByID(ctx, expired.ID); err == nil {
+		t.Fatalf("DeleteExpiredTokens kept an expired token")
+	}
+	if _, err := repo.GetByID(ctx, second.ID); err != nil {
+		t.Fatalf("DeleteExpiredTokens removed an unexpired token: %v", err)
+	}
+
+	requireNoError(t, repo.DeleteToken(ctx, other.ID), "DeleteToken")
+	if _, err := repo.GetByID(ctx, other.ID); err == nil {
+		t.Fatalf("DeleteToken kept the token")
+	}
+}
+
+func testRefreshTokenRotation(t *testing.T, repo ports.RefreshTokenRepository) {
+	ctx := context.Background()
+	userID := newID()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	first := &domain.RefreshToken{UserID: userID, TokenHash: "hash-first-" + newID(), ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	requireNoError(t, repo.Create(ctx, first), "Create")
+	if first.FamilyID != first.ID {
+		t.Fatalf("Create set FamilyID %q, want the token's own ID %q", first.FamilyID, first.ID)
+	}
+	next := &domain.RefreshToken{UserID: userID, TokenHash: "hash-next-" + newID(), FamilyID: first.FamilyID, ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	requireNoError(t, repo.Create(ctx, next), "Create(next)")
+	unrelated := &domain.RefreshToken{UserID: userID, TokenHash: "hash-unrelated-" + newID(), ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	requireNoError(t, repo.Create(ctx, unrelated), "Create(unrelated)")
+
+	requireNoError(t, repo.RotateToken(ctx, first.ID, next.ID), "RotateToken")
+	if err := repo.RotateToken(ctx, first.ID, next.ID); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("RotateToken(rotated) = %v, want FailedPrecondition", err)
+	}
+
+	// A rotated token is still found by hash so that its reuse can be noticed.
+	got, err := repo.GetByTokenHash(ctx, first.TokenHash)
+	requireNoError(t, err, "GetByTokenHash")
+	if got.ID != first.ID || !got.IsRevoked || got.ReplacedBy != next.ID || got.FamilyID != first.FamilyID {
+		t.Fatalf("GetByTokenHash = %+v, want the rotated token replaced by %q", got, next.ID)
+	}
+	if _, err := repo.GetByTokenHash(ctx, "hash-missing-"+newID()); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetByTokenHash(missing) = %v, want NotFound", err)
+	}
+
+	requireNoError(t, repo.RevokeFamily(ctx, first.FamilyID), "RevokeFamily")
+	if _, err := repo.GetValidToken(ctx, userID, next.TokenHash); err == nil {
+		t.Fatalf("RevokeFamily left a token of the family valid")
+	}
+	if _, err := repo.GetValidToken(ctx, userID, unrelated.TokenHash); err != nil {
+		t.Fatalf("RevokeFamily revoked a token of another family: %v", err)
+	}
+}
+
+func testSecurityEvents(t *testing.T, repo ports.SecurityEventRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	older := &domain.SecurityEvent{ID: newID(), UserID: userID, Type: domain.SecurityEventRefreshTokenReuse, Details: "older", IPAddress: "10.0.0.1", CreatedAt: now.Add(-time.Hour)}
+	newer := &domain.SecurityEvent{ID: newID(), UserID: userID, Type: domain.SecurityEventRefreshTokenReuse, Details: "newer", UserAgent: "curl", CreatedAt: now}
+	requireNoError(t, repo.CreateSecurityEvent(ctx, older), "CreateSecurityEvent")
+	requireNoError(t, repo.CreateSecurityEvent(ctx, newer), "CreateSecurityEvent")
+	requireNoError(t, repo.CreateSecurityEvent(ctx, older), "CreateSecurityEvent(again)")
+	requireNoError(t, repo.CreateSecurityEvent(ctx, &domain.SecurityEvent{ID: newID(), UserID: newID(), Type: domain.SecurityEventRefreshTokenReuse, CreatedAt: now}), "CreateSecurityEvent(other user)")
+
+	events, err := repo.ListSecurityEvents(ctx, userID)
+	requireNoError(t, err, "ListSecurityEvents")
+	if len(events) != 2 || events[0].ID != newer.ID || events[1].ID != older.ID {
+		t.Fatalf("ListSecurityEvents returned %d events, want newest first", len(events))
+	}
+	got := events[1]
+	if got.Type != older.Type || got.Details != "older" || got.IPAddress != "10.0.0.1" || !got.CreatedAt.Equal(older.CreatedAt) {
+		t.Fatalf("ListSecurityEvents()[1] = %+v, want %+v", got, older)
+	}
+}
+
+func testTwoFactor(t *testing.T, repo ports.TwoFactorRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	if _, err := repo.GetTwoFactor(ctx, userID); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetTwoFactor(missing) = %v, want NotFound", err)
+	}
+
+	tf := &domain.TwoFactor{UserID: userID, Secret: "JBSWY3DPEHPK3PXP", CreatedAt: now}
+	requireNoError(t, repo.SaveTwoFactor(ctx, tf), "SaveTwoFactor")
+	tf.Enabled = true
+	tf.EnabledAt = &now
+	tf.LastUsedStep = 100
+	tf.RecoveryCodes = []string{"hash-a", "hash-b"}
+	requireNoError(t, repo.SaveTwoFactor(ctx, tf), "SaveTwoFactor(enable)")
+
+	got, err := repo.GetTwoFactor(ctx, userID)
+	requireNoError(t, err, "GetTwoFactor")
+	sort.Strings(got.RecoveryCodes)
+	if !got.Enabled || got.Secret != tf.Secret || got.LastUsedStep != 100 || got.EnabledAt == nil || !got.EnabledAt.Equal(now) ||
+		!reflect.DeepEqual(got.RecoveryCodes, []string{"hash-a", "hash-b"}) {
+		t.Fatalf("GetTwoFactor = %+v, want %+v", got, tf)
+	}
+
+	if err := repo.UseTOTPStep(ctx, userID, 100); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("UseTOTPStep(used step) = %v, want FailedPrecondition", err)
+	}
+	requireNoError(t, repo.UseTOTPStep(ctx, userID, 101), "UseTOTPStep")
+	requireNoError(t, repo.UseRecoveryCode(ctx, userID, "hash-a"), "UseRecoveryCode")
+	if err := repo.UseRecoveryCode(ctx, userID, "hash-a"); status.Code(err) != codes.NotFound {
+		t.Fatalf("UseRecoveryCode(used) = %v, want NotFound", err)
+	}
+	got, err = repo.GetTwoFactor(ctx, userID)
+	requireNoError(t, err, "GetTwoFactor")
+	if got.LastUsedStep != 101 || !reflect.DeepEqual(got.RecoveryCodes, []string{"hash-b"}) {
+		t.Fatalf("GetTwoFactor after use = %+v, want step 101 and only hash-b left", got)
+	}
+
+	requireNoError(t, repo.DeleteTwoFactor(ctx, userID), "DeleteTwoFactor")
+	requireNotFound(t, repo.DeleteTwoFactor(ctx, userID), "DeleteTwoFactor(missing)")
+
+	live := &domain.TwoFactorChallenge{TokenHash: "challenge-" + newID(), UserID: userID, Email: "ama@example.com", ExpiresAt: now.Add(time.Minute), CreatedAt: now}
+	stale := &domain.TwoFactorChallenge{TokenHash: "challenge-" + newID(), UserID: userID, Email: "ama@example.com", ExpiresAt: now.Add(-time.Minute), CreatedAt: now}
+	requireNoError(t, repo.CreateTwoFactorChallenge(ctx, live), "CreateTwoFactorChallenge")
+	requireNoError(t, repo.CreateTwoFactorChallenge(ctx, stale), "CreateTwoFactorChallenge")
+
+	for want := 1; want <= 2; want++ {
+		attempts, err := repo.RecordChallengeAttempt(ctx, live.TokenHash)
+		requireNoError(t, err, "RecordChallengeAttempt")
+		if attempts != want {
+			t.Fatalf("RecordChallengeAttempt = %d, want %d", attempts, want)
+		}
+	}
+	c, err := repo.GetTwoFactorChallenge(ctx, live.TokenHash)
+	requireNoError(t, err, "GetTwoFactorChallenge")
+	if c.UserID != userID || c.Email != live.Email || c.Attempts != 2 || !c.ExpiresAt.Equal(live.ExpiresAt) {
+		t.Fatalf("GetTwoFactorChallenge = %+v, want %+v with 2 attempts", c, live)
+	}
+
+	requireNoError(t, repo.DeleteExpiredTwoFactorChallenges(ctx), "DeleteExpiredTwoFactorChallenges")
+	if _, err := repo.GetTwoFactorChallenge(ctx, stale.TokenHash); status.Code(err) != codes.NotFound {
+		t.Fatalf("DeleteExpiredTwoFactorChallenges kept an expired challenge: %v", err)
+	}
+	requireNoError(t, repo.DeleteTwoFactorChallenge(ctx, live.TokenHash), "DeleteTwoFactorChallenge")
+	requireNotFound(t, repo.DeleteTwoFactorChallenge(ctx, live.TokenHash), "DeleteTwoFactorChallenge(again)")
+}
+
+func testPasskeys(t *testing.T, repo ports.PasskeyRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	first := &domain.Passkey{
+		ID: "cred-" + newID(), UserID: userID, Name: "Laptop", PublicKey: []byte{0xa5, 0x01, 0x02},
+		AttestationType: "none", AAGUID: make([]byte, 16), Transports: []string{"internal", "hybrid"},
+		BackupEligible: true, BackupState: true, CreatedAt: now,
+	}
+	second := &domain.Passkey{ID: "cred-" + newID(), UserID: userID, Name: "Security key", PublicKey: []byte{0x01}, SignCount: 7, CreatedAt: now.Add(time.Second)}
+	requireNoError(t, repo.CreatePasskey(ctx, second), "CreatePasskey")
+	requireNoError(t, repo.CreatePasskey(ctx, first), "CreatePasskey")
+	requireNoError(t, repo.CreatePasskey(ctx, &domain.Passkey{ID: "cred-" + newID(), UserID: newID(), PublicKey: []byte{0x01}, CreatedAt: now}), "CreatePasskey(other user)")
+	if err := repo.CreatePasskey(ctx, &domain.Passkey{ID: first.ID, UserID: newID(), PublicKey: []byte{0x02}, CreatedAt: now}); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("CreatePasskey(duplicate ID) = %v, want AlreadyExists", err)
+	}
+
+	got, err := repo.ListPasskeys(ctx, userID)
+	requireNoError(t, err, "ListPasskeys")
+	if len(got) != 2 || got[0].ID != first.ID || got[1].ID != second.ID {
+		t.Fatalf("ListPasskeys returned %d passkeys, want the user's two oldest first", len(got))
+	}
+	p := got[0]
+	if p.Name != "Laptop" || !reflect.DeepEqual(p.PublicKey, first.PublicKey) || p.AttestationType != "none" || len(p.AAGUID) != 16 ||
+		!reflect.DeepEqual(p.Transports, first.Transports) || !p.BackupEligible || !p.BackupState || !p.CreatedAt.Equal(now) || p.LastUsedAt != nil {
+		t.Fatalf("ListPasskeys()[0] = %+v, want %+v", p, first)
+	}
+	if got[1].SignCount != 7 {
+		t.Fatalf("ListPasskeys()[1].SignCount = %d, want 7", got[1].SignCount)
+	}
+
+	used := now.Add(time.Minute)
+	requireNoError(t, repo.UpdatePasskeyUsage(ctx, second.ID, 8, false, used), "UpdatePasskeyUsage")
+	requireNotFound(t, repo.UpdatePasskeyUsage(ctx, "cred-missing", 1, false, used), "UpdatePasskeyUsage(missing)")
+	got, err = repo.ListPasskeys(ctx, userID)
+	requireNoError(t, err, "ListPasskeys")
+	if got[1].SignCount != 8 || got[1].LastUsedAt == nil || !got[1].LastUsedAt.Equal(used) {
+		t.Fatalf("ListPasskeys()[1] after use = %+v, want counter 8 used at %v", got[1], used)
+	}
+
+	requireNotFound(t, repo.DeletePasskey(ctx, newID(), first.ID), "DeletePasskey(other user)")
+	requireNoError(t, repo.DeletePasskey(ctx, userID, first.ID), "DeletePasskey")
+	requireNotFound(t, repo.DeletePasskey(ctx, userID, first.ID), "DeletePasskey(again)")
+
+	live := &domain.PasskeyCeremony{TokenHash: "ceremony-" + newID(), UserID: userID, Session: []byte(`{"challenge":"abc"}`), ExpiresAt: now.Add(time.Minute), CreatedAt: now}
+	login := &domain.PasskeyCeremony{TokenHash: "ceremony-" + newID(), Session: []byte(`{}`), ExpiresAt: now.Add(time.Minute), CreatedAt: now}
+	stale := &domain.PasskeyCeremony{TokenHash: "ceremony-" + newID(), Session: []byte(`{}`), ExpiresAt: now.Add(-time.Minute), CreatedAt: now}
+	for _, c := range []*domain.PasskeyCeremony{live, login, stale} {
+		requireNoError(t, repo.CreatePasskeyCeremony(ctx, c), "CreatePasskeyCeremony")
+	}
+	requireNoError(t, repo.DeleteExpiredPasskeyCeremonies(ctx), "DeleteExpiredPasskeyCeremonies")
+	if _, err := repo.TakePasskeyCeremony(ctx, stale.TokenHash); status.Code(err) != codes.NotFound {
+		t.Fatalf("DeleteExpiredPasskeyCeremonies kept an expired ceremony: %v", err)
+	}
+
+	c, err := repo.TakePasskeyCeremony(ctx, live.TokenHash)
+	requireNoError(t, err, "TakePasskeyCeremony")
+	if c.UserID != userID || string(c.Session) != string(live.Session) || !c.ExpiresAt.Equal(live.ExpiresAt) {
+		t.Fatalf("TakePasskeyCeremony = %+v, want %+v", c, live)
+	}
+	if _, err := repo.TakePasskeyCeremony(ctx, live.TokenHash); status.Code(err) != codes.NotFound {
+		t.Fatalf("TakePasskeyCeremony(again) = %v, want NotFound", err)
+	}
+	c, err = repo.TakePasskeyCeremony(ctx, login.TokenHash)
+	requireNoError(t, err, "TakePasskeyCeremony(login)")
+	if c.UserID != "" {
+		t.Fatalf("TakePasskeyCeremony(login).UserID = %q, want empty", c.UserID)
+	}
+}
+
+func testLoginAttempts(t *testing.T, repo ports.LoginAttemptRepoPort) {
+	ctx := context.Background()
+	key := "account:" + newID() + "/ama@example.com"
+	now := time.Now().UTC().Truncate(time.Second)
+
+	if _, err := repo.GetLoginAttempts(ctx, key); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetLoginAttempts(no failures) = %v, want NotFound", err)
+	}
+	requireNotFound(t, repo.LockLogin(ctx, key, now), "LockLogin(no failures)")
+	for i := 1; i <= 3; i++ {
+		a, err := repo.RecordLoginFailure(ctx, key, now.Add(time.Duration(i)*time.Second), now.Add(-time.Hour))
+		requireNoError(t, err, "RecordLoginFailure")
+		if a.Key != key || a.Failures != i || a.LockedUntil != nil {
+			t.Fatalf("RecordLoginFailure #%d = %+v, want %d failures", i, a, i)
+		}
+	}
+	other, err := repo.RecordLoginFailure(ctx, "ip:"+newID(), now, now.Add(-time.Hour))
+	requireNoError(t, err, "RecordLoginFailure(other key)")
+	if other.Failures != 1 {
+		t.Fatalf("RecordLoginFailure(other key).Failures = %d, want 1", other.Failures)
+	}
+
+	until := now.Add(time.Hour)
+	requireNoError(t, repo.LockLogin(ctx, key, until), "LockLogin")
+	a, err := repo.GetLoginAttempts(ctx, key)
+	requireNoError(t, err, "GetLoginAttempts")
+	if a.Failures != 3 || !a.LastFailureAt.Equal(now.Add(3*time.Second)) || a.LockedUntil == nil || !a.LockedUntil.Equal(until) {
+		t.Fatalf("GetLoginAttempts = %+v, want 3 failures locked until %v", a, until)
+	}
+
+	// A failure after the window starts the count over but keeps the lock.
+	a, err = repo.RecordLoginFailure(ctx, key, now.Add(time.Minute), now.Add(time.Minute))
+	requireNoError(t, err, "RecordLoginFailure(after window)")
+	if a.Failures != 1 || a.LockedUntil == nil || !a.LockedUntil.Equal(until) {
+		t.Fatalf("RecordLoginFailure(after window) = %+v, want 1 failure still locked", a)
+	}
+
+	stale := "ip:" + newID()
+	_, err = repo.RecordLoginFailure(ctx, stale, now.Add(-2*time.Hour), now.Add(-3*time.Hour))
+	requireNoError(t, err, "RecordLoginFailure(stale)")
+	requireNoError(t, repo.DeleteStaleLoginAttempts(ctx, now.Add(-time.Hour)), "DeleteStaleLoginAttempts")
+	if _, err := repo.GetLoginAttempts(ctx, stale); status.Code(err) != codes.NotFound {
+		t.Fatalf("DeleteStaleLoginAttempts kept a stale counter: %v", err)
+	}
+	requireNoError(t, repo.DeleteStaleLoginAttempts(ctx, now.Add(30*time.Minute)), "DeleteStaleLoginAttempts")
+	if _, err := repo.GetLoginAttempts(ctx, key); err != nil {
+		t.Fatalf("DeleteStaleLoginAttempts removed a locked counter: %v", err)
+	}
+
+	requireNoError(t, repo.ResetLoginAttempts(ctx, key), "ResetLoginAttempts")
+	if _, err := repo.GetLoginAttempts(ctx, key); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetLoginAttempts after reset = %v, want NotFound", err)
+	}
+	requireNoError(t, repo.ResetLoginAttempts(ctx, key), "ResetLoginAttempts(again)")
+
+	live := &domain.AccountUnlock{TokenHash: "unlock-" + newID(), Email: "ama@example.com", UserID: newID(), ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	expired := &domain.AccountUnlock{TokenHash: "unlock-" + newID(), Email: "ama@example.com", UserID: live.UserID, ExpiresAt: now.Add(-time.Minute), CreatedAt: now}
+	requireNoError(t, repo.CreateAccountUnlock(ctx, live), "CreateAccountUnlock")
+	requireNoError(t, repo.CreateAccountUnlock(ctx, expired), "CreateAccountUnlock")
+	requireNoError(t, repo.DeleteExpiredAccountUnlocks(ctx), "DeleteExpiredAccountUnlocks")
+	if _, err := repo.TakeAccountUnlock(ctx, expired.TokenHash); status.Code(err) != codes.NotFound {
+		t.Fatalf("DeleteExpiredAccountUnlocks kept an expired token: %v", err)
+	}
+	u, err := repo.TakeAccountUnlock(ctx, live.TokenHash)
+	requireNoError(t, err, "TakeAccountUnlock")
+	if u.Email != live.Email || u.UserID != live.UserID || !u.ExpiresAt.Equal(live.ExpiresAt) {
+		t.Fatalf("TakeAccountUnlock = %+v, want %+v", u, live)
+	}
+	if _, err := repo.TakeAccountUnlock(ctx, live.TokenHash); status.Code(err) != codes.NotFound {
+		t.Fatalf("TakeAccountUnlock(again) = %v, want NotFound", err)
+	}
+}
+
+func testCredentials(t *testing.T, repo ports.CredentialRepository) {
+	ctx := context.Background()
+	email := "Ama-" + newID() + "@Example.com"
+	c := &domain.Credential{
+		UID:          newID(),
+		Email:        email,
+		DisplayName:  "Ama Mensah",
+		PasswordHash: "hash",
+		CreatedAt:    base,
+		UpdatedAt:    base,
+	}
+	requireNoError(t, repo.CreateCredential(ctx, c), "CreateCredential")
+
+	dup := *c
+	dup.UID = newID()
+	if err := repo.CreateCredential(ctx, &dup); err == nil {
+		t.Fatalf("CreateCredential accepted a duplicate email")
+	}
+
+	got, err := repo.GetCredentialByEmail(ctx, email)
+	requireNoError(t, err, "GetCredentialByEmail")
+	if got.UID != c.UID {
+		t.Fatalf("GetCredentialByEmail returned %q, want %q", got.UID, c.UID)
+	}
+
+	got.PasswordHash = "rotated"
+	got.UpdatedAt = base.Add(time.Hour)
+	requireNoError(t, repo.UpdateCredential(ctx, got), "UpdateCredential")
+	got, err = repo.GetCredential(ctx, c.UID)
+	requireNoError(t, err, "GetCredential")
+	if got.PasswordHash != "rotated" {
+		t.Fatalf("UpdateCredential did not persist: %+v", got)
+	}
+
+	requireNoError(t, repo.DeleteCredential(ctx, c.UID), "DeleteCredential")
+	_, err = repo.GetCredential(ctx, c.UID)
+	requireNotFound(t, err, "GetCredential after delete")
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func testExchangeRates(t *testing.T, repo ports.ExchangeRateRepository) {
+	ctx := context.Background()
+	// XTS and XXX are the ISO 4217 codes reserved for testing, so the rows
+	// cannot clash with real rates in a shared database.
+	day := func(n int) time.Time { return time.Date(2025, time.March, n, 0, 0, 0, 0, time.UTC) }
+	err := repo.SaveRates(ctx, []domain.ExchangeRate{
+		{Base: "XTS", Quote: "XXX", Date: day(3), Rate: 1.3},
+		{Base: "XTS", Quote: "XXX", Date: day(1), Rate: 1.1},
+	})
+	requireNoError(t, err, "SaveRates")
+	err = repo.SaveRates(ctx, []domain.ExchangeRate{{Base: "XTS", Quote: "XXX", Date: day(1), Rate: 1.15}})
+	requireNoError(t, err, "SaveRates(replace)")
+
+	for _, c := range []struct {
+		on   time.Time
+		want float64
+		date time.Time
+	}{
+		{day(1), 1.15, day(1)},
+		{day(2).Add(13 * time.Hour), 1.15, day(1)},
+		{day(3), 1.3, day(3)},
+		{day(28), 1.3, day(3)},
+	} {
+		got, err := repo.FindRate(ctx, "XTS", "XXX", c.on)
+		requireNoError(t, err, "FindRate")
+		if got.Rate != c.want || !sameInstant(got.Date, c.date) || got.Base != "XTS" || got.Quote != "XXX" {
+			t.Fatalf("FindRate on %v = %+v, want %v dated %v", c.on, got, c.want, c.date)
+		}
+	}
+
+	if _, err := repo.FindRate(ctx, "XTS", "XXX", day(1).Add(-time.Second)); !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		t.Fatalf("FindRate before first date: err = %v, want ErrExchangeRateNotFound", err)
+	}
+	if _, err := repo.FindRate(ctx, "XXX", "XTS", day(5)); !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		t.Fatalf("FindRate(inverse pair): err = %v, want ErrExchangeRateNotFound", err)
+	}
+
+	all, err := repo.ListRates(ctx)
+	requireNoError(t, err, "ListRates")
+	var listed []domain.ExchangeRate
+	for _, rate := range all {
+		if rate.Base == "XTS" && rate.Quote == "XXX" {
+			listed = append(listed, rate)
+		}
+	}
+	if len(listed) != 2 || !sameInstant(listed[0].Date, day(1)) || listed[0].Rate != 1.15 || !sameInstant(listed[1].Date, day(3)) {
+		t.Fatalf("ListRates = %+v, want the day 1 and day 3 rates in date order", listed)
+	}
+}
+
+func testCategories(t *testing.T, repo ports.CategoryRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+
+	mk := func(name, parentID string) *domain.Category {
+		c := &domain.Category{UID: newID(), UserID: userID, ParentID: parentID, Name: name, Kind: domain.CategoryKindExpense, CreatedAt: base, UpdatedAt: base}
+		_, err := repo.CreateCategory(ctx, c)
+		requireNoError(t, err, "CreateCategory")
+		return c
+	}
+	food := mk("Food", "")
+	groceries := mk("Groceries", food.UID)
+	mk("Dining Out", food.UID)
+	_, err := repo.CreateCategory(ctx, &domain.Category{UID: newID(), UserID: newID(), Name: "Other", Kind: domain.CategoryKindIncome, CreatedAt: base, UpdatedAt: base})
+	requireNoError(t, err, "CreateCategory(other user)")
+
+	list, err := repo.ListCategoriesByUser(ctx, userID)
+	requireNoError(t, err, "ListCategoriesByUser")
+	if len(list) != 3 || list[0].Name != "Dining Out" || list[1].Name != "Food" || list[2].Name != "Groceries" {
+		t.Fatalf("ListCategoriesByUser = %+v, want 3 categories ordered by name", list)
+	}
+
+	got, err := repo.GetCategory(ctx, userID, groceries.UID)
+	requireNoError(t, err, "GetCategory")
+	if got.ParentID != food.UID || got.Kind != domain.CategoryKindExpense || !sameInstant(got.CreatedAt, base) {
+		t.Fatalf("GetCategory returned %+v", got)
+	}
+	_, err = repo.GetCategory(ctx, userID, newID())
+	requireNotFound(t, err, "GetCategory(missing)")
+
+	got.Name = "Supermarket"
+	got.ParentID = ""
+	got.UpdatedAt = base.Add(time.Hour)
+	_, err = repo.UpdateCategory(ctx, got)
+	requireNoError(t, err, "UpdateCategory")
+	got, err = repo.GetCategory(ctx, userID, groceries.UID)
+	requireNoError(t, err, "GetCategory after update")
+	if got.Name != "Supermarket" || got.ParentID != "" || !sameInstant(got.UpdatedAt, base.Add(time.Hour)) {
+		t.Fatalf("UpdateCategory did not persist: %+v", got)
+	}
+	missing := *got
+	missing.UID = newID()
+	_, err = repo.UpdateCategory(ctx, &missing)
+	requireNotFound(t, err, "UpdateCategory(missing)")
+
+	requireNoError(t, repo.DeleteCategory(ctx, userID, groceries.UID), "DeleteCategory")
+	requireNotFound(t, repo.DeleteCategory(ctx, userID, groceries.UID), "DeleteCategory(missing)")
+	list, err = repo.ListCategoriesByUser(ctx, userID)
+	requireNoError(t, err, "ListCategoriesByUser after delete")
+	if len(list) != 2 {
+		t.Fatalf("ListCategoriesByUser after delete returned %d categories, want 2", len(list))
+	}
+}
+
+func testAccounts(t *testing.T, repo ports.AccountRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+
+	mk := func(name string, accountType domain.AccountType, opening domain.Money, offset time.Duration) *domain.Account {
+		a := &domain.Account{
+			UID: newID(), UserID: userID, Name: name, Type: accountType, Currency: opening.Currency, OpeningBalance: opening,
+			CreatedAt: base.Add(offset), UpdatedAt: base.Add(offset),
+		}
+		_, err := repo.CreateAccount(ctx, a)
+		requireNoError(t, err, "CreateAccount")
+		return a
+	}
+	card := mk("Visa", domain.AccountTypeCreditCard, domain.NewMoney(-2500, "USD"), time.Hour)
+	checking := mk("Checking", domain.AccountTypeChecking, domain.NewMoney(100000, "GHS"), 0)
+	_, err := repo.CreateAccount(ctx, &domain.Account{UID: newID(), UserID: newID(), Name: "Other", Type: domain.AccountTypeCash, Currency: "USD", CreatedAt: base, UpdatedAt: base})
+	requireNoError(t, err, "CreateAccount(other user)")
+
+	list, err := repo.ListAccountsByUser(ctx, userID)
+	requireNoError(t, err, "ListAccountsByUser")
+	if len(list) != 2 || list[0].UID != checking.UID || list[1].UID != card.UID {
+		t.Fatalf("ListAccountsByUser = %+v, want 2 accounts oldest first", list)
+	}
+
+	got, err := repo.GetAccount(ctx, userID, card.UID)
+	requireNoError(t, err, "GetAccount")
+	if got.Type != domain.AccountTypeCreditCard || got.Currency != "USD" || got.OpeningBalance != domain.NewMoney(-2500, "USD") {
+		t.Fatalf("GetAccount returned %+v", got)
+	}
+	_, err = repo.GetAccount(ctx, userID, newID())
+	requireNotFound(t, err, "GetAccount(missing)")
+
+	got.Name = "Mastercard"
+	got.OpeningBalance = domain.NewMoney(-4000, "USD")
+	got.UpdatedAt = base.Add(2 * time.Hour)
+	_, err = repo.UpdateAccount(ctx, got)
+	requireNoError(t, err, "UpdateAccount")
+	got, err = repo.GetAccount(ctx, userID, card.UID)
+	requireNoError(t, err, "GetAccount after update")
+	if got.Name != "Mastercard" || got.OpeningBalance != domain.NewMoney(-4000, "USD") || !sameInstant(got.UpdatedAt, base.Add(2*time.Hour)) {
+		t.Fatalf("UpdateAccount did not persist: %+v", got)
+	}
+	missing := *got
+	missing.UID = newID()
+	_, err = repo.UpdateAccount(ctx, &missing)
+	requireNotFound(t, err, "UpdateAccount(missing)")
+
+	requireNoError(t, repo.DeleteAccount(ctx, userID, card.UID), "DeleteAccount")
+	requireNotFound(t, repo.DeleteAccount(ctx, userID, card.UID), "DeleteAccount(missing)")
+}
+
+func testTransfers(t *testing.T, repo ports.TransferRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+
+	mk := func(amount, toAmount domain.Money, rate float64, offset time.Duration) *domain.Transfer {
+		tr := &domain.Transfer{
+			UID: newID(), UserID: userID, FromAccountID: "acct-checking", ToAccountID: "acct-savings",
+			Amount: amount, ToAmount: toAmount, Rate: rate, Notes: "rainy day",
+			CreatedAt: base.Add(offset), UpdatedAt: base.Add(offset),
+		}
+		_, err := repo.CreateTransfer(ctx, tr)
+		requireNoError(t, err, "CreateTransfer")
+		return tr
+	}
+	older := mk(domain.NewMoney(10000, "USD"), domain.NewMoney(10000, "USD"), 1, 0)
+	newer := mk(domain.NewMoney(10000, "USD"), domain.NewMoney(1500000, "JPY"), 150.25, time.Hour)
+
+	list, err := repo.ListTransfersByUser(ctx, userID)
+	requireNoError(t, err, "ListTransfersByUser")
+	if len(list) != 2 || list[0].UID != newer.UID || list[1].UID != older.UID {
+		t.Fatalf("ListTransfersByUser = %+v, want 2 transfers newest first", list)
+	}
+
+	got, err := repo.GetTransfer(ctx, userID, newer.UID)
+	requireNoError(t, err, "GetTransfer")
+	if got.FromAccountID != "acct-checking" || got.ToAccountID != "acct-savings" || got.Amount != domain.NewMoney(10000, "USD") ||
+		got.ToAmount != domain.NewMoney(1500000, "JPY") || got.Rate != 150.25 || got.Notes != "rainy day" {
+		t.Fatalf("GetTransfer returned %+v", got)
+	}
+	_, err = repo.GetTransfer(ctx, newID(), newer.UID)
+	requireNotFound(t, err, "GetTransfer(other user)")
+
+	requireNoError(t, repo.DeleteTransfer(ctx, userID, older.UID), "DeleteTransfer")
+	requireNotFound(t, repo.DeleteTransfer(ctx, userID, older.UID), "DeleteTransfer(missing)")
+}
+
+func testBudgets(t *testing.T, repo ports.BudgetRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+
+	mk := func(categoryID, period string, planned domain.Money, offset time.Duration) *domain.Budget {
+		b := &domain.Budget{
+			UID: newID(), UserID: userID, CategoryID: categoryID, Period: period, Planned: planned,
+			CreatedAt: base.Add(offset), UpdatedAt: base.Add(offset),
+		}
+		_, err := repo.CreateBudget(ctx, b)
+		requireNoError(t, err, "CreateBudget")
+		return b
+	}
+	april := mk("cat-food", "2025-04", domain.NewMoney(40000, "USD"), 0)
+	rent := mk("cat-rent", "2025-03", domain.NewMoney(120000, "EUR"), 2*time.Hour)
+	food := mk("cat-food", "2025-03", domain.NewMoney(35000, "USD"), time.Hour)
+
+	list, err := repo.ListBudgetsByUser(ctx, userID)
+	requireNoError(t, err, "ListBudgetsByUser")
+	if len(list) != 3 || list[0].UID != food.UID || list[1].UID != rent.UID || list[2].UID != april.UID {
+		t.Fatalf("ListBudgetsByUser = %+v, want budgets ordered by period, then creation", list)
+	}
+
+	got, err := repo.GetBudget(ctx, userID, rent.UID)
+	requireNoError(t, err, "GetBudget")
+	if got.CategoryID != "cat-rent" || got.Period != "2025-03" || got.Planned != domain.NewMoney(120000, "EUR") || !sameInstant(got.CreatedAt, rent.CreatedAt) {
+		t.Fatalf("GetBudget returned %+v", got)
+	}
+	_, err = repo.GetBudget(ctx, newID(), rent.UID)
+	requireNotFound(t, err, "GetBudget(other user)")
+
+	rent.Planned = domain.NewMoney(125000, "EUR")
+	rent.Period = "2025-05"
+	rent.UpdatedAt = base.Add(3 * time.Hour)
+	_, err = repo.UpdateBudget(ctx, rent)
+	requireNoError(t, err, "UpdateBudget")
+	got, err = repo.GetBudget(ctx, userID, rent.UID)
+	requireNoError(t, err, "GetBudget(updated)")
+	if got.Planned != domain.NewMoney(125000, "EUR") || got.Period != "2025-05" {
+		t.Fatalf("GetBudget after update returned %+v", got)
+	}
+	_, err = repo.UpdateBudget(ctx, &domain.Budget{UID: newID(), UserID: userID, Planned: rent.Planned})
+	requireNotFound(t, err, "UpdateBudget(missing)")
+
+	requireNoError(t, repo.DeleteBudget(ctx, userID, food.UID), "DeleteBudget")
+	requireNotFound(t, repo.DeleteBudget(ctx, userID, food.UID), "DeleteBudget(missing)")
+}
+
+func testEnvelopes(t *testing.T, repo ports.EnvelopeRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+
+	mk := func(name string, rollover bool, offset time.Duration) *domain.Envelope {
+		e := &domain.Envelope{
+			UID: newID(), UserID: userID, Name: name, CategoryID: "cat-" + name, Allocation: domain.NewMoney(25000, "USD"),
+			Rollover: rollover, StartPeriod: "2025-03", CreatedAt: base.Add(offset), UpdatedAt: base.Add(offset),
+		}
+		_, err := repo.CreateEnvelope(ctx, e)
+		requireNoError(t, err, "CreateEnvelope")
+		return e
+	}
+	newer := mk("fun", false, time.Hour)
+	older := mk("groceries", true, 0)
+
+	list, err := repo.ListEnvelopesByUser(ctx, userID)
+	requireNoError(t, err, "ListEnvelopesByUser")
+	if len(list) != 2 || list[0].UID != older.UID || list[1].UID != newer.UID {
+		t.Fatalf("ListEnvelopesByUser = %+v, want 2 envelopes oldest first", list)
+	}
+
+	got, err := repo.GetEnvelope(ctx, userID, older.UID)
+	requireNoError(t, err, "GetEnvelope")
+	if got.Name != "groceries" || got.CategoryID != "cat-groceries" || got.Allocation != domain.NewMoney(25000, "USD") ||
+		!got.Rollover || got.StartPeriod != "2025-03" {
+		t.Fatalf("GetEnvelope returned %+v", got)
+	}
+	_, err = repo.GetEnvelope(ctx, newID(), older.UID)
+	requireNotFound(t, err, "GetEnvelope(other user)")
+
+	newer.Rollover = true
+	newer.Allocation = domain.NewMoney(5000, "USD")
+	newer.UpdatedAt = base.Add(2 * time.Hour)
+	_, err = repo.UpdateEnvelope(ctx, newer)
+	requireNoError(t, err, "UpdateEnvelope")
+	got, err = repo.GetEnvelope(ctx, userID, newer.UID)
+	requireNoError(t, err, "GetEnvelope(updated)")
+	if !got.Rollover || got.Allocation != domain.NewMoney(5000, "USD") {
+		t.Fatalf("GetEnvelope after update returned %+v", got)
+	}
+	_, err = repo.UpdateEnvelope(ctx, &domain.Envelope{UID: newID(), UserID: userID, Allocation: newer.Allocation})
+	requireNotFound(t, err, "UpdateEnvelope(missing)")
+
+	for i, offset := range []time.Duration{time.Hour, 0} {
+		_, err := repo.CreateEnvelopeMove(ctx, &domain.EnvelopeMove{
+			UID: newID(), UserID: userID, FromEnvelopeID: older.UID, ToEnvelopeID: newer.UID, Period: "2025-03",
+			Amount: domain.NewMoney(int64(1000*(i+1)), "USD"), Notes: "cover", CreatedAt: base.Add(offset),
+		})
+		requireNoError(t, err, "CreateEnvelopeMove")
+	}
+	moves, err := repo.ListEnvelopeMovesByUser(ctx, userID)
+	requireNoError(t, err, "ListEnvelopeMovesByUser")
+	if len(moves) != 2 || moves[0].Amount != domain.NewMoney(2000, "USD") || moves[1].Amount != domain.NewMoney(1000, "USD") ||
+		moves[0].FromEnvelopeID != older.UID || moves[0].ToEnvelopeID != newer.UID || moves[0].Period != "2025-03" || moves[0].Notes != "cover" {
+		t.Fatalf("ListEnvelopeMovesByUser = %+v, want 2 moves oldest first", moves)
+	}
+
+	requireNoError(t, repo.DeleteEnvelope(ctx, userID, older.UID), "DeleteEnvelope")
+	requireNotFound(t, repo.DeleteEnvelope(ctx, userID, older.UID), "DeleteEnvelope(missing)")
+}
+
+func testSavingsGoals(t *testing.T, repo ports.SavingsGoalRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+
+	mk := func(name string, due time.Time) *domain.SavingsGoal {
+		g := &domain.SavingsGoal{
+			UID: newID(), UserID: userID, Name: name, AccountID: "acc-savings", Target: domain.NewMoney(500000, "USD"),
+			TargetDate: due, CreatedAt: base, UpdatedAt: base,
+		}
+		_, err := repo.CreateSavingsGoal(ctx, g)
+		requireNoError(t, err, "CreateSavingsGoal")
+		return g
+	}
+	later := mk("car", base.AddDate(2, 0, 0))
+	sooner := mk("holiday", base.AddDate(0, 6, 0))
+
+	list, err := repo.ListSavingsGoalsByUser(ctx, userID)
+	requireNoError(t, err, "ListSavingsGoalsByUser")
+	if len(list) != 2 || list[0].UID != sooner.UID || list[1].UID != later.UID {
+		t.Fatalf("ListSavingsGoalsByUser = %+v, want 2 goals soonest first", list)
+	}
+
+	got, err := repo.GetSavingsGoal(ctx, userID, sooner.UID)
+	requireNoError(t, err, "GetSavingsGoal")
+	if got.Name != "holiday" || got.AccountID != "acc-savings" || got.Target != domain.NewMoney(500000, "USD") ||
+		!sameInstant(got.TargetDate, sooner.TargetDate) {
+		t.Fatalf("GetSavingsGoal returned %+v", got)
+	}
+	_, err = repo.GetSavingsGoal(ctx, newID(), sooner.UID)
+	requireNotFound(t, err, "GetSavingsGoal(other user)")
+
+	later.Target = domain.NewMoney(800000, "USD")
+	later.TargetDate = base.AddDate(0, 3, 0)
+	later.UpdatedAt = base.Add(time.Hour)
+	_, err = repo.UpdateSavingsGoal(ctx, later)
+	requireNoError(t, err, "UpdateSavingsGoal")
+	list, err = repo.ListSavingsGoalsByUser(ctx, userID)
+	requireNoError(t, err, "ListSavingsGoalsByUser(updated)")
+	if len(list) != 2 || list[0].UID != later.UID || list[0].Target != domain.NewMoney(800000, "USD") {
+		t.Fatalf("ListSavingsGoalsByUser after update = %+v", list)
+	}
+	_, err = repo.UpdateSavingsGoal(ctx, &domain.SavingsGoal{UID: newID(), UserID: userID, Target: later.Target})
+	requireNotFound(t, err, "UpdateSavingsGoal(missing)")
+
+	requireNoError(t, repo.DeleteSavingsGoal(ctx, userID, sooner.UID), "DeleteSavingsGoal")
+	requireNotFound(t, repo.DeleteSavingsGoal(ctx, userID, sooner.UID), "DeleteSavingsGoal(missing)")
+}
+
+func testDebts(t *testing.T, repo ports.DebtRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+
+	mk := func(name string, kind domain.DebtKind, offset time.Duration) *domain.Debt {
+		d := &domain.Debt{
+			UID: newID(), UserID: userID, Name: name, Kind: kind, Principal: domain.NewMoney(1200000, "USD"), APR: 6.25, Term: 48,
+			Frequency: "monthly", Payment: domain.NewMoney(0, "USD"), StartDate: base, CreatedAt: base.Add(offset), UpdatedAt: base.Add(offset),
+		}
+		_, err := repo.CreateDebt(ctx, d)
+		requireNoError(t, err, "CreateDebt")
+		return d
+	}
+	newer := mk("visa", domain.DebtKindCredit, time.Hour)
+	older := mk("car", domain.DebtKindLoan, 0)
+
+	list, err := repo.ListDebtsByUser(ctx, userID)
+	requireNoError(t, err, "ListDebtsByUser")
+	if len(list) != 2 || list[0].UID != older.UID || list[1].UID != newer.UID {
+		t.Fatalf("ListDebtsByUser = %+v, want 2 debts oldest first", list)
+	}
+
+	got, err := repo.GetDebt(ctx, userID, older.UID)
+	requireNoError(t, err, "GetDebt")
+	if got.Name != "car" || got.Kind != domain.DebtKindLoan || got.Principal != domain.NewMoney(1200000, "USD") || got.APR != 6.25 ||
+		got.Term != 48 || got.Frequency != "monthly" || got.Payment != domain.NewMoney(0, "USD") || !sameInstant(got.StartDate, base) {
+		t.Fatalf("GetDebt returned %+v", got)
+	}
+	_, err = repo.GetDebt(ctx, newID(), older.UID)
+	requireNotFound(t, err, "GetDebt(other user)")
+
+	newer.Term = 0
+	newer.APR = 24.99
+	newer.Payment = domain.NewMoney(5000, "USD")
+	newer.UpdatedAt = base.Add(2 * time.Hour)
+	_, err = repo.UpdateDebt(ctx, newer)
+	requireNoError(t, err, "UpdateDebt")
+	got, err = repo.GetDebt(ctx, userID, newer.UID)
+	requireNoError(t, err, "GetDebt(updated)")
+	if got.Term != 0 || got.APR != 24.99 || got.Payment != domain.NewMoney(5000, "USD") || got.Kind != domain.DebtKindCredit {
+		t.Fatalf("GetDebt after update returned %+v", got)
+	}
+	_, err = repo.UpdateDebt(ctx, &domain.Debt{UID: newID(), UserID: userID, Principal: newer.Principal})
+	requireNotFound(t, err, "UpdateDebt(missing)")
+
+	requireNoError(t, repo.DeleteDebt(ctx, userID, older.UID), "DeleteDebt")
+	requireNotFound(t, repo.DeleteDebt(ctx, userID, older.UID), "DeleteDebt(missing)")
+}
+
+func testHoldings(t *testing.T, repo ports.HoldingRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+
+	mk := func(name string, kind domain.HoldingKind, category domain.HoldingCategory, offset time.Duration) *domain.Holding {
+		h := &domain.Holding{
+			UID: newID(), UserID: userID, Name: name, Kind: kind, Category: category, Currency: "USD",
+			CreatedAt: base.Add(offset), UpdatedAt: base.Add(offset),
+		}
+		_, err := repo.CreateHolding(ctx, h)
+		requireNoError(t, err, "CreateHolding")
+		return h
+	}
+	newer := mk("mortgage", domain.HoldingKindLiability, domain.HoldingCategoryLoan, time.Hour)
+	older := mk("house", domain.HoldingKindAsset, domain.HoldingCategoryProperty, 0)
+
+	list, err := repo.ListHoldingsByUser(ctx, userID)
+	requireNoError(t, err, "ListHoldingsByUser")
+	if len(list) != 2 || list[0].UID != older.UID || list[1].UID != newer.UID {
+		t.Fatalf("ListHoldingsByUser = %+v, want 2 holdings oldest first", list)
+	}
+
+	got, err := repo.GetHolding(ctx, userID, older.UID)
+	requireNoError(t, err, "GetHolding")
+	if got.Name != "house" || got.Kind != domain.HoldingKindAsset || got.Category != domain.HoldingCategoryProperty || got.Currency != "USD" {
+		t.Fatalf("GetHolding returned %+v", got)
+	}
+	_, err = repo.GetHolding(ctx, newID(), older.UID)
+	requireNotFound(t, err, "GetHolding(other user)")
+
+	newer.Name = "home loan"
+	newer.Category = domain.HoldingCategoryOther
+	newer.UpdatedAt = base.Add(2 * time.Hour)
+	_, err = repo.UpdateHolding(ctx, newer)
+	requireNoError(t, err, "UpdateHolding")
+	got, err = repo.GetHolding(ctx, userID, newer.UID)
+	requireNoError(t, err, "GetHolding(updated)")
+	if got.Name != "home loan" || got.Category != domain.HoldingCategoryOther || got.Kind != domain.HoldingKindLiability {
+		t.Fatalf("GetHolding after update returned %+v", got)
+	}
+	_, err = repo.UpdateHolding(ctx, &domain.Holding{UID: newID(), UserID: userID})
+	requireNotFound(t, err, "UpdateHolding(missing)")
+
+	value := func(holdingID string, minor int64, day int) *domain.Valuation {
+		v := &domain.Valuation{
+			UID: newID(), UserID: userID, HoldingID: holdingID, Value: domain.NewMoney(minor, "USD"),
+			Date: base.AddDate(0, 0, day), Notes: "appraisal", CreatedAt: base,
+		}
+		_, err := repo.CreateValuation(ctx, v)
+		requireNoError(t, err, "CreateValuation")
+		return v
+	}
+	later := value(older.UID, 31000000, 30)
+	earlier := value(older.UID, 30000000, 0)
+	value(newer.UID, 25000000, 10)
+
+	vals, err := repo.ListValuationsByUser(ctx, userID)
+	requireNoError(t, err, "ListValuationsByUser")
+	if len(vals) != 3 || vals[0].UID != earlier.UID || vals[2].UID != later.UID {
+		t.Fatalf("ListValuationsByUser = %+v, want 3 valuations ordered by date", vals)
+	}
+	if vals[0].Value != domain.NewMoney(30000000, "USD") || !sameInstant(vals[0].Date, base) || vals[0].Notes != "appraisal" {
+		t.Fatalf("ListValuationsByUser returned %+v", vals[0])
+	}
+
+	requireNoError(t, repo.DeleteHolding(ctx, userID, older.UID), "DeleteHolding")
+	requireNotFound(t, repo.DeleteHolding(ctx, userID, older.UID), "DeleteHolding(missing)")
+	vals, err = repo.ListValuationsByUser(ctx, userID)
+	requireNoError(t, err, "ListValuationsByUser(after delete)")
+	if len(vals) != 1 || vals[0].HoldingID != newer.UID {
+		t.Fatalf("ListValuationsByUser after delete = %+v, want only the remaining holding's valuation", vals)
+	}
+}
+
+func testNetWorthSnapshots(t *testing.T, repo ports.NetWorthSnapshotRepoPort) {
+	ctx := context.Background()
+	userID := newID()
+
+	day := func(n int) time.Time { return base.AddDate(0, 0, n) }
+	save := func(date time.Time, assets int64, accounts ...domain.AccountBalance) {
+		t.Helper()
+		requireNoError(t, repo.SaveNetWorthSnapshot(ctx, &domain.NetWorthSnapshot{
+			UserID: userID, Date: date, TotalAssets: domain.NewMoney(assets, "USD"), TotalLiabilities: domain.NewMoney(2500, "USD"),
+			Accounts: accounts, CreatedAt: date,
+		}), "SaveNetWorthSnapshot")
+	}
+	checking := domain.AccountBalance{AccountID: newID(), Name: "Checking", Balance: domain.NewMoney(10000, "USD")}
+	card := domain.AccountBalance{AccountID: newID(), Name: "Card", Balance: domain.NewMoney(-2500, "USD")}
+	save(day(2), 30000, checking)
+	save(day(0), 10000, checking, card)
+	save(day(1), 20000)
+	// A second snapshot of the same day replaces the first.
+	save(day(2), 40000, card)
+
+	list, err := repo.ListNetWorthSnapshots(ctx, userID, time.Time{}, time.Time{})
+	requireNoError(t, err, "ListNetWorthSnapshots")
+	if len(list) != 3 || !sameInstant(list[0].Date, day(0)) || !sameInstant(list[2].Date, day(2)) {
+		t.Fatalf("ListNetWorthSnapshots = %+v, want 3 snapshots oldest first", list)
+	}
+	if list[0].TotalAssets != domain.NewMoney(10000, "USD") || list[0].TotalLiabilities != domain.NewMoney(2500, "USD") ||
+		len(list[0].Accounts) != 2 || list[0].Accounts[0] != checking || list[0].Accounts[1] != card {
+		t.Fatalf("ListNetWorthSnapshots returned %+v", list[0])
+	}
+	if len(list[1].Accounts) != 0 {
+		t.Fatalf("snapshot without accounts returned %+v", list[1].Accounts)
+	}
+	if list[2].TotalAssets != domain.NewMoney(40000, "USD") || len(list[2].Accounts) != 1 || list[2].Accounts[0] != card {
+		t.Fatalf("replaced snapshot = %+v", list[2])
+	}
+
+	list, err = repo.ListNetWorthSnapshots(ctx, userID, day(1), day(1))
+	requireNoError(t, err, "ListNetWorthSnapshots(range)")
+	if len(list) != 1 || !sameInstant(list[0].Date, day(1)) {
+		t.Fatalf("ListNetWorthSnapshots(range) = %+v, want the one snapshot of day 1", list)
+	}
+	list, err = repo.ListNetWorthSnapshots(ctx, newID(), time.Time{}, time.Time{})
+	requireNoError(t, err, "ListNetWorthSnapshots(other user)")
+	if len(list) != 0 {
+		t.Fatalf("ListNetWorthSnapshots(other user) = %+v, want none", list)
+	}
+}
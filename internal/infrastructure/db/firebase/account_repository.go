@@ -0,0 +1,111 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AccountRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *AccountRepository) accounts(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("accounts").Doc(userID).Collection("accounts")
+}
+
+func accountData(a *domain.Account) map[string]interface{} {
+	return map[string]interface{}{
+		"UID":            a.UID,
+		"UserID":         a.UserID,
+		"Name":           a.Name,
+		"Type":           string(a.Type),
+		"Currency":       a.Currency,
+		"OpeningBalance": a.OpeningBalance,
+		"CreatedAt":      a.CreatedAt,
+		"UpdatedAt":      a.UpdatedAt,
+	}
+}
+
+func (f *AccountRepository) CreateAccount(ctx context.Context, a *domain.Account) (*domain.Account, error) {
+	if a == nil || strings.TrimSpace(a.UserID) == "" || strings.TrimSpace(a.UID) == "" {
+		return nil, fmt.Errorf("invalid account")
+	}
+	if _, err := f.accounts(a.UserID).Doc(a.UID).Set(ctx, accountData(a)); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (f *AccountRepository) ListAccountsByUser(ctx context.Context, userID string) ([]*domain.Account, error) {
+	var res []*domain.Account
+	iter := f.accounts(userID).OrderBy("CreatedAt", firestore.Asc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var a domain.Account
+		if err := dsnap.DataTo(&a); err != nil {
+			return nil, err
+		}
+		res = append(res, &a)
+	}
+	return res, nil
+}
+
+func (f *AccountRepository) GetAccount(ctx context.Context, userID string, accountID string) (*domain.Account, error) {
+	dsnap, err := f.accounts(userID).Doc(accountID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, err
+	}
+	var a domain.Account
+	if err := dsnap.DataTo(&a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (f *AccountRepository) UpdateAccount(ctx context.Context, a *domain.Account) (*domain.Account, error) {
+	if a == nil || strings.TrimSpace(a.UserID) == "" || strings.TrimSpace(a.UID) == "" {
+		return nil, fmt.Errorf("invalid account")
+	}
+	_, err := f.accounts(a.UserID).Doc(a.UID).Update(ctx, []firestore.Update{
+		{Path: "Name", Value: a.Name},
+		{Path: "Type", Value: string(a.Type)},
+		{Path: "OpeningBalance", Value: a.OpeningBalance},
+		{Path: "UpdatedAt", Value: a.UpdatedAt},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+func (f *AccountRepository) DeleteAccount(ctx context.Context, userID string, accountID string) error {
+	docRef := f.accounts(userID).Doc(accountID)
+	if _, err := docRef.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "account not found")
+		}
+		return err
+	}
+	_, err := docRef.Delete(ctx)
+	return err
+}
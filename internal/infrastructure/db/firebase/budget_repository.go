@@ -0,0 +1,122 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type BudgetRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *BudgetRepository) budgets(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("budgets").Doc(userID).Collection("budgets")
+}
+
+func budgetData(b *domain.Budget) map[string]interface{} {
+	return map[string]interface{}{
+		"UID":        b.UID,
+		"UserID":     b.UserID,
+		"CategoryID": b.CategoryID,
+		"Period":     b.Period,
+		"Planned":    b.Planned,
+		"CreatedAt":  b.CreatedAt,
+		"UpdatedAt":  b.UpdatedAt,
+	}
+}
+
+func (f *BudgetRepository) CreateBudget(ctx context.Context, b *domain.Budget) (*domain.Budget, error) {
+	if b == nil || strings.TrimSpace(b.UserID) == "" || strings.TrimSpace(b.UID) == "" {
+		return nil, fmt.Errorf("invalid budget")
+	}
+	if _, err := f.budgets(b.UserID).Doc(b.UID).Set(ctx, budgetData(b)); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (f *BudgetRepository) ListBudgetsByUser(ctx context.Context, userID string) ([]*domain.Budget, error) {
+	var res []*domain.Budget
+	iter := f.budgets(userID).OrderBy("Period", firestore.Asc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var b domain.Budget
+		if err := dsnap.DataTo(&b); err != nil {
+			return nil, err
+		}
+		res = append(res, &b)
+	}
+	// Ordering within a period is done here rather than with a second
+	// OrderBy, which would need a composite index.
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Period != res[j].Period {
+			return res[i].Period < res[j].Period
+		}
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
+
+func (f *BudgetRepository) GetBudget(ctx context.Context, userID string, budgetID string) (*domain.Budget, error) {
+	dsnap, err := f.budgets(userID).Doc(budgetID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "budget not found")
+		}
+		return nil, err
+	}
+	var b domain.Budget
+	if err := dsnap.DataTo(&b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (f *BudgetRepository) UpdateBudget(ctx context.Context, b *domain.Budget) (*domain.Budget, error) {
+	if b == nil || strings.TrimSpace(b.UserID) == "" || strings.TrimSpace(b.UID) == "" {
+		return nil, fmt.Errorf("invalid budget")
+	}
+	_, err := f.budgets(b.UserID).Doc(b.UID).Update(ctx, []firestore.Update{
+		{Path: "CategoryID", Value: b.CategoryID},
+		{Path: "Period", Value: b.Period},
+		{Path: "Planned", Value: b.Planned},
+		{Path: "UpdatedAt", Value: b.UpdatedAt},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "budget not found")
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+func (f *BudgetRepository) DeleteBudget(ctx context.Context, userID string, budgetID string) error {
+	docRef := f.budgets(userID).Doc(budgetID)
+	if _, err := docRef.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "budget not found")
+		}
+		return err
+	}
+	_, err := docRef.Delete(ctx)
+	return err
+}
@@ -0,0 +1,110 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CategoryRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *CategoryRepository) categories(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("categories").Doc(userID).Collection("categories")
+}
+
+func categoryData(c *domain.Category) map[string]interface{} {
+	return map[string]interface{}{
+		"UID":       c.UID,
+		"UserID":    c.UserID,
+		"ParentID":  c.ParentID,
+		"Name":      c.Name,
+		"Kind":      string(c.Kind),
+		"CreatedAt": c.CreatedAt,
+		"UpdatedAt": c.UpdatedAt,
+	}
+}
+
+func (f *CategoryRepository) CreateCategory(ctx context.Context, c *domain.Category) (*domain.Category, error) {
+	if c == nil || strings.TrimSpace(c.UserID) == "" || strings.TrimSpace(c.UID) == "" {
+		return nil, fmt.Errorf("invalid category")
+	}
+	if _, err := f.categories(c.UserID).Doc(c.UID).Set(ctx, categoryData(c)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (f *CategoryRepository) ListCategoriesByUser(ctx context.Context, userID string) ([]*domain.Category, error) {
+	var res []*domain.Category
+	iter := f.categories(userID).OrderBy("Name", firestore.Asc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var c domain.Category
+		if err := dsnap.DataTo(&c); err != nil {
+			return nil, err
+		}
+		res = append(res, &c)
+	}
+	return res, nil
+}
+
+func (f *CategoryRepository) GetCategory(ctx context.Context, userID string, categoryID string) (*domain.Category, error) {
+	dsnap, err := f.categories(userID).Doc(categoryID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "category not found")
+		}
+		return nil, err
+	}
+	var c domain.Category
+	if err := dsnap.DataTo(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (f *CategoryRepository) UpdateCategory(ctx context.Context, c *domain.Category) (*domain.Category, error) {
+	if c == nil || strings.TrimSpace(c.UserID) == "" || strings.TrimSpace(c.UID) == "" {
+		return nil, fmt.Errorf("invalid category")
+	}
+	_, err := f.categories(c.UserID).Doc(c.UID).Update(ctx, []firestore.Update{
+		{Path: "ParentID", Value: c.ParentID},
+		{Path: "Name", Value: c.Name},
+		{Path: "Kind", Value: string(c.Kind)},
+		{Path: "UpdatedAt", Value: c.UpdatedAt},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "category not found")
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func (f *CategoryRepository) DeleteCategory(ctx context.Context, userID string, categoryID string) error {
+	docRef := f.categories(userID).Doc(categoryID)
+	if _, err := docRef.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "category not found")
+		}
+		return err
+	}
+	_, err := docRef.Delete(ctx)
+	return err
+}
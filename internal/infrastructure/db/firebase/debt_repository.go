@@ -0,0 +1,127 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type DebtRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *DebtRepository) debts(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("debts").Doc(userID).Collection("debts")
+}
+
+func debtData(d *domain.Debt) map[string]interface{} {
+	return map[string]interface{}{
+		"UID":       d.UID,
+		"UserID":    d.UserID,
+		"Name":      d.Name,
+		"Kind":      string(d.Kind),
+		"Principal": d.Principal,
+		"APR":       d.APR,
+		"Term":      d.Term,
+		"Frequency": d.Frequency,
+		"Payment":   d.Payment,
+		"StartDate": d.StartDate,
+		"CreatedAt": d.CreatedAt,
+		"UpdatedAt": d.UpdatedAt,
+	}
+}
+
+func (f *DebtRepository) CreateDebt(ctx context.Context, d *domain.Debt) (*domain.Debt, error) {
+	if d == nil || strings.TrimSpace(d.UserID) == "" || strings.TrimSpace(d.UID) == "" {
+		return nil, fmt.Errorf("invalid debt")
+	}
+	if _, err := f.debts(d.UserID).Doc(d.UID).Set(ctx, debtData(d)); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (f *DebtRepository) ListDebtsByUser(ctx context.Context, userID string) ([]*domain.Debt, error) {
+	var res []*domain.Debt
+	iter := f.debts(userID).OrderBy("CreatedAt", firestore.Asc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var d domain.Debt
+		if err := dsnap.DataTo(&d); err != nil {
+			return nil, err
+		}
+		res = append(res, &d)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
+
+func (f *DebtRepository) GetDebt(ctx context.Context, userID string, debtID string) (*domain.Debt, error) {
+	dsnap, err := f.debts(userID).Doc(debtID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "debt not found")
+		}
+		return nil, err
+	}
+	var d domain.Debt
+	if err := dsnap.DataTo(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (f *DebtRepository) UpdateDebt(ctx context.Context, d *domain.Debt) (*domain.Debt, error) {
+	if d == nil || strings.TrimSpace(d.UserID) == "" || strings.TrimSpace(d.UID) == "" {
+		return nil, fmt.Errorf("invalid debt")
+	}
+	_, err := f.debts(d.UserID).Doc(d.UID).Update(ctx, []firestore.Update{
+		{Path: "Name", Value: d.Name},
+		{Path: "Kind", Value: string(d.Kind)},
+		{Path: "Principal", Value: d.Principal},
+		{Path: "APR", Value: d.APR},
+		{Path: "Term", Value: d.Term},
+		{Path: "Frequency", Value: d.Frequency},
+		{Path: "Payment", Value: d.Payment},
+		{Path: "StartDate", Value: d.StartDate},
+		{Path: "UpdatedAt", Value: d.UpdatedAt},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "debt not found")
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+func (f *DebtRepository) DeleteDebt(ctx context.Context, userID string, debtID string) error {
+	docRef := f.debts(userID).Doc(debtID)
+	if _, err := docRef.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "debt not found")
+		}
+		return err
+	}
+	_, err := docRef.Delete(ctx)
+	return err
+}
@@ -0,0 +1,158 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type EnvelopeRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *EnvelopeRepository) envelopes(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("envelopes").Doc(userID).Collection("envelopes")
+}
+
+func (f *EnvelopeRepository) moves(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("envelope_moves").Doc(userID).Collection("moves")
+}
+
+func envelopeData(e *domain.Envelope) map[string]interface{} {
+	return map[string]interface{}{
+		"UID":         e.UID,
+		"UserID":      e.UserID,
+		"Name":        e.Name,
+		"CategoryID":  e.CategoryID,
+		"Allocation":  e.Allocation,
+		"Rollover":    e.Rollover,
+		"StartPeriod": e.StartPeriod,
+		"CreatedAt":   e.CreatedAt,
+		"UpdatedAt":   e.UpdatedAt,
+	}
+}
+
+func (f *EnvelopeRepository) CreateEnvelope(ctx context.Context, e *domain.Envelope) (*domain.Envelope, error) {
+	if e == nil || strings.TrimSpace(e.UserID) == "" || strings.TrimSpace(e.UID) == "" {
+		return nil, fmt.Errorf("invalid envelope")
+	}
+	if _, err := f.envelopes(e.UserID).Doc(e.UID).Set(ctx, envelopeData(e)); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (f *EnvelopeRepository) ListEnvelopesByUser(ctx context.Context, userID string) ([]*domain.Envelope, error) {
+	var res []*domain.Envelope
+	iter := f.envelopes(userID).OrderBy("CreatedAt", firestore.Asc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var e domain.Envelope
+		if err := dsnap.DataTo(&e); err != nil {
+			return nil, err
+		}
+		res = append(res, &e)
+	}
+	return res, nil
+}
+
+func (f *EnvelopeRepository) GetEnvelope(ctx context.Context, userID string, envelopeID string) (*domain.Envelope, error) {
+	dsnap, err := f.envelopes(userID).Doc(envelopeID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "envelope not found")
+		}
+		return nil, err
+	}
+	var e domain.Envelope
+	if err := dsnap.DataTo(&e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (f *EnvelopeRepository) UpdateEnvelope(ctx context.Context, e *domain.Envelope) (*domain.Envelope, error) {
+	if e == nil || strings.TrimSpace(e.UserID) == "" || strings.TrimSpace(e.UID) == "" {
+		return nil, fmt.Errorf("invalid envelope")
+	}
+	_, err := f.envelopes(e.UserID).Doc(e.UID).Update(ctx, []firestore.Update{
+		{Path: "Name", Value: e.Name},
+		{Path: "CategoryID", Value: e.CategoryID},
+		{Path: "Allocation", Value: e.Allocation},
+		{Path: "Rollover", Value: e.Rollover},
+		{Path: "StartPeriod", Value: e.StartPeriod},
+		{Path: "UpdatedAt", Value: e.UpdatedAt},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "envelope not found")
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+func (f *EnvelopeRepository) DeleteEnvelope(ctx context.Context, userID string, envelopeID string) error {
+	docRef := f.envelopes(userID).Doc(envelopeID)
+	if _, err := docRef.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "envelope not found")
+		}
+		return err
+	}
+	_, err := docRef.Delete(ctx)
+	return err
+}
+
+func (f *EnvelopeRepository) CreateEnvelopeMove(ctx context.Context, m *domain.EnvelopeMove) (*domain.EnvelopeMove, error) {
+	if m == nil || strings.TrimSpace(m.UserID) == "" || strings.TrimSpace(m.UID) == "" {
+		return nil, fmt.Errorf("invalid envelope move")
+	}
+	_, err := f.moves(m.UserID).Doc(m.UID).Set(ctx, map[string]interface{}{
+		"UID":            m.UID,
+		"UserID":         m.UserID,
+		"FromEnvelopeID": m.FromEnvelopeID,
+		"ToEnvelopeID":   m.ToEnvelopeID,
+		"Period":         m.Period,
+		"Amount":         m.Amount,
+		"Notes":          m.Notes,
+		"CreatedAt":      m.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (f *EnvelopeRepository) ListEnvelopeMovesByUser(ctx context.Context, userID string) ([]*domain.EnvelopeMove, error) {
+	var res []*domain.EnvelopeMove
+	iter := f.moves(userID).OrderBy("CreatedAt", firestore.Asc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var m domain.EnvelopeMove
+		if err := dsnap.DataTo(&m); err != nil {
+			return nil, err
+		}
+		res = append(res, &m)
+	}
+	return res, nil
+}
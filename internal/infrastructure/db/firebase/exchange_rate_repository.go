@@ -0,0 +1,96 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+)
+
+// ExchangeRateRepository keeps one document per day under
+// exchange_rates/{BASE}_{QUOTE}/rates/{YYYY-MM-DD}.
+type ExchangeRateRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *ExchangeRateRepository) rates(base, quote string) *firestore.CollectionRef {
+	return f.Firestore.Collection("exchange_rates").Doc(base + "_" + quote).Collection("rates")
+}
+
+func (f *ExchangeRateRepository) SaveRates(ctx context.Context, rates []domain.ExchangeRate) error {
+	bw := f.Firestore.BulkWriter(ctx)
+	var jobs []*firestore.BulkWriterJob
+	for _, rate := range rates {
+		job, err := bw.Set(f.rates(rate.Base, rate.Quote).Doc(rate.Date.UTC().Format("2006-01-02")), map[string]interface{}{
+			"Base":  rate.Base,
+			"Quote": rate.Quote,
+			"Date":  rate.Date.UTC(),
+			"Rate":  rate.Rate,
+		})
+		if err != nil {
+			bw.End()
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+	bw.End()
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *ExchangeRateRepository) FindRate(ctx context.Context, base, quote string, on time.Time) (*domain.ExchangeRate, error) {
+	iter := f.rates(base, quote).Where("Date", "<=", on.UTC()).OrderBy("Date", firestore.Desc).Limit(1).Documents(ctx)
+	defer iter.Stop()
+	dsnap, err := iter.Next()
+	if errors.Is(err, iterator.Done) {
+		return nil, domain.ErrExchangeRateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m domain.ExchangeRate
+	if err := dsnap.DataTo(&m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (f *ExchangeRateRepository) ListRates(ctx context.Context) ([]domain.ExchangeRate, error) {
+	// Sorted here rather than in the query, which would need a composite
+	// collection group index.
+	iter := f.Firestore.CollectionGroup("rates").Documents(ctx)
+	defer iter.Stop()
+	var out []domain.ExchangeRate
+	for {
+		dsnap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var m domain.ExchangeRate
+		if err := dsnap.DataTo(&m); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Base != out[j].Base {
+			return out[i].Base < out[j].Base
+		}
+		if out[i].Quote != out[j].Quote {
+			return out[i].Quote < out[j].Quote
+		}
+		return out[i].Date.Before(out[j].Date)
+	})
+	return out, nil
+}
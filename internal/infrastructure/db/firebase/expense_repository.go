@@ -27,8 +27,12 @@ func (f *ExpenseRepository) CreateExpense(ctx context.Context, expense *domain.E
 		"UID":                 expense.UID,
 		"UserID":              expense.UserID,
 		"Source":              expense.Source,
+		"AccountID":           expense.AccountID,
+		"CategoryID":          expense.CategoryID,
+		"DebtID":              expense.DebtID,
+		"Tags":                expense.Tags,
 		"Amount":              expense.Amount,
-		"Currency":            expense.Currency,
+		"Splits":              expense.Splits,
 		"Notes":               expense.Notes,
 		"IsRecurring":         expense.IsRecurring,
 		"RecurrenceFrequency": expense.RecurrenceFrequency,
@@ -82,8 +86,12 @@ func (f *ExpenseRepository) UpdateExpense(ctx context.Context, expense *domain.E
 		"UID":                 expense.UID,
 		"UserID":              expense.UserID,
 		"Source":              expense.Source,
+		"AccountID":           expense.AccountID,
+		"CategoryID":          expense.CategoryID,
+		"DebtID":              expense.DebtID,
+		"Tags":                expense.Tags,
 		"Amount":              expense.Amount,
-		"Currency":            expense.Currency,
+		"Splits":              expense.Splits,
 		"Notes":               expense.Notes,
 		"IsRecurring":         expense.IsRecurring,
 		"RecurrenceFrequency": expense.RecurrenceFrequency,
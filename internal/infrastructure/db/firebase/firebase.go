@@ -3,6 +3,7 @@ package firebase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	fb "firebase.google.com/go/v4"
 	fbAuth "firebase.google.com/go/v4/auth"
@@ -13,22 +14,43 @@ import (
 	"cloud.google.com/go/firestore"
 )
 
+// Auth bundles the Firebase Auth adapters. It is usable on its own so that
+// non-Firestore storage drivers can still authenticate against Firebase.
+type Auth struct {
+	App        *fb.App
+	AuthClient *fbAuth.Client
+
+	UserAuthenticator  *FirebaseAuth
+	TokenAuthenticator ports.TokenAuthenticator
+	TokenGenerator     ports.TokenGenerator
+}
+
 type Database struct {
-	App             *fb.App
+	*Auth
 	FirestoreClient *firestore.Client
-	AuthClient      *fbAuth.Client
-
-	UserRepository         *UserRepository
-	UserAuthenticator      *FirebaseAuth
-	IncomeRepository       *IncomeRepository
-	ExpenseRepository      *ExpenseRepository
-	IncomeSourceRepository *IncomeRepository
-	RefreshTokenRepository *RefreshTokenRepository
-	TokenAuthenticator     ports.TokenAuthenticator
-	TokenGenerator         ports.TokenGenerator
+
+	UserRepository             *UserRepository
+	IncomeRepository           *IncomeRepository
+	ExpenseRepository          *ExpenseRepository
+	IncomeSourceRepository     *IncomeRepository
+	RefreshTokenRepository     *RefreshTokenRepository
+	SecurityEventRepository    *SecurityEventRepository
+	TwoFactorRepository        *TwoFactorRepository
+	PasskeyRepository          *PasskeyRepository
+	LoginAttemptRepository     *LoginAttemptRepository
+	ExchangeRateRepository     *ExchangeRateRepository
+	CategoryRepository         *CategoryRepository
+	AccountRepository          *AccountRepository
+	TransferRepository         *TransferRepository
+	BudgetRepository           *BudgetRepository
+	EnvelopeRepository         *EnvelopeRepository
+	SavingsGoalRepository      *SavingsGoalRepository
+	DebtRepository             *DebtRepository
+	HoldingRepository          *HoldingRepository
+	NetWorthSnapshotRepository *NetWorthSnapshotRepository
 }
 
-func NewDatabase(ctx context.Context, cfg *config.Configuration) (*Database, error) {
+func NewAuth(ctx context.Context, cfg *config.Configuration) (*Auth, error) {
 	getStr := func(primary string, fallbacks ...string) string {
 		if v := cfg.V.GetString(primary); v != "" {
 			return v
@@ -66,28 +88,58 @@ func NewDatabase(ctx context.Context, cfg *config.Configuration) (*Database, err
 		return nil, fmt.Errorf("firebase auth init failed: %w", err)
 	}
 
-	fsClient, err := app.Firestore(ctx)
+	return &Auth{
+		App:                app,
+		AuthClient:         authClient,
+		UserAuthenticator:  &FirebaseAuth{Auth: authClient},
+		TokenAuthenticator: NewFirebaseTokenAuthenticator(authClient),
+		TokenGenerator:     NewFirebaseTokenGenerator(),
+	}, nil
+}
+
+func NewDatabase(ctx context.Context, cfg *config.Configuration) (*Database, error) {
+	auth, err := NewAuth(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	fsClient, err := auth.App.Firestore(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("firestore init failed: %w", err)
 	}
 
 	return &Database{
-		App:                    app,
-		FirestoreClient:        fsClient,
-		AuthClient:             authClient,
-		UserRepository:         &UserRepository{Firestore: fsClient},
-		UserAuthenticator:      &FirebaseAuth{Auth: authClient},
-		ExpenseRepository:      &ExpenseRepository{Firestore: fsClient},
-		IncomeRepository:       &IncomeRepository{Firestore: fsClient},
-		IncomeSourceRepository: &IncomeRepository{Firestore: fsClient},
-		RefreshTokenRepository: &RefreshTokenRepository{Firestore: fsClient},
-		TokenAuthenticator:     NewFirebaseTokenAuthenticator(authClient),
-		TokenGenerator:         NewFirebaseTokenGenerator(),
+		Auth:                       auth,
+		FirestoreClient:            fsClient,
+		UserRepository:             &UserRepository{Firestore: fsClient},
+		ExpenseRepository:          &ExpenseRepository{Firestore: fsClient},
+		IncomeRepository:           &IncomeRepository{Firestore: fsClient},
+		IncomeSourceRepository:     &IncomeRepository{Firestore: fsClient},
+		RefreshTokenRepository:     &RefreshTokenRepository{Firestore: fsClient},
+		SecurityEventRepository:    &SecurityEventRepository{Firestore: fsClient},
+		TwoFactorRepository:        &TwoFactorRepository{Firestore: fsClient},
+		PasskeyRepository:          &PasskeyRepository{Firestore: fsClient},
+		LoginAttemptRepository:     &LoginAttemptRepository{Firestore: fsClient},
+		ExchangeRateRepository:     &ExchangeRateRepository{Firestore: fsClient},
+		CategoryRepository:         &CategoryRepository{Firestore: fsClient},
+		AccountRepository:          &AccountRepository{Firestore: fsClient},
+		TransferRepository:         &TransferRepository{Firestore: fsClient},
+		BudgetRepository:           &BudgetRepository{Firestore: fsClient},
+		EnvelopeRepository:         &EnvelopeRepository{Firestore: fsClient},
+		SavingsGoalRepository:      &SavingsGoalRepository{Firestore: fsClient},
+		DebtRepository:             &DebtRepository{Firestore: fsClient},
+		HoldingRepository:          &HoldingRepository{Firestore: fsClient},
+		NetWorthSnapshotRepository: &NetWorthSnapshotRepository{Firestore: fsClient},
 	}, nil
 }
 
 func (d *Database) AutoMigrate(models ...interface{}) error { return nil }
 
+func (d *Database) Ping(ctx context.Context) error {
+	_, err := d.FirestoreClient.Collection("health_check").Doc("status").Set(ctx, map[string]interface{}{"last_checked": time.Now().UTC()})
+	return err
+}
+
 func (d *Database) Close() error {
 	if d.FirestoreClient != nil {
 		return d.FirestoreClient.Close()
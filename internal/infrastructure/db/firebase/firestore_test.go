@@ -0,0 +1,96 @@
+package firebase_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/dbtest"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/firebase"
+)
+
+// TestConformance runs against the Firestore emulator and is skipped when
+// FIRESTORE_EMULATOR_HOST is unset.
+func TestConformance(t *testing.T) {
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+		t.Skip("FIRESTORE_EMULATOR_HOST not set")
+	}
+
+	client, err := firestore.NewClient(context.Background(), "demo-budgeting")
+	if err != nil {
+		t.Fatalf("firestore client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	dbtest.Run(t, func(t *testing.T) dbtest.Backend {
+		return dbtest.Backend{
+			Users:          &firebase.UserRepository{Firestore: client},
+			Incomes:        &firebase.IncomeRepository{Firestore: client},
+			Expenses:       &firebase.ExpenseRepository{Firestore: client},
+			RefreshTokens:  &firebase.RefreshTokenRepository{Firestore: client},
+			SecurityEvents: &firebase.SecurityEventRepository{Firestore: client},
+			TwoFactor:      &firebase.TwoFactorRepository{Firestore: client},
+			Passkeys:       &firebase.PasskeyRepository{Firestore: client},
+			LoginAttempts:  &firebase.LoginAttemptRepository{Firestore: client},
+			Categories:     &firebase.CategoryRepository{Firestore: client},
+			Accounts:       &firebase.AccountRepository{Firestore: client},
+			Transfers:      &firebase.TransferRepository{Firestore: client},
+			Budgets:        &firebase.BudgetRepository{Firestore: client},
+			Envelopes:      &firebase.EnvelopeRepository{Firestore: client},
+			SavingsGoals:   &firebase.SavingsGoalRepository{Firestore: client},
+			Debts:          &firebase.DebtRepository{Firestore: client},
+			Holdings:       &firebase.HoldingRepository{Firestore: client},
+			NetWorth:       &firebase.NetWorthSnapshotRepository{Firestore: client},
+			ExchangeRates:  &firebase.ExchangeRateRepository{Firestore: client},
+		}
+	})
+}
+
+// TestRefreshTokenBackfill runs against the Firestore emulator and is skipped
+// when FIRESTORE_EMULATOR_HOST is unset.
+func TestRefreshTokenBackfill(t *testing.T) {
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+		t.Skip("FIRESTORE_EMULATOR_HOST not set")
+	}
+	ctx := context.Background()
+	// A project of its own, so no earlier run has applied the backfills yet.
+	client, err := firestore.NewClient(ctx, fmt.Sprintf("demo-backfill-%d", time.Now().UnixNano()))
+	if err != nil {
+		t.Fatalf("firestore client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	// A token issued before hashing holds the raw secret and has no family.
+	if _, err := client.Collection("refresh_tokens").Doc("legacy").Set(ctx, map[string]interface{}{
+		"id": "legacy", "user_id": "u1", "token_hash": "raw-secret", "is_revoked": false,
+		"expires_at": time.Now().Add(time.Hour), "created_at": time.Now(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tokens := &firebase.RefreshTokenRepository{Firestore: client}
+	if err := tokens.Create(ctx, &domain.RefreshToken{ID: "current", UserID: "u1", TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (&firebase.Database{FirestoreClient: client}).Migrator().Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	legacy, err := tokens.GetByID(ctx, "legacy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !legacy.IsRevoked || legacy.RevokedAt == nil || legacy.FamilyID != "legacy" {
+		t.Fatalf("legacy token = %+v, want it revoked in a family of its own", legacy)
+	}
+	current, err := tokens.GetByID(ctx, "current")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.IsRevoked {
+		t.Fatalf("current token = %+v, want it left valid", current)
+	}
+}
@@ -0,0 +1,178 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type HoldingRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *HoldingRepository) holdings(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("holdings").Doc(userID).Collection("holdings")
+}
+
+func (f *HoldingRepository) valuations(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("holding_valuations").Doc(userID).Collection("valuations")
+}
+
+func holdingData(h *domain.Holding) map[string]interface{} {
+	return map[string]interface{}{
+		"UID":       h.UID,
+		"UserID":    h.UserID,
+		"Name":      h.Name,
+		"Kind":      string(h.Kind),
+		"Category":  string(h.Category),
+		"Currency":  h.Currency,
+		"CreatedAt": h.CreatedAt,
+		"UpdatedAt": h.UpdatedAt,
+	}
+}
+
+func (f *HoldingRepository) CreateHolding(ctx context.Context, h *domain.Holding) (*domain.Holding, error) {
+	if h == nil || strings.TrimSpace(h.UserID) == "" || strings.TrimSpace(h.UID) == "" {
+		return nil, fmt.Errorf("invalid holding")
+	}
+	if _, err := f.holdings(h.UserID).Doc(h.UID).Set(ctx, holdingData(h)); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (f *HoldingRepository) ListHoldingsByUser(ctx context.Context, userID string) ([]*domain.Holding, error) {
+	var res []*domain.Holding
+	iter := f.holdings(userID).OrderBy("CreatedAt", firestore.Asc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var h domain.Holding
+		if err := dsnap.DataTo(&h); err != nil {
+			return nil, err
+		}
+		res = append(res, &h)
+	}
+	return res, nil
+}
+
+func (f *HoldingRepository) GetHolding(ctx context.Context, userID string, holdingID string) (*domain.Holding, error) {
+	dsnap, err := f.holdings(userID).Doc(holdingID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "holding not found")
+		}
+		return nil, err
+	}
+	var h domain.Holding
+	if err := dsnap.DataTo(&h); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (f *HoldingRepository) UpdateHolding(ctx context.Context, h *domain.Holding) (*domain.Holding, error) {
+	if h == nil || strings.TrimSpace(h.UserID) == "" || strings.TrimSpace(h.UID) == "" {
+		return nil, fmt.Errorf("invalid holding")
+	}
+	_, err := f.holdings(h.UserID).Doc(h.UID).Update(ctx, []firestore.Update{
+		{Path: "Name", Value: h.Name},
+		{Path: "Kind", Value: string(h.Kind)},
+		{Path: "Category", Value: string(h.Category)},
+		{Path: "UpdatedAt", Value: h.UpdatedAt},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "holding not found")
+		}
+		return nil, err
+	}
+	return h, nil
+}
+
+func (f *HoldingRepository) DeleteHolding(ctx context.Context, userID string, holdingID string) error {
+	docRef := f.holdings(userID).Doc(holdingID)
+	if _, err := docRef.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "holding not found")
+		}
+		return err
+	}
+
+	batch := f.Firestore.Batch()
+	batch.Delete(docRef)
+	iter := f.valuations(userID).Where("HoldingID", "==", holdingID).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return err
+		}
+		batch.Delete(dsnap.Ref)
+	}
+	_, err := batch.Commit(ctx)
+	return err
+}
+
+func (f *HoldingRepository) CreateValuation(ctx context.Context, v *domain.Valuation) (*domain.Valuation, error) {
+	if v == nil || strings.TrimSpace(v.UserID) == "" || strings.TrimSpace(v.UID) == "" {
+		return nil, fmt.Errorf("invalid valuation")
+	}
+	_, err := f.valuations(v.UserID).Doc(v.UID).Set(ctx, map[string]interface{}{
+		"UID":       v.UID,
+		"UserID":    v.UserID,
+		"HoldingID": v.HoldingID,
+		"Value":     v.Value,
+		"Date":      v.Date,
+		"Notes":     v.Notes,
+		"CreatedAt": v.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (f *HoldingRepository) ListValuationsByUser(ctx context.Context, userID string) ([]*domain.Valuation, error) {
+	var res []*domain.Valuation
+	iter := f.valuations(userID).OrderBy("Date", firestore.Asc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var v domain.Valuation
+		if err := dsnap.DataTo(&v); err != nil {
+			return nil, err
+		}
+		res = append(res, &v)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].Date.Equal(res[j].Date) {
+			return res[i].Date.Before(res[j].Date)
+		}
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
@@ -21,14 +21,16 @@ func (f *IncomeRepository) CreateIncome(ctx context.Context, income *domain.Inco
 		return nil, fmt.Errorf("invalid income")
 	}
 	_, err := f.Firestore.Collection("incomes").Doc(income.UserID).Collection("incomes").Doc(income.UID).Set(ctx, map[string]interface{}{
-		"UID":       income.UID,
-		"UserID":    income.UserID,
-		"Source":    income.Source,
-		"Amount":    income.Amount,
-		"Currency":  income.Currency,
-		"Notes":     income.Notes,
-		"CreatedAt": income.CreatedAt,
-		"UpdatedAt": income.UpdatedAt,
+		"UID":        income.UID,
+		"UserID":     income.UserID,
+		"Source":     income.Source,
+		"AccountID":  income.AccountID,
+		"CategoryID": income.CategoryID,
+		"Tags":       income.Tags,
+		"Amount":     income.Amount,
+		"Notes":      income.Notes,
+		"CreatedAt":  income.CreatedAt,
+		"UpdatedAt":  income.UpdatedAt,
 	})
 	if err != nil {
 		return nil, err
@@ -82,7 +84,6 @@ func (f *IncomeRepository) CreateIncomeSource(ctx context.Context, src *domain.I
 		"UserID":    src.UserID,
 		"Source":    src.Source,
 		"Amount":    src.Amount,
-		"Currency":  src.Currency,
 		"Frequency": src.Frequency,
 		"NextPayAt": src.NextPayAt,
 		"Active":    src.Active,
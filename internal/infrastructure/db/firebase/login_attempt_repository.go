@@ -0,0 +1,176 @@
+package firebase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LoginAttemptRepository keeps failed login counters under
+// login_attempts/{SHA-256 of the key}, since keys hold emails that may contain
+// characters not allowed in document IDs, and unlock tokens under
+// account_unlocks/{token hash}.
+type LoginAttemptRepository struct {
+	Firestore *firestore.Client
+}
+
+const (
+	loginAttemptsCollection  = "login_attempts"
+	accountUnlocksCollection = "account_unlocks"
+)
+
+func (f *LoginAttemptRepository) doc(key string) *firestore.DocumentRef {
+	sum := sha256.Sum256([]byte(key))
+	return f.Firestore.Collection(loginAttemptsCollection).Doc(hex.EncodeToString(sum[:]))
+}
+
+func (f *LoginAttemptRepository) RecordLoginFailure(ctx context.Context, key string, at, since time.Time) (*domain.LoginAttempts, error) {
+	ref := f.doc(key)
+	var a domain.LoginAttempts
+	err := f.Firestore.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		a = domain.LoginAttempts{Key: key}
+		doc, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			if err := doc.DataTo(&a); err != nil {
+				return err
+			}
+		}
+		if a.LastFailureAt.Before(since) {
+			a.Failures = 0
+		}
+		a.Failures++
+		a.LastFailureAt = at
+		return tx.Set(ref, &a)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (f *LoginAttemptRepository) GetLoginAttempts(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	doc, err := f.doc(key).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.NotFound, "no failed logins for %s", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var a domain.LoginAttempts
+	if err := doc.DataTo(&a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (f *LoginAttemptRepository) LockLogin(ctx context.Context, key string, until time.Time) error {
+	_, err := f.doc(key).Update(ctx, []firestore.Update{{Path: "locked_until", Value: until}})
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.NotFound, "no failed logins for %s", key)
+	}
+	return err
+}
+
+func (f *LoginAttemptRepository) ResetLoginAttempts(ctx context.Context, key string) error {
+	_, err := f.doc(key).Delete(ctx)
+	return err
+}
+
+func (f *LoginAttemptRepository) DeleteStaleLoginAttempts(ctx context.Context, before time.Time) error {
+	iter := f.Firestore.Collection(loginAttemptsCollection).
+		Where("last_failure_at", "<", before).
+		Documents(ctx)
+
+	batch := f.Firestore.Batch()
+	count := 0
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return err
+		}
+		var a domain.LoginAttempts
+		if err := doc.DataTo(&a); err != nil {
+			return err
+		}
+		if a.LockedUntil != nil && !a.LockedUntil.Before(before) {
+			continue
+		}
+		batch.Delete(doc.Ref)
+		count++
+	}
+	if count > 0 {
+		_, err := batch.Commit(ctx)
+		return err
+	}
+	return nil
+}
+
+func (f *LoginAttemptRepository) CreateAccountUnlock(ctx context.Context, u *domain.AccountUnlock) error {
+	if u == nil || u.TokenHash == "" {
+		return fmt.Errorf("invalid account unlock")
+	}
+	_, err := f.Firestore.Collection(accountUnlocksCollection).Doc(u.TokenHash).Create(ctx, u)
+	return err
+}
+
+func (f *LoginAttemptRepository) TakeAccountUnlock(ctx context.Context, tokenHash string) (*domain.AccountUnlock, error) {
+	ref := f.Firestore.Collection(accountUnlocksCollection).Doc(tokenHash)
+	var u domain.AccountUnlock
+	err := f.Firestore.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "account unlock not found")
+		}
+		if err != nil {
+			return err
+		}
+		if err := doc.DataTo(&u); err != nil {
+			return err
+		}
+		return tx.Delete(ref)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (f *LoginAttemptRepository) DeleteExpiredAccountUnlocks(ctx context.Context) error {
+	iter := f.Firestore.Collection(accountUnlocksCollection).
+		Where("expires_at", "<", time.Now()).
+		Documents(ctx)
+
+	batch := f.Firestore.Batch()
+	count := 0
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return err
+		}
+		batch.Delete(doc.Ref)
+		count++
+	}
+	if count > 0 {
+		_, err := batch.Commit(ctx)
+		return err
+	}
+	return nil
+}
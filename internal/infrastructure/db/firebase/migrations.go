@@ -0,0 +1,251 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/migrate"
+	"google.golang.org/api/iterator"
+)
+
+const migrationsCollection = "schema_migrations"
+
+// backfill is a Firestore data migration. Down may be nil for backfills that
+// only fill in missing data; reverting those just forgets they ran.
+type backfill struct {
+	migrate.Migration
+	Up   func(ctx context.Context, client *firestore.Client) error
+	Down func(ctx context.Context, client *firestore.Client) error
+}
+
+// amountGroups are the per-user collections whose documents carry an Amount.
+var amountGroups = []string{"incomes", "income_sources", "expenses"}
+
+var backfills = []backfill{
+	{
+		Migration: migrate.Migration{Version: 1, Name: "default_currency"},
+		Up: func(ctx context.Context, client *firestore.Client) error {
+			return updateGroups(ctx, client, amountGroups, func(data map[string]interface{}) []firestore.Update {
+				if v, _ := data["Currency"].(string); v != "" {
+					return nil
+				}
+				return []firestore.Update{{Path: "Currency", Value: domain.DefaultCurrency}}
+			})
+		},
+	},
+	{
+		// Amount used to be a float in major units with Currency beside it; it
+		// is now a domain.Money map of integer minor units and currency.
+		Migration: migrate.Migration{Version: 2, Name: "money_minor_units"},
+		Up: func(ctx context.Context, client *firestore.Client) error {
+			return updateGroups(ctx, client, amountGroups, func(data map[string]interface{}) []firestore.Update {
+				amount, ok := toFloat(data["Amount"])
+				if !ok {
+					return nil
+				}
+				currency, _ := data["Currency"].(string)
+				if currency == "" {
+					currency = domain.DefaultCurrency
+				}
+				return []firestore.Update{
+					{Path: "Amount", Value: domain.MoneyFromFloat(amount, currency)},
+					{Path: "Currency", Value: firestore.Delete},
+				}
+			})
+		},
+		Down: func(ctx context.Context, client *firestore.Client) error {
+			return updateGroups(ctx, client, amountGroups, func(data map[string]interface{}) []firestore.Update {
+				amount, ok := data["Amount"].(map[string]interface{})
+				if !ok {
+					return nil
+				}
+				minor, _ := amount["MinorUnits"].(int64)
+				currency, _ := amount["Currency"].(string)
+				return []firestore.Update{
+					{Path: "Amount", Value: domain.NewMoney(minor, currency).Float64()},
+					{Path: "Currency", Value: currency},
+				}
+			})
+		},
+	},
+	{
+		// Refresh tokens are now stored as SHA-256 hashes and rotated within a
+		// family. Tokens issued before that have no family and hold the raw
+		// secret, so they are revoked and their users have to log in again.
+		Migration: migrate.Migration{Version: 3, Name: "refresh_token_families"},
+		Up: func(ctx context.Context, client *firestore.Client) error {
+			now := time.Now()
+			return updateDocuments(ctx, client, []firestore.Query{client.Collection(refreshTokensCollection).Query}, func(dsnap *firestore.DocumentSnapshot) []firestore.Update {
+				data := dsnap.Data()
+				if v, _ := data["family_id"].(string); v != "" {
+					return nil
+				}
+				ups := []firestore.Update{{Path: "family_id", Value: dsnap.Ref.ID}}
+				if revoked, _ := data["is_revoked"].(bool); !revoked {
+					ups = append(ups, firestore.Update{Path: "is_revoked", Value: true}, firestore.Update{Path: "revoked_at", Value: now})
+				}
+				return ups
+			})
+		},
+	},
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// updateGroups applies the updates returned by fn to every per-user document
+// in the named collection groups. fn returns nil to leave a document as is.
+func updateGroups(ctx context.Context, client *firestore.Client, groups []string, fn func(data map[string]interface{}) []firestore.Update) error {
+	queries := make([]firestore.Query, len(groups))
+	for i, group := range groups {
+		queries[i] = client.CollectionGroup(group).Query
+	}
+	return updateDocuments(ctx, client, queries, func(dsnap *firestore.DocumentSnapshot) []firestore.Update {
+		// Skip top-level collections that share the group name, e.g.
+		// incomes/{uid} itself.
+		if dsnap.Ref.Parent.Parent == nil {
+			return nil
+		}
+		return fn(dsnap.Data())
+	})
+}
+
+// updateDocuments applies the updates returned by fn to every document the
+// queries return. fn returns nil to leave a document as is.
+func updateDocuments(ctx context.Context, client *firestore.Client, queries []firestore.Query, fn func(dsnap *firestore.DocumentSnapshot) []firestore.Update) error {
+	bw := client.BulkWriter(ctx)
+	var (
+		jobs []*firestore.BulkWriterJob
+		refs []*firestore.DocumentRef
+	)
+	for _, query := range queries {
+		iter := query.Documents(ctx)
+		for {
+			dsnap, err := iter.Next()
+			if err != nil {
+				iter.Stop()
+				if errors.Is(err, iterator.Done) {
+					break
+				}
+				bw.End()
+				return err
+			}
+			ups := fn(dsnap)
+			if len(ups) == 0 {
+				continue
+			}
+			job, err := bw.Update(dsnap.Ref, ups)
+			if err != nil {
+				iter.Stop()
+				bw.End()
+				return err
+			}
+			jobs = append(jobs, job)
+			refs = append(refs, dsnap.Ref)
+		}
+	}
+	// End flushes the remaining writes; a failed write only shows up in its
+	// job's results.
+	bw.End()
+	for i, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return fmt.Errorf("failed to update %s: %w", refs[i].Path, err)
+		}
+	}
+	return nil
+}
+
+type migrationDriver struct {
+	client *firestore.Client
+}
+
+func (d *Database) Migrator() *migrate.Migrator {
+	return migrate.New(&migrationDriver{client: d.FirestoreClient})
+}
+
+func (m *migrationDriver) Migrations() []migrate.Migration {
+	res := make([]migrate.Migration, 0, len(backfills))
+	for _, b := range backfills {
+		res = append(res, b.Migration)
+	}
+	return res
+}
+
+func (m *migrationDriver) Applied(ctx context.Context) (map[int]time.Time, error) {
+	applied := make(map[int]time.Time)
+	iter := m.client.Collection(migrationsCollection).Documents(ctx)
+	defer iter.Stop()
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var rec struct {
+			Version   int
+			AppliedAt time.Time
+		}
+		if err := dsnap.DataTo(&rec); err != nil {
+			return nil, err
+		}
+		applied[rec.Version] = rec.AppliedAt
+	}
+	return applied, nil
+}
+
+func (m *migrationDriver) Apply(ctx context.Context, mig migrate.Migration) error {
+	b, err := findBackfill(mig.Version)
+	if err != nil {
+		return err
+	}
+	if err := b.Up(ctx, m.client); err != nil {
+		return err
+	}
+	_, err = m.record(mig.Version).Set(ctx, map[string]interface{}{
+		"Version":   b.Version,
+		"Name":      b.Name,
+		"AppliedAt": time.Now().UTC(),
+	})
+	return err
+}
+
+func (m *migrationDriver) Revert(ctx context.Context, mig migrate.Migration) error {
+	b, err := findBackfill(mig.Version)
+	if err != nil {
+		return err
+	}
+	if b.Down != nil {
+		if err := b.Down(ctx, m.client); err != nil {
+			return err
+		}
+	}
+	_, err = m.record(mig.Version).Delete(ctx)
+	return err
+}
+
+func (m *migrationDriver) record(version int) *firestore.DocumentRef {
+	return m.client.Collection(migrationsCollection).Doc(fmt.Sprintf("%04d", version))
+}
+
+func findBackfill(version int) (*backfill, error) {
+	for i := range backfills {
+		if backfills[i].Version == version {
+			return &backfills[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown migration %d", version)
+}
@@ -0,0 +1,75 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+)
+
+// NetWorthSnapshotRepository keeps one document per user and day under
+// net_worth_snapshots/{uid}/snapshots/{YYYY-MM-DD}, with the account
+// balances embedded.
+type NetWorthSnapshotRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *NetWorthSnapshotRepository) snapshots(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("net_worth_snapshots").Doc(userID).Collection("snapshots")
+}
+
+func (f *NetWorthSnapshotRepository) SaveNetWorthSnapshot(ctx context.Context, s *domain.NetWorthSnapshot) error {
+	if s == nil || strings.TrimSpace(s.UserID) == "" || s.Date.IsZero() {
+		return fmt.Errorf("invalid net worth snapshot")
+	}
+	accounts := make([]map[string]interface{}, len(s.Accounts))
+	for i, a := range s.Accounts {
+		accounts[i] = map[string]interface{}{
+			"AccountID": a.AccountID,
+			"Name":      a.Name,
+			"Balance":   a.Balance,
+		}
+	}
+	date := s.Date.UTC()
+	_, err := f.snapshots(s.UserID).Doc(date.Format("2006-01-02")).Set(ctx, map[string]interface{}{
+		"UserID":           s.UserID,
+		"Date":             date,
+		"TotalAssets":      s.TotalAssets,
+		"TotalLiabilities": s.TotalLiabilities,
+		"Accounts":         accounts,
+		"CreatedAt":        s.CreatedAt,
+	})
+	return err
+}
+
+func (f *NetWorthSnapshotRepository) ListNetWorthSnapshots(ctx context.Context, userID string, from, to time.Time) ([]*domain.NetWorthSnapshot, error) {
+	query := f.snapshots(userID).Query
+	if !from.IsZero() {
+		query = query.Where("Date", ">=", from.UTC())
+	}
+	if !to.IsZero() {
+		query = query.Where("Date", "<=", to.UTC())
+	}
+	res := []*domain.NetWorthSnapshot{}
+	iter := query.OrderBy("Date", firestore.Asc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var s domain.NetWorthSnapshot
+		if err := dsnap.DataTo(&s); err != nil {
+			return nil, err
+		}
+		res = append(res, &s)
+	}
+	return res, nil
+}
@@ -0,0 +1,152 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PasskeyRepository keeps passkeys under passkeys/{credential ID}, beside the
+// refresh_tokens collection, and ceremonies under
+// passkey_ceremonies/{token hash}.
+type PasskeyRepository struct {
+	Firestore *firestore.Client
+}
+
+const (
+	passkeysCollection          = "passkeys"
+	passkeyCeremoniesCollection = "passkey_ceremonies"
+)
+
+func (f *PasskeyRepository) CreatePasskey(ctx context.Context, p *domain.Passkey) error {
+	if p == nil || p.ID == "" || p.UserID == "" {
+		return fmt.Errorf("invalid passkey")
+	}
+	_, err := f.Firestore.Collection(passkeysCollection).Doc(p.ID).Create(ctx, p)
+	if status.Code(err) == codes.AlreadyExists {
+		return status.Errorf(codes.AlreadyExists, "passkey already registered")
+	}
+	return err
+}
+
+func (f *PasskeyRepository) ListPasskeys(ctx context.Context, userID string) ([]*domain.Passkey, error) {
+	iter := f.Firestore.Collection(passkeysCollection).
+		Where("user_id", "==", userID).
+		Documents(ctx)
+
+	var out []*domain.Passkey
+	for {
+		doc, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var p domain.Passkey
+		if err := doc.DataTo(&p); err != nil {
+			return nil, err
+		}
+		out = append(out, &p)
+	}
+	// Sorted here rather than in the query to avoid a composite index.
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (f *PasskeyRepository) UpdatePasskeyUsage(ctx context.Context, id string, signCount uint32, backupState bool, usedAt time.Time) error {
+	_, err := f.Firestore.Collection(passkeysCollection).Doc(id).Update(ctx, []firestore.Update{
+		{Path: "sign_count", Value: signCount},
+		{Path: "backup_state", Value: backupState},
+		{Path: "last_used_at", Value: usedAt},
+	})
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.NotFound, "passkey not found")
+	}
+	return err
+}
+
+func (f *PasskeyRepository) DeletePasskey(ctx context.Context, userID, id string) error {
+	ref := f.Firestore.Collection(passkeysCollection).Doc(id)
+	return f.Firestore.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "passkey not found")
+		}
+		if err != nil {
+			return err
+		}
+		if owner, _ := doc.Data()["user_id"].(string); owner != userID {
+			return status.Errorf(codes.NotFound, "passkey not found")
+		}
+		return tx.Delete(ref)
+	})
+}
+
+func (f *PasskeyRepository) CreatePasskeyCeremony(ctx context.Context, c *domain.PasskeyCeremony) error {
+	if c == nil || c.TokenHash == "" {
+		return fmt.Errorf("invalid passkey ceremony")
+	}
+	_, err := f.Firestore.Collection(passkeyCeremoniesCollection).Doc(c.TokenHash).Create(ctx, c)
+	return err
+}
+
+func (f *PasskeyRepository) TakePasskeyCeremony(ctx context.Context, tokenHash string) (*domain.PasskeyCeremony, error) {
+	ref := f.Firestore.Collection(passkeyCeremoniesCollection).Doc(tokenHash)
+	var c domain.PasskeyCeremony
+	err := f.Firestore.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "passkey ceremony not found")
+		}
+		if err != nil {
+			return err
+		}
+		if err := doc.DataTo(&c); err != nil {
+			return err
+		}
+		return tx.Delete(ref)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (f *PasskeyRepository) DeleteExpiredPasskeyCeremonies(ctx context.Context) error {
+	iter := f.Firestore.Collection(passkeyCeremoniesCollection).
+		Where("expires_at", "<", time.Now()).
+		Documents(ctx)
+
+	batch := f.Firestore.Batch()
+	count := 0
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return err
+		}
+		batch.Delete(doc.Ref)
+		count++
+	}
+	if count > 0 {
+		_, err := batch.Commit(ctx)
+		return err
+	}
+	return nil
+}
@@ -27,6 +27,9 @@ func (r *RefreshTokenRepository) Create(ctx context.Context, token *domain.Refre
 	if token.ID == "" {
 		token.ID = generateTokenID()
 	}
+	if token.FamilyID == "" {
+		token.FamilyID = token.ID
+	}
 
 	_, err := r.Firestore.Collection(refreshTokensCollection).Doc(token.ID).Set(ctx, token)
 	return err
@@ -99,6 +102,53 @@ func (r *RefreshTokenRepository) GetValidToken(ctx context.Context, userID, toke
 	return &token, nil
 }
 
+func (r *RefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	iter := r.Firestore.Collection(refreshTokensCollection).
+		Where("token_hash", "==", tokenHash).
+		Limit(1).
+		Documents(ctx)
+
+	doc, err := iter.Next()
+	if err == iterator.Done {
+		return nil, status.Errorf(codes.NotFound, "refresh token not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var token domain.RefreshToken
+	if err := doc.DataTo(&token); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *RefreshTokenRepository) RotateToken(ctx context.Context, id, replacedBy string) error {
+	ref := r.Firestore.Collection(refreshTokensCollection).Doc(id)
+	return r.Firestore.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return status.Errorf(codes.NotFound, "refresh token not found")
+			}
+			return err
+		}
+		var token domain.RefreshToken
+		if err := doc.DataTo(&token); err != nil {
+			return err
+		}
+		if token.IsRevoked {
+			return status.Errorf(codes.FailedPrecondition, "refresh token already revoked")
+		}
+		return tx.Update(ref, []firestore.Update{
+			{Path: "is_revoked", Value: true},
+			{Path: "revoked_at", Value: time.Now()},
+			{Path: "replaced_by", Value: replacedBy},
+		})
+	})
+}
+
 func (r *RefreshTokenRepository) RevokeToken(ctx context.Context, id string) error {
 	now := time.Now()
 	_, err := r.Firestore.Collection(refreshTokensCollection).Doc(id).Update(ctx, []firestore.Update{
@@ -108,9 +158,17 @@ func (r *RefreshTokenRepository) RevokeToken(ctx context.Context, id string) err
 	return err
 }
 
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.revokeWhere(ctx, "family_id", familyID)
+}
+
 func (r *RefreshTokenRepository) RevokeAllUserTokens(ctx context.Context, userID string) error {
+	return r.revokeWhere(ctx, "user_id", userID)
+}
+
+func (r *RefreshTokenRepository) revokeWhere(ctx context.Context, field, value string) error {
 	iter := r.Firestore.Collection(refreshTokensCollection).
-		Where("user_id", "==", userID).
+		Where(field, "==", value).
 		Where("is_revoked", "==", false).
 		Documents(ctx)
 
@@ -0,0 +1,122 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SavingsGoalRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *SavingsGoalRepository) goals(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("savings_goals").Doc(userID).Collection("goals")
+}
+
+func savingsGoalData(g *domain.SavingsGoal) map[string]interface{} {
+	return map[string]interface{}{
+		"UID":        g.UID,
+		"UserID":     g.UserID,
+		"Name":       g.Name,
+		"AccountID":  g.AccountID,
+		"Target":     g.Target,
+		"TargetDate": g.TargetDate,
+		"CreatedAt":  g.CreatedAt,
+		"UpdatedAt":  g.UpdatedAt,
+	}
+}
+
+func (f *SavingsGoalRepository) CreateSavingsGoal(ctx context.Context, g *domain.SavingsGoal) (*domain.SavingsGoal, error) {
+	if g == nil || strings.TrimSpace(g.UserID) == "" || strings.TrimSpace(g.UID) == "" {
+		return nil, fmt.Errorf("invalid savings goal")
+	}
+	if _, err := f.goals(g.UserID).Doc(g.UID).Set(ctx, savingsGoalData(g)); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (f *SavingsGoalRepository) ListSavingsGoalsByUser(ctx context.Context, userID string) ([]*domain.SavingsGoal, error) {
+	var res []*domain.SavingsGoal
+	iter := f.goals(userID).OrderBy("TargetDate", firestore.Asc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var g domain.SavingsGoal
+		if err := dsnap.DataTo(&g); err != nil {
+			return nil, err
+		}
+		res = append(res, &g)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].TargetDate.Equal(res[j].TargetDate) {
+			return res[i].TargetDate.Before(res[j].TargetDate)
+		}
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
+
+func (f *SavingsGoalRepository) GetSavingsGoal(ctx context.Context, userID string, goalID string) (*domain.SavingsGoal, error) {
+	dsnap, err := f.goals(userID).Doc(goalID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "savings goal not found")
+		}
+		return nil, err
+	}
+	var g domain.SavingsGoal
+	if err := dsnap.DataTo(&g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (f *SavingsGoalRepository) UpdateSavingsGoal(ctx context.Context, g *domain.SavingsGoal) (*domain.SavingsGoal, error) {
+	if g == nil || strings.TrimSpace(g.UserID) == "" || strings.TrimSpace(g.UID) == "" {
+		return nil, fmt.Errorf("invalid savings goal")
+	}
+	_, err := f.goals(g.UserID).Doc(g.UID).Update(ctx, []firestore.Update{
+		{Path: "Name", Value: g.Name},
+		{Path: "AccountID", Value: g.AccountID},
+		{Path: "Target", Value: g.Target},
+		{Path: "TargetDate", Value: g.TargetDate},
+		{Path: "UpdatedAt", Value: g.UpdatedAt},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "savings goal not found")
+		}
+		return nil, err
+	}
+	return g, nil
+}
+
+func (f *SavingsGoalRepository) DeleteSavingsGoal(ctx context.Context, userID string, goalID string) error {
+	docRef := f.goals(userID).Doc(goalID)
+	if _, err := docRef.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "savings goal not found")
+		}
+		return err
+	}
+	_, err := docRef.Delete(ctx)
+	return err
+}
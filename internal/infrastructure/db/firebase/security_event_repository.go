@@ -0,0 +1,53 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SecurityEventRepository keeps events under security_events/{uid}/events.
+type SecurityEventRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *SecurityEventRepository) events(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("security_events").Doc(userID).Collection("events")
+}
+
+func (f *SecurityEventRepository) CreateSecurityEvent(ctx context.Context, e *domain.SecurityEvent) error {
+	if e == nil || e.ID == "" || e.UserID == "" {
+		return fmt.Errorf("invalid security event")
+	}
+	_, err := f.events(e.UserID).Doc(e.ID).Create(ctx, e)
+	if status.Code(err) == codes.AlreadyExists {
+		return nil
+	}
+	return err
+}
+
+func (f *SecurityEventRepository) ListSecurityEvents(ctx context.Context, userID string) ([]*domain.SecurityEvent, error) {
+	var res []*domain.SecurityEvent
+	iter := f.events(userID).OrderBy("created_at", firestore.Desc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var e domain.SecurityEvent
+		if err := dsnap.DataTo(&e); err != nil {
+			return nil, err
+		}
+		res = append(res, &e)
+	}
+	return res, nil
+}
@@ -0,0 +1,91 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TransferRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *TransferRepository) transfers(userID string) *firestore.CollectionRef {
+	return f.Firestore.Collection("transfers").Doc(userID).Collection("transfers")
+}
+
+func (f *TransferRepository) CreateTransfer(ctx context.Context, t *domain.Transfer) (*domain.Transfer, error) {
+	if t == nil || strings.TrimSpace(t.UserID) == "" || strings.TrimSpace(t.UID) == "" {
+		return nil, fmt.Errorf("invalid transfer")
+	}
+	_, err := f.transfers(t.UserID).Doc(t.UID).Set(ctx, map[string]interface{}{
+		"UID":           t.UID,
+		"UserID":        t.UserID,
+		"FromAccountID": t.FromAccountID,
+		"ToAccountID":   t.ToAccountID,
+		"Amount":        t.Amount,
+		"ToAmount":      t.ToAmount,
+		"Rate":          t.Rate,
+		"Notes":         t.Notes,
+		"CreatedAt":     t.CreatedAt,
+		"UpdatedAt":     t.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (f *TransferRepository) ListTransfersByUser(ctx context.Context, userID string) ([]*domain.Transfer, error) {
+	var res []*domain.Transfer
+	iter := f.transfers(userID).OrderBy("CreatedAt", firestore.Desc).Documents(ctx)
+	for {
+		dsnap, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, err
+		}
+		var t domain.Transfer
+		if err := dsnap.DataTo(&t); err != nil {
+			return nil, err
+		}
+		res = append(res, &t)
+	}
+	return res, nil
+}
+
+func (f *TransferRepository) GetTransfer(ctx context.Context, userID string, transferID string) (*domain.Transfer, error) {
+	dsnap, err := f.transfers(userID).Doc(transferID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "transfer not found")
+		}
+		return nil, err
+	}
+	var t domain.Transfer
+	if err := dsnap.DataTo(&t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (f *TransferRepository) DeleteTransfer(ctx context.Context, userID string, transferID string) error {
+	docRef := f.transfers(userID).Doc(transferID)
+	if _, err := docRef.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "transfer not found")
+		}
+		return err
+	}
+	_, err := docRef.Delete(ctx)
+	return err
+}
@@ -0,0 +1,182 @@
+package firebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TwoFactorRepository keeps one two_factor/{uid} document per enrolled user,
+// with the recovery code hashes embedded, and login challenges under
+// two_factor_challenges/{token hash}.
+type TwoFactorRepository struct {
+	Firestore *firestore.Client
+}
+
+func (f *TwoFactorRepository) settings(userID string) *firestore.DocumentRef {
+	return f.Firestore.Collection("two_factor").Doc(userID)
+}
+
+func (f *TwoFactorRepository) challenge(tokenHash string) *firestore.DocumentRef {
+	return f.Firestore.Collection("two_factor_challenges").Doc(tokenHash)
+}
+
+func (f *TwoFactorRepository) SaveTwoFactor(ctx context.Context, tf *domain.TwoFactor) error {
+	if tf == nil || tf.UserID == "" || tf.Secret == "" {
+		return fmt.Errorf("invalid two-factor settings")
+	}
+	_, err := f.settings(tf.UserID).Set(ctx, tf)
+	return err
+}
+
+func (f *TwoFactorRepository) GetTwoFactor(ctx context.Context, userID string) (*domain.TwoFactor, error) {
+	doc, err := f.settings(userID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.NotFound, "two-factor settings not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tf domain.TwoFactor
+	if err := doc.DataTo(&tf); err != nil {
+		return nil, err
+	}
+	return &tf, nil
+}
+
+func (f *TwoFactorRepository) DeleteTwoFactor(ctx context.Context, userID string) error {
+	_, err := f.settings(userID).Delete(ctx, firestore.Exists)
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.NotFound, "two-factor settings not found")
+	}
+	return err
+}
+
+func (f *TwoFactorRepository) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	return f.updateSettings(ctx, userID, func(tf *domain.TwoFactor) ([]firestore.Update, error) {
+		if tf.LastUsedStep >= step {
+			return nil, status.Errorf(codes.FailedPrecondition, "code already used")
+		}
+		return []firestore.Update{{Path: "last_used_step", Value: step}}, nil
+	})
+}
+
+func (f *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	return f.updateSettings(ctx, userID, func(tf *domain.TwoFactor) ([]firestore.Update, error) {
+		for i, hash := range tf.RecoveryCodes {
+			if hash == codeHash {
+				remaining := append(tf.RecoveryCodes[:i:i], tf.RecoveryCodes[i+1:]...)
+				return []firestore.Update{{Path: "recovery_codes", Value: remaining}}, nil
+			}
+		}
+		return nil, status.Errorf(codes.NotFound, "recovery code not found")
+	})
+}
+
+// updateSettings reads and updates the user's settings in one transaction so
+// that concurrent uses of the same code cannot both succeed.
+func (f *TwoFactorRepository) updateSettings(ctx context.Context, userID string, change func(*domain.TwoFactor) ([]firestore.Update, error)) error {
+	ref := f.settings(userID)
+	return f.Firestore.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.FailedPrecondition, "two-factor settings not found")
+		}
+		if err != nil {
+			return err
+		}
+		var tf domain.TwoFactor
+		if err := doc.DataTo(&tf); err != nil {
+			return err
+		}
+		updates, err := change(&tf)
+		if err != nil {
+			return err
+		}
+		return tx.Update(ref, updates)
+	})
+}
+
+func (f *TwoFactorRepository) CreateTwoFactorChallenge(ctx context.Context, c *domain.TwoFactorChallenge) error {
+	if c == nil || c.TokenHash == "" || c.UserID == "" {
+		return fmt.Errorf("invalid two-factor challenge")
+	}
+	_, err := f.challenge(c.TokenHash).Create(ctx, c)
+	return err
+}
+
+func (f *TwoFactorRepository) GetTwoFactorChallenge(ctx context.Context, tokenHash string) (*domain.TwoFactorChallenge, error) {
+	doc, err := f.challenge(tokenHash).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.NotFound, "two-factor challenge not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c domain.TwoFactorChallenge
+	if err := doc.DataTo(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (f *TwoFactorRepository) RecordChallengeAttempt(ctx context.Context, tokenHash string) (int, error) {
+	ref := f.challenge(tokenHash)
+	var attempts int
+	err := f.Firestore.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "two-factor challenge not found")
+		}
+		if err != nil {
+			return err
+		}
+		var c domain.TwoFactorChallenge
+		if err := doc.DataTo(&c); err != nil {
+			return err
+		}
+		attempts = c.Attempts + 1
+		return tx.Update(ref, []firestore.Update{{Path: "attempts", Value: attempts}})
+	})
+	return attempts, err
+}
+
+func (f *TwoFactorRepository) DeleteTwoFactorChallenge(ctx context.Context, tokenHash string) error {
+	_, err := f.challenge(tokenHash).Delete(ctx, firestore.Exists)
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.NotFound, "two-factor challenge not found")
+	}
+	return err
+}
+
+func (f *TwoFactorRepository) DeleteExpiredTwoFactorChallenges(ctx context.Context) error {
+	iter := f.Firestore.Collection("two_factor_challenges").
+		Where("expires_at", "<", time.Now()).
+		Documents(ctx)
+
+	batch := f.Firestore.Batch()
+	count := 0
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return err
+		}
+		batch.Delete(doc.Ref)
+		count++
+	}
+	if count > 0 {
+		_, err := batch.Commit(ctx)
+		return err
+	}
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 	"cloud.google.com/go/firestore"
 	"github.com/theHinneh/budgeting/internal/domain"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type UserRepository struct {
@@ -30,6 +32,7 @@ func (f *UserRepository) CreateUser(ctx context.Context, u *domain.User) (*domai
 		"ProviderID":    u.ProviderID,
 		"PhotoURL":      u.PhotoURL,
 		"EmailVerified": u.EmailVerified,
+		"BaseCurrency":  u.BaseCurrency,
 		"CreatedAt":     u.CreatedAt,
 		"UpdatedAt":     u.UpdatedAt,
 	})
@@ -52,10 +55,19 @@ func (f *UserRepository) GetUser(ctx context.Context, uid string) (*domain.User,
 }
 
 func (f *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
-	// Firestore does not directly support querying by email without a specific index.
-	// This would require a collection group query or pre-indexing.
-	// For simplicity, returning an error for now.
-	return nil, fmt.Errorf("GetUserByEmail not directly supported/efficient for Firestore without a specific index. Implement a query on 'Email' field if needed")
+	iter := f.Firestore.Collection("users").Where("Email", "==", strings.ToLower(strings.TrimSpace(email))).Limit(1).Documents(ctx)
+	dsnap, err := iter.Next()
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, err
+	}
+	var m domain.User
+	if err := dsnap.DataTo(&m); err != nil {
+		return nil, err
+	}
+	return &m, nil
 }
 
 func (f *UserRepository) UpdateUser(ctx context.Context, u *domain.User) (*domain.User, error) {
@@ -72,6 +84,7 @@ func (f *UserRepository) UpdateUser(ctx context.Context, u *domain.User) (*domai
 		"ProviderID":    u.ProviderID,
 		"PhotoURL":      u.PhotoURL,
 		"EmailVerified": u.EmailVerified,
+		"BaseCurrency":  u.BaseCurrency,
 		"UpdatedAt":     u.UpdatedAt,
 	}
 
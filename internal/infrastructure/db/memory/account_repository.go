@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AccountRepository struct {
+	accounts userScoped[domain.Account]
+}
+
+func NewAccountRepository() *AccountRepository {
+	return &AccountRepository{accounts: newUserScoped[domain.Account]()}
+}
+
+func (r *AccountRepository) CreateAccount(ctx context.Context, a *domain.Account) (*domain.Account, error) {
+	if a == nil || strings.TrimSpace(a.UserID) == "" || strings.TrimSpace(a.UID) == "" {
+		return nil, fmt.Errorf("invalid account")
+	}
+	r.accounts.mu.Lock()
+	defer r.accounts.mu.Unlock()
+	r.accounts.put(a.UserID, a.UID, a)
+	return a, nil
+}
+
+func (r *AccountRepository) ListAccountsByUser(ctx context.Context, userID string) ([]*domain.Account, error) {
+	r.accounts.mu.RLock()
+	defer r.accounts.mu.RUnlock()
+	res := r.accounts.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
+
+func (r *AccountRepository) GetAccount(ctx context.Context, userID string, accountID string) (*domain.Account, error) {
+	r.accounts.mu.RLock()
+	defer r.accounts.mu.RUnlock()
+	a, ok := r.accounts.get(userID, accountID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "account not found")
+	}
+	return a, nil
+}
+
+func (r *AccountRepository) UpdateAccount(ctx context.Context, a *domain.Account) (*domain.Account, error) {
+	if a == nil {
+		return nil, fmt.Errorf("invalid account")
+	}
+	r.accounts.mu.Lock()
+	defer r.accounts.mu.Unlock()
+	if _, ok := r.accounts.data[a.UserID][a.UID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "account not found")
+	}
+	r.accounts.put(a.UserID, a.UID, a)
+	return a, nil
+}
+
+func (r *AccountRepository) DeleteAccount(ctx context.Context, userID string, accountID string) error {
+	r.accounts.mu.Lock()
+	defer r.accounts.mu.Unlock()
+	if _, ok := r.accounts.data[userID][accountID]; !ok {
+		return status.Errorf(codes.NotFound, "account not found")
+	}
+	delete(r.accounts.data[userID], accountID)
+	return nil
+}
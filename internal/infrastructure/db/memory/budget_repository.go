@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type BudgetRepository struct {
+	budgets userScoped[domain.Budget]
+}
+
+func NewBudgetRepository() *BudgetRepository {
+	return &BudgetRepository{budgets: newUserScoped[domain.Budget]()}
+}
+
+func (r *BudgetRepository) CreateBudget(ctx context.Context, b *domain.Budget) (*domain.Budget, error) {
+	if b == nil || strings.TrimSpace(b.UserID) == "" || strings.TrimSpace(b.UID) == "" {
+		return nil, fmt.Errorf("invalid budget")
+	}
+	r.budgets.mu.Lock()
+	defer r.budgets.mu.Unlock()
+	r.budgets.put(b.UserID, b.UID, b)
+	return b, nil
+}
+
+func (r *BudgetRepository) ListBudgetsByUser(ctx context.Context, userID string) ([]*domain.Budget, error) {
+	r.budgets.mu.RLock()
+	defer r.budgets.mu.RUnlock()
+	res := r.budgets.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Period != res[j].Period {
+			return res[i].Period < res[j].Period
+		}
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
+
+func (r *BudgetRepository) GetBudget(ctx context.Context, userID string, budgetID string) (*domain.Budget, error) {
+	r.budgets.mu.RLock()
+	defer r.budgets.mu.RUnlock()
+	b, ok := r.budgets.get(userID, budgetID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "budget not found")
+	}
+	return b, nil
+}
+
+func (r *BudgetRepository) UpdateBudget(ctx context.Context, b *domain.Budget) (*domain.Budget, error) {
+	if b == nil {
+		return nil, fmt.Errorf("invalid budget")
+	}
+	r.budgets.mu.Lock()
+	defer r.budgets.mu.Unlock()
+	if _, ok := r.budgets.data[b.UserID][b.UID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "budget not found")
+	}
+	r.budgets.put(b.UserID, b.UID, b)
+	return b, nil
+}
+
+func (r *BudgetRepository) DeleteBudget(ctx context.Context, userID string, budgetID string) error {
+	r.budgets.mu.Lock()
+	defer r.budgets.mu.Unlock()
+	if _, ok := r.budgets.data[userID][budgetID]; !ok {
+		return status.Errorf(codes.NotFound, "budget not found")
+	}
+	delete(r.budgets.data[userID], budgetID)
+	return nil
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CategoryRepository struct {
+	categories userScoped[domain.Category]
+}
+
+func NewCategoryRepository() *CategoryRepository {
+	return &CategoryRepository{categories: newUserScoped[domain.Category]()}
+}
+
+func (r *CategoryRepository) CreateCategory(ctx context.Context, c *domain.Category) (*domain.Category, error) {
+	if c == nil || strings.TrimSpace(c.UserID) == "" || strings.TrimSpace(c.UID) == "" {
+		return nil, fmt.Errorf("invalid category")
+	}
+	r.categories.mu.Lock()
+	defer r.categories.mu.Unlock()
+	r.categories.put(c.UserID, c.UID, c)
+	return c, nil
+}
+
+func (r *CategoryRepository) ListCategoriesByUser(ctx context.Context, userID string) ([]*domain.Category, error) {
+	r.categories.mu.RLock()
+	defer r.categories.mu.RUnlock()
+	res := r.categories.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res, nil
+}
+
+func (r *CategoryRepository) GetCategory(ctx context.Context, userID string, categoryID string) (*domain.Category, error) {
+	r.categories.mu.RLock()
+	defer r.categories.mu.RUnlock()
+	c, ok := r.categories.get(userID, categoryID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "category not found")
+	}
+	return c, nil
+}
+
+func (r *CategoryRepository) UpdateCategory(ctx context.Context, c *domain.Category) (*domain.Category, error) {
+	if c == nil {
+		return nil, fmt.Errorf("invalid category")
+	}
+	r.categories.mu.Lock()
+	defer r.categories.mu.Unlock()
+	if _, ok := r.categories.data[c.UserID][c.UID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "category not found")
+	}
+	r.categories.put(c.UserID, c.UID, c)
+	return c, nil
+}
+
+func (r *CategoryRepository) DeleteCategory(ctx context.Context, userID string, categoryID string) error {
+	r.categories.mu.Lock()
+	defer r.categories.mu.Unlock()
+	if _, ok := r.categories.data[userID][categoryID]; !ok {
+		return status.Errorf(codes.NotFound, "category not found")
+	}
+	delete(r.categories.data[userID], categoryID)
+	return nil
+}
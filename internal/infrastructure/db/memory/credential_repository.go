@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CredentialRepository struct {
+	mu          sync.RWMutex
+	credentials map[string]*domain.Credential
+}
+
+func NewCredentialRepository() *CredentialRepository {
+	return &CredentialRepository{credentials: make(map[string]*domain.Credential)}
+}
+
+func (r *CredentialRepository) CreateCredential(ctx context.Context, c *domain.Credential) error {
+	if c == nil || strings.TrimSpace(c.UID) == "" || strings.TrimSpace(c.Email) == "" {
+		return fmt.Errorf("invalid credential")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	email := strings.ToLower(c.Email)
+	for _, existing := range r.credentials {
+		if existing.Email == email {
+			return status.Errorf(codes.AlreadyExists, "email already in use")
+		}
+	}
+	if _, ok := r.credentials[c.UID]; ok {
+		return status.Errorf(codes.AlreadyExists, "credential already exists")
+	}
+	cp := *c
+	cp.Email = email
+	r.credentials[c.UID] = &cp
+	return nil
+}
+
+func (r *CredentialRepository) GetCredential(ctx context.Context, uid string) (*domain.Credential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.credentials[uid]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "credential not found")
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (r *CredentialRepository) GetCredentialByEmail(ctx context.Context, email string) (*domain.Credential, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, c := range r.credentials {
+		if c.Email == email {
+			cp := *c
+			return &cp, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "credential not found")
+}
+
+func (r *CredentialRepository) UpdateCredential(ctx context.Context, c *domain.Credential) error {
+	if c == nil || strings.TrimSpace(c.UID) == "" {
+		return fmt.Errorf("invalid credential")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, ok := r.credentials[c.UID]
+	if !ok {
+		return status.Errorf(codes.NotFound, "credential not found")
+	}
+	cp := *c
+	cp.Email = strings.ToLower(c.Email)
+	cp.CreatedAt = existing.CreatedAt
+	r.credentials[c.UID] = &cp
+	return nil
+}
+
+func (r *CredentialRepository) DeleteCredential(ctx context.Context, uid string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.credentials, uid)
+	return nil
+}
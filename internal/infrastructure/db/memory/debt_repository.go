@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type DebtRepository struct {
+	debts userScoped[domain.Debt]
+}
+
+func NewDebtRepository() *DebtRepository {
+	return &DebtRepository{debts: newUserScoped[domain.Debt]()}
+}
+
+func (r *DebtRepository) CreateDebt(ctx context.Context, d *domain.Debt) (*domain.Debt, error) {
+	if d == nil || strings.TrimSpace(d.UserID) == "" || strings.TrimSpace(d.UID) == "" {
+		return nil, fmt.Errorf("invalid debt")
+	}
+	r.debts.mu.Lock()
+	defer r.debts.mu.Unlock()
+	r.debts.put(d.UserID, d.UID, d)
+	return d, nil
+}
+
+func (r *DebtRepository) ListDebtsByUser(ctx context.Context, userID string) ([]*domain.Debt, error) {
+	r.debts.mu.RLock()
+	defer r.debts.mu.RUnlock()
+	res := r.debts.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
+
+func (r *DebtRepository) GetDebt(ctx context.Context, userID string, debtID string) (*domain.Debt, error) {
+	r.debts.mu.RLock()
+	defer r.debts.mu.RUnlock()
+	d, ok := r.debts.get(userID, debtID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "debt not found")
+	}
+	return d, nil
+}
+
+func (r *DebtRepository) UpdateDebt(ctx context.Context, d *domain.Debt) (*domain.Debt, error) {
+	if d == nil {
+		return nil, fmt.Errorf("invalid debt")
+	}
+	r.debts.mu.Lock()
+	defer r.debts.mu.Unlock()
+	if _, ok := r.debts.data[d.UserID][d.UID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "debt not found")
+	}
+	r.debts.put(d.UserID, d.UID, d)
+	return d, nil
+}
+
+func (r *DebtRepository) DeleteDebt(ctx context.Context, userID string, debtID string) error {
+	r.debts.mu.Lock()
+	defer r.debts.mu.Unlock()
+	if _, ok := r.debts.data[userID][debtID]; !ok {
+		return status.Errorf(codes.NotFound, "debt not found")
+	}
+	delete(r.debts.data[userID], debtID)
+	return nil
+}
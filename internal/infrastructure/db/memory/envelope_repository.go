@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type EnvelopeRepository struct {
+	envelopes userScoped[domain.Envelope]
+	moves     userScoped[domain.EnvelopeMove]
+}
+
+func NewEnvelopeRepository() *EnvelopeRepository {
+	return &EnvelopeRepository{
+		envelopes: newUserScoped[domain.Envelope](),
+		moves:     newUserScoped[domain.EnvelopeMove](),
+	}
+}
+
+func (r *EnvelopeRepository) CreateEnvelope(ctx context.Context, e *domain.Envelope) (*domain.Envelope, error) {
+	if e == nil || strings.TrimSpace(e.UserID) == "" || strings.TrimSpace(e.UID) == "" {
+		return nil, fmt.Errorf("invalid envelope")
+	}
+	r.envelopes.mu.Lock()
+	defer r.envelopes.mu.Unlock()
+	r.envelopes.put(e.UserID, e.UID, e)
+	return e, nil
+}
+
+func (r *EnvelopeRepository) ListEnvelopesByUser(ctx context.Context, userID string) ([]*domain.Envelope, error) {
+	r.envelopes.mu.RLock()
+	defer r.envelopes.mu.RUnlock()
+	res := r.envelopes.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
+
+func (r *EnvelopeRepository) GetEnvelope(ctx context.Context, userID string, envelopeID string) (*domain.Envelope, error) {
+	r.envelopes.mu.RLock()
+	defer r.envelopes.mu.RUnlock()
+	e, ok := r.envelopes.get(userID, envelopeID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "envelope not found")
+	}
+	return e, nil
+}
+
+func (r *EnvelopeRepository) UpdateEnvelope(ctx context.Context, e *domain.Envelope) (*domain.Envelope, error) {
+	if e == nil {
+		return nil, fmt.Errorf("invalid envelope")
+	}
+	r.envelopes.mu.Lock()
+	defer r.envelopes.mu.Unlock()
+	if _, ok := r.envelopes.data[e.UserID][e.UID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "envelope not found")
+	}
+	r.envelopes.put(e.UserID, e.UID, e)
+	return e, nil
+}
+
+func (r *EnvelopeRepository) DeleteEnvelope(ctx context.Context, userID string, envelopeID string) error {
+	r.envelopes.mu.Lock()
+	defer r.envelopes.mu.Unlock()
+	if _, ok := r.envelopes.data[userID][envelopeID]; !ok {
+		return status.Errorf(codes.NotFound, "envelope not found")
+	}
+	delete(r.envelopes.data[userID], envelopeID)
+	return nil
+}
+
+func (r *EnvelopeRepository) CreateEnvelopeMove(ctx context.Context, m *domain.EnvelopeMove) (*domain.EnvelopeMove, error) {
+	if m == nil || strings.TrimSpace(m.UserID) == "" || strings.TrimSpace(m.UID) == "" {
+		return nil, fmt.Errorf("invalid envelope move")
+	}
+	r.moves.mu.Lock()
+	defer r.moves.mu.Unlock()
+	r.moves.put(m.UserID, m.UID, m)
+	return m, nil
+}
+
+func (r *EnvelopeRepository) ListEnvelopeMovesByUser(ctx context.Context, userID string) ([]*domain.EnvelopeMove, error) {
+	r.moves.mu.RLock()
+	defer r.moves.mu.RUnlock()
+	res := r.moves.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
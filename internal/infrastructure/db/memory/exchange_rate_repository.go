@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type ExchangeRateRepository struct {
+	mu sync.RWMutex
+	// rates holds each pair's rates sorted by date.
+	rates map[[2]string][]domain.ExchangeRate
+}
+
+func NewExchangeRateRepository() *ExchangeRateRepository {
+	return &ExchangeRateRepository{rates: make(map[[2]string][]domain.ExchangeRate)}
+}
+
+func (r *ExchangeRateRepository) SaveRates(ctx context.Context, rates []domain.ExchangeRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rate := range rates {
+		key := [2]string{rate.Base, rate.Quote}
+		stored := r.rates[key]
+		i := sort.Search(len(stored), func(i int) bool { return !stored[i].Date.Before(rate.Date) })
+		if i < len(stored) && stored[i].Date.Equal(rate.Date) {
+			stored[i] = rate
+			continue
+		}
+		stored = append(stored, domain.ExchangeRate{})
+		copy(stored[i+1:], stored[i:])
+		stored[i] = rate
+		r.rates[key] = stored
+	}
+	return nil
+}
+
+func (r *ExchangeRateRepository) FindRate(ctx context.Context, base, quote string, on time.Time) (*domain.ExchangeRate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stored := r.rates[[2]string{base, quote}]
+	i := sort.Search(len(stored), func(i int) bool { return stored[i].Date.After(on) })
+	if i == 0 {
+		return nil, domain.ErrExchangeRateNotFound
+	}
+	cp := stored[i-1]
+	return &cp, nil
+}
+
+func (r *ExchangeRateRepository) ListRates(ctx context.Context) ([]domain.ExchangeRate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([][2]string, 0, len(r.rates))
+	for key := range r.rates {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	var out []domain.ExchangeRate
+	for _, key := range keys {
+		out = append(out, r.rates[key]...)
+	}
+	return out, nil
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ExpenseRepository struct {
+	expenses userScoped[domain.Expense]
+}
+
+func NewExpenseRepository() *ExpenseRepository {
+	return &ExpenseRepository{expenses: newUserScoped[domain.Expense]()}
+}
+
+func (r *ExpenseRepository) CreateExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error) {
+	if expense == nil || strings.TrimSpace(expense.UserID) == "" || strings.TrimSpace(expense.UID) == "" {
+		return nil, fmt.Errorf("invalid expense")
+	}
+	r.expenses.mu.Lock()
+	defer r.expenses.mu.Unlock()
+	r.expenses.put(expense.UserID, expense.UID, expense)
+	return expense, nil
+}
+
+func (r *ExpenseRepository) ListExpensesByUser(ctx context.Context, userID string) ([]*domain.Expense, error) {
+	r.expenses.mu.RLock()
+	defer r.expenses.mu.RUnlock()
+	res := r.expenses.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool { return res[i].CreatedAt.After(res[j].CreatedAt) })
+	return res, nil
+}
+
+func (r *ExpenseRepository) GetExpense(ctx context.Context, userID string, expenseID string) (*domain.Expense, error) {
+	r.expenses.mu.RLock()
+	defer r.expenses.mu.RUnlock()
+	m, ok := r.expenses.get(userID, expenseID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "expense not found")
+	}
+	return m, nil
+}
+
+func (r *ExpenseRepository) UpdateExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error) {
+	return r.CreateExpense(ctx, expense)
+}
+
+func (r *ExpenseRepository) DeleteExpense(ctx context.Context, userID string, expenseID string) error {
+	r.expenses.mu.Lock()
+	defer r.expenses.mu.Unlock()
+	if _, ok := r.expenses.data[userID][expenseID]; !ok {
+		return status.Errorf(codes.NotFound, "expense not found")
+	}
+	delete(r.expenses.data[userID], expenseID)
+	return nil
+}
+
+func (r *ExpenseRepository) ListRecurringExpenses(ctx context.Context, userID string, before time.Time) ([]*domain.Expense, error) {
+	r.expenses.mu.RLock()
+	defer r.expenses.mu.RUnlock()
+	return r.expenses.list(userID, func(e *domain.Expense) bool {
+		return e.IsRecurring && !e.NextOccurrenceDate.After(before)
+	}), nil
+}
+
+func (r *ExpenseRepository) UpdateExpenseRecurringStatus(ctx context.Context, userID string, expenseID string, nextOccurrenceDate time.Time) error {
+	r.expenses.mu.Lock()
+	defer r.expenses.mu.Unlock()
+	e, ok := r.expenses.data[userID][expenseID]
+	if !ok {
+		return status.Errorf(codes.NotFound, "expense not found")
+	}
+	e.NextOccurrenceDate = nextOccurrenceDate
+	e.UpdatedAt = time.Now().UTC()
+	return nil
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type HoldingRepository struct {
+	holdings   userScoped[domain.Holding]
+	valuations userScoped[domain.Valuation]
+}
+
+func NewHoldingRepository() *HoldingRepository {
+	return &HoldingRepository{holdings: newUserScoped[domain.Holding](), valuations: newUserScoped[domain.Valuation]()}
+}
+
+func (r *HoldingRepository) CreateHolding(ctx context.Context, h *domain.Holding) (*domain.Holding, error) {
+	if h == nil || strings.TrimSpace(h.UserID) == "" || strings.TrimSpace(h.UID) == "" {
+		return nil, fmt.Errorf("invalid holding")
+	}
+	r.holdings.mu.Lock()
+	defer r.holdings.mu.Unlock()
+	r.holdings.put(h.UserID, h.UID, h)
+	return h, nil
+}
+
+func (r *HoldingRepository) ListHoldingsByUser(ctx context.Context, userID string) ([]*domain.Holding, error) {
+	r.holdings.mu.RLock()
+	defer r.holdings.mu.RUnlock()
+	res := r.holdings.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
+
+func (r *HoldingRepository) GetHolding(ctx context.Context, userID string, holdingID string) (*domain.Holding, error) {
+	r.holdings.mu.RLock()
+	defer r.holdings.mu.RUnlock()
+	h, ok := r.holdings.get(userID, holdingID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "holding not found")
+	}
+	return h, nil
+}
+
+func (r *HoldingRepository) UpdateHolding(ctx context.Context, h *domain.Holding) (*domain.Holding, error) {
+	if h == nil {
+		return nil, fmt.Errorf("invalid holding")
+	}
+	r.holdings.mu.Lock()
+	defer r.holdings.mu.Unlock()
+	if _, ok := r.holdings.data[h.UserID][h.UID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "holding not found")
+	}
+	r.holdings.put(h.UserID, h.UID, h)
+	return h, nil
+}
+
+func (r *HoldingRepository) DeleteHolding(ctx context.Context, userID string, holdingID string) error {
+	r.holdings.mu.Lock()
+	defer r.holdings.mu.Unlock()
+	if _, ok := r.holdings.data[userID][holdingID]; !ok {
+		return status.Errorf(codes.NotFound, "holding not found")
+	}
+	delete(r.holdings.data[userID], holdingID)
+
+	r.valuations.mu.Lock()
+	defer r.valuations.mu.Unlock()
+	for id, v := range r.valuations.data[userID] {
+		if v.HoldingID == holdingID {
+			delete(r.valuations.data[userID], id)
+		}
+	}
+	return nil
+}
+
+func (r *HoldingRepository) CreateValuation(ctx context.Context, v *domain.Valuation) (*domain.Valuation, error) {
+	if v == nil || strings.TrimSpace(v.UserID) == "" || strings.TrimSpace(v.UID) == "" {
+		return nil, fmt.Errorf("invalid valuation")
+	}
+	r.valuations.mu.Lock()
+	defer r.valuations.mu.Unlock()
+	r.valuations.put(v.UserID, v.UID, v)
+	return v, nil
+}
+
+func (r *HoldingRepository) ListValuationsByUser(ctx context.Context, userID string) ([]*domain.Valuation, error) {
+	r.valuations.mu.RLock()
+	defer r.valuations.mu.RUnlock()
+	res := r.valuations.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].Date.Equal(res[j].Date) {
+			return res[i].Date.Before(res[j].Date)
+		}
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].UID < res[j].UID
+	})
+	return res, nil
+}
@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type IncomeRepository struct {
+	incomes userScoped[domain.Income]
+	sources userScoped[domain.IncomeSource]
+}
+
+func NewIncomeRepository() *IncomeRepository {
+	return &IncomeRepository{
+		incomes: newUserScoped[domain.Income](),
+		sources: newUserScoped[domain.IncomeSource](),
+	}
+}
+
+func (r *IncomeRepository) CreateIncome(ctx context.Context, income *domain.Income) (*domain.Income, error) {
+	if income == nil || income.UserID == "" || income.UID == "" {
+		return nil, fmt.Errorf("invalid income")
+	}
+	r.incomes.mu.Lock()
+	defer r.incomes.mu.Unlock()
+	r.incomes.put(income.UserID, income.UID, income)
+	return income, nil
+}
+
+func (r *IncomeRepository) ListIncomesByUser(ctx context.Context, userID string) ([]*domain.Income, error) {
+	r.incomes.mu.RLock()
+	defer r.incomes.mu.RUnlock()
+	res := r.incomes.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool { return res[i].CreatedAt.After(res[j].CreatedAt) })
+	return res, nil
+}
+
+func (r *IncomeRepository) GetIncome(ctx context.Context, userID string, incomeID string) (*domain.Income, error) {
+	r.incomes.mu.RLock()
+	defer r.incomes.mu.RUnlock()
+	m, ok := r.incomes.get(userID, incomeID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "income not found")
+	}
+	return m, nil
+}
+
+func (r *IncomeRepository) DeleteIncome(ctx context.Context, userID string, incomeID string) error {
+	r.incomes.mu.Lock()
+	defer r.incomes.mu.Unlock()
+	delete(r.incomes.data[userID], incomeID)
+	return nil
+}
+
+func (r *IncomeRepository) CreateIncomeSource(ctx context.Context, src *domain.IncomeSource) (*domain.IncomeSource, error) {
+	if src == nil || src.UserID == "" || src.UID == "" {
+		return nil, fmt.Errorf("invalid income source")
+	}
+	r.sources.mu.Lock()
+	defer r.sources.mu.Unlock()
+	r.sources.put(src.UserID, src.UID, src)
+	return src, nil
+}
+
+func (r *IncomeRepository) ListIncomeSourcesByUser(ctx context.Context, userID string) ([]*domain.IncomeSource, error) {
+	r.sources.mu.RLock()
+	defer r.sources.mu.RUnlock()
+	res := r.sources.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Source < res[j].Source })
+	return res, nil
+}
+
+func (r *IncomeRepository) ListDueIncomeSources(ctx context.Context, userID string, before time.Time) ([]*domain.IncomeSource, error) {
+	r.sources.mu.RLock()
+	defer r.sources.mu.RUnlock()
+	return r.sources.list(userID, func(s *domain.IncomeSource) bool {
+		return s.Active && !s.NextPayAt.After(before)
+	}), nil
+}
+
+func (r *IncomeRepository) UpdateIncomeSource(ctx context.Context, userID string, id string, updates map[string]interface{}) error {
+	r.sources.mu.Lock()
+	defer r.sources.mu.Unlock()
+	src, ok := r.sources.data[userID][id]
+	if !ok {
+		return status.Errorf(codes.NotFound, "income source not found")
+	}
+	updated := *src
+	for k, v := range updates {
+		if err := setIncomeSourceField(&updated, k, v); err != nil {
+			return err
+		}
+	}
+	r.sources.data[userID][id] = &updated
+	return nil
+}
+
+func (r *IncomeRepository) DeleteIncomeSource(ctx context.Context, userID string, source string) error {
+	r.sources.mu.Lock()
+	defer r.sources.mu.Unlock()
+	for id, s := range r.sources.data[userID] {
+		if s.Source == source {
+			delete(r.sources.data[userID], id)
+		}
+	}
+	return nil
+}
+
+func setIncomeSourceField(src *domain.IncomeSource, field string, v interface{}) error {
+	ok := true
+	switch field {
+	case "Source":
+		src.Source, ok = v.(string)
+	case "Amount":
+		src.Amount, ok = v.(domain.Money)
+	case "Frequency":
+		src.Frequency, ok = v.(string)
+	case "NextPayAt":
+		src.NextPayAt, ok = v.(time.Time)
+	case "Active":
+		src.Active, ok = v.(bool)
+	case "Notes":
+		src.Notes, ok = v.(string)
+	case "UpdatedAt":
+		src.UpdatedAt, ok = v.(time.Time)
+	default:
+		return fmt.Errorf("unknown income source field %q", field)
+	}
+	if !ok {
+		return fmt.Errorf("invalid value for income source field %q", field)
+	}
+	return nil
+}
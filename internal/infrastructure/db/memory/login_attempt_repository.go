@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type LoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]*domain.LoginAttempts
+	unlocks  map[string]*domain.AccountUnlock
+}
+
+func NewLoginAttemptRepository() *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		attempts: make(map[string]*domain.LoginAttempts),
+		unlocks:  make(map[string]*domain.AccountUnlock),
+	}
+}
+
+func (r *LoginAttemptRepository) RecordLoginFailure(ctx context.Context, key string, at, since time.Time) (*domain.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.attempts[key]
+	if !ok {
+		a = &domain.LoginAttempts{Key: key}
+		r.attempts[key] = a
+	}
+	if a.LastFailureAt.Before(since) {
+		a.Failures = 0
+	}
+	a.Failures++
+	a.LastFailureAt = at
+	return copyLoginAttempts(a), nil
+}
+
+func (r *LoginAttemptRepository) GetLoginAttempts(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.attempts[key]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no failed logins for %s", key)
+	}
+	return copyLoginAttempts(a), nil
+}
+
+func (r *LoginAttemptRepository) LockLogin(ctx context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.attempts[key]
+	if !ok {
+		return status.Errorf(codes.NotFound, "no failed logins for %s", key)
+	}
+	a.LockedUntil = &until
+	return nil
+}
+
+func (r *LoginAttemptRepository) ResetLoginAttempts(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, key)
+	return nil
+}
+
+func (r *LoginAttemptRepository) DeleteStaleLoginAttempts(ctx context.Context, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, a := range r.attempts {
+		if a.LastFailureAt.Before(before) && (a.LockedUntil == nil || a.LockedUntil.Before(before)) {
+			delete(r.attempts, key)
+		}
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) CreateAccountUnlock(ctx context.Context, u *domain.AccountUnlock) error {
+	if u == nil || u.TokenHash == "" {
+		return fmt.Errorf("invalid account unlock")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *u
+	r.unlocks[u.TokenHash] = &cp
+	return nil
+}
+
+func (r *LoginAttemptRepository) TakeAccountUnlock(ctx context.Context, tokenHash string) (*domain.AccountUnlock, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.unlocks[tokenHash]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "account unlock not found")
+	}
+	delete(r.unlocks, tokenHash)
+	return u, nil
+}
+
+func (r *LoginAttemptRepository) DeleteExpiredAccountUnlocks(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for hash, u := range r.unlocks {
+		if u.ExpiresAt.Before(now) {
+			delete(r.unlocks, hash)
+		}
+	}
+	return nil
+}
+
+func copyLoginAttempts(a *domain.LoginAttempts) *domain.LoginAttempts {
+	cp := *a
+	if a.LockedUntil != nil {
+		t := *a.LockedUntil
+		cp.LockedUntil = &t
+	}
+	return &cp
+}
@@ -0,0 +1,99 @@
+// Package memory keeps every repository in process memory. It backs
+// DB_DRIVER=memory for demos and is the reference implementation the
+// conformance suite in dbtest is written against.
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+type Database struct {
+	UserRepository             *UserRepository
+	IncomeRepository           *IncomeRepository
+	ExpenseRepository          *ExpenseRepository
+	RefreshTokenRepository     *RefreshTokenRepository
+	SecurityEventRepository    *SecurityEventRepository
+	TwoFactorRepository        *TwoFactorRepository
+	PasskeyRepository          *PasskeyRepository
+	LoginAttemptRepository     *LoginAttemptRepository
+	CredentialRepository       *CredentialRepository
+	ExchangeRateRepository     *ExchangeRateRepository
+	CategoryRepository         *CategoryRepository
+	AccountRepository          *AccountRepository
+	TransferRepository         *TransferRepository
+	BudgetRepository           *BudgetRepository
+	EnvelopeRepository         *EnvelopeRepository
+	SavingsGoalRepository      *SavingsGoalRepository
+	DebtRepository             *DebtRepository
+	HoldingRepository          *HoldingRepository
+	NetWorthSnapshotRepository *NetWorthSnapshotRepository
+}
+
+func NewDatabase() *Database {
+	return &Database{
+		UserRepository:             NewUserRepository(),
+		IncomeRepository:           NewIncomeRepository(),
+		ExpenseRepository:          NewExpenseRepository(),
+		RefreshTokenRepository:     NewRefreshTokenRepository(),
+		SecurityEventRepository:    NewSecurityEventRepository(),
+		TwoFactorRepository:        NewTwoFactorRepository(),
+		PasskeyRepository:          NewPasskeyRepository(),
+		LoginAttemptRepository:     NewLoginAttemptRepository(),
+		CredentialRepository:       NewCredentialRepository(),
+		ExchangeRateRepository:     NewExchangeRateRepository(),
+		CategoryRepository:         NewCategoryRepository(),
+		AccountRepository:          NewAccountRepository(),
+		TransferRepository:         NewTransferRepository(),
+		BudgetRepository:           NewBudgetRepository(),
+		EnvelopeRepository:         NewEnvelopeRepository(),
+		SavingsGoalRepository:      NewSavingsGoalRepository(),
+		DebtRepository:             NewDebtRepository(),
+		HoldingRepository:          NewHoldingRepository(),
+		NetWorthSnapshotRepository: NewNetWorthSnapshotRepository(),
+	}
+}
+
+func (d *Database) Ping(ctx context.Context) error { return nil }
+
+func (d *Database) Close() error { return nil }
+
+// userScoped stores records per user, mirroring the users/{uid}/... layout
+// of the Firestore adapter.
+type userScoped[T any] struct {
+	mu   sync.RWMutex
+	data map[string]map[string]*T
+}
+
+func newUserScoped[T any]() userScoped[T] {
+	return userScoped[T]{data: make(map[string]map[string]*T)}
+}
+
+func (s *userScoped[T]) put(userID, id string, v *T) {
+	if s.data[userID] == nil {
+		s.data[userID] = make(map[string]*T)
+	}
+	cp := *v
+	s.data[userID][id] = &cp
+}
+
+func (s *userScoped[T]) get(userID, id string) (*T, bool) {
+	v, ok := s.data[userID][id]
+	if !ok {
+		return nil, false
+	}
+	cp := *v
+	return &cp, true
+}
+
+func (s *userScoped[T]) list(userID string, keep func(*T) bool) []*T {
+	var res []*T
+	for _, v := range s.data[userID] {
+		if keep != nil && !keep(v) {
+			continue
+		}
+		cp := *v
+		res = append(res, &cp)
+	}
+	return res
+}
@@ -0,0 +1,35 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/dbtest"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/memory"
+)
+
+func TestConformance(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T) dbtest.Backend {
+		db := memory.NewDatabase()
+		return dbtest.Backend{
+			Users:          db.UserRepository,
+			Incomes:        db.IncomeRepository,
+			Expenses:       db.ExpenseRepository,
+			RefreshTokens:  db.RefreshTokenRepository,
+			SecurityEvents: db.SecurityEventRepository,
+			TwoFactor:      db.TwoFactorRepository,
+			Passkeys:       db.PasskeyRepository,
+			LoginAttempts:  db.LoginAttemptRepository,
+			Credentials:    db.CredentialRepository,
+			Categories:     db.CategoryRepository,
+			Accounts:       db.AccountRepository,
+			Transfers:      db.TransferRepository,
+			Budgets:        db.BudgetRepository,
+			Envelopes:      db.EnvelopeRepository,
+			SavingsGoals:   db.SavingsGoalRepository,
+			Debts:          db.DebtRepository,
+			Holdings:       db.HoldingRepository,
+			NetWorth:       db.NetWorthSnapshotRepository,
+			ExchangeRates:  db.ExchangeRateRepository,
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type NetWorthSnapshotRepository struct {
+	mu sync.RWMutex
+	// snapshots holds each user's snapshots keyed by day.
+	snapshots map[string]map[time.Time]domain.NetWorthSnapshot
+}
+
+func NewNetWorthSnapshotRepository() *NetWorthSnapshotRepository {
+	return &NetWorthSnapshotRepository{snapshots: make(map[string]map[time.Time]domain.NetWorthSnapshot)}
+}
+
+func (r *NetWorthSnapshotRepository) SaveNetWorthSnapshot(ctx context.Context, s *domain.NetWorthSnapshot) error {
+	if s == nil || strings.TrimSpace(s.UserID) == "" || s.Date.IsZero() {
+		return fmt.Errorf("invalid net worth snapshot")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.snapshots[s.UserID] == nil {
+		r.snapshots[s.UserID] = make(map[time.Time]domain.NetWorthSnapshot)
+	}
+	cp := *s
+	cp.Date = s.Date.UTC()
+	cp.Accounts = append([]domain.AccountBalance(nil), s.Accounts...)
+	r.snapshots[s.UserID][cp.Date] = cp
+	return nil
+}
+
+func (r *NetWorthSnapshotRepository) ListNetWorthSnapshots(ctx context.Context, userID string, from, to time.Time) ([]*domain.NetWorthSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := []*domain.NetWorthSnapshot{}
+	for date, s := range r.snapshots[userID] {
+		if (!from.IsZero() && date.Before(from)) || (!to.IsZero() && date.After(to)) {
+			continue
+		}
+		cp := s
+		cp.Accounts = append([]domain.AccountBalance(nil), s.Accounts...)
+		res = append(res, &cp)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Date.Before(res[j].Date) })
+	return res, nil
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type PasskeyRepository struct {
+	mu         sync.Mutex
+	passkeys   map[string]*domain.Passkey
+	ceremonies map[string]*domain.PasskeyCeremony
+}
+
+func NewPasskeyRepository() *PasskeyRepository {
+	return &PasskeyRepository{
+		passkeys:   make(map[string]*domain.Passkey),
+		ceremonies: make(map[string]*domain.PasskeyCeremony),
+	}
+}
+
+func (r *PasskeyRepository) CreatePasskey(ctx context.Context, p *domain.Passkey) error {
+	if p == nil || p.ID == "" || p.UserID == "" {
+		return fmt.Errorf("invalid passkey")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.passkeys[p.ID]; ok {
+		return status.Errorf(codes.AlreadyExists, "passkey already registered")
+	}
+	r.passkeys[p.ID] = copyPasskey(p)
+	return nil
+}
+
+func (r *PasskeyRepository) ListPasskeys(ctx context.Context, userID string) ([]*domain.Passkey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*domain.Passkey
+	for _, p := range r.passkeys {
+		if p.UserID == userID {
+			out = append(out, copyPasskey(p))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (r *PasskeyRepository) UpdatePasskeyUsage(ctx context.Context, id string, signCount uint32, backupState bool, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.passkeys[id]
+	if !ok {
+		return status.Errorf(codes.NotFound, "passkey not found")
+	}
+	p.SignCount = signCount
+	p.BackupState = backupState
+	p.LastUsedAt = &usedAt
+	return nil
+}
+
+func (r *PasskeyRepository) DeletePasskey(ctx context.Context, userID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p, ok := r.passkeys[id]; !ok || p.UserID != userID {
+		return status.Errorf(codes.NotFound, "passkey not found")
+	}
+	delete(r.passkeys, id)
+	return nil
+}
+
+func (r *PasskeyRepository) CreatePasskeyCeremony(ctx context.Context, c *domain.PasskeyCeremony) error {
+	if c == nil || c.TokenHash == "" {
+		return fmt.Errorf("invalid passkey ceremony")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *c
+	cp.Session = append([]byte(nil), c.Session...)
+	r.ceremonies[c.TokenHash] = &cp
+	return nil
+}
+
+func (r *PasskeyRepository) TakePasskeyCeremony(ctx context.Context, tokenHash string) (*domain.PasskeyCeremony, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.ceremonies[tokenHash]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "passkey ceremony not found")
+	}
+	delete(r.ceremonies, tokenHash)
+	return c, nil
+}
+
+func (r *PasskeyRepository) DeleteExpiredPasskeyCeremonies(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for hash, c := range r.ceremonies {
+		if c.ExpiresAt.Before(now) {
+			delete(r.ceremonies, hash)
+		}
+	}
+	return nil
+}
+
+func copyPasskey(p *domain.Passkey) *domain.Passkey {
+	cp := *p
+	cp.PublicKey = append([]byte(nil), p.PublicKey...)
+	cp.AAGUID = append([]byte(nil), p.AAGUID...)
+	cp.Transports = append([]string(nil), p.Transports...)
+	if p.LastUsedAt != nil {
+		t := *p.LastUsedAt
+		cp.LastUsedAt = &t
+	}
+	return &cp
+}
@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RefreshTokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]*domain.RefreshToken
+}
+
+func NewRefreshTokenRepository() *RefreshTokenRepository {
+	return &RefreshTokenRepository{tokens: make(map[string]*domain.RefreshToken)}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+
+	if token.ID == "" {
+		token.ID = uuid.NewString()
+	}
+	if token.FamilyID == "" {
+		token.FamilyID = token.ID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *token
+	r.tokens[token.ID] = &cp
+	return nil
+}
+
+func (r *RefreshTokenRepository) GetByID(ctx context.Context, id string) (*domain.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tokens[id]
+	if !ok {
+		return nil, fmt.Errorf("refresh token not found")
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (r *RefreshTokenRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var tokens []*domain.RefreshToken
+	for _, t := range r.tokens {
+		if t.UserID == userID {
+			cp := *t
+			tokens = append(tokens, &cp)
+		}
+	}
+	sort.SliceStable(tokens, func(i, j int) bool { return tokens[i].CreatedAt.After(tokens[j].CreatedAt) })
+	return tokens, nil
+}
+
+func (r *RefreshTokenRepository) GetValidToken(ctx context.Context, userID, tokenHash string) (*domain.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.UserID == userID && t.TokenHash == tokenHash && !t.IsRevoked && t.ExpiresAt.After(now) {
+			cp := *t
+			return &cp, nil
+		}
+	}
+	return nil, fmt.Errorf("valid refresh token not found")
+}
+
+func (r *RefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, t := range r.tokens {
+		if t.TokenHash == tokenHash {
+			cp := *t
+			return &cp, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "refresh token not found")
+}
+
+func (r *RefreshTokenRepository) RotateToken(ctx context.Context, id, replacedBy string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.tokens[id]
+	if !ok {
+		return status.Errorf(codes.NotFound, "refresh token not found")
+	}
+	if t.IsRevoked {
+		return status.Errorf(codes.FailedPrecondition, "refresh token already revoked")
+	}
+	now := time.Now()
+	t.IsRevoked = true
+	t.RevokedAt = &now
+	t.ReplacedBy = replacedBy
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeToken(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.tokens[id]
+	if !ok {
+		return fmt.Errorf("refresh token not found")
+	}
+	now := time.Now()
+	t.IsRevoked = true
+	t.RevokedAt = &now
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.FamilyID == familyID && !t.IsRevoked {
+			t.IsRevoked = true
+			revokedAt := now
+			t.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeAllUserTokens(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.UserID == userID && !t.IsRevoked {
+			t.IsRevoked = true
+			revokedAt := now
+			t.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) DeleteExpiredTokens(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for id, t := range r.tokens {
+		if t.ExpiresAt.Before(now) {
+			delete(r.tokens, id)
+		}
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) DeleteToken(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tokens, id)
+	return nil
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SavingsGoalRepository struct {
+	goals userScoped[domain.SavingsGoal]
+}
+
+func NewSavingsGoalRepository() *SavingsGoalRepository {
+	return &SavingsGoalRepository{goals: newUserScoped[domain.SavingsGoal]()}
+}
+
+func (r *SavingsGoalRepository) CreateSavingsGoal(ctx context.Context, g *domain.SavingsGoal) (*domain.SavingsGoal, error) {
+	if g == nil || strings.TrimSpace(g.UserID) == "" || strings.TrimSpace(g.UID) == "" {
+		return nil, fmt.Errorf("invalid savings goal")
+	}
+	r.goals.mu.Lock()
+	defer r.goals.mu.Unlock()
+	r.goals.put(g.UserID, g.UID, g)
+	return g, nil
+}
+
+func (r *SavingsGoalRepository) ListSavingsGoalsByUser(ctx context.Context, userID string) ([]*domain.SavingsGoal, error) {
+	r.goals.mu.RLock()
+	defer r.goals.mu.RUnlock()
+	res := r.goals.list(userID, nil)
+	sortSavingsGoals(res)
+	return res, nil
+}
+
+func (r *SavingsGoalRepository) GetSavingsGoal(ctx context.Context, userID string, goalID string) (*domain.SavingsGoal, error) {
+	r.goals.mu.RLock()
+	defer r.goals.mu.RUnlock()
+	g, ok := r.goals.get(userID, goalID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "savings goal not found")
+	}
+	return g, nil
+}
+
+func (r *SavingsGoalRepository) UpdateSavingsGoal(ctx context.Context, g *domain.SavingsGoal) (*domain.SavingsGoal, error) {
+	if g == nil {
+		return nil, fmt.Errorf("invalid savings goal")
+	}
+	r.goals.mu.Lock()
+	defer r.goals.mu.Unlock()
+	if _, ok := r.goals.data[g.UserID][g.UID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "savings goal not found")
+	}
+	r.goals.put(g.UserID, g.UID, g)
+	return g, nil
+}
+
+func (r *SavingsGoalRepository) DeleteSavingsGoal(ctx context.Context, userID string, goalID string) error {
+	r.goals.mu.Lock()
+	defer r.goals.mu.Unlock()
+	if _, ok := r.goals.data[userID][goalID]; !ok {
+		return status.Errorf(codes.NotFound, "savings goal not found")
+	}
+	delete(r.goals.data[userID], goalID)
+	return nil
+}
+
+func sortSavingsGoals(goals []*domain.SavingsGoal) {
+	sort.SliceStable(goals, func(i, j int) bool {
+		if !goals[i].TargetDate.Equal(goals[j].TargetDate) {
+			return goals[i].TargetDate.Before(goals[j].TargetDate)
+		}
+		if !goals[i].CreatedAt.Equal(goals[j].CreatedAt) {
+			return goals[i].CreatedAt.Before(goals[j].CreatedAt)
+		}
+		return goals[i].UID < goals[j].UID
+	})
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type SecurityEventRepository struct {
+	events userScoped[domain.SecurityEvent]
+}
+
+func NewSecurityEventRepository() *SecurityEventRepository {
+	return &SecurityEventRepository{events: newUserScoped[domain.SecurityEvent]()}
+}
+
+func (r *SecurityEventRepository) CreateSecurityEvent(ctx context.Context, e *domain.SecurityEvent) error {
+	if e == nil || e.ID == "" || e.UserID == "" {
+		return fmt.Errorf("invalid security event")
+	}
+	r.events.mu.Lock()
+	defer r.events.mu.Unlock()
+	if _, ok := r.events.get(e.UserID, e.ID); !ok {
+		r.events.put(e.UserID, e.ID, e)
+	}
+	return nil
+}
+
+func (r *SecurityEventRepository) ListSecurityEvents(ctx context.Context, userID string) ([]*domain.SecurityEvent, error) {
+	r.events.mu.RLock()
+	defer r.events.mu.RUnlock()
+	res := r.events.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.After(res[j].CreatedAt)
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TransferRepository struct {
+	transfers userScoped[domain.Transfer]
+}
+
+func NewTransferRepository() *TransferRepository {
+	return &TransferRepository{transfers: newUserScoped[domain.Transfer]()}
+}
+
+func (r *TransferRepository) CreateTransfer(ctx context.Context, t *domain.Transfer) (*domain.Transfer, error) {
+	if t == nil || strings.TrimSpace(t.UserID) == "" || strings.TrimSpace(t.UID) == "" {
+		return nil, fmt.Errorf("invalid transfer")
+	}
+	r.transfers.mu.Lock()
+	defer r.transfers.mu.Unlock()
+	r.transfers.put(t.UserID, t.UID, t)
+	return t, nil
+}
+
+func (r *TransferRepository) ListTransfersByUser(ctx context.Context, userID string) ([]*domain.Transfer, error) {
+	r.transfers.mu.RLock()
+	defer r.transfers.mu.RUnlock()
+	res := r.transfers.list(userID, nil)
+	sort.SliceStable(res, func(i, j int) bool { return res[i].CreatedAt.After(res[j].CreatedAt) })
+	return res, nil
+}
+
+func (r *TransferRepository) GetTransfer(ctx context.Context, userID string, transferID string) (*domain.Transfer, error) {
+	r.transfers.mu.RLock()
+	defer r.transfers.mu.RUnlock()
+	t, ok := r.transfers.get(userID, transferID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "transfer not found")
+	}
+	return t, nil
+}
+
+func (r *TransferRepository) DeleteTransfer(ctx context.Context, userID string, transferID string) error {
+	r.transfers.mu.Lock()
+	defer r.transfers.mu.Unlock()
+	if _, ok := r.transfers.data[userID][transferID]; !ok {
+		return status.Errorf(codes.NotFound, "transfer not found")
+	}
+	delete(r.transfers.data[userID], transferID)
+	return nil
+}
@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TwoFactorRepository struct {
+	mu         sync.Mutex
+	settings   map[string]*domain.TwoFactor
+	challenges map[string]*domain.TwoFactorChallenge
+}
+
+func NewTwoFactorRepository() *TwoFactorRepository {
+	return &TwoFactorRepository{
+		settings:   make(map[string]*domain.TwoFactor),
+		challenges: make(map[string]*domain.TwoFactorChallenge),
+	}
+}
+
+func (r *TwoFactorRepository) SaveTwoFactor(ctx context.Context, tf *domain.TwoFactor) error {
+	if tf == nil || tf.UserID == "" || tf.Secret == "" {
+		return fmt.Errorf("invalid two-factor settings")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *tf
+	cp.RecoveryCodes = append([]string(nil), tf.RecoveryCodes...)
+	r.settings[tf.UserID] = &cp
+	return nil
+}
+
+func (r *TwoFactorRepository) GetTwoFactor(ctx context.Context, userID string) (*domain.TwoFactor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tf, ok := r.settings[userID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "two-factor settings not found")
+	}
+	cp := *tf
+	cp.RecoveryCodes = append([]string(nil), tf.RecoveryCodes...)
+	return &cp, nil
+}
+
+func (r *TwoFactorRepository) DeleteTwoFactor(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.settings[userID]; !ok {
+		return status.Errorf(codes.NotFound, "two-factor settings not found")
+	}
+	delete(r.settings, userID)
+	return nil
+}
+
+func (r *TwoFactorRepository) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tf, ok := r.settings[userID]
+	if !ok || tf.LastUsedStep >= step {
+		return status.Errorf(codes.FailedPrecondition, "code already used")
+	}
+	tf.LastUsedStep = step
+	return nil
+}
+
+func (r *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tf, ok := r.settings[userID]; ok {
+		for i, hash := range tf.RecoveryCodes {
+			if hash == codeHash {
+				tf.RecoveryCodes = append(tf.RecoveryCodes[:i:i], tf.RecoveryCodes[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return status.Errorf(codes.NotFound, "recovery code not found")
+}
+
+func (r *TwoFactorRepository) CreateTwoFactorChallenge(ctx context.Context, c *domain.TwoFactorChallenge) error {
+	if c == nil || c.TokenHash == "" || c.UserID == "" {
+		return fmt.Errorf("invalid two-factor challenge")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *c
+	r.challenges[c.TokenHash] = &cp
+	return nil
+}
+
+func (r *TwoFactorRepository) GetTwoFactorChallenge(ctx context.Context, tokenHash string) (*domain.TwoFactorChallenge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.challenges[tokenHash]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "two-factor challenge not found")
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (r *TwoFactorRepository) RecordChallengeAttempt(ctx context.Context, tokenHash string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.challenges[tokenHash]
+	if !ok {
+		return 0, status.Errorf(codes.NotFound, "two-factor challenge not found")
+	}
+	c.Attempts++
+	return c.Attempts, nil
+}
+
+func (r *TwoFactorRepository) DeleteTwoFactorChallenge(ctx context.Context, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.challenges[tokenHash]; !ok {
+		return status.Errorf(codes.NotFound, "two-factor challenge not found")
+	}
+	delete(r.challenges, tokenHash)
+	return nil
+}
+
+func (r *TwoFactorRepository) DeleteExpiredTwoFactorChallenges(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for hash, c := range r.challenges {
+		if c.ExpiresAt.Before(now) {
+			delete(r.challenges, hash)
+		}
+	}
+	return nil
+}
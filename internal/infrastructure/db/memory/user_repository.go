@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserRepository struct {
+	mu    sync.RWMutex
+	users map[string]*domain.User
+}
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{users: make(map[string]*domain.User)}
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, u *domain.User) (*domain.User, error) {
+	if u == nil || strings.TrimSpace(u.UID) == "" {
+		return nil, fmt.Errorf("invalid user profile")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *u
+	r.users[u.UID] = &cp
+	return u, nil
+}
+
+func (r *UserRepository) GetUser(ctx context.Context, uid string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	u, ok := r.users[uid]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	cp := *u
+	return &cp, nil
+}
+
+func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, u := range r.users {
+		if u.Email == email {
+			cp := *u
+			return &cp, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "user not found")
+}
+
+func (r *UserRepository) UpdateUser(ctx context.Context, u *domain.User) (*domain.User, error) {
+	if u == nil || strings.TrimSpace(u.UID) == "" {
+		return nil, fmt.Errorf("invalid user profile for update")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, ok := r.users[u.UID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	cp := *u
+	cp.CreatedAt = existing.CreatedAt
+	r.users[u.UID] = &cp
+	return u, nil
+}
+
+func (r *UserRepository) DeleteUser(ctx context.Context, uid string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.users, uid)
+	return nil
+}
+
+func (r *UserRepository) ListAllUserIDs(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.users))
+	for id := range r.users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
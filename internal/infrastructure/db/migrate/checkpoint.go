@@ -0,0 +1,80 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/theHinneh/budgeting/internal/application/ports"
+)
+
+// FileCheckpoint records data migration progress in a JSON file. The file is
+// rewritten atomically after every user so a crash never leaves it torn.
+type FileCheckpoint struct {
+	path string
+
+	mu        sync.Mutex
+	completed map[string]bool
+}
+
+type checkpointFile struct {
+	CompletedUsers []string `json:"completed_users"`
+}
+
+func NewFileCheckpoint(path string) (*FileCheckpoint, error) {
+	c := &FileCheckpoint{path: path, completed: make(map[string]bool)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f checkpointFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	for _, id := range f.CompletedUsers {
+		c.completed[id] = true
+	}
+	return c, nil
+}
+
+var _ ports.MigrationCheckpoint = (*FileCheckpoint)(nil)
+
+func (c *FileCheckpoint) CompletedUsers(ctx context.Context) (map[string]bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	res := make(map[string]bool, len(c.completed))
+	for id := range c.completed {
+		res[id] = true
+	}
+	return res, nil
+}
+
+func (c *FileCheckpoint) MarkUserCompleted(ctx context.Context, userID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.completed[userID] = true
+
+	f := checkpointFile{CompletedUsers: make([]string, 0, len(c.completed))}
+	for id := range c.completed {
+		f.CompletedUsers = append(f.CompletedUsers, id)
+	}
+	sort.Strings(f.CompletedUsers)
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
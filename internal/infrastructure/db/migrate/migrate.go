@@ -0,0 +1,111 @@
+// Package migrate applies versioned schema and data migrations. Each storage
+// driver supplies a Driver that knows how to run its own migrations and where
+// to record which versions have been applied.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type Migration struct {
+	Version int
+	Name    string
+}
+
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Driver interface {
+	// Migrations returns every migration shipped with the binary.
+	Migrations() []Migration
+	// Applied returns the applied versions and when they were applied.
+	Applied(ctx context.Context) (map[int]time.Time, error)
+	// Apply runs the migration and records it as applied.
+	Apply(ctx context.Context, m Migration) error
+	// Revert undoes the migration and removes its record.
+	Revert(ctx context.Context, m Migration) error
+}
+
+type Migrator struct {
+	driver Driver
+}
+
+func New(driver Driver) *Migrator {
+	return &Migrator{driver: driver}
+}
+
+func (m *Migrator) migrations() []Migration {
+	ms := append([]Migration(nil), m.driver.Migrations()...)
+	sort.Slice(ms, func(i, j int) bool { return ms[i].Version < ms[j].Version })
+	return ms
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.driver.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var res []Status
+	for _, mig := range m.migrations() {
+		at, ok := applied[mig.Version]
+		res = append(res, Status{Migration: mig, Applied: ok, AppliedAt: at})
+	}
+	return res, nil
+}
+
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, s := range statuses {
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies every pending migration in version order and returns the ones
+// it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, mig := range pending {
+		if err := m.driver.Apply(ctx, mig); err != nil {
+			return done, fmt.Errorf("migration %04d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// Down reverts the most recently applied migration. It returns nil when
+// nothing is applied.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(statuses) - 1; i >= 0; i-- {
+		if !statuses[i].Applied {
+			continue
+		}
+		mig := statuses[i].Migration
+		if err := m.driver.Revert(ctx, mig); err != nil {
+			return nil, fmt.Errorf("revert %04d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+		return &mig, nil
+	}
+	return nil, nil
+}
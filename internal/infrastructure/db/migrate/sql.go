@@ -0,0 +1,155 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var sqlFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type sqlMigration struct {
+	Migration
+	up   string
+	down string
+}
+
+// SQLDriver runs migrations stored as NNNN_name.up.sql / NNNN_name.down.sql
+// files and tracks them in the schema_migrations table. Each migration runs
+// in its own transaction.
+type SQLDriver struct {
+	db         *sql.DB
+	migrations map[int]*sqlMigration
+}
+
+func NewSQLDriver(db *sql.DB, fsys fs.FS, dir string) (*SQLDriver, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make(map[int]*sqlMigration)
+	for _, e := range entries {
+		match := sqlFilePattern.FindStringSubmatch(e.Name())
+		if e.IsDir() || match == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(match[1])
+		body, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := migrations[version]
+		if !ok {
+			m = &sqlMigration{Migration: Migration{Version: version, Name: match[2]}}
+			migrations[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.up = string(body)
+		} else {
+			m.down = string(body)
+		}
+	}
+
+	for v, m := range migrations {
+		if m.up == "" {
+			return nil, fmt.Errorf("migration %d has no up file", v)
+		}
+	}
+
+	return &SQLDriver{db: db, migrations: migrations}, nil
+}
+
+func (d *SQLDriver) Migrations() []Migration {
+	res := make([]Migration, 0, len(d.migrations))
+	for _, m := range d.migrations {
+		res = append(res, m.Migration)
+	}
+	return res
+}
+
+func (d *SQLDriver) ensureTable(ctx context.Context) error {
+	_, err := d.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT      NOT NULL,
+			applied_at TIMESTAMP NOT NULL
+		)`)
+	return err
+}
+
+func (d *SQLDriver) Applied(ctx context.Context) (map[int]time.Time, error) {
+	if err := d.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := d.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var (
+			version int
+			at      time.Time
+		)
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+func (d *SQLDriver) Apply(ctx context.Context, m Migration) error {
+	mig, ok := d.migrations[m.Version]
+	if !ok {
+		return fmt.Errorf("unknown migration %d", m.Version)
+	}
+	return d.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, mig.up); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)`,
+			mig.Version, mig.Name, time.Now().UTC())
+		return err
+	})
+}
+
+func (d *SQLDriver) Revert(ctx context.Context, m Migration) error {
+	mig, ok := d.migrations[m.Version]
+	if !ok {
+		return fmt.Errorf("unknown migration %d", m.Version)
+	}
+	if mig.down == "" {
+		return fmt.Errorf("migration %d is irreversible", m.Version)
+	}
+	return d.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, mig.down); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+		return err
+	})
+}
+
+func (d *SQLDriver) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,26 @@
+ALTER TABLE incomes ADD COLUMN amount DOUBLE PRECISION NOT NULL DEFAULT 0;
+UPDATE incomes SET amount = CAST(amount_minor AS DOUBLE PRECISION) / CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'UYI', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 1
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+        WHEN currency IN ('CLF', 'UYW') THEN 10000
+        ELSE 100
+    END;
+ALTER TABLE incomes DROP COLUMN amount_minor;
+
+ALTER TABLE income_sources ADD COLUMN amount DOUBLE PRECISION NOT NULL DEFAULT 0;
+UPDATE income_sources SET amount = CAST(amount_minor AS DOUBLE PRECISION) / CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'UYI', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 1
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+        WHEN currency IN ('CLF', 'UYW') THEN 10000
+        ELSE 100
+    END;
+ALTER TABLE income_sources DROP COLUMN amount_minor;
+
+ALTER TABLE expenses ADD COLUMN amount DOUBLE PRECISION NOT NULL DEFAULT 0;
+UPDATE expenses SET amount = CAST(amount_minor AS DOUBLE PRECISION) / CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'UYI', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 1
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+        WHEN currency IN ('CLF', 'UYW') THEN 10000
+        ELSE 100
+    END;
+ALTER TABLE expenses DROP COLUMN amount_minor;
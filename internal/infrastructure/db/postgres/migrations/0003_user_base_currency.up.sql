@@ -0,0 +1,3 @@
+-- The currency a user's totals and reports are converted into.
+
+ALTER TABLE users ADD COLUMN base_currency TEXT NOT NULL DEFAULT 'USD';
@@ -0,0 +1,3 @@
+ALTER TABLE incomes DROP COLUMN category_id;
+ALTER TABLE expenses DROP COLUMN category_id;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS categories (
+    uid        TEXT PRIMARY KEY,
+    user_id    TEXT        NOT NULL,
+    parent_id  TEXT        NOT NULL DEFAULT '',
+    name       TEXT        NOT NULL,
+    kind       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS categories_user_idx ON categories (user_id);
+
+ALTER TABLE expenses ADD COLUMN category_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE incomes ADD COLUMN category_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE incomes DROP COLUMN tags;
+ALTER TABLE expenses DROP COLUMN tags;
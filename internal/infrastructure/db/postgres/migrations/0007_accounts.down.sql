@@ -0,0 +1,3 @@
+ALTER TABLE incomes DROP COLUMN account_id;
+ALTER TABLE expenses DROP COLUMN account_id;
+DROP TABLE IF EXISTS accounts;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS accounts (
+    uid                   TEXT PRIMARY KEY,
+    user_id               TEXT        NOT NULL,
+    name                  TEXT        NOT NULL,
+    type                  TEXT        NOT NULL,
+    currency              TEXT        NOT NULL,
+    opening_balance_minor BIGINT      NOT NULL DEFAULT 0,
+    created_at            TIMESTAMPTZ NOT NULL,
+    updated_at            TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS accounts_user_idx ON accounts (user_id);
+
+ALTER TABLE expenses ADD COLUMN account_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE incomes ADD COLUMN account_id TEXT NOT NULL DEFAULT '';
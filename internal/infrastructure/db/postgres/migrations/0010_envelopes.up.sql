@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS envelopes (
+    uid              TEXT PRIMARY KEY,
+    user_id          TEXT        NOT NULL,
+    name             TEXT        NOT NULL,
+    category_id      TEXT        NOT NULL,
+    allocation_minor BIGINT      NOT NULL,
+    currency         TEXT        NOT NULL,
+    rollover         BOOLEAN     NOT NULL DEFAULT TRUE,
+    start_period     TEXT        NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS envelopes_user_idx ON envelopes (user_id);
+
+CREATE TABLE IF NOT EXISTS envelope_moves (
+    uid              TEXT PRIMARY KEY,
+    user_id          TEXT        NOT NULL,
+    from_envelope_id TEXT        NOT NULL,
+    to_envelope_id   TEXT        NOT NULL,
+    period           TEXT        NOT NULL,
+    amount_minor     BIGINT      NOT NULL,
+    currency         TEXT        NOT NULL,
+    notes            TEXT        NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS envelope_moves_user_idx ON envelope_moves (user_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS savings_goals (
+    uid          TEXT PRIMARY KEY,
+    user_id      TEXT        NOT NULL,
+    name         TEXT        NOT NULL,
+    account_id   TEXT        NOT NULL,
+    target_minor BIGINT      NOT NULL,
+    currency     TEXT        NOT NULL,
+    target_date  TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS savings_goals_user_idx ON savings_goals (user_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS debts (
+    uid             TEXT PRIMARY KEY,
+    user_id         TEXT             NOT NULL,
+    name            TEXT             NOT NULL,
+    kind            TEXT             NOT NULL,
+    principal_minor BIGINT           NOT NULL,
+    currency        TEXT             NOT NULL,
+    apr             DOUBLE PRECISION NOT NULL,
+    term            INTEGER          NOT NULL DEFAULT 0,
+    frequency       TEXT             NOT NULL,
+    payment_minor   BIGINT           NOT NULL DEFAULT 0,
+    start_date      TIMESTAMPTZ      NOT NULL,
+    created_at      TIMESTAMPTZ      NOT NULL,
+    updated_at      TIMESTAMPTZ      NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS debts_user_idx ON debts (user_id);
+
+ALTER TABLE expenses ADD COLUMN debt_id TEXT NOT NULL DEFAULT '';
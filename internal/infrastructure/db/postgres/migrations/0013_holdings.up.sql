@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS holdings (
+    uid        TEXT PRIMARY KEY,
+    user_id    TEXT        NOT NULL,
+    name       TEXT        NOT NULL,
+    kind       TEXT        NOT NULL,
+    category   TEXT        NOT NULL,
+    currency   TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS holdings_user_idx ON holdings (user_id);
+
+CREATE TABLE IF NOT EXISTS holding_valuations (
+    uid         TEXT PRIMARY KEY,
+    user_id     TEXT        NOT NULL,
+    holding_id  TEXT        NOT NULL,
+    value_minor BIGINT      NOT NULL,
+    currency    TEXT        NOT NULL,
+    valued_at   TIMESTAMPTZ NOT NULL,
+    notes       TEXT        NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS holding_valuations_user_idx ON holding_valuations (user_id, valued_at);
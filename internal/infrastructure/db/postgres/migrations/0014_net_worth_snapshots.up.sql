@@ -0,0 +1,23 @@
+-- One snapshot per user and day, with each account's balance that day in
+-- the order the snapshot listed them.
+
+CREATE TABLE IF NOT EXISTS net_worth_snapshots (
+    user_id           TEXT        NOT NULL,
+    snapshot_date     TIMESTAMPTZ NOT NULL,
+    assets_minor      BIGINT      NOT NULL,
+    liabilities_minor BIGINT      NOT NULL,
+    currency          TEXT        NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, snapshot_date)
+);
+
+CREATE TABLE IF NOT EXISTS account_balance_snapshots (
+    user_id       TEXT        NOT NULL,
+    snapshot_date TIMESTAMPTZ NOT NULL,
+    position      INTEGER     NOT NULL,
+    account_id    TEXT        NOT NULL,
+    name          TEXT        NOT NULL,
+    balance_minor BIGINT      NOT NULL,
+    currency      TEXT        NOT NULL,
+    PRIMARY KEY (user_id, snapshot_date, account_id)
+);
@@ -0,0 +1 @@
+ALTER TABLE expenses ADD COLUMN splits TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS security_events;
+DROP INDEX IF EXISTS refresh_tokens_family_idx;
+ALTER TABLE refresh_tokens DROP COLUMN replaced_by;
+ALTER TABLE refresh_tokens DROP COLUMN family_id;
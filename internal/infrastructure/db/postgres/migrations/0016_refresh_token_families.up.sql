@@ -0,0 +1,23 @@
+-- Refresh tokens are now stored as SHA-256 hashes and rotated within a
+-- family. Tokens issued before this migration hold the raw secret, so they
+-- are revoked and their users have to log in again.
+
+ALTER TABLE refresh_tokens ADD COLUMN family_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE refresh_tokens ADD COLUMN replaced_by TEXT NOT NULL DEFAULT '';
+
+UPDATE refresh_tokens SET family_id = id;
+UPDATE refresh_tokens SET is_revoked = TRUE, revoked_at = CURRENT_TIMESTAMP WHERE NOT is_revoked;
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_idx ON refresh_tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS security_events (
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT        NOT NULL,
+    type       TEXT        NOT NULL,
+    details    TEXT        NOT NULL DEFAULT '',
+    ip_address TEXT        NOT NULL DEFAULT '',
+    user_agent TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS security_events_user_idx ON security_events (user_id, created_at DESC);
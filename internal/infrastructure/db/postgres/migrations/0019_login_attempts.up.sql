@@ -0,0 +1,21 @@
+-- Failed login counters per account and per client IP, shared by every
+-- instance, and the tokens mailed to unlock a locked account.
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    key             TEXT PRIMARY KEY,
+    failures        INTEGER     NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    locked_until    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS login_attempts_last_failure_idx ON login_attempts (last_failure_at);
+
+CREATE TABLE IF NOT EXISTS account_unlocks (
+    token_hash TEXT PRIMARY KEY,
+    email      TEXT        NOT NULL,
+    user_id    TEXT        NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS account_unlocks_expires_idx ON account_unlocks (expires_at);
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/migrate"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/sqlstore"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+type Database struct {
+	*sqlstore.Store
+}
+
+func NewDatabase(ctx context.Context, dbConfig config.DatabaseConfig) (*Database, error) {
+	db, err := sql.Open("pgx", buildDSN(dbConfig))
+	if err != nil {
+		return nil, fmt.Errorf("postgres open failed: %w", err)
+	}
+
+	if dbConfig.MaxOpenConnections > 0 {
+		db.SetMaxOpenConns(dbConfig.MaxOpenConnections)
+	}
+	if dbConfig.MaxIdleConnections > 0 {
+		db.SetMaxIdleConns(dbConfig.MaxIdleConnections)
+	}
+	if dbConfig.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(dbConfig.ConnMaxLifetime)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("postgres connection failed: %w", err)
+	}
+
+	d := &Database{Store: sqlstore.New(db)}
+
+	if err := d.ensureSchema(ctx, dbConfig.PgSchema); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// ensureSchema creates the configured schema so that the search_path
+// resolves before any migration runs.
+func (d *Database) ensureSchema(ctx context.Context, pgSchema string) error {
+	if pgSchema = strings.TrimSpace(pgSchema); pgSchema == "" {
+		return nil
+	}
+	if _, err := d.DB.ExecContext(ctx, "CREATE SCHEMA IF NOT EXISTS "+quoteIdent(pgSchema)); err != nil {
+		return fmt.Errorf("postgres schema creation failed: %w", err)
+	}
+	return nil
+}
+
+func (d *Database) Migrator() (*migrate.Migrator, error) {
+	driver, err := migrate.NewSQLDriver(d.DB, migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(driver), nil
+}
+
+func buildDSN(c config.DatabaseConfig) string {
+	host := c.Host
+	if host == "" {
+		host = "localhost"
+	}
+	port := c.Port
+	if port == "" {
+		port = "5432"
+	}
+
+	u := url.URL{
+		Scheme: "postgres",
+		Host:   host + ":" + port,
+		Path:   "/" + c.Name,
+	}
+	if c.User != "" {
+		if c.Password != "" {
+			u.User = url.UserPassword(c.User, c.Password)
+		} else {
+			u.User = url.User(c.User)
+		}
+	}
+
+	q := url.Values{}
+	if c.SSLMode != "" {
+		q.Set("sslmode", c.SSLMode)
+	}
+	if c.PgSSLCert != "" {
+		q.Set("sslcert", c.PgSSLCert)
+	}
+	if c.PgSSLKey != "" {
+		q.Set("sslkey", c.PgSSLKey)
+	}
+	if c.PgSSLRootCert != "" {
+		q.Set("sslrootcert", c.PgSSLRootCert)
+	}
+	if c.ConnTimeout > 0 {
+		q.Set("connect_timeout", strconv.Itoa(int(c.ConnTimeout.Seconds())))
+	}
+
+	searchPath := c.PgSearchPath
+	if searchPath == "" && c.PgSchema != "" {
+		searchPath = c.PgSchema
+	}
+	if searchPath != "" {
+		q.Set("search_path", searchPath)
+	}
+
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
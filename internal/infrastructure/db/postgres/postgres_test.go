@@ -0,0 +1,66 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/dbtest"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/postgres"
+)
+
+// TestConformance runs against the database described by the TEST_PG_*
+// variables and is skipped when TEST_PG_HOST is unset.
+func TestConformance(t *testing.T) {
+	host := os.Getenv("TEST_PG_HOST")
+	if host == "" {
+		t.Skip("TEST_PG_HOST not set")
+	}
+	cfg := config.DatabaseConfig{
+		Host:     host,
+		Port:     os.Getenv("TEST_PG_PORT"),
+		Name:     os.Getenv("TEST_PG_NAME"),
+		User:     os.Getenv("TEST_PG_USER"),
+		Password: os.Getenv("TEST_PG_PASSWORD"),
+		SSLMode:  "disable",
+	}
+
+	db, err := postgres.NewDatabase(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator, err := db.Migrator()
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	dbtest.Run(t, func(t *testing.T) dbtest.Backend {
+		return dbtest.Backend{
+			Users:          db.UserRepository,
+			Incomes:        db.IncomeRepository,
+			Expenses:       db.ExpenseRepository,
+			RefreshTokens:  db.RefreshTokenRepository,
+			SecurityEvents: db.SecurityEventRepository,
+			TwoFactor:      db.TwoFactorRepository,
+			Passkeys:       db.PasskeyRepository,
+			LoginAttempts:  db.LoginAttemptRepository,
+			Credentials:    db.CredentialRepository,
+			Categories:     db.CategoryRepository,
+			Accounts:       db.AccountRepository,
+			Transfers:      db.TransferRepository,
+			Budgets:        db.BudgetRepository,
+			Envelopes:      db.EnvelopeRepository,
+			SavingsGoals:   db.SavingsGoalRepository,
+			Debts:          db.DebtRepository,
+			Holdings:       db.HoldingRepository,
+			NetWorth:       db.NetWorthSnapshotRepository,
+			ExchangeRates:  db.ExchangeRateRepository,
+		}
+	})
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS credentials;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS expenses;
+DROP TABLE IF EXISTS income_sources;
+DROP TABLE IF EXISTS incomes;
+DROP TABLE IF EXISTS users;
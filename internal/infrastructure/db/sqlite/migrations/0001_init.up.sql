@@ -0,0 +1,88 @@
+CREATE TABLE IF NOT EXISTS users (
+    uid            TEXT PRIMARY KEY,
+    username       TEXT        NOT NULL DEFAULT '',
+    email          TEXT        NOT NULL DEFAULT '',
+    first_name     TEXT        NOT NULL DEFAULT '',
+    last_name      TEXT        NOT NULL DEFAULT '',
+    phone_number   TEXT,
+    provider_id    TEXT        NOT NULL DEFAULT '',
+    photo_url      TEXT        NOT NULL DEFAULT '',
+    email_verified BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMP   NOT NULL,
+    updated_at     TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS users_email_idx ON users (email);
+
+CREATE TABLE IF NOT EXISTS incomes (
+    uid        TEXT PRIMARY KEY,
+    user_id    TEXT             NOT NULL,
+    source     TEXT             NOT NULL,
+    amount     REAL             NOT NULL,
+    currency   TEXT             NOT NULL DEFAULT '',
+    notes      TEXT             NOT NULL DEFAULT '',
+    created_at TIMESTAMP        NOT NULL,
+    updated_at TIMESTAMP        NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS incomes_user_created_idx ON incomes (user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS income_sources (
+    uid         TEXT PRIMARY KEY,
+    user_id     TEXT             NOT NULL,
+    source      TEXT             NOT NULL,
+    amount      REAL             NOT NULL,
+    currency    TEXT             NOT NULL DEFAULT '',
+    frequency   TEXT             NOT NULL,
+    next_pay_at TIMESTAMP        NOT NULL,
+    active      BOOLEAN          NOT NULL DEFAULT TRUE,
+    notes       TEXT             NOT NULL DEFAULT '',
+    created_at  TIMESTAMP        NOT NULL,
+    updated_at  TIMESTAMP        NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS income_sources_user_due_idx ON income_sources (user_id, active, next_pay_at);
+
+CREATE TABLE IF NOT EXISTS expenses (
+    uid                  TEXT PRIMARY KEY,
+    user_id              TEXT             NOT NULL,
+    source               TEXT             NOT NULL,
+    amount               REAL             NOT NULL,
+    currency             TEXT             NOT NULL DEFAULT '',
+    notes                TEXT             NOT NULL DEFAULT '',
+    is_recurring         BOOLEAN          NOT NULL DEFAULT FALSE,
+    recurrence_frequency TEXT             NOT NULL DEFAULT '',
+    next_occurrence_date TIMESTAMP        NOT NULL,
+    created_at           TIMESTAMP        NOT NULL,
+    updated_at           TIMESTAMP        NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS expenses_user_created_idx ON expenses (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS expenses_user_recurring_idx ON expenses (user_id, is_recurring, next_occurrence_date);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id          TEXT PRIMARY KEY,
+    user_id     TEXT        NOT NULL,
+    token_hash  TEXT        NOT NULL,
+    is_revoked  BOOLEAN     NOT NULL DEFAULT FALSE,
+    expires_at  TIMESTAMP   NOT NULL,
+    created_at  TIMESTAMP   NOT NULL,
+    revoked_at  TIMESTAMP  ,
+    device_info TEXT        NOT NULL DEFAULT '',
+    ip_address  TEXT        NOT NULL DEFAULT '',
+    user_agent  TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_idx ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS refresh_tokens_hash_idx ON refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS refresh_tokens_expires_idx ON refresh_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS credentials (
+    uid           TEXT PRIMARY KEY,
+    email         TEXT        NOT NULL UNIQUE,
+    display_name  TEXT        NOT NULL DEFAULT '',
+    phone_number  TEXT,
+    password_hash TEXT        NOT NULL,
+    created_at    TIMESTAMP   NOT NULL,
+    updated_at    TIMESTAMP   NOT NULL
+);
@@ -0,0 +1,32 @@
+-- Amounts move from floating point major units to integer minor units.
+-- The scale follows domain.CurrencyExponent.
+
+ALTER TABLE incomes ADD COLUMN amount_minor BIGINT NOT NULL DEFAULT 0;
+UPDATE incomes SET currency = UPPER(COALESCE(NULLIF(currency, ''), 'USD'));
+UPDATE incomes SET amount_minor = CAST(ROUND(amount * CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'UYI', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 1
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+        WHEN currency IN ('CLF', 'UYW') THEN 10000
+        ELSE 100
+    END) AS BIGINT);
+ALTER TABLE incomes DROP COLUMN amount;
+
+ALTER TABLE income_sources ADD COLUMN amount_minor BIGINT NOT NULL DEFAULT 0;
+UPDATE income_sources SET currency = UPPER(COALESCE(NULLIF(currency, ''), 'USD'));
+UPDATE income_sources SET amount_minor = CAST(ROUND(amount * CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'UYI', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 1
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+        WHEN currency IN ('CLF', 'UYW') THEN 10000
+        ELSE 100
+    END) AS BIGINT);
+ALTER TABLE income_sources DROP COLUMN amount;
+
+ALTER TABLE expenses ADD COLUMN amount_minor BIGINT NOT NULL DEFAULT 0;
+UPDATE expenses SET currency = UPPER(COALESCE(NULLIF(currency, ''), 'USD'));
+UPDATE expenses SET amount_minor = CAST(ROUND(amount * CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'UYI', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 1
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+        WHEN currency IN ('CLF', 'UYW') THEN 10000
+        ELSE 100
+    END) AS BIGINT);
+ALTER TABLE expenses DROP COLUMN amount;
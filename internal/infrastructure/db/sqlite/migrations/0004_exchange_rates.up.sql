@@ -0,0 +1,9 @@
+-- One rate per currency pair and day: units of quote per unit of base.
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    base      TEXT      NOT NULL,
+    quote     TEXT      NOT NULL,
+    rate_date TIMESTAMP NOT NULL,
+    rate      REAL      NOT NULL,
+    PRIMARY KEY (base, quote, rate_date)
+);
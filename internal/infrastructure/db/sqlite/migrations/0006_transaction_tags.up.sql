@@ -0,0 +1,2 @@
+ALTER TABLE expenses ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE incomes ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS transfers (
+    uid             TEXT PRIMARY KEY,
+    user_id         TEXT             NOT NULL,
+    from_account_id TEXT             NOT NULL,
+    to_account_id   TEXT             NOT NULL,
+    amount_minor    BIGINT           NOT NULL,
+    currency        TEXT             NOT NULL,
+    to_amount_minor BIGINT           NOT NULL,
+    to_currency     TEXT             NOT NULL,
+    rate            REAL             NOT NULL,
+    notes           TEXT             NOT NULL DEFAULT '',
+    created_at      TIMESTAMP        NOT NULL,
+    updated_at      TIMESTAMP        NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS transfers_user_idx ON transfers (user_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS budgets (
+    uid           TEXT PRIMARY KEY,
+    user_id       TEXT        NOT NULL,
+    category_id   TEXT        NOT NULL,
+    period        TEXT        NOT NULL,
+    planned_minor BIGINT      NOT NULL,
+    currency      TEXT        NOT NULL,
+    created_at    TIMESTAMP   NOT NULL,
+    updated_at    TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS budgets_user_period_idx ON budgets (user_id, period);
@@ -0,0 +1,2 @@
+ALTER TABLE expenses DROP COLUMN debt_id;
+DROP TABLE IF EXISTS debts;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS debts (
+    uid             TEXT PRIMARY KEY,
+    user_id         TEXT             NOT NULL,
+    name            TEXT             NOT NULL,
+    kind            TEXT             NOT NULL,
+    principal_minor BIGINT           NOT NULL,
+    currency        TEXT             NOT NULL,
+    apr             REAL             NOT NULL,
+    term            INTEGER          NOT NULL DEFAULT 0,
+    frequency       TEXT             NOT NULL,
+    payment_minor   BIGINT           NOT NULL DEFAULT 0,
+    start_date      TIMESTAMP        NOT NULL,
+    created_at      TIMESTAMP        NOT NULL,
+    updated_at      TIMESTAMP        NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS debts_user_idx ON debts (user_id);
+
+ALTER TABLE expenses ADD COLUMN debt_id TEXT NOT NULL DEFAULT '';
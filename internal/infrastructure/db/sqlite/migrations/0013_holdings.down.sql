@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS holding_valuations;
+DROP TABLE IF EXISTS holdings;
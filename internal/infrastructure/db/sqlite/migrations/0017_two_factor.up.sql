@@ -0,0 +1,28 @@
+-- TOTP enrollments with their hashed single-use recovery codes, and the
+-- short-lived challenges a password login hands out when 2FA is enabled.
+
+CREATE TABLE IF NOT EXISTS two_factor (
+    user_id        TEXT PRIMARY KEY,
+    secret         TEXT        NOT NULL,
+    enabled        BOOLEAN     NOT NULL DEFAULT FALSE,
+    last_used_step BIGINT      NOT NULL DEFAULT 0,
+    created_at     TIMESTAMP   NOT NULL,
+    enabled_at     TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS two_factor_recovery_codes (
+    user_id   TEXT NOT NULL,
+    code_hash TEXT NOT NULL,
+    PRIMARY KEY (user_id, code_hash)
+);
+
+CREATE TABLE IF NOT EXISTS two_factor_challenges (
+    token_hash TEXT PRIMARY KEY,
+    user_id    TEXT        NOT NULL,
+    email      TEXT        NOT NULL,
+    attempts   INTEGER     NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP   NOT NULL,
+    created_at TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS two_factor_challenges_expires_idx ON two_factor_challenges (expires_at);
@@ -0,0 +1,30 @@
+-- WebAuthn credentials registered as passkeys, kept next to the refresh
+-- token sessions they start, and the state of ceremonies between their begin
+-- and finish requests.
+
+CREATE TABLE IF NOT EXISTS passkeys (
+    id               TEXT PRIMARY KEY,
+    user_id          TEXT        NOT NULL,
+    name             TEXT        NOT NULL,
+    public_key       BLOB        NOT NULL,
+    attestation_type TEXT        NOT NULL DEFAULT '',
+    aaguid           BLOB,
+    transports       TEXT        NOT NULL DEFAULT '[]',
+    sign_count       BIGINT      NOT NULL DEFAULT 0,
+    backup_eligible  BOOLEAN     NOT NULL DEFAULT FALSE,
+    backup_state     BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at       TIMESTAMP   NOT NULL,
+    last_used_at     TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS passkeys_user_idx ON passkeys (user_id);
+
+CREATE TABLE IF NOT EXISTS passkey_ceremonies (
+    token_hash TEXT PRIMARY KEY,
+    user_id    TEXT        NOT NULL DEFAULT '',
+    session    BLOB        NOT NULL,
+    expires_at TIMESTAMP   NOT NULL,
+    created_at TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS passkey_ceremonies_expires_idx ON passkey_ceremonies (expires_at);
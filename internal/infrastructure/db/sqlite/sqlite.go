@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/migrate"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/sqlstore"
+	_ "modernc.org/sqlite"
+)
+
+const defaultPath = "budgeting.db"
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+type Database struct {
+	*sqlstore.Store
+}
+
+// NewDatabase opens (creating if needed) the SQLite file named by
+// DatabaseConfig.Name. The special name ":memory:" keeps everything in RAM.
+func NewDatabase(ctx context.Context, dbConfig config.DatabaseConfig) (*Database, error) {
+	db, err := sql.Open("sqlite", buildDSN(dbConfig))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite open failed: %w", err)
+	}
+
+	if dbConfig.MaxOpenConnections > 0 {
+		db.SetMaxOpenConns(dbConfig.MaxOpenConnections)
+	}
+	if dbConfig.MaxIdleConnections > 0 {
+		db.SetMaxIdleConns(dbConfig.MaxIdleConnections)
+	}
+	if dbConfig.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(dbConfig.ConnMaxLifetime)
+	}
+	if strings.TrimSpace(dbConfig.Name) == ":memory:" {
+		// Every connection to ":memory:" is a separate database.
+		db.SetMaxOpenConns(1)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("sqlite connection failed: %w", err)
+	}
+
+	return &Database{Store: sqlstore.New(db)}, nil
+}
+
+func (d *Database) Migrator() (*migrate.Migrator, error) {
+	driver, err := migrate.NewSQLDriver(d.DB, migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(driver), nil
+}
+
+func buildDSN(c config.DatabaseConfig) string {
+	path := strings.TrimSpace(c.Name)
+	if path == "" {
+		path = defaultPath
+	}
+
+	q := url.Values{}
+	q.Add("_pragma", "foreign_keys(1)")
+	q.Add("_pragma", "busy_timeout(5000)")
+	if path != ":memory:" {
+		q.Add("_pragma", "journal_mode(WAL)")
+	}
+	q.Set("_time_format", "sqlite")
+
+	return "file:" + path + "?" + q.Encode()
+}
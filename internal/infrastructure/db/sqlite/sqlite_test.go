@@ -0,0 +1,123 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/dbtest"
+	"github.com/theHinneh/budgeting/internal/infrastructure/db/sqlite"
+)
+
+func TestConformance(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T) dbtest.Backend {
+		db, err := sqlite.NewDatabase(context.Background(), config.DatabaseConfig{
+			Name: filepath.Join(t.TempDir(), "budgeting.db"),
+		})
+		if err != nil {
+			t.Fatalf("open sqlite: %v", err)
+		}
+		t.Cleanup(func() { _ = db.Close() })
+		migrator, err := db.Migrator()
+		if err != nil {
+			t.Fatalf("load migrations: %v", err)
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			t.Fatalf("migrate: %v", err)
+		}
+		return dbtest.Backend{
+			Users:          db.UserRepository,
+			Incomes:        db.IncomeRepository,
+			Expenses:       db.ExpenseRepository,
+			RefreshTokens:  db.RefreshTokenRepository,
+			SecurityEvents: db.SecurityEventRepository,
+			TwoFactor:      db.TwoFactorRepository,
+			Passkeys:       db.PasskeyRepository,
+			LoginAttempts:  db.LoginAttemptRepository,
+			Credentials:    db.CredentialRepository,
+			Categories:     db.CategoryRepository,
+			Accounts:       db.AccountRepository,
+			Transfers:      db.TransferRepository,
+			Budgets:        db.BudgetRepository,
+			Envelopes:      db.EnvelopeRepository,
+			SavingsGoals:   db.SavingsGoalRepository,
+			Debts:          db.DebtRepository,
+			Holdings:       db.HoldingRepository,
+			NetWorth:       db.NetWorthSnapshotRepository,
+			ExchangeRates:  db.ExchangeRateRepository,
+		}
+	})
+}
+
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.NewDatabase(ctx, config.DatabaseConfig{
+		Name: filepath.Join(t.TempDir(), "budgeting.db"),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator, err := db.Migrator()
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil || len(pending) == 0 {
+		t.Fatalf("expected pending migrations on a new database, got %v (err %v)", pending, err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if len(applied) != len(pending) {
+		t.Fatalf("applied %d of %d migrations", len(applied), len(pending))
+	}
+	if pending, _ := migrator.Pending(ctx); len(pending) != 0 {
+		t.Fatalf("still pending after up: %v", pending)
+	}
+
+	// Rows written before amounts became integer minor units must convert.
+	for range applied[1:] {
+		if _, err := migrator.Down(ctx); err != nil {
+			t.Fatalf("down: %v", err)
+		}
+	}
+	_, err = db.DB.ExecContext(ctx, `INSERT INTO incomes (uid, user_id, source, amount, currency, created_at, updated_at)
+		VALUES ('i1', 'u1', 'salary', 19.99, 'usd', '2024-01-01 00:00:00', '2024-01-01 00:00:00'),
+		       ('i2', 'u1', 'gift', 1500, 'JPY', '2024-01-02 00:00:00', '2024-01-02 00:00:00')`)
+	if err != nil {
+		t.Fatalf("insert legacy income: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	incomes, err := db.IncomeRepository.ListIncomesByUser(ctx, "u1")
+	if err != nil {
+		t.Fatalf("list incomes: %v", err)
+	}
+	if len(incomes) != 2 || incomes[0].Amount != domain.NewMoney(1500, "JPY") || incomes[1].Amount != domain.NewMoney(1999, "USD") {
+		t.Fatalf("converted incomes = %+v", incomes)
+	}
+
+	for range applied {
+		if _, err := migrator.Down(ctx); err != nil {
+			t.Fatalf("down: %v", err)
+		}
+	}
+	if _, err := db.DB.ExecContext(ctx, "SELECT 1 FROM users"); err == nil {
+		t.Fatal("users table still exists after reverting every migration")
+	}
+	if reverted, err := migrator.Down(ctx); err != nil || reverted != nil {
+		t.Fatalf("down on empty schema: %v, %v", reverted, err)
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up after down: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AccountRepository struct {
+	DB *sql.DB
+}
+
+const accountColumns = `uid, user_id, name, type, currency, opening_balance_minor, created_at, updated_at`
+
+func (r *AccountRepository) CreateAccount(ctx context.Context, a *domain.Account) (*domain.Account, error) {
+	if a == nil || strings.TrimSpace(a.UserID) == "" || strings.TrimSpace(a.UID) == "" {
+		return nil, fmt.Errorf("invalid account")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO accounts (`+accountColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (uid) DO UPDATE SET
+			name = EXCLUDED.name,
+			type = EXCLUDED.type,
+			currency = EXCLUDED.currency,
+			opening_balance_minor = EXCLUDED.opening_balance_minor,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		a.UID, a.UserID, a.Name, string(a.Type), a.Currency, a.OpeningBalance.MinorUnits, a.CreatedAt.UTC(), a.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *AccountRepository) ListAccountsByUser(ctx context.Context, userID string) ([]*domain.Account, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+accountColumns+` FROM accounts WHERE user_id = $1 ORDER BY created_at ASC, uid ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.Account
+	for rows.Next() {
+		a, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+	return res, rows.Err()
+}
+
+func (r *AccountRepository) GetAccount(ctx context.Context, userID string, accountID string) (*domain.Account, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+accountColumns+` FROM accounts WHERE user_id = $1 AND uid = $2`, userID, accountID)
+	a, err := scanAccount(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "account not found")
+	}
+	return a, err
+}
+
+func (r *AccountRepository) UpdateAccount(ctx context.Context, a *domain.Account) (*domain.Account, error) {
+	if a == nil || strings.TrimSpace(a.UserID) == "" || strings.TrimSpace(a.UID) == "" {
+		return nil, fmt.Errorf("invalid account")
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE accounts SET name = $3, type = $4, opening_balance_minor = $5, updated_at = $6
+		WHERE user_id = $1 AND uid = $2`,
+		a.UserID, a.UID, a.Name, string(a.Type), a.OpeningBalance.MinorUnits, a.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, status.Errorf(codes.NotFound, "account not found")
+	}
+	return a, nil
+}
+
+func (r *AccountRepository) DeleteAccount(ctx context.Context, userID string, accountID string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM accounts WHERE user_id = $1 AND uid = $2`, userID, accountID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "account not found")
+	}
+	return nil
+}
+
+func scanAccount(row scanner) (*domain.Account, error) {
+	var (
+		a           domain.Account
+		accountType string
+	)
+	if err := row.Scan(&a.UID, &a.UserID, &a.Name, &accountType, &a.Currency, &a.OpeningBalance.MinorUnits, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	a.Type = domain.AccountType(accountType)
+	a.OpeningBalance.Currency = a.Currency
+	return &a, nil
+}
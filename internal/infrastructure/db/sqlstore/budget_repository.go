@@ -0,0 +1,105 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type BudgetRepository struct {
+	DB *sql.DB
+}
+
+const budgetColumns = `uid, user_id, category_id, period, planned_minor, currency, created_at, updated_at`
+
+func (r *BudgetRepository) CreateBudget(ctx context.Context, b *domain.Budget) (*domain.Budget, error) {
+	if b == nil || strings.TrimSpace(b.UserID) == "" || strings.TrimSpace(b.UID) == "" {
+		return nil, fmt.Errorf("invalid budget")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO budgets (`+budgetColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (uid) DO UPDATE SET
+			category_id = EXCLUDED.category_id,
+			period = EXCLUDED.period,
+			planned_minor = EXCLUDED.planned_minor,
+			currency = EXCLUDED.currency,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		b.UID, b.UserID, b.CategoryID, b.Period, b.Planned.MinorUnits, b.Planned.Currency, b.CreatedAt.UTC(), b.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *BudgetRepository) ListBudgetsByUser(ctx context.Context, userID string) ([]*domain.Budget, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+budgetColumns+` FROM budgets WHERE user_id = $1 ORDER BY period ASC, created_at ASC, uid ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.Budget
+	for rows.Next() {
+		b, err := scanBudget(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, b)
+	}
+	return res, rows.Err()
+}
+
+func (r *BudgetRepository) GetBudget(ctx context.Context, userID string, budgetID string) (*domain.Budget, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+budgetColumns+` FROM budgets WHERE user_id = $1 AND uid = $2`, userID, budgetID)
+	b, err := scanBudget(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "budget not found")
+	}
+	return b, err
+}
+
+func (r *BudgetRepository) UpdateBudget(ctx context.Context, b *domain.Budget) (*domain.Budget, error) {
+	if b == nil || strings.TrimSpace(b.UserID) == "" || strings.TrimSpace(b.UID) == "" {
+		return nil, fmt.Errorf("invalid budget")
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE budgets SET category_id = $3, period = $4, planned_minor = $5, currency = $6, updated_at = $7
+		WHERE user_id = $1 AND uid = $2`,
+		b.UserID, b.UID, b.CategoryID, b.Period, b.Planned.MinorUnits, b.Planned.Currency, b.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, status.Errorf(codes.NotFound, "budget not found")
+	}
+	return b, nil
+}
+
+func (r *BudgetRepository) DeleteBudget(ctx context.Context, userID string, budgetID string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM budgets WHERE user_id = $1 AND uid = $2`, userID, budgetID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "budget not found")
+	}
+	return nil
+}
+
+func scanBudget(row scanner) (*domain.Budget, error) {
+	var b domain.Budget
+	if err := row.Scan(&b.UID, &b.UserID, &b.CategoryID, &b.Period, &b.Planned.MinorUnits, &b.Planned.Currency, &b.CreatedAt, &b.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
@@ -0,0 +1,108 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CategoryRepository struct {
+	DB *sql.DB
+}
+
+const categoryColumns = `uid, user_id, parent_id, name, kind, created_at, updated_at`
+
+func (r *CategoryRepository) CreateCategory(ctx context.Context, c *domain.Category) (*domain.Category, error) {
+	if c == nil || strings.TrimSpace(c.UserID) == "" || strings.TrimSpace(c.UID) == "" {
+		return nil, fmt.Errorf("invalid category")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO categories (`+categoryColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (uid) DO UPDATE SET
+			parent_id = EXCLUDED.parent_id,
+			name = EXCLUDED.name,
+			kind = EXCLUDED.kind,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		c.UID, c.UserID, c.ParentID, c.Name, string(c.Kind), c.CreatedAt.UTC(), c.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *CategoryRepository) ListCategoriesByUser(ctx context.Context, userID string) ([]*domain.Category, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+categoryColumns+` FROM categories WHERE user_id = $1 ORDER BY name ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.Category
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, rows.Err()
+}
+
+func (r *CategoryRepository) GetCategory(ctx context.Context, userID string, categoryID string) (*domain.Category, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+categoryColumns+` FROM categories WHERE user_id = $1 AND uid = $2`, userID, categoryID)
+	c, err := scanCategory(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "category not found")
+	}
+	return c, err
+}
+
+func (r *CategoryRepository) UpdateCategory(ctx context.Context, c *domain.Category) (*domain.Category, error) {
+	if c == nil || strings.TrimSpace(c.UserID) == "" || strings.TrimSpace(c.UID) == "" {
+		return nil, fmt.Errorf("invalid category")
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE categories SET parent_id = $3, name = $4, kind = $5, updated_at = $6
+		WHERE user_id = $1 AND uid = $2`,
+		c.UserID, c.UID, c.ParentID, c.Name, string(c.Kind), c.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, status.Errorf(codes.NotFound, "category not found")
+	}
+	return c, nil
+}
+
+func (r *CategoryRepository) DeleteCategory(ctx context.Context, userID string, categoryID string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM categories WHERE user_id = $1 AND uid = $2`, userID, categoryID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "category not found")
+	}
+	return nil
+}
+
+func scanCategory(row scanner) (*domain.Category, error) {
+	var (
+		c    domain.Category
+		kind string
+	)
+	if err := row.Scan(&c.UID, &c.UserID, &c.ParentID, &c.Name, &kind, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	c.Kind = domain.CategoryKind(kind)
+	return &c, nil
+}
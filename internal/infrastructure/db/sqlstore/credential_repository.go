@@ -0,0 +1,87 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CredentialRepository struct {
+	DB *sql.DB
+}
+
+const credentialColumns = `uid, email, display_name, phone_number, password_hash, created_at, updated_at`
+
+func (r *CredentialRepository) CreateCredential(ctx context.Context, c *domain.Credential) error {
+	if c == nil || strings.TrimSpace(c.UID) == "" || strings.TrimSpace(c.Email) == "" {
+		return fmt.Errorf("invalid credential")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO credentials (`+credentialColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		c.UID, strings.ToLower(c.Email), c.DisplayName, nullString(c.PhoneNumber), c.PasswordHash,
+		c.CreatedAt.UTC(), c.UpdatedAt.UTC(),
+	)
+	return err
+}
+
+func (r *CredentialRepository) GetCredential(ctx context.Context, uid string) (*domain.Credential, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+credentialColumns+` FROM credentials WHERE uid = $1`, uid)
+	c, err := scanCredential(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "credential not found")
+	}
+	return c, err
+}
+
+func (r *CredentialRepository) GetCredentialByEmail(ctx context.Context, email string) (*domain.Credential, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+credentialColumns+` FROM credentials WHERE email = $1`, strings.ToLower(strings.TrimSpace(email)))
+	c, err := scanCredential(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "credential not found")
+	}
+	return c, err
+}
+
+func (r *CredentialRepository) UpdateCredential(ctx context.Context, c *domain.Credential) error {
+	if c == nil || strings.TrimSpace(c.UID) == "" {
+		return fmt.Errorf("invalid credential")
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE credentials SET email = $2, display_name = $3, phone_number = $4, password_hash = $5, updated_at = $6
+		WHERE uid = $1`,
+		c.UID, strings.ToLower(c.Email), c.DisplayName, nullString(c.PhoneNumber), c.PasswordHash, c.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "credential not found")
+	}
+	return nil
+}
+
+func (r *CredentialRepository) DeleteCredential(ctx context.Context, uid string) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM credentials WHERE uid = $1`, uid)
+	return err
+}
+
+func scanCredential(row scanner) (*domain.Credential, error) {
+	var (
+		c     domain.Credential
+		phone sql.NullString
+	)
+	if err := row.Scan(&c.UID, &c.Email, &c.DisplayName, &phone, &c.PasswordHash, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if phone.Valid {
+		c.PhoneNumber = &phone.String
+	}
+	return &c, nil
+}
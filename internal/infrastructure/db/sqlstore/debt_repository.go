@@ -0,0 +1,117 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type DebtRepository struct {
+	DB *sql.DB
+}
+
+const debtColumns = `uid, user_id, name, kind, principal_minor, currency, apr, term, frequency, payment_minor, start_date, created_at, updated_at`
+
+func (r *DebtRepository) CreateDebt(ctx context.Context, d *domain.Debt) (*domain.Debt, error) {
+	if d == nil || strings.TrimSpace(d.UserID) == "" || strings.TrimSpace(d.UID) == "" {
+		return nil, fmt.Errorf("invalid debt")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO debts (`+debtColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (uid) DO UPDATE SET
+			name = EXCLUDED.name,
+			kind = EXCLUDED.kind,
+			principal_minor = EXCLUDED.principal_minor,
+			currency = EXCLUDED.currency,
+			apr = EXCLUDED.apr,
+			term = EXCLUDED.term,
+			frequency = EXCLUDED.frequency,
+			payment_minor = EXCLUDED.payment_minor,
+			start_date = EXCLUDED.start_date,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		d.UID, d.UserID, d.Name, string(d.Kind), d.Principal.MinorUnits, d.Principal.Currency, d.APR, d.Term, d.Frequency, d.Payment.MinorUnits,
+		d.StartDate.UTC(), d.CreatedAt.UTC(), d.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (r *DebtRepository) ListDebtsByUser(ctx context.Context, userID string) ([]*domain.Debt, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+debtColumns+` FROM debts WHERE user_id = $1 ORDER BY created_at ASC, uid ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.Debt
+	for rows.Next() {
+		d, err := scanDebt(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, d)
+	}
+	return res, rows.Err()
+}
+
+func (r *DebtRepository) GetDebt(ctx context.Context, userID string, debtID string) (*domain.Debt, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+debtColumns+` FROM debts WHERE user_id = $1 AND uid = $2`, userID, debtID)
+	d, err := scanDebt(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "debt not found")
+	}
+	return d, err
+}
+
+func (r *DebtRepository) UpdateDebt(ctx context.Context, d *domain.Debt) (*domain.Debt, error) {
+	if d == nil || strings.TrimSpace(d.UserID) == "" || strings.TrimSpace(d.UID) == "" {
+		return nil, fmt.Errorf("invalid debt")
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE debts SET name = $3, kind = $4, principal_minor = $5, currency = $6, apr = $7, term = $8, frequency = $9,
+			payment_minor = $10, start_date = $11, updated_at = $12
+		WHERE user_id = $1 AND uid = $2`,
+		d.UserID, d.UID, d.Name, string(d.Kind), d.Principal.MinorUnits, d.Principal.Currency, d.APR, d.Term, d.Frequency,
+		d.Payment.MinorUnits, d.StartDate.UTC(), d.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, status.Errorf(codes.NotFound, "debt not found")
+	}
+	return d, nil
+}
+
+func (r *DebtRepository) DeleteDebt(ctx context.Context, userID string, debtID string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM debts WHERE user_id = $1 AND uid = $2`, userID, debtID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "debt not found")
+	}
+	return nil
+}
+
+func scanDebt(row scanner) (*domain.Debt, error) {
+	var d domain.Debt
+	var kind string
+	if err := row.Scan(&d.UID, &d.UserID, &d.Name, &kind, &d.Principal.MinorUnits, &d.Principal.Currency, &d.APR, &d.Term, &d.Frequency,
+		&d.Payment.MinorUnits, &d.StartDate, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		return nil, err
+	}
+	d.Kind = domain.DebtKind(kind)
+	d.Payment.Currency = d.Principal.Currency
+	return &d, nil
+}
@@ -0,0 +1,152 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type EnvelopeRepository struct {
+	DB *sql.DB
+}
+
+const (
+	envelopeColumns     = `uid, user_id, name, category_id, allocation_minor, currency, rollover, start_period, created_at, updated_at`
+	envelopeMoveColumns = `uid, user_id, from_envelope_id, to_envelope_id, period, amount_minor, currency, notes, created_at`
+)
+
+func (r *EnvelopeRepository) CreateEnvelope(ctx context.Context, e *domain.Envelope) (*domain.Envelope, error) {
+	if e == nil || strings.TrimSpace(e.UserID) == "" || strings.TrimSpace(e.UID) == "" {
+		return nil, fmt.Errorf("invalid envelope")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO envelopes (`+envelopeColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (uid) DO UPDATE SET
+			name = EXCLUDED.name,
+			category_id = EXCLUDED.category_id,
+			allocation_minor = EXCLUDED.allocation_minor,
+			currency = EXCLUDED.currency,
+			rollover = EXCLUDED.rollover,
+			start_period = EXCLUDED.start_period,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		e.UID, e.UserID, e.Name, e.CategoryID, e.Allocation.MinorUnits, e.Allocation.Currency, e.Rollover, e.StartPeriod,
+		e.CreatedAt.UTC(), e.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *EnvelopeRepository) ListEnvelopesByUser(ctx context.Context, userID string) ([]*domain.Envelope, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+envelopeColumns+` FROM envelopes WHERE user_id = $1 ORDER BY created_at ASC, uid ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.Envelope
+	for rows.Next() {
+		e, err := scanEnvelope(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, rows.Err()
+}
+
+func (r *EnvelopeRepository) GetEnvelope(ctx context.Context, userID string, envelopeID string) (*domain.Envelope, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+envelopeColumns+` FROM envelopes WHERE user_id = $1 AND uid = $2`, userID, envelopeID)
+	e, err := scanEnvelope(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "envelope not found")
+	}
+	return e, err
+}
+
+func (r *EnvelopeRepository) UpdateEnvelope(ctx context.Context, e *domain.Envelope) (*domain.Envelope, error) {
+	if e == nil || strings.TrimSpace(e.UserID) == "" || strings.TrimSpace(e.UID) == "" {
+		return nil, fmt.Errorf("invalid envelope")
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE envelopes SET name = $3, category_id = $4, allocation_minor = $5, rollover = $6, start_period = $7, updated_at = $8
+		WHERE user_id = $1 AND uid = $2`,
+		e.UserID, e.UID, e.Name, e.CategoryID, e.Allocation.MinorUnits, e.Rollover, e.StartPeriod, e.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, status.Errorf(codes.NotFound, "envelope not found")
+	}
+	return e, nil
+}
+
+func (r *EnvelopeRepository) DeleteEnvelope(ctx context.Context, userID string, envelopeID string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM envelopes WHERE user_id = $1 AND uid = $2`, userID, envelopeID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "envelope not found")
+	}
+	return nil
+}
+
+func (r *EnvelopeRepository) CreateEnvelopeMove(ctx context.Context, m *domain.EnvelopeMove) (*domain.EnvelopeMove, error) {
+	if m == nil || strings.TrimSpace(m.UserID) == "" || strings.TrimSpace(m.UID) == "" {
+		return nil, fmt.Errorf("invalid envelope move")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO envelope_moves (`+envelopeMoveColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (uid) DO UPDATE SET
+			from_envelope_id = EXCLUDED.from_envelope_id,
+			to_envelope_id = EXCLUDED.to_envelope_id,
+			period = EXCLUDED.period,
+			amount_minor = EXCLUDED.amount_minor,
+			currency = EXCLUDED.currency,
+			notes = EXCLUDED.notes,
+			created_at = EXCLUDED.created_at`,
+		m.UID, m.UserID, m.FromEnvelopeID, m.ToEnvelopeID, m.Period, m.Amount.MinorUnits, m.Amount.Currency, m.Notes, m.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (r *EnvelopeRepository) ListEnvelopeMovesByUser(ctx context.Context, userID string) ([]*domain.EnvelopeMove, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+envelopeMoveColumns+` FROM envelope_moves WHERE user_id = $1 ORDER BY created_at ASC, uid ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.EnvelopeMove
+	for rows.Next() {
+		var m domain.EnvelopeMove
+		if err := rows.Scan(&m.UID, &m.UserID, &m.FromEnvelopeID, &m.ToEnvelopeID, &m.Period, &m.Amount.MinorUnits, &m.Amount.Currency, &m.Notes, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, &m)
+	}
+	return res, rows.Err()
+}
+
+func scanEnvelope(row scanner) (*domain.Envelope, error) {
+	var e domain.Envelope
+	if err := row.Scan(&e.UID, &e.UserID, &e.Name, &e.CategoryID, &e.Allocation.MinorUnits, &e.Allocation.Currency, &e.Rollover, &e.StartPeriod, &e.CreatedAt, &e.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,76 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type ExchangeRateRepository struct {
+	DB *sql.DB
+}
+
+func (r *ExchangeRateRepository) SaveRates(ctx context.Context, rates []domain.ExchangeRate) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO exchange_rates (base, quote, rate_date, rate)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (base, quote, rate_date) DO UPDATE SET rate = EXCLUDED.rate`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, rate := range rates {
+		if _, err := stmt.ExecContext(ctx, rate.Base, rate.Quote, rate.Date.UTC(), rate.Rate); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *ExchangeRateRepository) FindRate(ctx context.Context, base, quote string, on time.Time) (*domain.ExchangeRate, error) {
+	row := r.DB.QueryRowContext(ctx, `
+		SELECT base, quote, rate_date, rate FROM exchange_rates
+		WHERE base = $1 AND quote = $2 AND rate_date <= $3
+		ORDER BY rate_date DESC LIMIT 1`,
+		base, quote, on.UTC(),
+	)
+	var m domain.ExchangeRate
+	err := row.Scan(&m.Base, &m.Quote, &m.Date, &m.Rate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrExchangeRateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *ExchangeRateRepository) ListRates(ctx context.Context) ([]domain.ExchangeRate, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT base, quote, rate_date, rate FROM exchange_rates
+		ORDER BY base, quote, rate_date`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.ExchangeRate
+	for rows.Next() {
+		var m domain.ExchangeRate
+		if err := rows.Scan(&m.Base, &m.Quote, &m.Date, &m.Rate); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,134 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ExpenseRepository struct {
+	DB *sql.DB
+}
+
+const expenseColumns = `uid, user_id, source, account_id, category_id, debt_id, tags, amount_minor, currency, splits, notes, is_recurring, recurrence_frequency, next_occurrence_date, created_at, updated_at`
+
+func (r *ExpenseRepository) CreateExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error) {
+	if expense == nil || strings.TrimSpace(expense.UserID) == "" || strings.TrimSpace(expense.UID) == "" {
+		return nil, fmt.Errorf("invalid expense")
+	}
+	if err := r.upsert(ctx, expense); err != nil {
+		return nil, err
+	}
+	return expense, nil
+}
+
+func (r *ExpenseRepository) ListExpensesByUser(ctx context.Context, userID string) ([]*domain.Expense, error) {
+	return r.query(ctx, `SELECT `+expenseColumns+` FROM expenses WHERE user_id = $1 ORDER BY created_at DESC`, userID)
+}
+
+func (r *ExpenseRepository) GetExpense(ctx context.Context, userID string, expenseID string) (*domain.Expense, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+expenseColumns+` FROM expenses WHERE user_id = $1 AND uid = $2`, userID, expenseID)
+	m, err := scanExpense(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "expense not found")
+	}
+	return m, err
+}
+
+func (r *ExpenseRepository) UpdateExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error) {
+	if expense == nil || strings.TrimSpace(expense.UserID) == "" || strings.TrimSpace(expense.UID) == "" {
+		return nil, fmt.Errorf("invalid expense")
+	}
+	if err := r.upsert(ctx, expense); err != nil {
+		return nil, err
+	}
+	return expense, nil
+}
+
+func (r *ExpenseRepository) DeleteExpense(ctx context.Context, userID string, expenseID string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM expenses WHERE user_id = $1 AND uid = $2`, userID, expenseID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "expense not found")
+	}
+	return nil
+}
+
+func (r *ExpenseRepository) ListRecurringExpenses(ctx context.Context, userID string, before time.Time) ([]*domain.Expense, error) {
+	return r.query(ctx, `SELECT `+expenseColumns+` FROM expenses WHERE user_id = $1 AND is_recurring AND next_occurrence_date <= $2`, userID, before.UTC())
+}
+
+func (r *ExpenseRepository) UpdateExpenseRecurringStatus(ctx context.Context, userID string, expenseID string, nextOccurrenceDate time.Time) error {
+	res, err := r.DB.ExecContext(ctx, `UPDATE expenses SET next_occurrence_date = $3, updated_at = $4 WHERE user_id = $1 AND uid = $2`,
+		userID, expenseID, nextOccurrenceDate.UTC(), time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "expense not found")
+	}
+	return nil
+}
+
+func (r *ExpenseRepository) upsert(ctx context.Context, e *domain.Expense) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO expenses (`+expenseColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (uid) DO UPDATE SET
+			source = EXCLUDED.source,
+			account_id = EXCLUDED.account_id,
+			category_id = EXCLUDED.category_id,
+			debt_id = EXCLUDED.debt_id,
+			tags = EXCLUDED.tags,
+			amount_minor = EXCLUDED.amount_minor,
+			currency = EXCLUDED.currency,
+			splits = EXCLUDED.splits,
+			notes = EXCLUDED.notes,
+			is_recurring = EXCLUDED.is_recurring,
+			recurrence_frequency = EXCLUDED.recurrence_frequency,
+			next_occurrence_date = EXCLUDED.next_occurrence_date,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		e.UID, e.UserID, e.Source, e.AccountID, e.CategoryID, e.DebtID, tagList(e.Tags), e.Amount.MinorUnits, e.Amount.Currency, splitList(e.Splits), e.Notes, e.IsRecurring, e.RecurrenceFrequency,
+		e.NextOccurrenceDate.UTC(), e.CreatedAt.UTC(), e.UpdatedAt.UTC(),
+	)
+	return err
+}
+
+func (r *ExpenseRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Expense, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.Expense
+	for rows.Next() {
+		m, err := scanExpense(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	return res, rows.Err()
+}
+
+func scanExpense(row scanner) (*domain.Expense, error) {
+	var m domain.Expense
+	if err := row.Scan(
+		&m.UID, &m.UserID, &m.Source, &m.AccountID, &m.CategoryID, &m.DebtID, (*tagList)(&m.Tags), &m.Amount.MinorUnits, &m.Amount.Currency, (*splitList)(&m.Splits), &m.Notes, &m.IsRecurring,
+		&m.RecurrenceFrequency, &m.NextOccurrenceDate, &m.CreatedAt, &m.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,163 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type HoldingRepository struct {
+	DB *sql.DB
+}
+
+const (
+	holdingColumns   = `uid, user_id, name, kind, category, currency, created_at, updated_at`
+	valuationColumns = `uid, user_id, holding_id, value_minor, currency, valued_at, notes, created_at`
+)
+
+func (r *HoldingRepository) CreateHolding(ctx context.Context, h *domain.Holding) (*domain.Holding, error) {
+	if h == nil || strings.TrimSpace(h.UserID) == "" || strings.TrimSpace(h.UID) == "" {
+		return nil, fmt.Errorf("invalid holding")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO holdings (`+holdingColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (uid) DO UPDATE SET
+			name = EXCLUDED.name,
+			kind = EXCLUDED.kind,
+			category = EXCLUDED.category,
+			currency = EXCLUDED.currency,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		h.UID, h.UserID, h.Name, string(h.Kind), string(h.Category), h.Currency, h.CreatedAt.UTC(), h.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (r *HoldingRepository) ListHoldingsByUser(ctx context.Context, userID string) ([]*domain.Holding, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+holdingColumns+` FROM holdings WHERE user_id = $1 ORDER BY created_at ASC, uid ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.Holding
+	for rows.Next() {
+		h, err := scanHolding(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, h)
+	}
+	return res, rows.Err()
+}
+
+func (r *HoldingRepository) GetHolding(ctx context.Context, userID string, holdingID string) (*domain.Holding, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+holdingColumns+` FROM holdings WHERE user_id = $1 AND uid = $2`, userID, holdingID)
+	h, err := scanHolding(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "holding not found")
+	}
+	return h, err
+}
+
+func (r *HoldingRepository) UpdateHolding(ctx context.Context, h *domain.Holding) (*domain.Holding, error) {
+	if h == nil || strings.TrimSpace(h.UserID) == "" || strings.TrimSpace(h.UID) == "" {
+		return nil, fmt.Errorf("invalid holding")
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE holdings SET name = $3, kind = $4, category = $5, updated_at = $6
+		WHERE user_id = $1 AND uid = $2`,
+		h.UserID, h.UID, h.Name, string(h.Kind), string(h.Category), h.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, status.Errorf(codes.NotFound, "holding not found")
+	}
+	return h, nil
+}
+
+func (r *HoldingRepository) DeleteHolding(ctx context.Context, userID string, holdingID string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM holdings WHERE user_id = $1 AND uid = $2`, userID, holdingID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "holding not found")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM holding_valuations WHERE user_id = $1 AND holding_id = $2`, userID, holdingID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *HoldingRepository) CreateValuation(ctx context.Context, v *domain.Valuation) (*domain.Valuation, error) {
+	if v == nil || strings.TrimSpace(v.UserID) == "" || strings.TrimSpace(v.UID) == "" {
+		return nil, fmt.Errorf("invalid valuation")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO holding_valuations (`+valuationColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (uid) DO UPDATE SET
+			holding_id = EXCLUDED.holding_id,
+			value_minor = EXCLUDED.value_minor,
+			currency = EXCLUDED.currency,
+			valued_at = EXCLUDED.valued_at,
+			notes = EXCLUDED.notes,
+			created_at = EXCLUDED.created_at`,
+		v.UID, v.UserID, v.HoldingID, v.Value.MinorUnits, v.Value.Currency, v.Date.UTC(), v.Notes, v.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (r *HoldingRepository) ListValuationsByUser(ctx context.Context, userID string) ([]*domain.Valuation, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+valuationColumns+` FROM holding_valuations WHERE user_id = $1 ORDER BY valued_at ASC, created_at ASC, uid ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.Valuation
+	for rows.Next() {
+		var v domain.Valuation
+		if err := rows.Scan(&v.UID, &v.UserID, &v.HoldingID, &v.Value.MinorUnits, &v.Value.Currency, &v.Date, &v.Notes, &v.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, &v)
+	}
+	return res, rows.Err()
+}
+
+func scanHolding(row scanner) (*domain.Holding, error) {
+	var (
+		h        domain.Holding
+		kind     string
+		category string
+	)
+	if err := row.Scan(&h.UID, &h.UserID, &h.Name, &kind, &category, &h.Currency, &h.CreatedAt, &h.UpdatedAt); err != nil {
+		return nil, err
+	}
+	h.Kind = domain.HoldingKind(kind)
+	h.Category = domain.HoldingCategory(category)
+	return &h, nil
+}
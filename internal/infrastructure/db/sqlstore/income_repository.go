@@ -0,0 +1,206 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type IncomeRepository struct {
+	DB *sql.DB
+}
+
+const (
+	incomeColumns       = `uid, user_id, source, account_id, category_id, tags, amount_minor, currency, notes, created_at, updated_at`
+	incomeSourceColumns = `uid, user_id, source, amount_minor, currency, frequency, next_pay_at, active, notes, created_at, updated_at`
+)
+
+// incomeSourceFields maps the field names accepted by UpdateIncomeSource to
+// their column names. "Amount" takes a domain.Money and is handled apart.
+var incomeSourceFields = map[string]string{
+	"Source":    "source",
+	"Frequency": "frequency",
+	"NextPayAt": "next_pay_at",
+	"Active":    "active",
+	"Notes":     "notes",
+	"UpdatedAt": "updated_at",
+}
+
+func (r *IncomeRepository) CreateIncome(ctx context.Context, income *domain.Income) (*domain.Income, error) {
+	if income == nil || income.UserID == "" || income.UID == "" {
+		return nil, fmt.Errorf("invalid income")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO incomes (`+incomeColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (uid) DO UPDATE SET
+			source = EXCLUDED.source,
+			account_id = EXCLUDED.account_id,
+			category_id = EXCLUDED.category_id,
+			tags = EXCLUDED.tags,
+			amount_minor = EXCLUDED.amount_minor,
+			currency = EXCLUDED.currency,
+			notes = EXCLUDED.notes,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		income.UID, income.UserID, income.Source, income.AccountID, income.CategoryID, tagList(income.Tags), income.Amount.MinorUnits, income.Amount.Currency, income.Notes,
+		income.CreatedAt.UTC(), income.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return income, nil
+}
+
+func (r *IncomeRepository) ListIncomesByUser(ctx context.Context, userID string) ([]*domain.Income, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+incomeColumns+` FROM incomes WHERE user_id = $1 ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.Income
+	for rows.Next() {
+		m, err := scanIncome(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	return res, rows.Err()
+}
+
+func (r *IncomeRepository) GetIncome(ctx context.Context, userID string, incomeID string) (*domain.Income, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+incomeColumns+` FROM incomes WHERE user_id = $1 AND uid = $2`, userID, incomeID)
+	m, err := scanIncome(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "income not found")
+	}
+	return m, err
+}
+
+func (r *IncomeRepository) DeleteIncome(ctx context.Context, userID string, incomeID string) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM incomes WHERE user_id = $1 AND uid = $2`, userID, incomeID)
+	return err
+}
+
+func (r *IncomeRepository) CreateIncomeSource(ctx context.Context, src *domain.IncomeSource) (*domain.IncomeSource, error) {
+	if src == nil || src.UserID == "" || src.UID == "" {
+		return nil, fmt.Errorf("invalid income source")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO income_sources (`+incomeSourceColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (uid) DO UPDATE SET
+			source = EXCLUDED.source,
+			amount_minor = EXCLUDED.amount_minor,
+			currency = EXCLUDED.currency,
+			frequency = EXCLUDED.frequency,
+			next_pay_at = EXCLUDED.next_pay_at,
+			active = EXCLUDED.active,
+			notes = EXCLUDED.notes,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		src.UID, src.UserID, src.Source, src.Amount.MinorUnits, src.Amount.Currency, src.Frequency, src.NextPayAt.UTC(),
+		src.Active, src.Notes, src.CreatedAt.UTC(), src.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+func (r *IncomeRepository) ListIncomeSourcesByUser(ctx context.Context, userID string) ([]*domain.IncomeSource, error) {
+	return r.queryIncomeSources(ctx, `SELECT `+incomeSourceColumns+` FROM income_sources WHERE user_id = $1 ORDER BY source ASC`, userID)
+}
+
+func (r *IncomeRepository) ListDueIncomeSources(ctx context.Context, userID string, before time.Time) ([]*domain.IncomeSource, error) {
+	return r.queryIncomeSources(ctx, `SELECT `+incomeSourceColumns+` FROM income_sources WHERE user_id = $1 AND active AND next_pay_at <= $2`, userID, before.UTC())
+}
+
+func (r *IncomeRepository) UpdateIncomeSource(ctx context.Context, userID string, id string, updates map[string]interface{}) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	sets := make([]string, 0, len(updates))
+	args := []interface{}{userID, id}
+	for k, v := range updates {
+		if k == "Amount" {
+			m, ok := v.(domain.Money)
+			if !ok {
+				return fmt.Errorf("income source Amount must be domain.Money, got %T", v)
+			}
+			args = append(args, m.MinorUnits, m.Currency)
+			sets = append(sets, fmt.Sprintf("amount_minor = $%d, currency = $%d", len(args)-1, len(args)))
+			continue
+		}
+		col, ok := incomeSourceFields[k]
+		if !ok {
+			return fmt.Errorf("unknown income source field %q", k)
+		}
+		if t, ok := v.(time.Time); ok {
+			v = t.UTC()
+		}
+		args = append(args, v)
+		sets = append(sets, fmt.Sprintf("%s = $%d", col, len(args)))
+	}
+
+	res, err := r.DB.ExecContext(ctx, `UPDATE income_sources SET `+strings.Join(sets, ", ")+` WHERE user_id = $1 AND uid = $2`, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "income source not found")
+	}
+	return nil
+}
+
+func (r *IncomeRepository) DeleteIncomeSource(ctx context.Context, userID string, source string) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM income_sources WHERE user_id = $1 AND source = $2`, userID, source)
+	return err
+}
+
+func (r *IncomeRepository) queryIncomeSources(ctx context.Context, query string, args ...interface{}) ([]*domain.IncomeSource, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.IncomeSource
+	for rows.Next() {
+		m, err := scanIncomeSource(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	return res, rows.Err()
+}
+
+func scanIncome(row scanner) (*domain.Income, error) {
+	var m domain.Income
+	if err := row.Scan(&m.UID, &m.UserID, &m.Source, &m.AccountID, &m.CategoryID, (*tagList)(&m.Tags), &m.Amount.MinorUnits, &m.Amount.Currency, &m.Notes, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func scanIncomeSource(row scanner) (*domain.IncomeSource, error) {
+	var m domain.IncomeSource
+	if err := row.Scan(
+		&m.UID, &m.UserID, &m.Source, &m.Amount.MinorUnits, &m.Amount.Currency, &m.Frequency, &m.NextPayAt,
+		&m.Active, &m.Notes, &m.CreatedAt, &m.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,110 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type LoginAttemptRepository struct {
+	DB *sql.DB
+}
+
+func (r *LoginAttemptRepository) RecordLoginFailure(ctx context.Context, key string, at, since time.Time) (*domain.LoginAttempts, error) {
+	return scanLoginAttempts(r.DB.QueryRowContext(ctx, `
+		INSERT INTO login_attempts (key, failures, last_failure_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at < $3 THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = excluded.last_failure_at
+		RETURNING key, failures, last_failure_at, locked_until`,
+		key, at.UTC(), since.UTC(),
+	))
+}
+
+func (r *LoginAttemptRepository) GetLoginAttempts(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	a, err := scanLoginAttempts(r.DB.QueryRowContext(ctx, `
+		SELECT key, failures, last_failure_at, locked_until
+		FROM login_attempts WHERE key = $1`, key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "no failed logins for %s", key)
+	}
+	return a, err
+}
+
+func (r *LoginAttemptRepository) LockLogin(ctx context.Context, key string, until time.Time) error {
+	res, err := r.DB.ExecContext(ctx, `UPDATE login_attempts SET locked_until = $2 WHERE key = $1`, key, until.UTC())
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "no failed logins for %s", key)
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) ResetLoginAttempts(ctx context.Context, key string) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM login_attempts WHERE key = $1`, key)
+	return err
+}
+
+func (r *LoginAttemptRepository) DeleteStaleLoginAttempts(ctx context.Context, before time.Time) error {
+	_, err := r.DB.ExecContext(ctx, `
+		DELETE FROM login_attempts
+		WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until < $1)`, before.UTC())
+	return err
+}
+
+func (r *LoginAttemptRepository) CreateAccountUnlock(ctx context.Context, u *domain.AccountUnlock) error {
+	if u == nil || u.TokenHash == "" {
+		return fmt.Errorf("invalid account unlock")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO account_unlocks (token_hash, email, user_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		u.TokenHash, u.Email, u.UserID, u.ExpiresAt.UTC(), u.CreatedAt.UTC(),
+	)
+	return err
+}
+
+func (r *LoginAttemptRepository) TakeAccountUnlock(ctx context.Context, tokenHash string) (*domain.AccountUnlock, error) {
+	var u domain.AccountUnlock
+	err := r.DB.QueryRowContext(ctx, `
+		DELETE FROM account_unlocks WHERE token_hash = $1
+		RETURNING token_hash, email, user_id, expires_at, created_at`, tokenHash,
+	).Scan(&u.TokenHash, &u.Email, &u.UserID, &u.ExpiresAt, &u.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "account unlock not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *LoginAttemptRepository) DeleteExpiredAccountUnlocks(ctx context.Context) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM account_unlocks WHERE expires_at < $1`, time.Now().UTC())
+	return err
+}
+
+func scanLoginAttempts(row *sql.Row) (*domain.LoginAttempts, error) {
+	var (
+		a           domain.LoginAttempts
+		lockedUntil sql.NullTime
+	)
+	if err := row.Scan(&a.Key, &a.Failures, &a.LastFailureAt, &lockedUntil); err != nil {
+		return nil, err
+	}
+	if lockedUntil.Valid {
+		a.LockedUntil = &lockedUntil.Time
+	}
+	return &a, nil
+}
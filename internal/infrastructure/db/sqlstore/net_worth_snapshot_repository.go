@@ -0,0 +1,118 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type NetWorthSnapshotRepository struct {
+	DB *sql.DB
+}
+
+func (r *NetWorthSnapshotRepository) SaveNetWorthSnapshot(ctx context.Context, s *domain.NetWorthSnapshot) error {
+	if s == nil || strings.TrimSpace(s.UserID) == "" || s.Date.IsZero() {
+		return fmt.Errorf("invalid net worth snapshot")
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	date := s.Date.UTC()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO net_worth_snapshots (user_id, snapshot_date, assets_minor, liabilities_minor, currency, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, snapshot_date) DO UPDATE SET
+			assets_minor = EXCLUDED.assets_minor,
+			liabilities_minor = EXCLUDED.liabilities_minor,
+			currency = EXCLUDED.currency,
+			created_at = EXCLUDED.created_at`,
+		s.UserID, date, s.TotalAssets.MinorUnits, s.TotalLiabilities.MinorUnits, s.TotalAssets.Currency, s.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM account_balance_snapshots WHERE user_id = $1 AND snapshot_date = $2`, s.UserID, date); err != nil {
+		return err
+	}
+	for i, a := range s.Accounts {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO account_balance_snapshots (user_id, snapshot_date, position, account_id, name, balance_minor, currency)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			s.UserID, date, i, a.AccountID, a.Name, a.Balance.MinorUnits, a.Balance.Currency,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *NetWorthSnapshotRepository) ListNetWorthSnapshots(ctx context.Context, userID string, from, to time.Time) ([]*domain.NetWorthSnapshot, error) {
+	where, args := `user_id = $1`, []interface{}{userID}
+	if !from.IsZero() {
+		args = append(args, from.UTC())
+		where += fmt.Sprintf(` AND snapshot_date >= $%d`, len(args))
+	}
+	if !to.IsZero() {
+		args = append(args, to.UTC())
+		where += fmt.Sprintf(` AND snapshot_date <= $%d`, len(args))
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT user_id, snapshot_date, assets_minor, liabilities_minor, currency, created_at
+		FROM net_worth_snapshots WHERE `+where+` ORDER BY snapshot_date ASC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.NetWorthSnapshot
+	byDate := make(map[time.Time]*domain.NetWorthSnapshot)
+	for rows.Next() {
+		var (
+			s        domain.NetWorthSnapshot
+			currency string
+		)
+		if err := rows.Scan(&s.UserID, &s.Date, &s.TotalAssets.MinorUnits, &s.TotalLiabilities.MinorUnits, &currency, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		s.Date = s.Date.UTC()
+		s.TotalAssets.Currency, s.TotalLiabilities.Currency = currency, currency
+		res = append(res, &s)
+		byDate[s.Date] = &s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return res, nil
+	}
+
+	rows, err = r.DB.QueryContext(ctx, `
+		SELECT snapshot_date, account_id, name, balance_minor, currency
+		FROM account_balance_snapshots WHERE `+where+` ORDER BY snapshot_date ASC, position ASC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			date time.Time
+			a    domain.AccountBalance
+		)
+		if err := rows.Scan(&date, &a.AccountID, &a.Name, &a.Balance.MinorUnits, &a.Balance.Currency); err != nil {
+			return nil, err
+		}
+		if s := byDate[date.UTC()]; s != nil {
+			s.Accounts = append(s.Accounts, a)
+		}
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,133 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type PasskeyRepository struct {
+	DB *sql.DB
+}
+
+func (r *PasskeyRepository) CreatePasskey(ctx context.Context, p *domain.Passkey) error {
+	if p == nil || p.ID == "" || p.UserID == "" {
+		return fmt.Errorf("invalid passkey")
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO passkeys (id, user_id, name, public_key, attestation_type, aaguid, transports,
+			sign_count, backup_eligible, backup_state, created_at, last_used_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (id) DO NOTHING`,
+		p.ID, p.UserID, p.Name, p.PublicKey, p.AttestationType, p.AAGUID, tagList(p.Transports),
+		int64(p.SignCount), p.BackupEligible, p.BackupState, p.CreatedAt.UTC(), nullTime(p.LastUsedAt),
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.AlreadyExists, "passkey already registered")
+	}
+	return nil
+}
+
+func (r *PasskeyRepository) ListPasskeys(ctx context.Context, userID string) ([]*domain.Passkey, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, user_id, name, public_key, attestation_type, aaguid, transports,
+			sign_count, backup_eligible, backup_state, created_at, last_used_at
+		FROM passkeys WHERE user_id = $1
+		ORDER BY created_at, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.Passkey
+	for rows.Next() {
+		var (
+			p          domain.Passkey
+			transports tagList
+			signCount  int64
+			lastUsedAt sql.NullTime
+		)
+		if err := rows.Scan(&p.ID, &p.UserID, &p.Name, &p.PublicKey, &p.AttestationType, &p.AAGUID, &transports,
+			&signCount, &p.BackupEligible, &p.BackupState, &p.CreatedAt, &lastUsedAt); err != nil {
+			return nil, err
+		}
+		p.Transports = transports
+		p.SignCount = uint32(signCount)
+		if lastUsedAt.Valid {
+			p.LastUsedAt = &lastUsedAt.Time
+		}
+		out = append(out, &p)
+	}
+	return out, rows.Err()
+}
+
+func (r *PasskeyRepository) UpdatePasskeyUsage(ctx context.Context, id string, signCount uint32, backupState bool, usedAt time.Time) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE passkeys SET sign_count = $2, backup_state = $3, last_used_at = $4
+		WHERE id = $1`, id, int64(signCount), backupState, usedAt.UTC())
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "passkey not found")
+	}
+	return nil
+}
+
+func (r *PasskeyRepository) DeletePasskey(ctx context.Context, userID, id string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM passkeys WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "passkey not found")
+	}
+	return nil
+}
+
+func (r *PasskeyRepository) CreatePasskeyCeremony(ctx context.Context, c *domain.PasskeyCeremony) error {
+	if c == nil || c.TokenHash == "" {
+		return fmt.Errorf("invalid passkey ceremony")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO passkey_ceremonies (token_hash, user_id, session, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		c.TokenHash, c.UserID, c.Session, c.ExpiresAt.UTC(), c.CreatedAt.UTC(),
+	)
+	return err
+}
+
+func (r *PasskeyRepository) TakePasskeyCeremony(ctx context.Context, tokenHash string) (*domain.PasskeyCeremony, error) {
+	var c domain.PasskeyCeremony
+	err := r.DB.QueryRowContext(ctx, `
+		DELETE FROM passkey_ceremonies WHERE token_hash = $1
+		RETURNING token_hash, user_id, session, expires_at, created_at`, tokenHash,
+	).Scan(&c.TokenHash, &c.UserID, &c.Session, &c.ExpiresAt, &c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "passkey ceremony not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *PasskeyRepository) DeleteExpiredPasskeyCeremonies(ctx context.Context) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM passkey_ceremonies WHERE expires_at < $1`, time.Now().UTC())
+	return err
+}
@@ -0,0 +1,156 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RefreshTokenRepository struct {
+	DB *sql.DB
+}
+
+const refreshTokenColumns = `id, user_id, token_hash, family_id, replaced_by, is_revoked, expires_at, created_at, revoked_at, device_info, ip_address, user_agent`
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+
+	if token.ID == "" {
+		token.ID = uuid.NewString()
+	}
+	if token.FamilyID == "" {
+		token.FamilyID = token.ID
+	}
+
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (`+refreshTokenColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		token.ID, token.UserID, token.TokenHash, token.FamilyID, token.ReplacedBy, token.IsRevoked, token.ExpiresAt.UTC(), token.CreatedAt.UTC(),
+		nullTime(token.RevokedAt), token.DeviceInfo, token.IPAddress, token.UserAgent,
+	)
+	return err
+}
+
+func (r *RefreshTokenRepository) GetByID(ctx context.Context, id string) (*domain.RefreshToken, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+refreshTokenColumns+` FROM refresh_tokens WHERE id = $1`, id)
+	token, err := scanRefreshToken(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("refresh token not found")
+	}
+	return token, err
+}
+
+func (r *RefreshTokenRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.RefreshToken, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+refreshTokenColumns+` FROM refresh_tokens WHERE user_id = $1 ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*domain.RefreshToken
+	for rows.Next() {
+		token, err := scanRefreshToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *RefreshTokenRepository) GetValidToken(ctx context.Context, userID, tokenHash string) (*domain.RefreshToken, error) {
+	row := r.DB.QueryRowContext(ctx, `
+		SELECT `+refreshTokenColumns+` FROM refresh_tokens
+		WHERE user_id = $1 AND token_hash = $2 AND NOT is_revoked AND expires_at > $3
+		LIMIT 1`,
+		userID, tokenHash, time.Now().UTC(),
+	)
+	token, err := scanRefreshToken(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("valid refresh token not found")
+	}
+	return token, err
+}
+
+func (r *RefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+refreshTokenColumns+` FROM refresh_tokens WHERE token_hash = $1`, tokenHash)
+	token, err := scanRefreshToken(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "refresh token not found")
+	}
+	return token, err
+}
+
+func (r *RefreshTokenRepository) RotateToken(ctx context.Context, id, replacedBy string) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE refresh_tokens SET is_revoked = TRUE, revoked_at = $3, replaced_by = $2
+		WHERE id = $1 AND NOT is_revoked`,
+		id, replacedBy, time.Now().UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.FailedPrecondition, "refresh token already revoked")
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeToken(ctx context.Context, id string) error {
+	res, err := r.DB.ExecContext(ctx, `UPDATE refresh_tokens SET is_revoked = TRUE, revoked_at = $2 WHERE id = $1`, id, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("refresh token not found")
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := r.DB.ExecContext(ctx, `UPDATE refresh_tokens SET is_revoked = TRUE, revoked_at = $2 WHERE family_id = $1 AND NOT is_revoked`, familyID, time.Now().UTC())
+	return err
+}
+
+func (r *RefreshTokenRepository) RevokeAllUserTokens(ctx context.Context, userID string) error {
+	_, err := r.DB.ExecContext(ctx, `UPDATE refresh_tokens SET is_revoked = TRUE, revoked_at = $2 WHERE user_id = $1 AND NOT is_revoked`, userID, time.Now().UTC())
+	return err
+}
+
+func (r *RefreshTokenRepository) DeleteExpiredTokens(ctx context.Context) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE expires_at < $1`, time.Now().UTC())
+	return err
+}
+
+func (r *RefreshTokenRepository) DeleteToken(ctx context.Context, id string) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE id = $1`, id)
+	return err
+}
+
+func scanRefreshToken(row scanner) (*domain.RefreshToken, error) {
+	var (
+		t         domain.RefreshToken
+		revokedAt sql.NullTime
+	)
+	if err := row.Scan(
+		&t.ID, &t.UserID, &t.TokenHash, &t.FamilyID, &t.ReplacedBy, &t.IsRevoked, &t.ExpiresAt, &t.CreatedAt, &revokedAt,
+		&t.DeviceInfo, &t.IPAddress, &t.UserAgent,
+	); err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+	return &t, nil
+}
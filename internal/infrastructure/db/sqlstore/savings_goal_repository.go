@@ -0,0 +1,106 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SavingsGoalRepository struct {
+	DB *sql.DB
+}
+
+const savingsGoalColumns = `uid, user_id, name, account_id, target_minor, currency, target_date, created_at, updated_at`
+
+func (r *SavingsGoalRepository) CreateSavingsGoal(ctx context.Context, g *domain.SavingsGoal) (*domain.SavingsGoal, error) {
+	if g == nil || strings.TrimSpace(g.UserID) == "" || strings.TrimSpace(g.UID) == "" {
+		return nil, fmt.Errorf("invalid savings goal")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO savings_goals (`+savingsGoalColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (uid) DO UPDATE SET
+			name = EXCLUDED.name,
+			account_id = EXCLUDED.account_id,
+			target_minor = EXCLUDED.target_minor,
+			currency = EXCLUDED.currency,
+			target_date = EXCLUDED.target_date,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		g.UID, g.UserID, g.Name, g.AccountID, g.Target.MinorUnits, g.Target.Currency, g.TargetDate.UTC(), g.CreatedAt.UTC(), g.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (r *SavingsGoalRepository) ListSavingsGoalsByUser(ctx context.Context, userID string) ([]*domain.SavingsGoal, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+savingsGoalColumns+` FROM savings_goals WHERE user_id = $1 ORDER BY target_date ASC, created_at ASC, uid ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.SavingsGoal
+	for rows.Next() {
+		g, err := scanSavingsGoal(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, g)
+	}
+	return res, rows.Err()
+}
+
+func (r *SavingsGoalRepository) GetSavingsGoal(ctx context.Context, userID string, goalID string) (*domain.SavingsGoal, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+savingsGoalColumns+` FROM savings_goals WHERE user_id = $1 AND uid = $2`, userID, goalID)
+	g, err := scanSavingsGoal(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "savings goal not found")
+	}
+	return g, err
+}
+
+func (r *SavingsGoalRepository) UpdateSavingsGoal(ctx context.Context, g *domain.SavingsGoal) (*domain.SavingsGoal, error) {
+	if g == nil || strings.TrimSpace(g.UserID) == "" || strings.TrimSpace(g.UID) == "" {
+		return nil, fmt.Errorf("invalid savings goal")
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE savings_goals SET name = $3, account_id = $4, target_minor = $5, currency = $6, target_date = $7, updated_at = $8
+		WHERE user_id = $1 AND uid = $2`,
+		g.UserID, g.UID, g.Name, g.AccountID, g.Target.MinorUnits, g.Target.Currency, g.TargetDate.UTC(), g.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, status.Errorf(codes.NotFound, "savings goal not found")
+	}
+	return g, nil
+}
+
+func (r *SavingsGoalRepository) DeleteSavingsGoal(ctx context.Context, userID string, goalID string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM savings_goals WHERE user_id = $1 AND uid = $2`, userID, goalID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "savings goal not found")
+	}
+	return nil
+}
+
+func scanSavingsGoal(row scanner) (*domain.SavingsGoal, error) {
+	var g domain.SavingsGoal
+	if err := row.Scan(&g.UID, &g.UserID, &g.Name, &g.AccountID, &g.Target.MinorUnits, &g.Target.Currency, &g.TargetDate, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
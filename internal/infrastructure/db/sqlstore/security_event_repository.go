@@ -0,0 +1,50 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type SecurityEventRepository struct {
+	DB *sql.DB
+}
+
+const securityEventColumns = `id, user_id, type, details, ip_address, user_agent, created_at`
+
+func (r *SecurityEventRepository) CreateSecurityEvent(ctx context.Context, e *domain.SecurityEvent) error {
+	if e == nil || e.ID == "" || e.UserID == "" {
+		return fmt.Errorf("invalid security event")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO security_events (`+securityEventColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO NOTHING`,
+		e.ID, e.UserID, string(e.Type), e.Details, e.IPAddress, e.UserAgent, e.CreatedAt.UTC(),
+	)
+	return err
+}
+
+func (r *SecurityEventRepository) ListSecurityEvents(ctx context.Context, userID string) ([]*domain.SecurityEvent, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+securityEventColumns+` FROM security_events WHERE user_id = $1 ORDER BY created_at DESC, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.SecurityEvent
+	for rows.Next() {
+		var (
+			e         domain.SecurityEvent
+			eventType string
+		)
+		if err := rows.Scan(&e.ID, &e.UserID, &eventType, &e.Details, &e.IPAddress, &e.UserAgent, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Type = domain.SecurityEventType(eventType)
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
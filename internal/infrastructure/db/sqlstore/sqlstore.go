@@ -0,0 +1,177 @@
+// Package sqlstore implements the repository ports on top of database/sql.
+// Queries use $N placeholders and ON CONFLICT upserts, which both PostgreSQL
+// and SQLite understand, so the postgres and sqlite adapters share them.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type Store struct {
+	DB *sql.DB
+
+	UserRepository             *UserRepository
+	IncomeRepository           *IncomeRepository
+	ExpenseRepository          *ExpenseRepository
+	RefreshTokenRepository     *RefreshTokenRepository
+	SecurityEventRepository    *SecurityEventRepository
+	TwoFactorRepository        *TwoFactorRepository
+	PasskeyRepository          *PasskeyRepository
+	LoginAttemptRepository     *LoginAttemptRepository
+	CredentialRepository       *CredentialRepository
+	ExchangeRateRepository     *ExchangeRateRepository
+	CategoryRepository         *CategoryRepository
+	AccountRepository          *AccountRepository
+	TransferRepository         *TransferRepository
+	BudgetRepository           *BudgetRepository
+	EnvelopeRepository         *EnvelopeRepository
+	SavingsGoalRepository      *SavingsGoalRepository
+	DebtRepository             *DebtRepository
+	HoldingRepository          *HoldingRepository
+	NetWorthSnapshotRepository *NetWorthSnapshotRepository
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{
+		DB:                         db,
+		UserRepository:             &UserRepository{DB: db},
+		IncomeRepository:           &IncomeRepository{DB: db},
+		ExpenseRepository:          &ExpenseRepository{DB: db},
+		RefreshTokenRepository:     &RefreshTokenRepository{DB: db},
+		SecurityEventRepository:    &SecurityEventRepository{DB: db},
+		TwoFactorRepository:        &TwoFactorRepository{DB: db},
+		PasskeyRepository:          &PasskeyRepository{DB: db},
+		LoginAttemptRepository:     &LoginAttemptRepository{DB: db},
+		CredentialRepository:       &CredentialRepository{DB: db},
+		ExchangeRateRepository:     &ExchangeRateRepository{DB: db},
+		CategoryRepository:         &CategoryRepository{DB: db},
+		AccountRepository:          &AccountRepository{DB: db},
+		TransferRepository:         &TransferRepository{DB: db},
+		BudgetRepository:           &BudgetRepository{DB: db},
+		EnvelopeRepository:         &EnvelopeRepository{DB: db},
+		SavingsGoalRepository:      &SavingsGoalRepository{DB: db},
+		DebtRepository:             &DebtRepository{DB: db},
+		HoldingRepository:          &HoldingRepository{DB: db},
+		NetWorthSnapshotRepository: &NetWorthSnapshotRepository{DB: db},
+	}
+}
+
+func (s *Store) Ping(ctx context.Context) error {
+	return s.DB.PingContext(ctx)
+}
+
+func (s *Store) Close() error {
+	if s.DB != nil {
+		return s.DB.Close()
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+// tagList stores a record's tags in a single TEXT column as a JSON array.
+type tagList []string
+
+func (t tagList) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(t))
+	return string(b), err
+}
+
+func (t *tagList) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	case nil:
+		*t = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into tags", src)
+	}
+	var tags []string
+	if err := json.Unmarshal(b, &tags); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		tags = nil
+	}
+	*t = tags
+	return nil
+}
+
+// splitList stores an expense's splits in a single TEXT column as a JSON
+// array.
+type splitList []domain.ExpenseSplit
+
+type storedSplit struct {
+	CategoryID  string `json:"category_id"`
+	AmountMinor int64  `json:"amount_minor"`
+	Currency    string `json:"currency"`
+	Notes       string `json:"notes,omitempty"`
+}
+
+func (l splitList) Value() (driver.Value, error) {
+	stored := make([]storedSplit, len(l))
+	for i, s := range l {
+		stored[i] = storedSplit{CategoryID: s.CategoryID, AmountMinor: s.Amount.MinorUnits, Currency: s.Amount.Currency, Notes: s.Notes}
+	}
+	b, err := json.Marshal(stored)
+	return string(b), err
+}
+
+func (l *splitList) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into splits", src)
+	}
+	var stored []storedSplit
+	if err := json.Unmarshal(b, &stored); err != nil {
+		return err
+	}
+	if len(stored) == 0 {
+		*l = nil
+		return nil
+	}
+	splits := make([]domain.ExpenseSplit, len(stored))
+	for i, s := range stored {
+		splits[i] = domain.ExpenseSplit{CategoryID: s.CategoryID, Amount: domain.NewMoney(s.AmountMinor, s.Currency), Notes: s.Notes}
+	}
+	*l = splits
+	return nil
+}
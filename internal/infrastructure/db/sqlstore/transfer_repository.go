@@ -0,0 +1,95 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TransferRepository struct {
+	DB *sql.DB
+}
+
+const transferColumns = `uid, user_id, from_account_id, to_account_id, amount_minor, currency, to_amount_minor, to_currency, rate, notes, created_at, updated_at`
+
+func (r *TransferRepository) CreateTransfer(ctx context.Context, t *domain.Transfer) (*domain.Transfer, error) {
+	if t == nil || strings.TrimSpace(t.UserID) == "" || strings.TrimSpace(t.UID) == "" {
+		return nil, fmt.Errorf("invalid transfer")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO transfers (`+transferColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (uid) DO UPDATE SET
+			from_account_id = EXCLUDED.from_account_id,
+			to_account_id = EXCLUDED.to_account_id,
+			amount_minor = EXCLUDED.amount_minor,
+			currency = EXCLUDED.currency,
+			to_amount_minor = EXCLUDED.to_amount_minor,
+			to_currency = EXCLUDED.to_currency,
+			rate = EXCLUDED.rate,
+			notes = EXCLUDED.notes,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		t.UID, t.UserID, t.FromAccountID, t.ToAccountID, t.Amount.MinorUnits, t.Amount.Currency, t.ToAmount.MinorUnits, t.ToAmount.Currency,
+		t.Rate, t.Notes, t.CreatedAt.UTC(), t.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *TransferRepository) ListTransfersByUser(ctx context.Context, userID string) ([]*domain.Transfer, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+transferColumns+` FROM transfers WHERE user_id = $1 ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*domain.Transfer
+	for rows.Next() {
+		t, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
+
+func (r *TransferRepository) GetTransfer(ctx context.Context, userID string, transferID string) (*domain.Transfer, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+transferColumns+` FROM transfers WHERE user_id = $1 AND uid = $2`, userID, transferID)
+	t, err := scanTransfer(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "transfer not found")
+	}
+	return t, err
+}
+
+func (r *TransferRepository) DeleteTransfer(ctx context.Context, userID string, transferID string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM transfers WHERE user_id = $1 AND uid = $2`, userID, transferID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "transfer not found")
+	}
+	return nil
+}
+
+func scanTransfer(row scanner) (*domain.Transfer, error) {
+	var t domain.Transfer
+	if err := row.Scan(
+		&t.UID, &t.UserID, &t.FromAccountID, &t.ToAccountID, &t.Amount.MinorUnits, &t.Amount.Currency, &t.ToAmount.MinorUnits, &t.ToAmount.Currency,
+		&t.Rate, &t.Notes, &t.CreatedAt, &t.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,192 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TwoFactorRepository struct {
+	DB *sql.DB
+}
+
+func (r *TwoFactorRepository) SaveTwoFactor(ctx context.Context, tf *domain.TwoFactor) error {
+	if tf == nil || tf.UserID == "" || tf.Secret == "" {
+		return fmt.Errorf("invalid two-factor settings")
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO two_factor (user_id, secret, enabled, last_used_step, created_at, enabled_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret = EXCLUDED.secret,
+			enabled = EXCLUDED.enabled,
+			last_used_step = EXCLUDED.last_used_step,
+			created_at = EXCLUDED.created_at,
+			enabled_at = EXCLUDED.enabled_at`,
+		tf.UserID, tf.Secret, tf.Enabled, tf.LastUsedStep, tf.CreatedAt.UTC(), nullTime(tf.EnabledAt),
+	)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1`, tf.UserID); err != nil {
+		return err
+	}
+	for _, hash := range tf.RecoveryCodes {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO two_factor_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, tf.UserID, hash); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *TwoFactorRepository) GetTwoFactor(ctx context.Context, userID string) (*domain.TwoFactor, error) {
+	var (
+		tf        domain.TwoFactor
+		enabledAt sql.NullTime
+	)
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT user_id, secret, enabled, last_used_step, created_at, enabled_at
+		FROM two_factor WHERE user_id = $1`, userID,
+	).Scan(&tf.UserID, &tf.Secret, &tf.Enabled, &tf.LastUsedStep, &tf.CreatedAt, &enabledAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "two-factor settings not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if enabledAt.Valid {
+		tf.EnabledAt = &enabledAt.Time
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `SELECT code_hash FROM two_factor_recovery_codes WHERE user_id = $1 ORDER BY code_hash`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		tf.RecoveryCodes = append(tf.RecoveryCodes, hash)
+	}
+	return &tf, rows.Err()
+}
+
+func (r *TwoFactorRepository) DeleteTwoFactor(ctx context.Context, userID string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM two_factor WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "two-factor settings not found")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *TwoFactorRepository) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	res, err := r.DB.ExecContext(ctx, `UPDATE two_factor SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`, userID, step)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.FailedPrecondition, "code already used")
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1 AND code_hash = $2`, userID, codeHash)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "recovery code not found")
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) CreateTwoFactorChallenge(ctx context.Context, c *domain.TwoFactorChallenge) error {
+	if c == nil || c.TokenHash == "" || c.UserID == "" {
+		return fmt.Errorf("invalid two-factor challenge")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO two_factor_challenges (token_hash, user_id, email, attempts, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		c.TokenHash, c.UserID, c.Email, c.Attempts, c.ExpiresAt.UTC(), c.CreatedAt.UTC(),
+	)
+	return err
+}
+
+func (r *TwoFactorRepository) GetTwoFactorChallenge(ctx context.Context, tokenHash string) (*domain.TwoFactorChallenge, error) {
+	var c domain.TwoFactorChallenge
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT token_hash, user_id, email, attempts, expires_at, created_at
+		FROM two_factor_challenges WHERE token_hash = $1`, tokenHash,
+	).Scan(&c.TokenHash, &c.UserID, &c.Email, &c.Attempts, &c.ExpiresAt, &c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "two-factor challenge not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *TwoFactorRepository) RecordChallengeAttempt(ctx context.Context, tokenHash string) (int, error) {
+	var attempts int
+	err := r.DB.QueryRowContext(ctx, `
+		UPDATE two_factor_challenges SET attempts = attempts + 1
+		WHERE token_hash = $1
+		RETURNING attempts`, tokenHash,
+	).Scan(&attempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, status.Errorf(codes.NotFound, "two-factor challenge not found")
+	}
+	return attempts, err
+}
+
+func (r *TwoFactorRepository) DeleteTwoFactorChallenge(ctx context.Context, tokenHash string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM two_factor_challenges WHERE token_hash = $1`, tokenHash)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "two-factor challenge not found")
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) DeleteExpiredTwoFactorChallenges(ctx context.Context) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM two_factor_challenges WHERE expires_at < $1`, time.Now().UTC())
+	return err
+}
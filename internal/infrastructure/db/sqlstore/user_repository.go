@@ -0,0 +1,126 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserRepository struct {
+	DB *sql.DB
+}
+
+const userColumns = `uid, username, email, first_name, last_name, phone_number, provider_id, photo_url, email_verified, base_currency, created_at, updated_at`
+
+func (r *UserRepository) CreateUser(ctx context.Context, u *domain.User) (*domain.User, error) {
+	if u == nil || strings.TrimSpace(u.UID) == "" {
+		return nil, fmt.Errorf("invalid user profile")
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO users (`+userColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (uid) DO UPDATE SET
+			username = EXCLUDED.username,
+			email = EXCLUDED.email,
+			first_name = EXCLUDED.first_name,
+			last_name = EXCLUDED.last_name,
+			phone_number = EXCLUDED.phone_number,
+			provider_id = EXCLUDED.provider_id,
+			photo_url = EXCLUDED.photo_url,
+			email_verified = EXCLUDED.email_verified,
+			base_currency = EXCLUDED.base_currency,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`,
+		u.UID, u.Username, u.Email, u.FirstName, u.LastName, nullString(u.PhoneNumber),
+		u.ProviderID, u.PhotoURL, u.EmailVerified, u.BaseCurrency, u.CreatedAt.UTC(), u.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (r *UserRepository) GetUser(ctx context.Context, uid string) (*domain.User, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE uid = $1`, uid)
+	u, err := scanUser(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	return u, err
+}
+
+func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE email = $1 LIMIT 1`, strings.ToLower(strings.TrimSpace(email)))
+	u, err := scanUser(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	return u, err
+}
+
+func (r *UserRepository) UpdateUser(ctx context.Context, u *domain.User) (*domain.User, error) {
+	if u == nil || strings.TrimSpace(u.UID) == "" {
+		return nil, fmt.Errorf("invalid user profile for update")
+	}
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE users SET
+			username = $2, email = $3, first_name = $4, last_name = $5, phone_number = $6,
+			provider_id = $7, photo_url = $8, email_verified = $9, base_currency = $10, updated_at = $11
+		WHERE uid = $1`,
+		u.UID, u.Username, u.Email, u.FirstName, u.LastName, nullString(u.PhoneNumber),
+		u.ProviderID, u.PhotoURL, u.EmailVerified, u.BaseCurrency, u.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	return u, nil
+}
+
+func (r *UserRepository) DeleteUser(ctx context.Context, uid string) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM users WHERE uid = $1`, uid)
+	return err
+}
+
+func (r *UserRepository) ListAllUserIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT uid FROM users ORDER BY uid`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, rows.Err()
+}
+
+func scanUser(row scanner) (*domain.User, error) {
+	var (
+		u     domain.User
+		phone sql.NullString
+	)
+	if err := row.Scan(
+		&u.UID, &u.Username, &u.Email, &u.FirstName, &u.LastName, &phone,
+		&u.ProviderID, &u.PhotoURL, &u.EmailVerified, &u.BaseCurrency, &u.CreatedAt, &u.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if phone.Valid {
+		u.PhoneNumber = &phone.String
+	}
+	return &u, nil
+}
@@ -0,0 +1,52 @@
+package exchangerate
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+// ecbEnvelope is the eurofxref format the European Central Bank publishes
+// its daily, 90-day and historical reference rates in. Every rate is quoted
+// against EUR.
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// ParseECB reads ECB eurofxref XML, such as eurofxref-hist.xml, into EUR
+// based rates.
+func ParseECB(r io.Reader) ([]domain.ExchangeRate, error) {
+	var env ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&env); err != nil {
+		return nil, fmt.Errorf("invalid ECB XML: %w", err)
+	}
+
+	var rates []domain.ExchangeRate
+	for _, d := range env.Days {
+		date, err := time.Parse("2006-01-02", d.Time)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECB date %q", d.Time)
+		}
+		for _, cube := range d.Rates {
+			rate, err := strconv.ParseFloat(cube.Rate, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ECB rate %q for %s on %s", cube.Rate, cube.Currency, d.Time)
+			}
+			rates = append(rates, domain.ExchangeRate{Base: "EUR", Quote: cube.Currency, Date: date, Rate: rate})
+		}
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("no rates found in ECB XML")
+	}
+	return rates, nil
+}
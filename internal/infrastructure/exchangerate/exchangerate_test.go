@@ -0,0 +1,119 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func requireRate(t *testing.T, got float64, err error, want float64) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("Rate: %v", err)
+	}
+	if math.Abs(got-want) > 1e-9 {
+		t.Fatalf("Rate = %v, want %v", got, want)
+	}
+}
+
+func TestStaticProvider(t *testing.T) {
+	rates, err := ParseStaticRates("EUR/USD=1.25, usd/ghs=10")
+	if err != nil {
+		t.Fatalf("ParseStaticRates: %v", err)
+	}
+	p, err := NewStaticProvider(rates)
+	if err != nil {
+		t.Fatalf("NewStaticProvider: %v", err)
+	}
+	ctx := context.Background()
+	on := day("2024-03-01")
+
+	r, err := p.Rate(ctx, "EUR", "USD", on)
+	requireRate(t, r, err, 1.25)
+	r, err = p.Rate(ctx, "USD", "EUR", on)
+	requireRate(t, r, err, 0.8)
+	r, err = p.Rate(ctx, "EUR", "GHS", on)
+	requireRate(t, r, err, 12.5)
+	r, err = p.Rate(ctx, "JPY", "JPY", on)
+	requireRate(t, r, err, 1)
+
+	if _, err := p.Rate(ctx, "EUR", "JPY", on); !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		t.Fatalf("missing pair: err = %v, want ErrExchangeRateNotFound", err)
+	}
+	if _, err := ParseStaticRates("EUR/USD"); err == nil {
+		t.Fatal("ParseStaticRates accepted an entry without a rate")
+	}
+}
+
+func TestFileProviderUsesNearestEarlierDate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	csv := "date,base,quote,rate\n# ECB reference rates\n2024-01-02,EUR,USD,1.10\n2024-01-05,EUR,USD,1.20\n"
+	if err := os.WriteFile(path, []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewFileProvider(path)
+	if err != nil {
+		t.Fatalf("NewFileProvider: %v", err)
+	}
+	ctx := context.Background()
+
+	r, err := p.Rate(ctx, "EUR", "USD", day("2024-01-04"))
+	requireRate(t, r, err, 1.10)
+	r, err = p.Rate(ctx, "EUR", "USD", day("2024-01-05").Add(15*time.Hour))
+	requireRate(t, r, err, 1.20)
+	r, err = p.Rate(ctx, "USD", "EUR", day("2024-02-01"))
+	requireRate(t, r, err, 1/1.20)
+
+	if _, err := p.Rate(ctx, "EUR", "USD", day("2024-01-01")); !errors.Is(err, domain.ErrExchangeRateNotFound) {
+		t.Fatalf("date before first rate: err = %v, want ErrExchangeRateNotFound", err)
+	}
+}
+
+func TestParseECB(t *testing.T) {
+	xml := `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender><gesmes:name>European Central Bank</gesmes:name></gesmes:Sender>
+	<Cube>
+		<Cube time="2024-01-05">
+			<Cube currency="USD" rate="1.0921"/>
+			<Cube currency="JPY" rate="158.08"/>
+		</Cube>
+		<Cube time="2024-01-04">
+			<Cube currency="USD" rate="1.0953"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+	rates, err := ParseECB(strings.NewReader(xml))
+	if err != nil {
+		t.Fatalf("ParseECB: %v", err)
+	}
+	want := []domain.ExchangeRate{
+		{Base: "EUR", Quote: "USD", Date: day("2024-01-05"), Rate: 1.0921},
+		{Base: "EUR", Quote: "JPY", Date: day("2024-01-05"), Rate: 158.08},
+		{Base: "EUR", Quote: "USD", Date: day("2024-01-04"), Rate: 1.0953},
+	}
+	if !reflect.DeepEqual(rates, want) {
+		t.Fatalf("ParseECB = %+v, want %+v", rates, want)
+	}
+
+	if _, err := ParseECB(strings.NewReader("<Envelope><Cube/></Envelope>")); err == nil {
+		t.Fatal("ParseECB accepted a document without rates")
+	}
+}
@@ -0,0 +1,79 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+// FileProvider serves historical rates loaded from a CSV file with the
+// columns date,base,quote,rate. The file is read once, at construction.
+type FileProvider struct {
+	table *table
+}
+
+var _ ports.ExchangeRateProvider = (*FileProvider)(nil)
+
+func NewFileProvider(path string) (*FileProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rates, err := ParseCSV(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	t, err := newTable(rates)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &FileProvider{table: t}, nil
+}
+
+func (p *FileProvider) Rate(_ context.Context, base, quote string, on time.Time) (float64, error) {
+	return p.table.rate(base, quote, on)
+}
+
+// ParseCSV reads date,base,quote,rate records, dates formatted YYYY-MM-DD.
+// A leading header row is skipped.
+func ParseCSV(r io.Reader) ([]domain.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rates []domain.ExchangeRate
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rates, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if first && strings.EqualFold(record[0], "date") {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		date, err := time.Parse("2006-01-02", record[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", line, record[0])
+		}
+		rate, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rate %q", line, record[3])
+		}
+		rates = append(rates, domain.ExchangeRate{Base: record[1], Quote: record[2], Date: date, Rate: rate})
+	}
+}
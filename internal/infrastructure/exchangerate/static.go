@@ -0,0 +1,61 @@
+package exchangerate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+// StaticProvider serves a fixed table of rates that apply on every date. It
+// needs no network access and suits development and offline use.
+type StaticProvider struct {
+	table *table
+}
+
+var _ ports.ExchangeRateProvider = (*StaticProvider)(nil)
+
+func NewStaticProvider(rates []domain.ExchangeRate) (*StaticProvider, error) {
+	for i := range rates {
+		rates[i].Date = time.Time{}
+	}
+	t, err := newTable(rates)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticProvider{table: t}, nil
+}
+
+func (p *StaticProvider) Rate(_ context.Context, base, quote string, on time.Time) (float64, error) {
+	return p.table.rate(base, quote, on)
+}
+
+// ParseStaticRates parses a comma separated list of BASE/QUOTE=RATE entries,
+// e.g. "EUR/USD=1.08,USD/GHS=15.5".
+func ParseStaticRates(spec string) ([]domain.ExchangeRate, error) {
+	var rates []domain.ExchangeRate
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		currencies, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate %q: want BASE/QUOTE=RATE", entry)
+		}
+		base, quote, ok := strings.Cut(currencies, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate %q: want BASE/QUOTE=RATE", entry)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate %q: %w", entry, err)
+		}
+		rates = append(rates, domain.ExchangeRate{Base: strings.TrimSpace(base), Quote: strings.TrimSpace(quote), Rate: rate})
+	}
+	return rates, nil
+}
@@ -0,0 +1,94 @@
+package exchangerate
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/domain"
+)
+
+type pair struct{ base, quote string }
+
+// table answers rate lookups from a fixed set of dated rates. A lookup uses
+// the latest rate on or before the requested date, falling back to the
+// inverse pair and then to a cross rate through a third currency.
+type table struct {
+	pairs      map[pair][]domain.ExchangeRate
+	currencies []string
+}
+
+func newTable(rates []domain.ExchangeRate) (*table, error) {
+	t := &table{pairs: map[pair][]domain.ExchangeRate{}}
+	seen := map[string]bool{}
+	for _, r := range rates {
+		base, ok := domain.NormalizeCurrency(r.Base)
+		if !ok {
+			return nil, fmt.Errorf("invalid base currency %q", r.Base)
+		}
+		quote, ok := domain.NormalizeCurrency(r.Quote)
+		if !ok {
+			return nil, fmt.Errorf("invalid quote currency %q", r.Quote)
+		}
+		if r.Rate <= 0 {
+			return nil, fmt.Errorf("invalid rate %v for %s/%s", r.Rate, base, quote)
+		}
+		r.Base, r.Quote = base, quote
+		t.pairs[pair{base, quote}] = append(t.pairs[pair{base, quote}], r)
+		for _, c := range []string{base, quote} {
+			if !seen[c] {
+				seen[c] = true
+				t.currencies = append(t.currencies, c)
+			}
+		}
+	}
+	for _, rs := range t.pairs {
+		sort.SliceStable(rs, func(i, j int) bool { return rs[i].Date.Before(rs[j].Date) })
+	}
+	sort.Strings(t.currencies)
+	return t, nil
+}
+
+func (t *table) rate(base, quote string, on time.Time) (float64, error) {
+	base, _ = domain.NormalizeCurrency(base)
+	quote, _ = domain.NormalizeCurrency(quote)
+	if base == quote {
+		return 1, nil
+	}
+	if r, ok := t.direct(base, quote, on); ok {
+		return r, nil
+	}
+	for _, via := range t.currencies {
+		if via == base || via == quote {
+			continue
+		}
+		first, ok := t.direct(base, via, on)
+		if !ok {
+			continue
+		}
+		if second, ok := t.direct(via, quote, on); ok {
+			return first * second, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s/%s on %s", domain.ErrExchangeRateNotFound, base, quote, on.Format("2006-01-02"))
+}
+
+func (t *table) direct(base, quote string, on time.Time) (float64, bool) {
+	if r, ok := latest(t.pairs[pair{base, quote}], on); ok {
+		return r, true
+	}
+	if r, ok := latest(t.pairs[pair{quote, base}], on); ok {
+		return 1 / r, true
+	}
+	return 0, false
+}
+
+// latest returns the last rate dated on or before on; rates must be sorted
+// by date.
+func latest(rates []domain.ExchangeRate, on time.Time) (float64, bool) {
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].Date.After(on) })
+	if i == 0 {
+		return 0, false
+	}
+	return rates[i-1].Rate, true
+}
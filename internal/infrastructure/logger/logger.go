@@ -36,6 +36,10 @@ func Debug(msg string, fields ...zap.Field) {
 	zapLogger.Debug(msg, fields...)
 }
 
+func Warn(msg string, fields ...zap.Field) {
+	zapLogger.Warn(msg, fields...)
+}
+
 func Error(msg string, fields ...zap.Field) {
 	zapLogger.Error(msg, fields...)
 }
@@ -0,0 +1,90 @@
+// Package mail sends the server's email through an SMTP relay.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"net/url"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+)
+
+// SMTPMailer delivers plain text email with net/smtp, which upgrades the
+// connection with STARTTLS when the relay offers it.
+type SMTPMailer struct {
+	cfg       config.MailConfig
+	from      *netmail.Address
+	unlockURL string
+	send      func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+var _ ports.AuthMailer = (*SMTPMailer)(nil)
+
+// NewSMTPMailer returns a mailer for the relay in cfg. unlockURL is the page
+// that account unlock emails link to; without it they carry the bare token.
+func NewSMTPMailer(cfg config.MailConfig, unlockURL string) (*SMTPMailer, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("SMTP host is required")
+	}
+	from, err := netmail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", cfg.From, err)
+	}
+	if unlockURL != "" {
+		if _, err := url.Parse(unlockURL); err != nil {
+			return nil, fmt.Errorf("invalid account unlock URL: %w", err)
+		}
+	}
+	return &SMTPMailer{cfg: cfg, from: from, unlockURL: unlockURL, send: smtp.SendMail}, nil
+}
+
+func (m *SMTPMailer) SendAccountUnlock(ctx context.Context, email, token string, lockedUntil time.Time) error {
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "There were too many failed attempts to sign in to your account, so signing in is blocked until %s.\r\n\r\n",
+		lockedUntil.UTC().Format("15:04 MST on 2 January 2006"))
+	if m.unlockURL != "" {
+		fmt.Fprintf(&body, "If it was you, you can unlock your account now:\r\n\r\n%s\r\n\r\n", m.unlockLink(token))
+	} else {
+		fmt.Fprintf(&body, "If it was you, you can unlock your account now with this code:\r\n\r\n%s\r\n\r\n", token)
+	}
+	body.WriteString("If it was not you, someone may be guessing your password. Consider changing it once you are signed in.\r\n")
+	return m.deliver(email, "Your account has been locked", body.Bytes())
+}
+
+func (m *SMTPMailer) unlockLink(token string) string {
+	u, _ := url.Parse(m.unlockURL) // checked in NewSMTPMailer
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func (m *SMTPMailer) deliver(to, subject string, body []byte) error {
+	rcpt, err := netmail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", rcpt.String())
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.Write(body)
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+	return m.send(net.JoinHostPort(m.cfg.Host, m.cfg.Port), auth, m.from.Address, []string{rcpt.Address}, msg.Bytes())
+}
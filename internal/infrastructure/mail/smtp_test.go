@@ -0,0 +1,75 @@
+package mail
+
+import (
+	"context"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/infrastructure/config"
+)
+
+type sent struct {
+	addr string
+	from string
+	to   []string
+	msg  string
+}
+
+func newTestMailer(t *testing.T, unlockURL string) (*SMTPMailer, *sent) {
+	t.Helper()
+	m, err := NewSMTPMailer(config.MailConfig{Host: "smtp.example.com", Port: "587", From: "Budgeting <no-reply@example.com>"}, unlockURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s sent
+	m.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		s = sent{addr: addr, from: from, to: to, msg: string(msg)}
+		return nil
+	}
+	return m, &s
+}
+
+func TestSendAccountUnlock(t *testing.T) {
+	m, s := newTestMailer(t, "https://app.example.com/unlock?source=email")
+	lockedUntil := time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC)
+	if err := m.SendAccountUnlock(context.Background(), "ama@example.com", "tok en", lockedUntil); err != nil {
+		t.Fatalf("SendAccountUnlock: %v", err)
+	}
+
+	if s.addr != "smtp.example.com:587" || s.from != "no-reply@example.com" || len(s.to) != 1 || s.to[0] != "ama@example.com" {
+		t.Fatalf("sent via %s from %s to %v", s.addr, s.from, s.to)
+	}
+	for _, want := range []string{
+		"From: \"Budgeting\" <no-reply@example.com>\r\n",
+		"To: <ama@example.com>\r\n",
+		"Subject: Your account has been locked\r\n",
+		"until 14:30 UTC on 1 March 2026",
+		"https://app.example.com/unlock?source=email&token=tok+en\r\n",
+	} {
+		if !strings.Contains(s.msg, want) {
+			t.Errorf("message does not contain %q:\n%s", want, s.msg)
+		}
+	}
+}
+
+func TestSendAccountUnlockWithoutURL(t *testing.T) {
+	m, s := newTestMailer(t, "")
+	if err := m.SendAccountUnlock(context.Background(), "ama@example.com", "secret-token", time.Now()); err != nil {
+		t.Fatalf("SendAccountUnlock: %v", err)
+	}
+	if !strings.Contains(s.msg, "with this code:\r\n\r\nsecret-token\r\n") {
+		t.Errorf("message does not carry the token:\n%s", s.msg)
+	}
+}
+
+func TestSendAccountUnlockRejectsHeaderInjection(t *testing.T) {
+	m, s := newTestMailer(t, "")
+	if err := m.SendAccountUnlock(context.Background(), "ama@example.com\r\nBcc: eve@example.com", "t", time.Now()); err == nil {
+		t.Fatal("SendAccountUnlock accepted a recipient with a header in it")
+	}
+	if s.msg != "" {
+		t.Fatal("message was sent")
+	}
+}
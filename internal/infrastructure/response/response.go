@@ -146,13 +146,14 @@ func ForbiddenResponse(ctx *gin.Context, message string, reason error, isDevelop
 	ctx.JSON(http.StatusForbidden, response)
 }
 
-func LockedResponse(ctx *gin.Context, message string, reason error, isDevelopment bool) {
+// TooManyRequestsResponse Too Many Requests Response
+func TooManyRequestsResponse(ctx *gin.Context, message string, reason error, isDevelopment bool) {
 	response := gin.H{
-		"status":  "locked",
+		"status":  "too many requests",
 		"message": message,
 	}
 	if reason != nil && isDevelopment {
 		response["reason"] = reason.Error()
 	}
-	ctx.JSON(http.StatusLocked, response)
+	ctx.JSON(http.StatusTooManyRequests, response)
 }
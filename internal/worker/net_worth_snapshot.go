@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/theHinneh/budgeting/internal/application/ports"
+	"github.com/theHinneh/budgeting/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// StartNetWorthSnapshotWorker records every user's net worth once a day,
+// starting on startup. Monthly history is read from the daily snapshots.
+func StartNetWorthSnapshotWorker(netWorthService ports.NetWorthServicePort, userService ports.UserRepository) {
+	go func() {
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+
+		logger.Info("Starting net worth snapshot worker")
+		snapshotNetWorth(netWorthService, userService)
+		for range ticker.C {
+			snapshotNetWorth(netWorthService, userService)
+		}
+	}()
+}
+
+func snapshotNetWorth(netWorthService ports.NetWorthServicePort, userService ports.UserRepository) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	userIDs, err := userService.ListAllUserIDs(ctx)
+	if err != nil {
+		logger.Error("Failed to list all user IDs for net worth snapshots", zap.Error(err))
+		return
+	}
+
+	now := time.Now().UTC()
+	var taken int
+	for _, userID := range userIDs {
+		if _, err := netWorthService.SnapshotNetWorth(ctx, userID, now); err != nil {
+			logger.Error("Failed to snapshot net worth for user", zap.String("userID", userID), zap.Error(err))
+			continue
+		}
+		taken++
+	}
+	logger.Info("Finished net worth snapshots", zap.Int("count", taken))
+}